@@ -0,0 +1,207 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"net"
+	"net/http"
+	"net/http/httputil"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"sync/atomic"
+)
+
+// devProxy keeps the port the browser was opened on stable during development.
+// It listens on the requested port and forwards to the app process, whose port
+// may change across restarts when findAvailablePort has to move it.
+type devProxy struct {
+	port    string
+	backend atomic.Pointer[url.URL]
+	proxy   *httputil.ReverseProxy
+	routes  []*devProxyUpstream // API paths forwarded to other backends instead of the app
+}
+
+// DevProxyRoute forwards a path prefix to another backend during development,
+// configured in package.yaml:
+//
+//	config:
+//	  proxy:
+//	    - path: /api
+//	      target: http://localhost:3000
+//	      rewrite: /v1   # /api/users -> http://localhost:3000/v1/users
+type DevProxyRoute struct {
+	Path        string `yaml:"path"`         // Path prefix to forward, e.g. /api
+	Target      string `yaml:"target"`       // Backend URL, e.g. http://localhost:3000
+	StripPrefix bool   `yaml:"strip_prefix"` // Remove the prefix before forwarding
+	Rewrite     string `yaml:"rewrite"`      // Replace the prefix with this path before forwarding
+}
+
+// devProxyUpstream is a configured route with its reverse proxy
+type devProxyUpstream struct {
+	route  DevProxyRoute
+	prefix string
+	proxy  *httputil.ReverseProxy
+}
+
+// activeDevProxy is the running dev proxy, or nil when the app is served directly
+var activeDevProxy *devProxy
+
+// devRestartingPage is shown to page navigations while the app process is restarting
+const devRestartingPage = `<!DOCTYPE html>
+<html><head><meta charset="UTF-8"><meta http-equiv="refresh" content="1"><title>Restarting…</title></head>
+<body style="font-family: sans-serif; color: #555; padding: 40px;">🔄 Restarting the Godin server…</body></html>`
+
+// startDevProxy listens on host:port and forwards requests to the matching route
+// or else the backend set with SetBackend
+func startDevProxy(host, port string, routes []DevProxyRoute) (*devProxy, error) {
+	port = strings.TrimPrefix(port, ":")
+	listener, err := net.Listen("tcp", net.JoinHostPort(host, port))
+	if err != nil {
+		return nil, err
+	}
+
+	p := &devProxy{port: port}
+	p.proxy = &httputil.ReverseProxy{
+		Rewrite: func(r *httputil.ProxyRequest) {
+			r.SetURL(p.backend.Load())
+			// Keep the browser's Host so origin checks and absolute URLs match the proxy
+			r.Out.Host = r.In.Host
+			r.SetXForwarded()
+		},
+		// Stream responses such as server-sent events without buffering
+		FlushInterval: -1,
+		ErrorHandler: func(w http.ResponseWriter, r *http.Request, err error) {
+			p.unavailable(w, r)
+		},
+	}
+	p.addRoutes(routes)
+
+	go func() {
+		if err := http.Serve(listener, p); err != nil {
+			log.Printf("❌ Dev proxy stopped: %v", err)
+		}
+	}()
+	return p, nil
+}
+
+// addRoutes forwards the routes' path prefixes to their backends, including
+// WebSocket upgrades; longer prefixes take precedence
+func (p *devProxy) addRoutes(routes []DevProxyRoute) {
+	for _, route := range routes {
+		target, err := url.Parse(route.Target)
+		if err != nil || target.Scheme == "" || target.Host == "" {
+			log.Printf("⚠️  Ignoring proxy route %s: invalid target %q", route.Path, route.Target)
+			continue
+		}
+
+		upstream := &devProxyUpstream{route: route, prefix: "/" + strings.Trim(route.Path, "/")}
+		upstream.proxy = &httputil.ReverseProxy{
+			Rewrite: func(r *httputil.ProxyRequest) {
+				r.Out.URL.Path = upstream.rewritePath(r.In.URL.Path)
+				r.Out.URL.RawPath = ""
+				r.SetURL(target)
+				r.SetXForwarded()
+			},
+			FlushInterval: -1,
+			ErrorHandler: func(w http.ResponseWriter, r *http.Request, err error) {
+				log.Printf("❌ Proxy %s -> %s failed: %v", r.URL.Path, route.Target, err)
+				http.Error(w, fmt.Sprintf("Backend %s unavailable", route.Target), http.StatusBadGateway)
+			},
+		}
+
+		p.routes = append(p.routes, upstream)
+		log.Printf("🔀 Dev proxy forwards %s to %s", upstream.prefix, route.Target)
+	}
+
+	sort.SliceStable(p.routes, func(i, j int) bool {
+		return len(p.routes[i].prefix) > len(p.routes[j].prefix)
+	})
+}
+
+// upstreamFor returns the configured route matching a request path, if any
+func (p *devProxy) upstreamFor(path string) *devProxyUpstream {
+	for _, upstream := range p.routes {
+		if upstream.prefix == "/" || path == upstream.prefix || strings.HasPrefix(path, upstream.prefix+"/") {
+			return upstream
+		}
+	}
+	return nil
+}
+
+// rewritePath applies the route's prefix stripping or rewriting to a request path
+func (u *devProxyUpstream) rewritePath(path string) string {
+	if u.route.Rewrite == "" && !u.route.StripPrefix {
+		return path
+	}
+
+	rest := strings.TrimPrefix(path, u.prefix)
+	if u.prefix == "/" {
+		rest = path
+	}
+	rewritten := strings.TrimSuffix(u.route.Rewrite, "/") + rest
+	if !strings.HasPrefix(rewritten, "/") {
+		rewritten = "/" + rewritten
+	}
+	return rewritten
+}
+
+// SetBackend points the proxy at the app process listening on port
+func (p *devProxy) SetBackend(port string) {
+	port = strings.TrimPrefix(port, ":")
+	p.backend.Store(&url.URL{Scheme: "http", Host: net.JoinHostPort("127.0.0.1", port)})
+	log.Printf("🔀 Dev proxy on port %s now forwards to port %s", p.port, port)
+}
+
+// ServeHTTP forwards a request, including WebSocket upgrades, to the matching
+// proxy route or else the current app backend
+func (p *devProxy) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if upstream := p.upstreamFor(r.URL.Path); upstream != nil {
+		upstream.proxy.ServeHTTP(w, r)
+		return
+	}
+
+	if p.backend.Load() == nil {
+		p.unavailable(w, r)
+		return
+	}
+	p.proxy.ServeHTTP(w, r)
+}
+
+// unavailable answers while the app is restarting; pages retry on their own
+func (p *devProxy) unavailable(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Retry-After", "1")
+	if strings.Contains(r.Header.Get("Accept"), "text/html") && r.Header.Get("HX-Request") == "" {
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		w.WriteHeader(http.StatusServiceUnavailable)
+		fmt.Fprint(w, devRestartingPage)
+		return
+	}
+	http.Error(w, "Godin server is restarting", http.StatusServiceUnavailable)
+}
+
+// startDevProxyFor starts the dev proxy on the requested port and returns the port the
+// app should listen on behind it, or the requested port when the proxy cannot start
+func startDevProxyFor(port string) string {
+	// Forward API paths configured in package.yaml to their own backends
+	var routes []DevProxyRoute
+	if config, err := loadPackageConfig("."); err == nil {
+		routes = config.Config.Proxy
+	}
+
+	proxy, err := startDevProxy(currentServerHost, port, routes)
+	if err != nil {
+		log.Printf("⚠️  Dev proxy disabled, serving the app directly: %v", err)
+		return port
+	}
+	activeDevProxy = proxy
+
+	portNum, err := strconv.Atoi(proxy.port)
+	if err != nil {
+		portNum = 8080
+	}
+	backendPort := findAvailablePort(strconv.Itoa(portNum + 1))
+	log.Printf("🔀 Dev proxy listening on %s; the app runs behind it on port %s", serverURL(proxy.port), backendPort)
+	return backendPort
+}
@@ -0,0 +1,370 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"unicode"
+
+	"gopkg.in/yaml.v3"
+)
+
+// routesFile and routeHandlersFile are written by godin generate routes
+const (
+	routesFile        = "routes.yaml"
+	routeHandlersFile = "routes_gen.go"
+)
+
+// defaultCoreImport is used for the generated handler map when no scanned file imports core
+const defaultCoreImport = "github.com/gideonsigilai/godin/pkg/core"
+
+// routeFileEntry is one route in routes.yaml, matching core.RouteEntry
+type routeFileEntry struct {
+	Method  string `yaml:"method,omitempty"`
+	Path    string `yaml:"path"`
+	Handler string `yaml:"handler"`
+	Public  bool   `yaml:"public,omitempty"`
+}
+
+// routeFile is the layout of routes.yaml, matching core.RouteTable
+type routeFile struct {
+	Routes []routeFileEntry `yaml:"routes"`
+}
+
+// scannedHandlers are the route handlers found in a package directory
+type scannedHandlers struct {
+	pkgName    string
+	coreImport string
+	names      []string
+}
+
+// generateRoutes adds every handler in the current directory that routes.yaml does
+// not route yet, then writes routes_gen.go mapping handler names to functions
+// for app.LoadRoutes
+func generateRoutes() {
+	scanned, err := scanRouteHandlers(".")
+	if err != nil {
+		log.Fatalf("Failed to scan handlers: %v", err)
+	}
+	if len(scanned.names) == 0 {
+		log.Fatal("No handlers found. Handlers are functions of the form func(ctx *core.Context) widgets.Widget.")
+	}
+
+	table, err := readRouteFile(routesFile)
+	if err != nil && !os.IsNotExist(err) {
+		log.Fatalf("Failed to read %s: %v", routesFile, err)
+	}
+
+	// Keep the routes already declared, adding only handlers without one
+	routed := make(map[string]bool)
+	for _, entry := range table.Routes {
+		routed[entry.Handler] = true
+	}
+	var added []routeFileEntry
+	for _, name := range scanned.names {
+		if !routed[name] {
+			added = append(added, guessRoute(name))
+		}
+	}
+	if err := appendRouteFile(routesFile, table, added); err != nil {
+		log.Fatalf("Failed to write %s: %v", routesFile, err)
+	}
+	for _, entry := range added {
+		log.Printf("➕ %s %s -> %s", routeMethod(entry), entry.Path, entry.Handler)
+	}
+
+	// Map the handlers the routes use, warning about names that no longer exist
+	found := make(map[string]bool)
+	for _, name := range scanned.names {
+		found[name] = true
+	}
+	var used []string
+	for _, entry := range append(table.Routes, added...) {
+		if !found[entry.Handler] {
+			log.Printf("⚠️  %s %s names unknown handler %q", routeMethod(entry), entry.Path, entry.Handler)
+			continue
+		}
+		if !containsString(used, entry.Handler) {
+			used = append(used, entry.Handler)
+		}
+	}
+	sort.Strings(used)
+
+	source, err := routeHandlersSource(scanned, used)
+	if err != nil {
+		log.Fatalf("Failed to generate %s: %v", routeHandlersFile, err)
+	}
+	if err := os.WriteFile(routeHandlersFile, source, 0644); err != nil {
+		log.Fatalf("Failed to write %s: %v", routeHandlersFile, err)
+	}
+
+	log.Printf("✅ %s routes %d handlers (%d new); %s maps them for app.LoadRoutes", routesFile, len(used), len(added), routeHandlersFile)
+	log.Printf(`Register them with: app.LoadRoutes("%s", routeHandlers)`, routesFile)
+}
+
+// scanRouteHandlers finds the top-level functions in dir shaped like core.Handler
+func scanRouteHandlers(dir string) (*scannedHandlers, error) {
+	fset := token.NewFileSet()
+	files, err := filepath.Glob(filepath.Join(dir, "*.go"))
+	if err != nil {
+		return nil, err
+	}
+
+	scanned := &scannedHandlers{}
+	for _, path := range files {
+		if strings.HasSuffix(path, "_test.go") || filepath.Base(path) == routeHandlersFile {
+			continue
+		}
+		file, err := parser.ParseFile(fset, path, nil, parser.SkipObjectResolution)
+		if err != nil {
+			return nil, err
+		}
+		scanned.pkgName = file.Name.Name
+
+		// Find the names core, and pkg/godin which aliases its types, are imported under in this file
+		imports := handlerImports{}
+		for _, spec := range file.Imports {
+			importPath, _ := strconv.Unquote(spec.Path.Value)
+			var name string
+			switch {
+			case strings.HasSuffix(importPath, "/pkg/core"):
+				name = "core"
+			case strings.HasSuffix(importPath, "/pkg/godin"):
+				name = "godin"
+			case strings.HasSuffix(importPath, "/pkg/widgets"):
+				// Only a dot import matters: it makes Widget usable unqualified
+				if spec.Name != nil && spec.Name.Name == "." {
+					imports.dot = true
+				}
+				continue
+			default:
+				continue
+			}
+			if spec.Name != nil {
+				name = spec.Name.Name
+			}
+			switch name {
+			case "_":
+				continue
+			case ".":
+				imports.dot = true
+			default:
+				imports.names = append(imports.names, name)
+			}
+			scanned.coreImport = importPath[:strings.LastIndex(importPath, "/")] + "/core"
+		}
+		if len(imports.names) == 0 && !imports.dot {
+			continue
+		}
+
+		for _, decl := range file.Decls {
+			fn, ok := decl.(*ast.FuncDecl)
+			if ok && fn.Recv == nil && isRouteHandler(fn.Type, imports) {
+				scanned.names = append(scanned.names, fn.Name.Name)
+			}
+		}
+	}
+
+	if scanned.coreImport == "" {
+		scanned.coreImport = defaultCoreImport
+	}
+	sort.Strings(scanned.names)
+	return scanned, nil
+}
+
+// handlerImports are the ways a file can refer to core.Context: through the names
+// pkg/core and pkg/godin are imported under, or unqualified after a dot import
+type handlerImports struct {
+	names []string
+	dot   bool
+}
+
+// qualifies reports whether a type expression names typeName from core or pkg/godin
+func (imports handlerImports) qualifies(expr ast.Expr, typeName string) bool {
+	switch t := expr.(type) {
+	case *ast.Ident:
+		return imports.dot && t.Name == typeName
+	case *ast.SelectorExpr:
+		pkg, ok := t.X.(*ast.Ident)
+		return ok && t.Sel.Name == typeName && containsString(imports.names, pkg.Name)
+	}
+	return false
+}
+
+// isRouteHandler reports whether a function takes only a *core.Context and returns only a Widget
+func isRouteHandler(fn *ast.FuncType, imports handlerImports) bool {
+	if fn.TypeParams != nil || fn.Params == nil || fn.Results == nil {
+		return false
+	}
+	if len(fn.Params.List) != 1 || len(fn.Params.List[0].Names) > 1 || len(fn.Results.List) != 1 || len(fn.Results.List[0].Names) > 1 {
+		return false
+	}
+
+	star, ok := fn.Params.List[0].Type.(*ast.StarExpr)
+	if !ok || !imports.qualifies(star.X, "Context") {
+		return false
+	}
+
+	// Widget is the same interface in core, widgets and pkg/godin, whatever the package is imported as
+	switch result := fn.Results.List[0].Type.(type) {
+	case *ast.SelectorExpr:
+		return result.Sel.Name == "Widget"
+	case *ast.Ident:
+		return imports.dot && result.Name == "Widget"
+	}
+	return false
+}
+
+// guessRoute derives a route from a handler name: HomeHandler serves GET /,
+// UserProfileHandler GET /user-profile and AddTodoHandler POST /add-todo
+func guessRoute(handler string) routeFileEntry {
+	name := strings.TrimSuffix(handler, "Handler")
+	entry := routeFileEntry{Path: "/", Handler: handler}
+
+	switch name {
+	case "", "Home", "home", "Index", "index":
+		return entry
+	}
+	entry.Path = "/" + kebabCase(name)
+
+	for _, verb := range []string{"Add", "Create", "Delete", "Post", "Remove", "Save", "Submit", "Toggle", "Update"} {
+		if strings.HasPrefix(name, verb) || strings.HasPrefix(name, strings.ToLower(verb)) {
+			entry.Method = "POST"
+			break
+		}
+	}
+	return entry
+}
+
+// kebabCase turns a Go identifier such as UserProfile into user-profile
+func kebabCase(name string) string {
+	var out []rune
+	runes := []rune(name)
+	for i, r := range runes {
+		if unicode.IsUpper(r) {
+			// Start a new word, keeping acronyms such as "API" together
+			if i > 0 && (unicode.IsLower(runes[i-1]) || (i+1 < len(runes) && unicode.IsLower(runes[i+1]) && unicode.IsUpper(runes[i-1]))) {
+				out = append(out, '-')
+			}
+			r = unicode.ToLower(r)
+		}
+		out = append(out, r)
+	}
+	return string(out)
+}
+
+// readRouteFile parses routes.yaml, returning an empty table when it does not exist
+func readRouteFile(path string) (*routeFile, error) {
+	table := &routeFile{}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return table, err
+	}
+	if err := yaml.Unmarshal(data, table); err != nil {
+		return table, err
+	}
+	return table, nil
+}
+
+// appendRouteFile adds entries to the end of routes.yaml, keeping the existing
+// entries and their comments as they are
+func appendRouteFile(path string, table *routeFile, entries []routeFileEntry) error {
+	existing, err := os.ReadFile(path)
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	if len(existing) > 0 && len(entries) == 0 {
+		return nil
+	}
+
+	var buf bytes.Buffer
+	if len(existing) == 0 {
+		buf.WriteString("# Routes loaded with app.LoadRoutes; see godin generate routes\n")
+		buf.WriteString("routes:\n")
+	} else {
+		buf.Write(existing)
+		if !bytes.HasSuffix(existing, []byte("\n")) {
+			buf.WriteString("\n")
+		}
+		if len(table.Routes) == 0 && !bytes.Contains(existing, []byte("routes:")) {
+			buf.WriteString("routes:\n")
+		}
+	}
+
+	for _, entry := range entries {
+		if entry.Method != "" {
+			fmt.Fprintf(&buf, "  - method: %s\n    path: %s\n", entry.Method, entry.Path)
+		} else {
+			fmt.Fprintf(&buf, "  - path: %s\n", entry.Path)
+		}
+		fmt.Fprintf(&buf, "    handler: %s\n", entry.Handler)
+	}
+
+	return os.WriteFile(path, buf.Bytes(), 0644)
+}
+
+// routeHandlersSource returns the Go source of routes_gen.go
+func routeHandlersSource(scanned *scannedHandlers, names []string) ([]byte, error) {
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "// Code generated by godin generate routes. DO NOT EDIT.\n\n")
+	fmt.Fprintf(&buf, "package %s\n\n", scanned.pkgName)
+	fmt.Fprintf(&buf, "import %q\n\n", scanned.coreImport)
+	fmt.Fprintf(&buf, "// routeHandlers maps the handler names in %s to their functions, for app.LoadRoutes\n", routesFile)
+	fmt.Fprintf(&buf, "var routeHandlers = map[string]core.Handler{\n")
+	for _, name := range names {
+		fmt.Fprintf(&buf, "\t%q: %s,\n", name, name)
+	}
+	fmt.Fprintf(&buf, "}\n")
+	return format.Source(buf.Bytes())
+}
+
+// printRouteFile prints the routes declared in a routes file without running the app
+func printRouteFile(path string) {
+	table, err := readRouteFile(path)
+	if err != nil {
+		log.Fatalf("Failed to read %s: %v", path, err)
+	}
+
+	routes := append([]routeFileEntry(nil), table.Routes...)
+	sort.SliceStable(routes, func(i, j int) bool {
+		if routes[i].Path != routes[j].Path {
+			return routes[i].Path < routes[j].Path
+		}
+		return routeMethod(routes[i]) < routeMethod(routes[j])
+	})
+
+	writer := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(writer, "METHOD\tPATH\tHANDLER")
+	for _, route := range routes {
+		fmt.Fprintf(writer, "%s\t%s\t%s\n", routeMethod(route), route.Path, route.Handler)
+	}
+	writer.Flush()
+}
+
+// routeMethod returns an entry's method, defaulting to GET like app.LoadRoutes
+func routeMethod(entry routeFileEntry) string {
+	if entry.Method == "" {
+		return "GET"
+	}
+	return strings.ToUpper(entry.Method)
+}
+
+// containsString reports whether values contains value
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
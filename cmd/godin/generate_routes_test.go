@@ -0,0 +1,137 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestKebabCase(t *testing.T) {
+	tests := map[string]string{
+		"Home":          "home",
+		"UserProfile":   "user-profile",
+		"userProfile":   "user-profile",
+		"APIKeys":       "api-keys",
+		"ExportCSV":     "export-csv",
+		"HTTPServerLog": "http-server-log",
+		"Page2":         "page2",
+		"":              "",
+	}
+
+	for name, expected := range tests {
+		if actual := kebabCase(name); actual != expected {
+			t.Errorf("kebabCase(%q) = %q, expected %q", name, actual, expected)
+		}
+	}
+}
+
+func TestGuessRoute(t *testing.T) {
+	tests := []struct {
+		handler  string
+		expected routeFileEntry
+	}{
+		{"HomeHandler", routeFileEntry{Path: "/", Handler: "HomeHandler"}},
+		{"IndexHandler", routeFileEntry{Path: "/", Handler: "IndexHandler"}},
+		{"Handler", routeFileEntry{Path: "/", Handler: "Handler"}},
+		{"UserProfileHandler", routeFileEntry{Path: "/user-profile", Handler: "UserProfileHandler"}},
+		{"About", routeFileEntry{Path: "/about", Handler: "About"}},
+		{"AddTodoHandler", routeFileEntry{Method: "POST", Path: "/add-todo", Handler: "AddTodoHandler"}},
+		{"deleteUser", routeFileEntry{Method: "POST", Path: "/delete-user", Handler: "deleteUser"}},
+		{"UpdateSettingsHandler", routeFileEntry{Method: "POST", Path: "/update-settings", Handler: "UpdateSettingsHandler"}},
+	}
+
+	for _, test := range tests {
+		if actual := guessRoute(test.handler); actual != test.expected {
+			t.Errorf("guessRoute(%q) = %+v, expected %+v", test.handler, actual, test.expected)
+		}
+	}
+}
+
+func TestScanRouteHandlers(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"core.go": `package app
+
+import "github.com/gideonsigilai/godin/pkg/core"
+
+func HomeHandler(ctx *core.Context) core.Widget { return nil }
+func helper(ctx *core.Context) string          { return "" }
+func TwoParams(ctx *core.Context, n int) core.Widget { return nil }
+`,
+		"aliased.go": `package app
+
+import (
+	g "github.com/gideonsigilai/godin/pkg/godin"
+	"github.com/gideonsigilai/godin/pkg/widgets"
+)
+
+func AboutHandler(ctx *g.Context) widgets.Widget { return nil }
+`,
+		"dot.go": `package app
+
+import . "github.com/gideonsigilai/godin/pkg/godin"
+
+func ContactHandler(ctx *Context) Widget { return nil }
+`,
+		"unrelated.go": `package app
+
+type Context struct{}
+type Widget interface{}
+
+func NotAHandler(ctx *Context) Widget { return nil }
+`,
+		"handlers_test.go": `package app
+
+import "github.com/gideonsigilai/godin/pkg/core"
+
+func TestOnlyHandler(ctx *core.Context) core.Widget { return nil }
+`,
+	}
+	for name, source := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(source), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	scanned, err := scanRouteHandlers(dir)
+	if err != nil {
+		t.Fatalf("Expected the package to scan, got %v", err)
+	}
+
+	expected := []string{"AboutHandler", "ContactHandler", "HomeHandler"}
+	if !reflect.DeepEqual(scanned.names, expected) {
+		t.Errorf("Expected handlers %v, got %v", expected, scanned.names)
+	}
+	if scanned.pkgName != "app" {
+		t.Errorf("Expected package app, got %q", scanned.pkgName)
+	}
+	if scanned.coreImport != defaultCoreImport {
+		t.Errorf("Expected the generated map to import %q, got %q", defaultCoreImport, scanned.coreImport)
+	}
+}
+
+func TestRouteHandlersSource(t *testing.T) {
+	scanned := &scannedHandlers{pkgName: "app", coreImport: defaultCoreImport}
+
+	source, err := routeHandlersSource(scanned, []string{"AboutHandler", "HomeHandler"})
+	if err != nil {
+		t.Fatalf("Expected valid Go source, got %v", err)
+	}
+
+	expected := `// Code generated by godin generate routes. DO NOT EDIT.
+
+package app
+
+import "github.com/gideonsigilai/godin/pkg/core"
+
+// routeHandlers maps the handler names in routes.yaml to their functions, for app.LoadRoutes
+var routeHandlers = map[string]core.Handler{
+	"AboutHandler": AboutHandler,
+	"HomeHandler":  HomeHandler,
+}
+`
+	if string(source) != expected {
+		t.Errorf("Expected:\n%s\ngot:\n%s", expected, source)
+	}
+}
@@ -1,7 +1,9 @@
 package main
 
 import (
+	"bytes"
 	"fmt"
+	"io"
 	"log"
 	"net"
 	"net/http"
@@ -50,23 +52,29 @@ This command starts a development server with the following features:
 - Hot refresh: Refreshes the browser when static files change
 - Interactive commands: 'r' for manual hot reload, 'R' for manual hot refresh
 - File watching: Monitors file changes in real-time
+- Stable URL: A dev proxy keeps the browser on --port while the app restarts behind it
 
 Examples:
   godin serve                    # Start server on default port 8080
   godin serve --port 3000        # Start server on custom port
+  godin serve --host 0.0.0.0     # Listen on all interfaces (e.g. for testing on mobile devices)
   godin serve --watch            # Enable file watching (default)
   godin serve --listen           # Enable interactive commands`,
 	Run: func(cmd *cobra.Command, args []string) {
 		fmt.Println("🚀 Godin serve command started")
 
 		port, _ := cmd.Flags().GetString("port")
+		host, _ := cmd.Flags().GetString("host")
 		watch, _ := cmd.Flags().GetBool("watch")
 		listen, _ := cmd.Flags().GetBool("listen")
 		enhancedReload, _ := cmd.Flags().GetBool("enhanced-reload")
 		restartRetries, _ := cmd.Flags().GetInt("restart-retries")
 		debounce, _ := cmd.Flags().GetDuration("debounce")
 
-		fmt.Printf("📋 Parsed flags: port=%s, watch=%v, listen=%v\n", port, watch, listen)
+		fmt.Printf("📋 Parsed flags: host=%s, port=%s, watch=%v, listen=%v\n", host, port, watch, listen)
+
+		setConfigEnv("development")
+		setServerHost(host)
 
 		startDevServerEnhanced(port, watch, listen, enhancedReload, restartRetries, debounce)
 	},
@@ -80,14 +88,57 @@ var buildCmd = &cobra.Command{
 This command compiles your application into a standalone executable named 'app.exe'
 (or 'app' on Unix systems) that can be deployed to production servers.
 
+Use --target to cross-compile for other platforms, e.g. a Linux server from a
+Windows or macOS machine, or --all for every common platform. Cross-compiled
+executables get a platform suffix such as app-linux-amd64. Builds are static
+(CGO_ENABLED=0) unless --cgo is set.
+
 Examples:
   godin build                    # Build to app.exe in current directory
   godin build --output dist/     # Build to dist/app.exe
-  godin build --name myapp       # Build to myapp.exe`,
+  godin build --name myapp       # Build to myapp.exe
+  godin build --target linux/amd64              # Build app-linux-amd64
+  godin build --target linux/amd64,linux/arm64  # Build for several platforms
+  godin build --all --output dist/              # Build every common platform into dist/
+  godin build --prod             # Executable loads the config.production profile unless GODIN_ENV is set`,
 	Run: func(cmd *cobra.Command, args []string) {
 		output, _ := cmd.Flags().GetString("output")
 		name, _ := cmd.Flags().GetString("name")
-		buildApp(output, name)
+		targets, _ := cmd.Flags().GetStringSlice("target")
+		all, _ := cmd.Flags().GetBool("all")
+		cgo, _ := cmd.Flags().GetBool("cgo")
+		prod, _ := cmd.Flags().GetBool("prod")
+		if all {
+			targets = commonBuildTargets
+		}
+		buildApp(output, name, targets, cgo, prod)
+	},
+}
+
+var cleanCmd = &cobra.Command{
+	Use:   "clean",
+	Short: "Remove build artifacts",
+	Long: `Remove the files godin build and godin serve leave in the project: the
+executable (and its cross-compiled app-GOOS-GOARCH variants), the dist/ and
+bin/ directories, and temp_build_check files left by the pre-build check.
+
+--cache also clears the Go build cache, and --all the downloaded module cache
+as well. Both caches are shared by every Go project on the machine, so the next
+build of any project rebuilds or downloads what it needs again. --all asks for
+confirmation before clearing the module cache; --yes skips the question.
+
+Examples:
+  godin clean                    # Remove build artifacts
+  godin clean --name myapp       # The executable was built with --name myapp
+  godin clean --cache            # Also clear the machine-wide Go build cache
+  godin clean --all              # Also clear the build and module caches, after confirming
+  godin clean --all --yes        # The same without asking, e.g. in CI`,
+	Run: func(cmd *cobra.Command, args []string) {
+		name, _ := cmd.Flags().GetString("name")
+		cache, _ := cmd.Flags().GetBool("cache")
+		all, _ := cmd.Flags().GetBool("all")
+		yes, _ := cmd.Flags().GetBool("yes")
+		cleanProject(name, cache || all, all && (yes || confirmModCacheClean()))
 	},
 }
 
@@ -106,14 +157,68 @@ This command starts your application with:
 Examples:
   godin run                      # Run on default port 8080
   godin run --port 3000          # Run on custom port
+  godin run --host 192.168.1.10  # Listen on a specific LAN address
   godin run --no-debug           # Run without debug features`,
 	Run: func(cmd *cobra.Command, args []string) {
 		port, _ := cmd.Flags().GetString("port")
+		host, _ := cmd.Flags().GetString("host")
 		debug, _ := cmd.Flags().GetBool("debug")
+		setConfigEnv("development")
+		setServerHost(host)
 		runApp(port, debug)
 	},
 }
 
+var routesCmd = &cobra.Command{
+	Use:   "routes",
+	Short: "List the application's registered routes",
+	Long: `List every route the application registers, with its method and handler.
+
+The application is started in a list-routes mode (GODIN_LIST_ROUTES=1) in which
+app.Serve prints the route table and exits instead of listening. With --static,
+the routes declared in routes.yaml are read from the file instead, without
+building or running the app.
+
+Examples:
+  godin routes                   # Print METHOD, PATH and HANDLER for each route
+  godin routes --static          # Print the routes declared in routes.yaml`,
+	Run: func(cmd *cobra.Command, args []string) {
+		static, _ := cmd.Flags().GetBool("static")
+		if static {
+			file, _ := cmd.Flags().GetString("file")
+			printRouteFile(file)
+			return
+		}
+		listRoutes()
+	},
+}
+
+var generateCmd = &cobra.Command{
+	Use:   "generate",
+	Short: "Generate code and configuration",
+}
+
+var generateRoutesCmd = &cobra.Command{
+	Use:   "routes",
+	Short: "Declare the project's handlers in routes.yaml",
+	Long: `Scan the Go files in the current directory for handlers, functions of the
+form func(ctx *core.Context) widgets.Widget, and declare a route in routes.yaml for
+each handler that has none yet. Paths are derived from handler names
+(UserProfileHandler serves GET /user-profile, AddTodoHandler POST /add-todo); edit
+them in routes.yaml, which later runs keep as it is.
+
+It also writes routes_gen.go with a routeHandlers map from handler names to
+functions, so the app can register the routes with:
+
+  app.LoadRoutes("routes.yaml", routeHandlers)
+
+Examples:
+  godin generate routes          # Update routes.yaml and routes_gen.go`,
+	Run: func(cmd *cobra.Command, args []string) {
+		generateRoutes()
+	},
+}
+
 var packageCmd = &cobra.Command{
 	Use:   "package",
 	Short: "Package management commands",
@@ -207,6 +312,7 @@ Examples:
 func init() {
 	// Serve command flags
 	serveCmd.Flags().StringP("port", "p", "8080", "Server port")
+	serveCmd.Flags().String("host", "", "Host interface to bind (defaults to package.yaml server.host, or all interfaces)")
 	serveCmd.Flags().BoolP("watch", "w", true, "Enable file watching")
 	serveCmd.Flags().BoolP("listen", "l", false, "Enable interactive commands (r for reload, R for refresh)")
 	serveCmd.Flags().Bool("enhanced-reload", true, "Enable enhanced hot-reload with build caching and health monitoring")
@@ -216,9 +322,24 @@ func init() {
 	// Build command flags
 	buildCmd.Flags().StringP("output", "o", ".", "Output directory")
 	buildCmd.Flags().StringP("name", "n", "app", "Output executable name (without extension)")
+	buildCmd.Flags().StringSliceP("target", "t", nil, "GOOS/GOARCH platforms to build for, e.g. linux/amd64 (repeatable or comma separated)")
+	buildCmd.Flags().Bool("all", false, "Build for every common platform: "+strings.Join(commonBuildTargets, ", "))
+	buildCmd.Flags().Bool("cgo", false, "Enable cgo (disabled by default for static executables)")
+	buildCmd.Flags().Bool("prod", false, "Default the executable to the production config profile (GODIN_ENV still overrides it)")
+
+	// Routes command flags
+	routesCmd.Flags().Bool("static", false, "Read the routes declared in a routes file instead of running the app")
+	routesCmd.Flags().String("file", "routes.yaml", "Routes file read with --static")
+
+	// Clean command flags
+	cleanCmd.Flags().StringP("name", "n", "app", "Executable name used with godin build --name")
+	cleanCmd.Flags().Bool("cache", false, "Also clear the Go build cache, shared by every Go project on the machine")
+	cleanCmd.Flags().Bool("all", false, "Also clear the Go build cache and the downloaded module cache, after confirming")
+	cleanCmd.Flags().Bool("yes", false, "Clear the module cache with --all without asking")
 
 	// Run command flags
 	runCmd.Flags().StringP("port", "p", "8080", "Server port")
+	runCmd.Flags().String("host", "", "Host interface to bind (defaults to package.yaml server.host, or all interfaces)")
 	runCmd.Flags().Bool("debug", true, "Enable debug mode (default: true)")
 
 	// Package add command flags
@@ -238,12 +359,16 @@ func init() {
 	packageCmd.AddCommand(packageAddCmd)
 	packageCmd.AddCommand(packageListCmd)
 	packageCmd.AddCommand(packageRemoveCmd)
+	generateCmd.AddCommand(generateRoutesCmd)
 
 	rootCmd.AddCommand(initCmd)
 	rootCmd.AddCommand(createCmd)
 	rootCmd.AddCommand(runCmd)
 	rootCmd.AddCommand(serveCmd)
 	rootCmd.AddCommand(buildCmd)
+	rootCmd.AddCommand(cleanCmd)
+	rootCmd.AddCommand(routesCmd)
+	rootCmd.AddCommand(generateCmd)
 	rootCmd.AddCommand(getCmd)
 	rootCmd.AddCommand(packageCmd)
 }
@@ -464,8 +589,62 @@ var (
 	buildInProgress bool
 	restartQueue    = make(chan restartRequest, 10)
 	serverHealth    = make(chan bool, 1)
+	serverBuildFail = make(chan bool, 1)
+	lastBuildOK     bool
 )
 
+// buildFailureLimit is how many builds in a row may fail before automatic
+// restarts stop until the next file change
+const buildFailureLimit = 3
+
+var (
+	buildFailures   int  // Builds that failed in a row
+	waitingForFix   bool // Automatic restarts are paused until a file changes
+	buildStateMutex sync.Mutex
+)
+
+// recordBuildResult counts builds failing in a row; at buildFailureLimit it
+// pauses automatic restarts, saying so once instead of logging every retry
+func recordBuildResult(ok bool) {
+	buildStateMutex.Lock()
+	defer buildStateMutex.Unlock()
+
+	if ok {
+		buildFailures = 0
+		waitingForFix = false
+		return
+	}
+
+	buildFailures++
+	if buildFailures >= buildFailureLimit && !waitingForFix {
+		waitingForFix = true
+		log.Printf("⏸️  Build failed %d times in a row - waiting for you to fix the error. Save a file to try again.", buildFailures)
+	}
+}
+
+// buildsPaused reports whether automatic restarts are paused after repeated build failures
+func buildsPaused() bool {
+	buildStateMutex.Lock()
+	defer buildStateMutex.Unlock()
+	return waitingForFix
+}
+
+// allowRestart reports whether a restart may go ahead. While builds are paused,
+// health-check restarts are dropped; file changes and manual reloads resume them.
+func allowRestart(reason string) bool {
+	buildStateMutex.Lock()
+	defer buildStateMutex.Unlock()
+
+	if !waitingForFix {
+		return true
+	}
+	if reason == "health-check-failure" {
+		return false
+	}
+	waitingForFix = false
+	return true
+}
+
 // restartRequest represents a server restart request with context
 type restartRequest struct {
 	reason    string
@@ -527,7 +706,8 @@ func startInteractiveListener() {
 
 // startServerProcess starts the Go application server with enhanced hot-reload
 func startServerProcess(port string, watch bool) {
-	// Set the current server port for hot refresh
+	// Keep the requested port stable behind the dev proxy, and track the app's port for hot refresh
+	port = startDevProxyFor(port)
 	currentServerPort = port
 
 	// Handle Ctrl+C gracefully
@@ -570,7 +750,8 @@ func startServerProcess(port string, watch bool) {
 
 // startServerProcessEnhanced starts the Go application server with configurable enhanced hot-reload features
 func startServerProcessEnhanced(port string, watch bool, restartRetries int, debounce time.Duration) {
-	// Set the current server port for hot refresh
+	// Keep the requested port stable behind the dev proxy, and track the app's port for hot refresh
+	port = startDevProxyFor(port)
 	currentServerPort = port
 
 	// Handle Ctrl+C gracefully
@@ -662,6 +843,10 @@ func startServer(port string) {
 	// Pre-build check to catch compilation errors early
 	if !performPreBuildCheck() {
 		log.Printf("❌ Pre-build check failed, skipping server start")
+		select {
+		case serverBuildFail <- true:
+		default:
+		}
 		return
 	}
 
@@ -689,6 +874,11 @@ func startServer(port string) {
 		env = append(env, "GODIN_PORT="+port)
 	}
 
+	// Behind the dev proxy the app only needs to be reachable locally
+	if activeDevProxy != nil {
+		env = append(env, "GODIN_HOST=127.0.0.1")
+	}
+
 	serverCmd.Env = env
 
 	if err := serverCmd.Start(); err != nil {
@@ -697,7 +887,12 @@ func startServer(port string) {
 	}
 
 	log.Printf("✅ Server started successfully (PID: %d)", serverCmd.Process.Pid)
-	log.Printf("🌐 Visit http://localhost:%s", port)
+	if activeDevProxy != nil {
+		activeDevProxy.SetBackend(port)
+		log.Printf("🌐 Visit %s", serverURL(activeDevProxy.port))
+	} else {
+		log.Printf("🌐 Visit %s", serverURL(port))
+	}
 
 	// Wait a moment for server to fully start
 	time.Sleep(1 * time.Second)
@@ -779,6 +974,48 @@ func triggerHotReload() {
 // Global variable to track current server port
 var currentServerPort string = "8080"
 
+// Global variable to track the host interface the server binds to ("" means all interfaces)
+var currentServerHost string
+
+// defaultEnvSymbol is the variable holding the config profile an app loads without GODIN_ENV
+const defaultEnvSymbol = "github.com/gideonsigilai/godin/pkg/core.DefaultEnv"
+
+// setConfigEnv selects the config profile the app loads, unless GODIN_ENV already names one
+func setConfigEnv(env string) {
+	if os.Getenv("GODIN_ENV") == "" {
+		os.Setenv("GODIN_ENV", env)
+	}
+	log.Printf("⚙️  Config profile: %s", os.Getenv("GODIN_ENV"))
+}
+
+// setServerHost resolves the bind host from the --host flag or package.yaml server.host
+// and exports it as GODIN_HOST so the app's Serve listens on host:port
+func setServerHost(host string) {
+	if host == "" {
+		if config, err := loadPackageConfig("."); err == nil {
+			host = config.Config.Server.Host
+		}
+	}
+
+	currentServerHost = host
+	if host != "" {
+		os.Setenv("GODIN_HOST", host)
+		log.Printf("🌐 Binding to host %s", host)
+	}
+}
+
+// serverURL returns the base URL used to reach the local server on the given port
+func serverURL(port string) string {
+	port = strings.TrimPrefix(port, ":")
+
+	host := currentServerHost
+	if host == "" || host == "0.0.0.0" || host == "::" {
+		host = "localhost"
+	}
+
+	return "http://" + net.JoinHostPort(host, port)
+}
+
 // triggerHotRefresh triggers a browser refresh (via WebSocket if available)
 func triggerHotRefresh() {
 	log.Println("🔄 Hot refresh signal sent")
@@ -788,15 +1025,9 @@ func triggerHotRefresh() {
 		// Wait a moment for server to be ready
 		time.Sleep(200 * time.Millisecond)
 
-		// Prepare the URL - handle both :port and port formats
-		port := currentServerPort
-		if !strings.HasPrefix(port, ":") {
-			port = ":" + port
-		}
-
 		// Try to send hot refresh signal to the running server
 		client := &http.Client{Timeout: 2 * time.Second}
-		url := fmt.Sprintf("http://localhost%s/api/hot-refresh", port)
+		url := serverURL(currentServerPort) + "/api/hot-refresh"
 
 		log.Printf("🔄 Sending hot refresh to: %s", url)
 
@@ -821,6 +1052,10 @@ func triggerHotRefresh() {
 
 // queueRestart adds a restart request to the queue
 func queueRestart(reason, port string) {
+	if !allowRestart(reason) {
+		return
+	}
+
 	req := restartRequest{
 		reason:    reason,
 		timestamp: time.Now(),
@@ -868,19 +1103,28 @@ func processRestartQueue() {
 
 // performRestart performs the actual server restart with enhanced error handling
 func performRestart(port string) {
+	// Hold the build lock only to claim the restart; the pre-build check takes it while starting
 	buildMutex.Lock()
-	defer buildMutex.Unlock()
-
 	if buildInProgress {
+		buildMutex.Unlock()
 		log.Println("⚠️  Build already in progress, skipping restart")
 		return
 	}
-
 	buildInProgress = true
+	buildMutex.Unlock()
+
 	defer func() {
+		buildMutex.Lock()
 		buildInProgress = false
+		buildMutex.Unlock()
 	}()
 
+	// Forget a build failure reported before this restart
+	select {
+	case <-serverBuildFail:
+	default:
+	}
+
 	log.Println("🔄 Initiating enhanced server restart...")
 
 	// Stop the current server
@@ -923,6 +1167,10 @@ func performRestart(port string) {
 		case <-serverHealth:
 			log.Printf("✅ Server restart successful on attempt %d", i+1)
 			return
+		case <-serverBuildFail:
+			// Building the same code again would fail the same way
+			log.Println("❌ Build failed - not retrying until the code changes")
+			return
 		case <-time.After(10 * time.Second):
 			log.Printf("⚠️  Server health check timeout on attempt %d", i+1)
 			if i < maxRetries-1 {
@@ -944,6 +1192,10 @@ func monitorServerHealth() {
 	for {
 		select {
 		case <-ticker.C:
+			// A server stopped by a build error stays down until the code changes
+			if buildsPaused() {
+				continue
+			}
 			if serverCmd != nil && serverCmd.Process != nil {
 				// Check if process is still running
 				if err := serverCmd.Process.Signal(syscall.Signal(0)); err != nil {
@@ -961,9 +1213,9 @@ func performPreBuildCheck() bool {
 	buildMutex.Lock()
 	defer buildMutex.Unlock()
 
-	// Skip if we just did a build check recently
+	// Reuse the result of a build check done moments ago
 	if time.Since(lastBuildTime) < 5*time.Second {
-		return true
+		return lastBuildOK
 	}
 
 	log.Println("🔍 Performing pre-build check...")
@@ -979,6 +1231,8 @@ func performPreBuildCheck() bool {
 	os.Remove("temp_build_check.exe")
 
 	lastBuildTime = time.Now()
+	lastBuildOK = err == nil
+	recordBuildResult(lastBuildOK)
 
 	if err != nil {
 		log.Printf("❌ Pre-build check failed: %v", err)
@@ -1308,6 +1562,12 @@ func handleFileChangeEvent(event fsnotify.Event) {
 
 	log.Printf("📝 File change detected: %s (type: %s)", filePath, event.Op.String())
 
+	// Theme files are watched and reloaded by the running app (no restart)
+	if fileName == "theme.json" || fileName == "theme.yaml" || fileName == "theme.yml" {
+		log.Printf("🎨 Theme file changed (%s) - app reloads theme in place", filePath)
+		return
+	}
+
 	switch ext {
 	case ".go", ".yaml", ".yml":
 		// Go files or config changes require hot reload (restart)
@@ -1330,7 +1590,16 @@ func handleFileChangeEvent(event fsnotify.Event) {
 	}
 }
 
-func buildApp(output, name string) {
+// commonBuildTargets are the platforms godin build --all compiles for
+var commonBuildTargets = []string{
+	"linux/amd64",
+	"linux/arm64",
+	"darwin/amd64",
+	"darwin/arm64",
+	"windows/amd64",
+}
+
+func buildApp(output, name string, targets []string, cgo, prod bool) {
 	log.Printf("Building Godin application...")
 
 	// Check if we're in a Godin project
@@ -1338,12 +1607,11 @@ func buildApp(output, name string) {
 		log.Fatal("Error: Not in a Godin project directory. Make sure package.yaml exists.")
 	}
 
-	// Determine output path
-	var outputPath string
-	if runtime.GOOS == "windows" {
-		outputPath = filepath.Join(output, name+".exe")
-	} else {
-		outputPath = filepath.Join(output, name)
+	// Validate every target before compiling any of them
+	for _, target := range targets {
+		if _, _, err := parseBuildTarget(target); err != nil {
+			log.Fatalf("Error: %v", err)
+		}
 	}
 
 	// Create output directory if it doesn't exist
@@ -1351,18 +1619,152 @@ func buildApp(output, name string) {
 		log.Fatalf("Failed to create output directory: %v", err)
 	}
 
-	// Build the application
-	log.Printf("Compiling to %s...", outputPath)
+	// Without targets, build for this machine under the plain name
+	if len(targets) == 0 {
+		outputPath := filepath.Join(output, executableName(name, runtime.GOOS))
+		if err := compileApp(outputPath, runtime.GOOS, runtime.GOARCH, cgo, prod); err != nil {
+			log.Fatalf("Build failed: %v", err)
+		}
+		log.Printf("✅ Build successful!")
+		reportExecutable(outputPath)
+		log.Printf("🚀 Ready for deployment!")
+		return
+	}
 
-	buildCmd := exec.Command("go", "build", "-o", outputPath, ".")
-	buildCmd.Stdout = os.Stdout
-	buildCmd.Stderr = os.Stderr
+	// Keep going after a failed target so one unsupported platform does not hide the rest
+	var failed []string
+	for _, target := range targets {
+		goos, goarch, _ := parseBuildTarget(target)
+		outputPath := filepath.Join(output, executableName(fmt.Sprintf("%s-%s-%s", name, goos, goarch), goos))
+		if err := compileApp(outputPath, goos, goarch, cgo, prod); err != nil {
+			log.Printf("❌ Build for %s failed: %v", target, err)
+			failed = append(failed, target)
+			continue
+		}
+		reportExecutable(outputPath)
+	}
+
+	if len(failed) > 0 {
+		log.Fatalf("Build failed for %s", strings.Join(failed, ", "))
+	}
+	log.Printf("✅ Built %d platforms successfully!", len(targets))
+	log.Printf("🚀 Ready for deployment!")
+}
+
+// cleanProject removes the project's build artifacts, and with cache or
+// modCache the Go build cache or module cache
+func cleanProject(name string, cache, modCache bool) {
+	// Only delete inside a Godin project, where these names are known to be ours
+	if !isGodinProject() {
+		log.Fatal("Error: Not in a Godin project directory. Make sure package.yaml exists.")
+	}
 
-	if err := buildCmd.Run(); err != nil {
-		log.Fatalf("Build failed: %v", err)
+	paths := []string{
+		executableName(name, runtime.GOOS),
+		"dist",
+		"bin",
+		"temp_build_check",
+		"temp_build_check.exe",
+	}
+	for _, target := range commonBuildTargets {
+		goos, goarch, _ := parseBuildTarget(target)
+		paths = append(paths, executableName(fmt.Sprintf("%s-%s-%s", name, goos, goarch), goos))
 	}
 
-	log.Printf("✅ Build successful!")
+	removed := 0
+	for _, path := range paths {
+		if _, err := os.Stat(path); err != nil {
+			continue
+		}
+		if err := os.RemoveAll(path); err != nil {
+			log.Printf("⚠️  Could not remove %s: %v", path, err)
+			continue
+		}
+		log.Printf("🗑️  Removed %s", path)
+		removed++
+	}
+	if removed == 0 {
+		log.Printf("✨ No build artifacts to remove")
+	}
+
+	if cache {
+		log.Printf("⚠️  The Go build cache is shared by every Go project on this machine; they will all rebuild from scratch")
+		runGoClean("build cache", "-cache")
+	}
+	if modCache {
+		runGoClean("module cache", "-modcache")
+	}
+	log.Printf("✅ Clean complete")
+}
+
+// confirmModCacheClean warns that the module cache is shared and asks before
+// clearing it; anything but "y" or "yes", including no terminal, keeps it
+func confirmModCacheClean() bool {
+	log.Printf("⚠️  The Go module cache holds the dependencies of every Go project on this machine;")
+	log.Printf("    they will all be downloaded again, which needs network access")
+	fmt.Print("Clear the module cache? [y/N] ")
+
+	var answer string
+	fmt.Scanln(&answer)
+	switch strings.ToLower(strings.TrimSpace(answer)) {
+	case "y", "yes":
+		return true
+	}
+	log.Printf("Keeping the module cache")
+	return false
+}
+
+// runGoClean clears one of Go's caches with go clean
+func runGoClean(what, flag string) {
+	log.Printf("🧹 Clearing the Go %s...", what)
+	cmd := exec.Command("go", "clean", flag)
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	if err := cmd.Run(); err != nil {
+		log.Printf("⚠️  Could not clear the Go %s: %v", what, err)
+	}
+}
+
+// parseBuildTarget splits a GOOS/GOARCH pair such as "linux/amd64"
+func parseBuildTarget(target string) (string, string, error) {
+	parts := strings.Split(strings.TrimSpace(target), "/")
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return "", "", fmt.Errorf("invalid target %q, expected GOOS/GOARCH such as linux/amd64", target)
+	}
+	return parts[0], parts[1], nil
+}
+
+// executableName adds the .exe extension Windows executables need
+func executableName(name, goos string) string {
+	if goos == "windows" {
+		return name + ".exe"
+	}
+	return name
+}
+
+// compileApp builds the project in the current directory for one platform; prod
+// builds load the production config profile unless GODIN_ENV says otherwise
+func compileApp(outputPath, goos, goarch string, cgo, prod bool) error {
+	log.Printf("Compiling %s/%s to %s...", goos, goarch, outputPath)
+
+	cgoEnabled := "0"
+	if cgo {
+		cgoEnabled = "1"
+	}
+
+	args := []string{"build", "-o", outputPath}
+	if prod {
+		args = append(args, "-ldflags", "-X "+defaultEnvSymbol+"=production")
+	}
+	buildCmd := exec.Command("go", append(args, ".")...)
+	buildCmd.Stdout = os.Stdout
+	buildCmd.Stderr = os.Stderr
+	buildCmd.Env = append(os.Environ(), "GOOS="+goos, "GOARCH="+goarch, "CGO_ENABLED="+cgoEnabled)
+	return buildCmd.Run()
+}
+
+// reportExecutable logs a built executable and its size
+func reportExecutable(outputPath string) {
 	log.Printf("📦 Executable created: %s", outputPath)
 
 	// Show file size
@@ -1371,8 +1773,25 @@ func buildApp(output, name string) {
 		sizeStr := formatFileSize(size)
 		log.Printf("📊 File size: %s", sizeStr)
 	}
+}
 
-	log.Printf("🚀 Ready for deployment!")
+func listRoutes() {
+	// Check if we're in a Godin project
+	if !isGodinProject() {
+		log.Fatal("Error: Not in a Godin project directory. Make sure package.yaml exists.")
+	}
+
+	// Keep startup logs out of the table, showing them only if the app fails
+	var stderr bytes.Buffer
+	listCmd := exec.Command("go", "run", ".")
+	listCmd.Stdout = os.Stdout
+	listCmd.Stderr = &stderr
+	listCmd.Env = append(os.Environ(), "GODIN_LIST_ROUTES=1")
+
+	if err := listCmd.Run(); err != nil {
+		os.Stderr.Write(stderr.Bytes())
+		log.Fatalf("Failed to list routes: %v", err)
+	}
 }
 
 func runApp(port string, debug bool) {
@@ -1434,7 +1853,7 @@ func runApp(port string, debug bool) {
 	}()
 
 	log.Printf("✅ Application started successfully!")
-	log.Printf("🌐 Visit http://localhost:%s to view your app", port)
+	log.Printf("🌐 Visit %s to view your app", serverURL(port))
 	log.Printf("⏹️  Press Ctrl+C to stop the server")
 
 	// Wait for interrupt signal
@@ -1528,6 +1947,12 @@ type PackageConfig struct {
 	Dependencies    map[string]PackageDependency `yaml:"dependencies"`
 	DevDependencies map[string]PackageDependency `yaml:"dev_dependencies"`
 	Scripts         map[string]string            `yaml:"scripts"`
+	Config          struct {
+		Server struct {
+			Host string `yaml:"host"`
+		} `yaml:"server"`
+		Proxy []DevProxyRoute `yaml:"proxy"` // Paths `godin serve` forwards to other backends
+	} `yaml:"config"`
 }
 
 // PackageDependency represents a package dependency
@@ -1551,6 +1976,20 @@ func loadPackageConfig(dir string) (*PackageConfig, error) {
 		return nil, fmt.Errorf("failed to parse package.yaml: %w", err)
 	}
 
+	// Merge the profile for GODIN_ENV, e.g. config.development, over the base config
+	if env := os.Getenv("GODIN_ENV"); env != "" {
+		var profiles struct {
+			Config map[string]yaml.Node `yaml:"config"`
+		}
+		if err := yaml.Unmarshal(data, &profiles); err == nil {
+			if profile, ok := profiles.Config[env]; ok {
+				if err := profile.Decode(&config.Config); err != nil {
+					return nil, fmt.Errorf("failed to parse package.yaml profile %s: %w", env, err)
+				}
+			}
+		}
+	}
+
 	return &config, nil
 }
 
@@ -1682,7 +2121,7 @@ func createPackageYaml(appName, description string) {
 			"build":   "godin build --prod",
 			"test":    "godin test",
 			"install": "go mod tidy",
-			"clean":   "rm -rf dist/ bin/",
+			"clean":   "godin clean",
 		},
 		"config": map[string]interface{}{
 			"server": map[string]interface{}{
@@ -1772,19 +2211,19 @@ func HomeHandler(ctx *Context) Widget {
 		Child: Column{
 			Children: []Widget{
 				Text{
-					Data: "Welcome to ` + appName + `!",
+					Data:    "Welcome to ` + appName + `!",
+					Variant: TextThemeHeadline,
 					TextStyle: &TextStyle{
-						FontSize:   &[]float64{32}[0],
 						FontWeight: FontWeightBold,
 						Color:      Color("#333"),
 					},
 				},
 				SizedBox{Height: &[]float64{20}[0]},
 				Text{
-					Data: "Your Godin app is ready. Start building amazing things!",
+					Data:    "Your Godin app is ready. Start building amazing things!",
+					Variant: TextThemeBody,
 					TextStyle: &TextStyle{
-						FontSize: &[]float64{16}[0],
-						Color:    Color("#666"),
+						Color: Color("#666"),
 					},
 				},
 			},
@@ -1825,6 +2264,7 @@ func createCounterTemplate(appName string) {
 import (
 	"fmt"
 	"log"
+	"time"
 
 	. "github.com/gideonsigilai/godin/pkg/godin"
 )
@@ -1949,6 +2389,9 @@ func HomeHandler(ctx *Context) Widget {
 	mainGoContent += `
 // AboutHandler renders the about page
 func AboutHandler(ctx *core.Context) widgets.Widget {
+	// The about page is the same for everyone, so browsers and CDNs may keep it
+	ctx.Cache(10*time.Minute, core.CachePublic())
+
 	return widgets.Container{
 		Style: "min-height: 100vh; font-family: 'Segoe UI', Tahoma, Geneva, Verdana, sans-serif;",
 		Child: widgets.Column{
@@ -2219,11 +2662,20 @@ func HomeHandler(ctx *Context) Widget {
 												Children: []widgets.Widget{
 													widgets.Expanded{
 														Child: widgets.TextField{
-															ID: "new-todo-input",
+															ID:        "new-todo-input",
+															AutoFocus: true,
 															Decoration: &widgets.InputDecoration{
 																HintText: "What needs to be done?",
 															},
 															Style: "padding: 10px; border: 1px solid #ddd; border-radius: 4px;",
+															// Enter adds the todo and swaps in the updated list
+															OnSubmittedBuilder: func(ctx *core.Context, text string) widgets.Widget {
+																addTodo(text)
+																ctx.RequestFocus("new-todo-input")
+																return todoItems()
+															},
+															SubmitTarget:  "#todo-items",
+															ClearOnSubmit: true,
 														},
 													},
 													widgets.SizedBox{Width: &[]float64{10}[0]},
@@ -2244,7 +2696,7 @@ func HomeHandler(ctx *Context) Widget {
 								widgets.Card{
 									Style: "padding: 20px; border-radius: 8px; box-shadow: 0 2px 4px rgba(0,0,0,0.1);",
 									Child: widgets.Column{
-										Children: append([]widgets.Widget{
+										Children: []widgets.Widget{
 											widgets.Text{
 												Data: fmt.Sprintf("Todo List (%d items)", len(todos)),
 												TextStyle: &widgets.TextStyle{
@@ -2254,7 +2706,8 @@ func HomeHandler(ctx *Context) Widget {
 												},
 											},
 											widgets.SizedBox{Height: &[]float64{15}[0]},
-										}, getTodoWidgets()...),
+											todoItems(),
+										},
 									},
 								},
 							},
@@ -2266,6 +2719,28 @@ func HomeHandler(ctx *Context) Widget {
 	}
 }
 
+// todoItems returns the todo list, swapped in place when a todo is added
+func todoItems() widgets.Widget {
+	return widgets.Column{
+		ID:       "todo-items",
+		Children: getTodoWidgets(),
+	}
+}
+
+// addTodo appends a new todo unless text is empty
+func addTodo(text string) {
+	if text == "" {
+		return
+	}
+	todos = append(todos, Todo{
+		ID:        nextID,
+		Text:      text,
+		Completed: false,
+	})
+	nextID++
+	log.Printf("Added todo: %s", text)
+}
+
 // getTodoWidgets returns a slice of widgets for each todo
 func getTodoWidgets() []widgets.Widget {
 	var todoWidgets []widgets.Widget
@@ -2321,7 +2796,8 @@ func createTodoItem(todo Todo) widgets.Widget {
 					OnPressed: func() {
 						log.Printf("Delete todo %d", todo.ID)
 					},
-					Style: "color: #f44336; cursor: pointer; padding: 5px;",
+					Style:   "color: #f44336; cursor: pointer; padding: 5px;",
+					Confirm: "Delete this todo?",
 				},
 			},
 		},
@@ -2332,21 +2808,11 @@ func createTodoItem(todo Todo) widgets.Widget {
 
 // AddTodoHandler adds a new todo
 func AddTodoHandler(ctx *core.Context) widgets.Widget {
-	text := ctx.FormValue("text")
-	if text != "" {
-		todos = append(todos, Todo{
-			ID:        nextID,
-			Text:      text,
-			Completed: false,
-		})
-		nextID++
-		log.Printf("Added todo: %s", text)
-	}
+	addTodo(ctx.FormValue("text"))
+	ctx.RequestFocus("new-todo-input")
 
 	// Return updated todo list
-	return widgets.Column{
-		Children: getTodoWidgets(),
-	}
+	return todoItems()
 }
 
 // ToggleTodoHandler toggles a todo's completion status
@@ -2988,6 +3454,11 @@ func fixFrameworkImports() error {
 		return fmt.Errorf("could not locate Godin framework source code")
 	}
 
+	// Keep the current go.mod and go.sum so a rewrite that breaks the build can be undone
+	moduleName := getModuleName()
+	originalMod, modErr := os.ReadFile("go.mod")
+	originalSum, sumErr := os.ReadFile("go.sum")
+
 	// Create or update go.mod to use local framework
 	goModContent := fmt.Sprintf(`module %s
 
@@ -2998,7 +3469,7 @@ replace github.com/gideonsigilai/godin => %s
 require (
 	github.com/gideonsigilai/godin v0.0.0-00010101000000-000000000000
 )
-`, getModuleName(), frameworkRoot)
+`, moduleName, frameworkRoot)
 
 	// Write the updated go.mod
 	fmt.Println("📝 Writing updated go.mod...")
@@ -3031,9 +3502,69 @@ require (
 		log.Printf("⚠️  Warning: go mod tidy timed out")
 	}
 
+	// Make sure the project's imports still resolve against the new go.mod
+	fmt.Println("🔍 Verifying project imports...")
+	if output, err := resolveImports(); err != nil {
+		fmt.Println("❌ Project imports no longer resolve, restoring go.mod")
+		restoreModFile("go.mod", originalMod, modErr == nil)
+		restoreModFile("go.sum", originalSum, sumErr == nil)
+
+		fmt.Printf("   Module name used: %s\n", moduleName)
+		fmt.Println("   The project's own imports must start with this module name.")
+		fmt.Println("   Set the module line in go.mod to the path your imports use, then try again.")
+		if output != "" {
+			fmt.Printf("   go list output:\n%s\n", output)
+		}
+		return fmt.Errorf("project imports do not resolve with module %q: %v", moduleName, err)
+	}
+	fmt.Println("✅ Project imports resolve")
+
 	return nil
 }
 
+// resolveImports runs go list -deps ./... to check that every import in the
+// project resolves, returning its error output; without -deps, go list does
+// not fail on missing imports
+func resolveImports() (string, error) {
+	var output bytes.Buffer
+	listCmd := exec.Command("go", "list", "-deps", "./...")
+	listCmd.Stdout = io.Discard
+	listCmd.Stderr = &output
+
+	if err := listCmd.Start(); err != nil {
+		return "", err
+	}
+	done := make(chan error, 1)
+	go func() {
+		done <- listCmd.Wait()
+	}()
+
+	select {
+	case err := <-done:
+		return strings.TrimSpace(output.String()), err
+	case <-time.After(30 * time.Second):
+		listCmd.Process.Kill()
+		<-done
+		return strings.TrimSpace(output.String()), fmt.Errorf("go list timed out")
+	}
+}
+
+// restoreModFile puts back a file's original contents, removing it if it did not exist
+func restoreModFile(path string, content []byte, existed bool) {
+	var err error
+	if existed {
+		err = os.WriteFile(path, content, 0644)
+	} else {
+		err = os.Remove(path)
+		if os.IsNotExist(err) {
+			err = nil
+		}
+	}
+	if err != nil {
+		log.Printf("⚠️  Warning: could not restore %s: %v", path, err)
+	}
+}
+
 // getModuleName extracts the module name from go.mod or uses directory name
 func getModuleName() string {
 	// Try to read existing go.mod
@@ -3078,16 +3609,10 @@ func testHotReloadEndpoints(port string) {
 	// Wait for server to be fully ready
 	time.Sleep(2 * time.Second)
 
-	// Prepare the URL - handle both :port and port formats
-	testPort := port
-	if !strings.HasPrefix(testPort, ":") {
-		testPort = ":" + testPort
-	}
-
 	client := &http.Client{Timeout: 3 * time.Second}
 
 	// Test the hot-refresh endpoint
-	url := fmt.Sprintf("http://localhost%s/api/hot-refresh", testPort)
+	url := serverURL(port) + "/api/hot-refresh"
 	log.Printf("🧪 Testing hot reload endpoint: %s", url)
 
 	resp, err := client.Post(url, "application/json", strings.NewReader(`{"type":"test"}`))
@@ -365,25 +365,20 @@ func InteractiveButtonSection() widgets.Widget {
 					MainAxisAlignment: widgets.MainAxisAlignmentSpaceAround,
 					Children: []widgets.Widget{
 						// Dynamic button that changes based on state
-						func() widgets.Widget {
-							var button widgets.Widget
-							if buttonEnabled {
-								button = widgets.ElevatedButton{
-									ID:    "dynamic-button",
-									Child: widgets.Text{Data: "Enabled Button"},
-									OnPressed: func() {
-										log.Println("Enabled button clicked")
-									},
-								}
-							} else {
-								button = widgets.ElevatedButton{
-									ID:    "dynamic-button",
-									Child: widgets.Text{Data: "Disabled Button"},
-									// OnPressed is nil, making it disabled
-								}
-							}
-							return button
-						}(),
+						widgets.IfElse(buttonEnabled,
+							widgets.ElevatedButton{
+								ID:    "dynamic-button",
+								Child: widgets.Text{Data: "Enabled Button"},
+								OnPressed: func() {
+									log.Println("Enabled button clicked")
+								},
+							},
+							widgets.ElevatedButton{
+								ID:    "dynamic-button",
+								Child: widgets.Text{Data: "Disabled Button"},
+								// OnPressed is nil, making it disabled
+							},
+						),
 
 						widgets.TextButton{
 							ID:    "toggle-button",
@@ -478,36 +473,18 @@ func StateManagementSection() widgets.Widget {
 
 				widgets.SizedBox{Height: &[]float64{20}[0]},
 
-				// Button type selectors with setState
-				widgets.Row{
-					MainAxisAlignment: widgets.MainAxisAlignmentSpaceAround,
-					Children: []widgets.Widget{
-						widgets.OutlinedButton{
-							ID:    "primary-btn",
-							Child: widgets.Text{Data: "Primary"},
-							OnPressed: func() {
-								selectedType = "primary"
-								log.Printf("Selected type: %s", selectedType)
-							},
-						},
-
-						widgets.OutlinedButton{
-							ID:    "secondary-btn",
-							Child: widgets.Text{Data: "Secondary"},
-							OnPressed: func() {
-								selectedType = "secondary"
-								log.Printf("Selected type: %s", selectedType)
-							},
-						},
-
-						widgets.OutlinedButton{
-							ID:    "danger-btn",
-							Child: widgets.Text{Data: "Danger"},
-							OnPressed: func() {
-								selectedType = "danger"
-								log.Printf("Selected type: %s", selectedType)
-							},
-						},
+				// Button type selector
+				widgets.SegmentedButton[string]{
+					ID: "button-type",
+					Segments: []widgets.ButtonSegment[string]{
+						{Value: "primary", Label: widgets.Text{Data: "Primary"}},
+						{Value: "secondary", Label: widgets.Text{Data: "Secondary"}},
+						{Value: "danger", Label: widgets.Text{Data: "Danger"}},
+					},
+					Selected: []string{selectedType},
+					OnSelectionChanged: func(selected []string) {
+						selectedType = selected[0]
+						log.Printf("Selected type: %s", selectedType)
 					},
 				},
 
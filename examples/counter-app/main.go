@@ -299,15 +299,7 @@ func SettingsHandler(ctx *core.Context) widgets.Widget {
 // AppBarWidget creates a reusable app bar with navigation
 func AppBarWidget(title string) widgets.Widget {
 	return widgets.AppBar{
-		Title: widgets.Text{
-			Data: title,
-			TextStyle: &widgets.TextStyle{
-				Color:      widgets.ColorWhite,
-				FontSize:   &[]float64{20}[0],
-				FontWeight: widgets.FontWeightBold,
-			},
-		},
-		Style: "background: linear-gradient(135deg, #2196F3, #1976D2); color: white; padding: 16px 20px; box-shadow: 0 2px 4px rgba(0,0,0,0.1);",
+		Title: widgets.Text{Data: title},
 		Actions: []widgets.Widget{
 			// Navigation menu
 			widgets.Row{
@@ -60,6 +60,9 @@ func createCustomLightTheme() *core.ThemeData {
 	theme.ColorScheme.Secondary = core.NewColor(156, 39, 176, 255)   // Purple
 	theme.ColorScheme.Surface = core.NewColor(255, 255, 255, 255)    // White
 	theme.ColorScheme.Background = core.NewColor(248, 249, 250, 255) // Light gray
+	theme.ColorScheme.OnPrimary = theme.ColorScheme.ContrastColor(theme.ColorScheme.Primary)
+	theme.ColorScheme.OnSecondary = theme.ColorScheme.ContrastColor(theme.ColorScheme.Secondary)
+	logThemeWarnings("light", theme)
 	return theme
 }
 
@@ -69,10 +72,20 @@ func createCustomDarkTheme() *core.ThemeData {
 	theme.ColorScheme = core.NewDarkColorScheme()
 	theme.ColorScheme.Primary = core.NewColor(144, 202, 249, 255)   // Light blue
 	theme.ColorScheme.Secondary = core.NewColor(206, 147, 216, 255) // Light purple
+	theme.ColorScheme.OnPrimary = theme.ColorScheme.ContrastColor(theme.ColorScheme.Primary)
+	theme.ColorScheme.OnSecondary = theme.ColorScheme.ContrastColor(theme.ColorScheme.Secondary)
 	theme.Brightness = core.BrightnessDark
+	logThemeWarnings("dark", theme)
 	return theme
 }
 
+// logThemeWarnings reports low-contrast color pairs in a custom theme
+func logThemeWarnings(name string, theme *core.ThemeData) {
+	for _, warning := range theme.Validate() {
+		log.Printf("⚠️ %s theme: %s", name, warning)
+	}
+}
+
 // HomeHandler renders the main showcase page
 func HomeHandler(ctx *core.Context) core.Widget {
 	theme := ctx.Theme()
@@ -635,24 +648,25 @@ func showAlertDialog(ctx *core.Context) {
 			WithColor(theme.ColorScheme.OnSurface).
 			WithFontSize(16),
 	}
+	var dialogID string
 	alertDialog.Actions = []core.Widget{
 		widgets.TextButton{
 			Child: widgets.Text{Content: "Cancel"},
 			OnPressed: func() {
-				widgets.DismissDialog(ctx, "current-dialog")
+				widgets.DismissDialog(ctx, dialogID)
 				log.Println("Dialog cancelled")
 			},
 		},
 		widgets.ElevatedButton{
 			Child: widgets.Text{Content: "OK"},
 			OnPressed: func() {
-				widgets.DismissDialog(ctx, "current-dialog")
+				widgets.DismissDialog(ctx, dialogID)
 				log.Println("Dialog confirmed")
 			},
 		},
 	}
 
-	widgets.ShowDialog(ctx, alertDialog, widgets.DialogOptions{
+	dialogID = widgets.ShowDialog(ctx, alertDialog, widgets.DialogOptions{
 		BarrierDismissible: true,
 	})
 }
@@ -693,12 +707,15 @@ func showCustomDialog(ctx *core.Context) {
 		},
 	}
 
-	widgets.ShowDialog(ctx, customDialog)
+	widgets.ShowDialog(ctx, customDialog, widgets.DialogOptions{
+		BarrierDismissible: true,
+	})
 }
 
 func showBottomSheet(ctx *core.Context) {
 	theme := ctx.Theme()
 
+	var sheetID string
 	bottomSheet := widgets.NewBottomSheet()
 	bottomSheet.Child = widgets.Container{
 		Padding: core.NewEdgeInsetsAll(24),
@@ -720,19 +737,24 @@ func showBottomSheet(ctx *core.Context) {
 				widgets.ElevatedButton{
 					Child: widgets.Text{Content: "Close"},
 					OnPressed: func() {
-						widgets.DismissBottomSheet(ctx, "current-sheet")
+						widgets.DismissBottomSheet(ctx, sheetID)
 					},
 				},
 			},
 		},
 	}
 
-	widgets.ShowBottomSheet(ctx, bottomSheet)
+	sheetID = widgets.ShowBottomSheet(ctx, bottomSheet, widgets.BottomSheetOptions{
+		IsDraggable:    true,
+		EnableDrag:     true,
+		ShowDragHandle: true,
+	})
 }
 
 func showModalBottomSheet(ctx *core.Context) {
 	theme := ctx.Theme()
 
+	var sheetID string
 	modalBottomSheet := widgets.NewModalBottomSheet()
 	modalBottomSheet.Child = widgets.Container{
 		Padding: core.NewEdgeInsetsAll(24),
@@ -757,13 +779,13 @@ func showModalBottomSheet(ctx *core.Context) {
 						widgets.OutlinedButton{
 							Child: widgets.Text{Content: "Cancel"},
 							OnPressed: func() {
-								widgets.DismissBottomSheet(ctx, "current-modal-sheet")
+								widgets.DismissBottomSheet(ctx, sheetID)
 							},
 						},
 						widgets.ElevatedButton{
 							Child: widgets.Text{Content: "Confirm"},
 							OnPressed: func() {
-								widgets.DismissBottomSheet(ctx, "current-modal-sheet")
+								widgets.DismissBottomSheet(ctx, sheetID)
 								log.Println("Modal bottom sheet confirmed")
 							},
 						},
@@ -773,7 +795,11 @@ func showModalBottomSheet(ctx *core.Context) {
 		},
 	}
 
-	widgets.ShowModalBottomSheet(ctx, modalBottomSheet)
+	sheetID = widgets.ShowModalBottomSheet(ctx, modalBottomSheet, widgets.BottomSheetOptions{
+		IsDraggable:    true,
+		EnableDrag:     true,
+		ShowDragHandle: true,
+	})
 }
 
 // Additional handlers for different demo pages
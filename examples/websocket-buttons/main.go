@@ -111,8 +111,10 @@ func homePage() core.Widget {
 						current := core.GetStateInt("counter")
 						newValue := current + 1
 						fmt.Printf("Current: %d, New: %d\n", current, newValue)
-						core.SetState("counter", newValue)
-						core.SetState("message", fmt.Sprintf("✅ WebSocket button worked! Counter: %d", newValue))
+						core.SetStates(map[string]interface{}{
+							"counter": newValue,
+							"message": fmt.Sprintf("✅ WebSocket button worked! Counter: %d", newValue),
+						})
 						fmt.Println("✅ WEBSOCKET BUTTON CALLBACK COMPLETE!")
 					},
 				},
@@ -137,8 +139,10 @@ func homePage() core.Widget {
 							message = fmt.Sprintf("🔢 Odd! WebSocket added 10: %d → %d", counter, newCounter)
 						}
 
-						core.SetState("counter", newCounter)
-						core.SetState("message", message)
+						core.SetStates(map[string]interface{}{
+							"counter": newCounter,
+							"message": message,
+						})
 						fmt.Printf("Complex logic executed: %s\n", message)
 					},
 				},
@@ -150,8 +154,10 @@ func homePage() core.Widget {
 					Style: "margin: 10px; padding: 15px 30px; font-size: 16px; background: #dc3545; border: none; border-radius: 8px;",
 					OnPressed: func() {
 						fmt.Println("🔄 Reset button clicked!")
-						core.SetState("counter", 0)
-						core.SetState("message", "🔄 WebSocket reset complete! Ready for more testing.")
+						core.SetStates(map[string]interface{}{
+							"counter": 0,
+							"message": "🔄 WebSocket reset complete! Ready for more testing.",
+						})
 						fmt.Println("Reset executed successfully")
 					},
 				},
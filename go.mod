@@ -3,6 +3,7 @@ module github.com/gideonsigilai/godin
 go 1.24.4
 
 require (
+	github.com/andybalholm/brotli v1.2.5
 	github.com/fsnotify/fsnotify v1.9.0
 	github.com/gorilla/mux v1.8.1
 	github.com/gorilla/websocket v1.5.3
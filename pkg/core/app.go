@@ -3,8 +3,11 @@ package core
 import (
 	"fmt"
 	"log"
+	"net"
 	"net/http"
 	"os"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/gideonsigilai/godin/pkg/packages"
@@ -21,30 +24,42 @@ type App struct {
 	state              *state.StateManager
 	packages           *packages.PackageManager
 	config             *Config
-	handlers           map[string]Handler // Global handler registry
-	buttonCallbacks    map[string]func()  // Button callback registry for WebSocket (deprecated)
-	callbackRegistry   *CallbackRegistry  // New comprehensive callback registry
-	htmxIntegrator     *HTMXIntegrator    // HTMX integration system
-	dialogManager      interface{}        // Dialog management system (will be properly typed later)
-	navigator          interface{}        // Navigation system (will be properly typed later)
-	mediaQueryProvider interface{}        // MediaQuery system (will be properly typed later)
-	themeProvider      *ThemeProvider     // Theme management system
-}
-
-// Config holds application configuration
-type Config struct {
-	Server struct {
-		Port string `yaml:"port"`
-		Host string `yaml:"host"`
-	} `yaml:"server"`
-	WebSocket struct {
-		Enabled bool   `yaml:"enabled"`
-		Path    string `yaml:"path"`
-	} `yaml:"websocket"`
-	Static struct {
-		Dir   string `yaml:"dir"`
-		Cache bool   `yaml:"cache"`
-	} `yaml:"static"`
+	handlers           map[string]Handler    // Global handler registry
+	buttonCallbacks    map[string]func()     // Button callback registry for WebSocket (deprecated)
+	callbackRegistry   *CallbackRegistry     // New comprehensive callback registry
+	htmxIntegrator     *HTMXIntegrator       // HTMX integration system
+	dialogManager      interface{}           // Dialog management system (will be properly typed later)
+	navigator          interface{}           // Navigation system (will be properly typed later)
+	mediaQueryProvider interface{}           // MediaQuery system (will be properly typed later)
+	themeProvider      *ThemeProvider        // Theme management system
+	breakpoints        Breakpoints           // Responsive breakpoints used by MediaQuery and builders
+	assets             *AssetManager         // Static asset fingerprinting
+	metrics            *Metrics              // Prometheus metrics (nil when disabled)
+	compression        *Compression          // Response compression (nil when disabled)
+	csrf               *CSRFGuard            // CSRF validation and public route registry
+	listeners          *ListenerRegistry     // Consumer/ValueListener subscriptions released on DOM removal
+	routeHandlers      map[*mux.Route]string // Handler names of routes registered with GET/POST/PUT/DELETE
+	basePath           string                // Prefix the app is mounted under, "" for the top-level app
+	mounts             []*mountedApp         // Sub-applications mounted with Mount
+	parent             *App                  // App this one is mounted in, nil for the top-level app
+	localizations      *Localizations        // Message catalogs used by ctx.T
+	db                 *DB                   // Pooled database from package.yaml or UseDB (nil when unused)
+	dbMutex            sync.Mutex            // Guards db and dbRetryAt
+	dbRetryAt          time.Time             // Earliest time DB retries a failed open
+	panicHandlers      []PanicHandler        // Reporters added with OnPanic
+	panicMutex         sync.RWMutex          // Guards panicHandlers
+	panicPage          Handler               // Page shown for panics outside debug mode, set with SetPanicPage
+	sessions           *SessionStore         // Server-side sessions behind ctx.Session
+	snackBars          *SnackBarController   // Snackbar queue settings behind ctx.ShowSnackBar
+	drafts             DraftStore            // Form drafts saved by Form.AutoSaveKey
+	stateRefreshers    []StateRefresher      // Out-of-band refreshes for state changed without WebSocket
+	stateRefreshMutex  sync.RWMutex          // Guards stateRefreshers
+	uploadLimits       map[*mux.Route]int64  // Upload size limits of routes set with Route.MaxUploadSize
+	uploadMutex        sync.RWMutex          // Guards uploadLimits
+	syncableState      map[string]bool       // State keys clients may resync, see AllowStateSync
+	syncableMutex      sync.RWMutex          // Guards syncableState
+	themeFileBase      *ThemeData            // Theme before any theme file was applied, see LoadThemeFile
+	themeFileMutex     sync.Mutex            // Guards themeFileBase
 }
 
 // New creates a new Godin application
@@ -57,14 +72,25 @@ func New() *App {
 		websocket:       websocketManager,
 		state:           stateManager,
 		packages:        packages.NewPackageManager(),
-		config:          &Config{},
+		config:          LoadConfig("."),
 		handlers:        make(map[string]Handler),
 		buttonCallbacks: make(map[string]func()),
+		breakpoints:     DefaultBreakpoints,
+		csrf:            NewCSRFGuard(),
+		listeners:       NewListenerRegistry(),
+		routeHandlers:   make(map[*mux.Route]string),
+		uploadLimits:    make(map[*mux.Route]int64),
+		syncableState:   make(map[string]bool),
+		sessions:        NewSessionStore(),
+		snackBars:       NewSnackBarController(),
 	}
 
 	// Initialize callback registry
 	app.callbackRegistry = NewCallbackRegistry(app)
 
+	// Keep form drafts in the session until the app sets another store
+	app.drafts = &sessionDraftStore{sessions: app.sessions}
+
 	// Initialize HTMX integrator
 	app.htmxIntegrator = NewHTMXIntegrator(&HTMXConfig{
 		EndpointPrefix: "/api/callbacks",
@@ -77,16 +103,51 @@ func New() *App {
 	// Initialize global state management for native Go code execution
 	InitGlobalState()
 
+	// Start keys declared with DefineState at their initial values
+	seedDeclaredState(stateManager)
+
 	// Setup state API endpoints for Consumer widgets
 	app.setupStateAPI()
 
+	// Setup repaint boundary endpoint for partial updates
+	app.setupBoundaryAPI()
+
+	// Release listeners whose elements were removed from the page
+	app.setupListenerAPI()
+
+	// Save form drafts posted by autosaving forms
+	app.setupDraftAPI()
+
+	// Save the values of fields with a RestorationID
+	app.setupRestorationAPI()
+
+	// Answer client resync requests after WebSocket reconnects
+	websocketManager.SetSnapshotProvider(app.stateSnapshot)
+
 	// Setup WebSocket button click handling
 	app.setupButtonClickHandling()
 
 	// Setup hot-reload endpoints for development
 	app.setupHotReloadEndpoints()
 
+	// Serve the living style guide for development
+	app.setupStyleGuide()
+
+	// Load message catalogs for ctx.T from the i18n directory when it exists
+	app.localizations = NewLocalizations(app.config.I18n.DefaultLocale)
+	if err := app.localizations.LoadDir(app.config.I18n.Dir); err != nil && !os.IsNotExist(err) {
+		log.Printf("Failed to load message catalogs: %v", err)
+	}
+
 	app.server = NewServer(app)
+	app.assets = NewAssetManager(app.server.findWebStaticPath())
+	app.assets.SetEnabled(!app.config.Debug.DevMode)
+
+	// Enable WebSocket when package.yaml or the environment asks for it
+	app.websocket.SetHeartbeat(app.config.WebSocket.PingInterval, app.config.WebSocket.IdleTimeout)
+	if app.config.WebSocket.Enabled {
+		app.websocket.Enable(app.config.WebSocket.Path)
+	}
 	return app
 }
 
@@ -98,44 +159,93 @@ type Widget interface {
 // Handler represents a route handler function
 type Handler func(ctx *Context) Widget
 
-// GET registers a GET route handler
-func (app *App) GET(path string, handler Handler) {
-	app.router.HandleFunc(path, app.wrapHandler(handler)).Methods("GET")
+// GET registers a GET route handler.
+// Path parameters may be typed, e.g. "/users/{id:int}" or "/files/{id:uuid}",
+// and non-matching URLs are rejected with a 404 before the handler runs.
+func (app *App) GET(path string, handler Handler) *Route {
+	return app.handle("GET", path, handler)
 }
 
 // POST registers a POST route handler
-func (app *App) POST(path string, handler Handler) {
-	app.router.HandleFunc(path, app.wrapHandler(handler)).Methods("POST")
+func (app *App) POST(path string, handler Handler) *Route {
+	return app.handle("POST", path, handler)
 }
 
 // PUT registers a PUT route handler
-func (app *App) PUT(path string, handler Handler) {
-	app.router.HandleFunc(path, app.wrapHandler(handler)).Methods("PUT")
+func (app *App) PUT(path string, handler Handler) *Route {
+	return app.handle("PUT", path, handler)
 }
 
 // DELETE registers a DELETE route handler
-func (app *App) DELETE(path string, handler Handler) {
-	app.router.HandleFunc(path, app.wrapHandler(handler)).Methods("DELETE")
+func (app *App) DELETE(path string, handler Handler) *Route {
+	return app.handle("DELETE", path, handler)
+}
+
+// handle registers a route handler for the given method
+func (app *App) handle(method, path string, handler Handler) *Route {
+	expanded := expandRouteParams(path)
+	route := app.router.HandleFunc(expanded, checkRouteParams(path, app.wrapHandler(handler))).Methods(method)
+	app.routeHandlers[route] = handlerName(handler)
+	return &Route{app: app, path: expanded, route: route}
 }
 
 // wrapHandler wraps a Godin handler to work with HTTP
 func (app *App) wrapHandler(handler Handler) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		ctx := NewContext(w, r, app)
-		widget := handler(ctx)
+		start := ctx.startHandlerTiming()
+		widget := app.runHandler(handler, ctx)
+		ctx.recordTiming(timingHandler, start)
 
 		if widget != nil {
+			// In debug mode, ?__debug=tree shows the widget hierarchy instead of the page
+			if ctx.writeDebugTree(widget) {
+				return
+			}
+
 			// Use template rendering for full page responses
-			ctx.RenderTemplate(widget, "Godin App")
+			ctx.RenderTemplate(widget, app.config.Page.Title)
+		} else {
+			ctx.writeStateFragments()
 		}
 	}
 }
 
-// Serve starts the application server
+// runHandler calls a handler with its state changes batched, so several
+// SetState calls produce a single WebSocket broadcast
+func (app *App) runHandler(handler Handler, ctx *Context) (widget Widget) {
+	ctx.Batch(func() {
+		widget = handler(ctx)
+	})
+	return widget
+}
+
+// Serve starts the application server; an empty addr uses the configured host and port,
+// and a port-only addr such as ":8080" binds to the configured host (server.host or GODIN_HOST)
 func (app *App) Serve(addr string) error {
+	if addr == "" {
+		addr = app.config.Addr()
+	} else if strings.HasPrefix(addr, ":") && app.config.Server.Host != "" {
+		addr = net.JoinHostPort(app.config.Server.Host, strings.TrimPrefix(addr, ":"))
+	}
+
+	// `godin routes` runs the app only to list what it registers
+	if os.Getenv(ListRoutesEnv) != "" {
+		if err := app.PrintRoutes(os.Stdout); err != nil {
+			return err
+		}
+		os.Exit(0)
+	}
+
+	app.loadThemeFiles()
 	return app.server.Start(addr)
 }
 
+// Config returns the application configuration loaded from package.yaml and GODIN_* env vars
+func (app *App) Config() *Config {
+	return app.config
+}
+
 // WebSocket returns the WebSocket manager
 func (app *App) WebSocket() *WebSocketManager {
 	return app.websocket
@@ -161,7 +271,7 @@ func GetEnvPort() string {
 // setupHotReloadEndpoints sets up development hot-reload endpoints
 func (app *App) setupHotReloadEndpoints() {
 	// Only setup in development mode
-	if os.Getenv("GODIN_DEV_MODE") != "true" {
+	if !app.config.Debug.DevMode {
 		return
 	}
 
@@ -214,10 +324,16 @@ func (app *App) RegisterHandler(handler Handler) string {
 	// Register the handler with the app's router
 	app.router.HandleFunc("/handlers/"+handlerID, func(w http.ResponseWriter, r *http.Request) {
 		ctx := NewContext(w, r, app)
-		widget := handler(ctx)
+		start := ctx.startHandlerTiming()
+		widget := app.runHandler(handler, ctx)
+		ctx.recordTiming(timingHandler, start)
 		if widget != nil {
-			html := widget.Render(ctx)
+			start = time.Now()
+			html := ctx.renderWidget(widget) + ctx.stateFragmentsHTML() + ctx.pageAssetsFragment()
+			ctx.recordTiming(timingRender, start)
 			ctx.WriteHTML(html)
+		} else {
+			ctx.writeStateFragments()
 		}
 	}).Methods("GET", "POST", "PUT", "DELETE")
 
@@ -236,25 +352,30 @@ func (app *App) GetHandlerCount() int {
 
 // ExecuteButtonCallback executes a button callback by ID
 func (app *App) ExecuteButtonCallback(buttonID string) bool {
+	// Create a proper context for state operations
+	// We create a minimal context that has access to the app and state
+	ctx := &Context{
+		App:   app,
+		state: make(map[string]interface{}),
+	}
+	return app.executeButtonCallback(ctx, buttonID)
+}
+
+// executeButtonCallback executes a button callback by ID with ctx as the current
+// context, so the state it sets is recorded on ctx
+func (app *App) executeButtonCallback(ctx *Context, buttonID string) bool {
 	fmt.Printf("🔍 ExecuteButtonCallback called for buttonID: %s\n", buttonID)
 
 	if callback, exists := app.buttonCallbacks[buttonID]; exists {
 		fmt.Printf("✅ Button callback found for ID: %s\n", buttonID)
 
-		// Create a proper context for state operations
-		// We create a minimal context that has access to the app and state
-		ctx := &Context{
-			App:   app,
-			state: make(map[string]interface{}),
-		}
-
 		fmt.Printf("🔧 Setting global context for button callback\n")
 		// Set up global state context so core.SetState() and core.GetStateInt() work
 		SetGlobalContext(ctx)
 
 		fmt.Printf("🚀 Executing button callback for ID: %s\n", buttonID)
-		// Execute the callback
-		callback()
+		// Execute the callback, coalescing its state changes into one broadcast
+		ctx.Batch(callback)
 
 		fmt.Printf("🧹 Cleaning up global context\n")
 		// Clean up global context
@@ -277,7 +398,13 @@ func (app *App) setupButtonClickHandling() {
 
 		fmt.Printf("Button click received: %s\n", buttonID)
 
-		if app.ExecuteButtonCallback(buttonID) {
+		ctx := NewContext(w, r, app)
+		if app.executeButtonCallback(ctx, buttonID) {
+			// Without a WebSocket, Consumers of the state it set refresh out of band
+			if html := ctx.stateFragmentsHTML(); html != "" {
+				ctx.WriteHTML(html)
+				return
+			}
 			w.WriteHeader(http.StatusOK)
 			w.Write([]byte("OK"))
 		} else {
@@ -313,6 +440,32 @@ func (app *App) setupStateAPI() {
 	}).Methods("GET")
 }
 
+// AllowStateSync lets clients read the current values of keys when they
+// resync after a WebSocket reconnect. Consumers allow their key as they render,
+// so only state shown on some page is ever sent; other keys stay on the server.
+func (app *App) AllowStateSync(keys ...string) {
+	app.syncableMutex.Lock()
+	defer app.syncableMutex.Unlock()
+	for _, key := range keys {
+		app.syncableState[key] = true
+	}
+}
+
+// stateSnapshot returns the current values of the requested state keys that
+// clients may resync; keys not allowed with AllowStateSync are left out
+func (app *App) stateSnapshot(keys []string) map[string]interface{} {
+	app.syncableMutex.RLock()
+	defer app.syncableMutex.RUnlock()
+
+	values := make(map[string]interface{}, len(keys))
+	for _, key := range keys {
+		if app.syncableState[key] {
+			values[key] = app.state.Get(key)
+		}
+	}
+	return values
+}
+
 // StateConsumer is a simple widget for rendering state values in API responses
 type StateConsumer struct {
 	StateKey string
@@ -343,6 +496,26 @@ func (sc *StateConsumer) Render(ctx *Context) string {
 	}
 }
 
+// Assets returns the static asset manager
+func (app *App) Assets() *AssetManager {
+	return app.assets
+}
+
+// AssetURL returns the (fingerprinted) URL for a static asset path such as "css/app.css"
+func (app *App) AssetURL(assetPath string) string {
+	return app.assets.URL(assetPath)
+}
+
+// ReadAsset returns the contents of a static asset such as "icons/logo.svg"
+func (app *App) ReadAsset(assetPath string) ([]byte, error) {
+	return app.assets.Read(assetPath)
+}
+
+// SetAssetFingerprinting enables or disables content-hashed static asset URLs
+func (app *App) SetAssetFingerprinting(enabled bool) {
+	app.assets.SetEnabled(enabled)
+}
+
 // Router returns the underlying mux router for advanced routing
 func (app *App) Router() *mux.Router {
 	return app.router
@@ -415,9 +588,31 @@ func (app *App) MediaQueryProvider() *MediaQueryProvider {
 
 // SetMediaQueryProvider sets the media query provider
 func (app *App) SetMediaQueryProvider(provider *MediaQueryProvider) {
+	if provider != nil {
+		provider.SetBreakpoints(app.breakpoints)
+	}
 	app.mediaQueryProvider = provider
 }
 
+// Breakpoints returns the responsive breakpoints configured for the app
+func (app *App) Breakpoints() Breakpoints {
+	return app.breakpoints
+}
+
+// SetBreakpoints sets the responsive breakpoints; unset values fall back to the defaults
+func (app *App) SetBreakpoints(breakpoints Breakpoints) {
+	app.breakpoints = breakpoints.withDefaults()
+	if provider := app.MediaQueryProvider(); provider != nil {
+		provider.SetBreakpoints(app.breakpoints)
+	}
+}
+
+// WithBreakpoints sets custom responsive breakpoints (builder pattern)
+func (app *App) WithBreakpoints(breakpoints Breakpoints) *App {
+	app.SetBreakpoints(breakpoints)
+	return app
+}
+
 // ThemeProvider returns the theme provider
 func (app *App) ThemeProvider() *ThemeProvider {
 	return app.themeProvider
@@ -0,0 +1,173 @@
+package core
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+)
+
+// fingerprintPattern matches fingerprinted asset paths like css/app.1a2b3c4d.css
+var fingerprintPattern = regexp.MustCompile(`^(.+)\.([0-9a-f]{8})(\.[^./]+)$`)
+
+// AssetManager resolves static asset paths to content-hashed URLs for cache busting
+type AssetManager struct {
+	root    string            // Directory that /static/ is served from
+	prefix  string            // URL prefix for static assets
+	enabled bool              // Whether URLs are fingerprinted
+	hashes  map[string]string // Asset path -> content hash
+	files   map[string][]byte // Asset path -> contents read with Read
+	mutex   sync.RWMutex
+}
+
+// NewAssetManager creates a new asset manager; fingerprinting is enabled outside dev mode
+func NewAssetManager(root string) *AssetManager {
+	return &AssetManager{
+		root:    root,
+		prefix:  "/static/",
+		enabled: os.Getenv("GODIN_DEV_MODE") != "true",
+		hashes:  make(map[string]string),
+		files:   make(map[string][]byte),
+	}
+}
+
+// SetRoot sets the directory assets are read from and clears cached hashes
+func (am *AssetManager) SetRoot(root string) {
+	am.mutex.Lock()
+	defer am.mutex.Unlock()
+	am.root = root
+	am.hashes = make(map[string]string)
+	am.files = make(map[string][]byte)
+}
+
+// SetPrefix sets the URL prefix assets are served under, e.g. "/admin/static/" for a mounted app
+func (am *AssetManager) SetPrefix(prefix string) {
+	am.mutex.Lock()
+	defer am.mutex.Unlock()
+	am.prefix = prefix
+}
+
+// SetEnabled enables or disables fingerprinted URLs
+func (am *AssetManager) SetEnabled(enabled bool) {
+	am.mutex.Lock()
+	defer am.mutex.Unlock()
+	am.enabled = enabled
+}
+
+// IsEnabled returns whether fingerprinted URLs are generated
+func (am *AssetManager) IsEnabled() bool {
+	am.mutex.RLock()
+	defer am.mutex.RUnlock()
+	return am.enabled
+}
+
+// URL returns the public URL for an asset, e.g. "css/app.css" -> "/static/css/app.1a2b3c4d.css"
+func (am *AssetManager) URL(assetPath string) string {
+	assetPath = strings.TrimPrefix(assetPath, "/")
+	assetPath = strings.TrimPrefix(assetPath, "static/")
+
+	if !am.IsEnabled() {
+		return am.prefix + assetPath
+	}
+
+	hash := am.hash(assetPath)
+	if hash == "" {
+		// Missing files are linked unversioned so the 404 is obvious
+		return am.prefix + assetPath
+	}
+
+	ext := path.Ext(assetPath)
+	return am.prefix + strings.TrimSuffix(assetPath, ext) + "." + hash + ext
+}
+
+// Invalidate forgets cached hashes and contents so changed files are reread
+func (am *AssetManager) Invalidate() {
+	am.mutex.Lock()
+	defer am.mutex.Unlock()
+	am.hashes = make(map[string]string)
+	am.files = make(map[string][]byte)
+}
+
+// Read returns the contents of a static asset such as "icons/logo.svg", caching
+// them until the assets are invalidated. Paths outside the static directory are
+// rejected.
+func (am *AssetManager) Read(assetPath string) ([]byte, error) {
+	assetPath = strings.TrimPrefix(assetPath, "/")
+	assetPath = strings.TrimPrefix(assetPath, "static/")
+	assetPath = path.Clean(assetPath)
+	if assetPath == "." || assetPath == ".." || strings.HasPrefix(assetPath, "../") {
+		return nil, fmt.Errorf("asset path %q is outside the static directory", assetPath)
+	}
+
+	am.mutex.RLock()
+	data, exists := am.files[assetPath]
+	root := am.root
+	am.mutex.RUnlock()
+
+	if exists {
+		return data, nil
+	}
+
+	data, err := os.ReadFile(filepath.Join(root, filepath.FromSlash(assetPath)))
+	if err != nil {
+		return nil, err
+	}
+
+	am.mutex.Lock()
+	am.files[assetPath] = data
+	am.mutex.Unlock()
+
+	return data, nil
+}
+
+// hash returns the short content hash for an asset, computing it on first use
+func (am *AssetManager) hash(assetPath string) string {
+	am.mutex.RLock()
+	hash, exists := am.hashes[assetPath]
+	root := am.root
+	am.mutex.RUnlock()
+
+	if exists {
+		return hash
+	}
+
+	data, err := os.ReadFile(filepath.Join(root, filepath.FromSlash(assetPath)))
+	if err != nil {
+		return ""
+	}
+
+	sum := sha256.Sum256(data)
+	hash = hex.EncodeToString(sum[:])[:8]
+
+	am.mutex.Lock()
+	am.hashes[assetPath] = hash
+	am.mutex.Unlock()
+
+	return hash
+}
+
+// Handler wraps a static file handler so fingerprinted paths resolve to the original file
+// and are served with long-lived cache headers
+func (am *AssetManager) Handler(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		match := fingerprintPattern.FindStringSubmatch(r.URL.Path)
+		if match != nil {
+			original := match[1] + match[3]
+			if am.hash(original) == match[2] {
+				w.Header().Set("Cache-Control", "public, max-age=31536000, immutable")
+				r2 := r.Clone(r.Context())
+				r2.URL.Path = original
+				next.ServeHTTP(w, r2)
+				return
+			}
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
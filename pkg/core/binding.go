@@ -0,0 +1,223 @@
+package core
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// MaxJSONBodySize is the default size limit applied by DecodeJSON (1 MB)
+var MaxJSONBodySize int64 = 1 << 20
+
+// DecodeJSON reads the request body and unmarshals it into v, rejecting bodies
+// larger than MaxJSONBodySize and returning descriptive errors for malformed input
+func (c *Context) DecodeJSON(v interface{}) error {
+	return c.DecodeJSONLimit(v, MaxJSONBodySize)
+}
+
+// DecodeJSONLimit is DecodeJSON with an explicit body size limit in bytes
+func (c *Context) DecodeJSONLimit(v interface{}, maxBytes int64) error {
+	if c.Request == nil || c.Request.Body == nil || c.Request.Body == http.NoBody {
+		return fmt.Errorf("request body is empty")
+	}
+
+	if contentType := c.Request.Header.Get("Content-Type"); contentType != "" &&
+		!strings.HasPrefix(strings.ToLower(contentType), "application/json") {
+		return fmt.Errorf("unsupported content type %q, expected application/json", contentType)
+	}
+
+	body := c.Request.Body
+	if maxBytes > 0 {
+		if c.Response != nil {
+			body = http.MaxBytesReader(c.Response, body, maxBytes)
+		} else {
+			body = io.NopCloser(io.LimitReader(body, maxBytes+1))
+		}
+	}
+
+	data, err := io.ReadAll(body)
+	if err != nil {
+		var maxErr *http.MaxBytesError
+		if errors.As(err, &maxErr) {
+			return fmt.Errorf("request body exceeds %d bytes", maxBytes)
+		}
+		return fmt.Errorf("failed to read request body: %w", err)
+	}
+	if maxBytes > 0 && int64(len(data)) > maxBytes {
+		return fmt.Errorf("request body exceeds %d bytes", maxBytes)
+	}
+	if len(strings.TrimSpace(string(data))) == 0 {
+		return fmt.Errorf("request body is empty")
+	}
+
+	if err := json.Unmarshal(data, v); err != nil {
+		var syntaxErr *json.SyntaxError
+		var typeErr *json.UnmarshalTypeError
+		switch {
+		case errors.As(err, &syntaxErr):
+			return fmt.Errorf("malformed JSON at offset %d: %v", syntaxErr.Offset, syntaxErr)
+		case errors.As(err, &typeErr):
+			if typeErr.Field != "" {
+				return fmt.Errorf("invalid value for field %q: expected %s, got JSON %s", typeErr.Field, typeErr.Type, typeErr.Value)
+			}
+			return fmt.Errorf("invalid JSON value: expected %s, got JSON %s", typeErr.Type, typeErr.Value)
+		default:
+			return fmt.Errorf("invalid JSON body: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// BindQuery populates a struct from URL query parameters using `query:"..."` tags
+func (c *Context) BindQuery(v interface{}) error {
+	return bindValues(c.Request.URL.Query(), "query", v)
+}
+
+// bindValues populates the struct pointed to by v from url.Values using the given tag name
+func bindValues(values url.Values, tagName string, v interface{}) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Ptr || rv.IsNil() {
+		return fmt.Errorf("bind target must be a non-nil pointer to a struct")
+	}
+
+	rv = rv.Elem()
+	if rv.Kind() != reflect.Struct {
+		return fmt.Errorf("bind target must be a pointer to a struct, got %s", rv.Kind())
+	}
+
+	rt := rv.Type()
+	for i := 0; i < rt.NumField(); i++ {
+		field := rt.Field(i)
+		fieldValue := rv.Field(i)
+
+		if !fieldValue.CanSet() {
+			continue
+		}
+
+		// Recurse into embedded structs so shared filter structs can be reused
+		if field.Anonymous && fieldValue.Kind() == reflect.Struct {
+			if err := bindValues(values, tagName, fieldValue.Addr().Interface()); err != nil {
+				return err
+			}
+			continue
+		}
+
+		name := field.Tag.Get(tagName)
+		if name == "-" {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		name = strings.Split(name, ",")[0]
+
+		raw, exists := values[name]
+		if !exists || len(raw) == 0 {
+			continue
+		}
+
+		if err := setFieldFromStrings(fieldValue, raw); err != nil {
+			return fmt.Errorf("invalid value for %s %q: %v", tagName, name, err)
+		}
+	}
+
+	return nil
+}
+
+// setFieldFromStrings converts raw string values into the field's type
+func setFieldFromStrings(field reflect.Value, raw []string) error {
+	if field.Kind() == reflect.Slice && field.Type().Elem().Kind() != reflect.Uint8 {
+		slice := reflect.MakeSlice(field.Type(), 0, len(raw))
+		for _, item := range raw {
+			elem := reflect.New(field.Type().Elem()).Elem()
+			if err := setFieldFromString(elem, item); err != nil {
+				return err
+			}
+			slice = reflect.Append(slice, elem)
+		}
+		field.Set(slice)
+		return nil
+	}
+
+	return setFieldFromString(field, raw[0])
+}
+
+// setFieldFromString converts a single string value into the field's type
+func setFieldFromString(field reflect.Value, value string) error {
+	// Pointer fields are allocated so callers can tell "absent" from "zero"
+	if field.Kind() == reflect.Ptr {
+		elem := reflect.New(field.Type().Elem())
+		if err := setFieldFromString(elem.Elem(), value); err != nil {
+			return err
+		}
+		field.Set(elem)
+		return nil
+	}
+
+	if field.Type() == reflect.TypeOf(time.Duration(0)) {
+		d, err := time.ParseDuration(value)
+		if err != nil {
+			return err
+		}
+		field.SetInt(int64(d))
+		return nil
+	}
+
+	if field.Type() == reflect.TypeOf(time.Time{}) {
+		t, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			// Fall back to plain dates as produced by <input type="date">
+			t, err = time.Parse("2006-01-02", value)
+			if err != nil {
+				return err
+			}
+		}
+		field.Set(reflect.ValueOf(t))
+		return nil
+	}
+
+	switch field.Kind() {
+	case reflect.String:
+		field.SetString(value)
+	case reflect.Bool:
+		if value == "on" {
+			field.SetBool(true)
+			return nil
+		}
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return err
+		}
+		field.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(value, 10, field.Type().Bits())
+		if err != nil {
+			return err
+		}
+		field.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, err := strconv.ParseUint(value, 10, field.Type().Bits())
+		if err != nil {
+			return err
+		}
+		field.SetUint(n)
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(value, field.Type().Bits())
+		if err != nil {
+			return err
+		}
+		field.SetFloat(f)
+	default:
+		return fmt.Errorf("unsupported field type %s", field.Type())
+	}
+
+	return nil
+}
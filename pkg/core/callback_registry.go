@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"net/http"
 	"reflect"
+	"runtime/debug"
 	"sync"
 	"time"
 
@@ -97,6 +98,12 @@ func (cr *CallbackRegistry) RegisterCallback(widgetID, widgetType, callbackType
 
 // ExecuteCallback executes a callback by ID with optional parameters
 func (cr *CallbackRegistry) ExecuteCallback(callbackID string, params map[string]interface{}) error {
+	return cr.executeCallback(nil, callbackID, params)
+}
+
+// executeCallback executes a callback with ctx as the current context, or the
+// context it was registered with when ctx is nil
+func (cr *CallbackRegistry) executeCallback(ctx *Context, callbackID string, params map[string]interface{}) error {
 	cr.mutex.RLock()
 	info, exists := cr.callbacks[callbackID]
 	cr.mutex.RUnlock()
@@ -110,13 +117,24 @@ func (cr *CallbackRegistry) ExecuteCallback(callbackID string, params map[string
 	info.LastUsed = time.Now()
 	cr.mutex.Unlock()
 
+	if ctx == nil {
+		ctx = info.Context
+	}
+
 	// Set up global context for state operations
-	if info.Context != nil {
-		SetGlobalContext(info.Context)
+	if ctx != nil {
+		SetGlobalContext(ctx)
 		defer SetGlobalContext(nil)
 	}
 
-	// Execute the callback function
+	// Execute the callback function, coalescing its state changes into one broadcast
+	if ctx != nil && ctx.App != nil {
+		var err error
+		ctx.Batch(func() {
+			err = cr.executeFunction(info.Function, params)
+		})
+		return err
+	}
 	return cr.executeFunction(info.Function, params)
 }
 
@@ -176,7 +194,11 @@ func (cr *CallbackRegistry) executeFunction(fn interface{}, params map[string]in
 	// Execute the function
 	defer func() {
 		if r := recover(); r != nil {
-			fmt.Printf("Callback execution panic: %v\n", r)
+			if cr.app != nil {
+				cr.app.reportPanic(r, debug.Stack(), nil)
+			} else {
+				fmt.Printf("Callback execution panic: %v\n", r)
+			}
 		}
 	}()
 
@@ -190,6 +212,8 @@ func (cr *CallbackRegistry) generateEndpoint(callbackID string) string {
 
 	// Register the endpoint with the router
 	cr.router.HandleFunc(endpointPath, func(w http.ResponseWriter, r *http.Request) {
+		ctx := NewContext(w, r, cr.app)
+
 		// Parse parameters from request
 		params := make(map[string]interface{})
 
@@ -216,19 +240,25 @@ func (cr *CallbackRegistry) generateEndpoint(callbackID string) string {
 		// Parse JSON body if present
 		if r.Header.Get("Content-Type") == "application/json" {
 			var jsonParams map[string]interface{}
-			if err := NewContext(w, r, cr.app).JSON(&jsonParams); err == nil {
+			if err := ctx.JSON(&jsonParams); err == nil {
 				for key, value := range jsonParams {
 					params[key] = value
 				}
 			}
 		}
 
-		// Execute the callback
-		if err := cr.ExecuteCallback(callbackID, params); err != nil {
+		// Execute the callback for this request
+		if err := cr.executeCallback(ctx, callbackID, params); err != nil {
 			http.Error(w, fmt.Sprintf("Callback execution failed: %v", err), http.StatusInternalServerError)
 			return
 		}
 
+		// Without a WebSocket, Consumers of the state it set refresh out of band
+		if html := ctx.stateFragmentsHTML(); html != "" {
+			ctx.WriteHTML(html)
+			return
+		}
+
 		// Return success response
 		w.Header().Set("Content-Type", "application/json")
 		w.WriteHeader(http.StatusOK)
@@ -0,0 +1,29 @@
+package core
+
+// ColorSchemeCookieName is the cookie godin.js keeps set to the OS color scheme,
+// "light" or "dark", when the app uses ThemeModeSystem
+const ColorSchemeCookieName = "godin_color_scheme"
+
+// PrefersDarkMode reports whether the client's OS uses a dark color scheme, as
+// last reported by godin.js. It is false on a client's first request.
+func (c *Context) PrefersDarkMode() bool {
+	if c == nil || c.Request == nil {
+		return false
+	}
+	cookie, err := c.Request.Cookie(ColorSchemeCookieName)
+	return err == nil && cookie.Value == string(BrightnessDark)
+}
+
+// themeMode returns the app's theme mode for the page's data-theme-mode
+// attribute, which tells godin.js to report and follow the OS color scheme
+func (c *Context) themeMode() string {
+	if c.App == nil || c.App.ThemeProvider() == nil {
+		return ""
+	}
+	return string(c.App.ThemeProvider().ThemeMode())
+}
+
+// colorScheme returns the brightness of the theme the page is rendered with
+func (c *Context) colorScheme() string {
+	return string(c.Theme().Brightness)
+}
@@ -0,0 +1,256 @@
+package core
+
+import (
+	"bufio"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/andybalholm/brotli"
+)
+
+// CompressionEncoder wraps a writer with a content encoding such as gzip or br
+type CompressionEncoder func(w io.Writer) io.WriteCloser
+
+// Compression negotiates a response content encoding from Accept-Encoding and
+// compresses responses whose type is in ContentTypes and whose body reaches MinSize.
+// br (brotli) and gzip are built in, br preferred; other encodings can be added
+// with RegisterEncoder.
+type Compression struct {
+	MinSize      int      // Responses smaller than this many bytes are sent uncompressed
+	ContentTypes []string // Media types eligible for compression
+	encoders     map[string]CompressionEncoder
+	preference   []string // Encodings in server preference order, most preferred first
+}
+
+// NewCompression creates a compression middleware with br, gzip and the default allowlist
+func NewCompression() *Compression {
+	c := &Compression{
+		MinSize: 1024,
+		ContentTypes: []string{
+			"text/html",
+			"text/css",
+			"text/javascript",
+			"application/javascript",
+			"application/json",
+		},
+		encoders: make(map[string]CompressionEncoder),
+	}
+
+	c.RegisterEncoder("gzip", func(w io.Writer) io.WriteCloser {
+		return gzip.NewWriter(w)
+	})
+	c.RegisterEncoder("br", func(w io.Writer) io.WriteCloser {
+		return brotli.NewWriter(w)
+	})
+	return c
+}
+
+// EnableCompression compresses HTML, CSS, JS and JSON responses with br or gzip for clients that accept it
+func (app *App) EnableCompression() *Compression {
+	if app.compression == nil {
+		app.compression = NewCompression()
+	}
+	return app.compression
+}
+
+// Compression returns the compression middleware, or nil when compression is disabled
+func (app *App) Compression() *Compression {
+	return app.compression
+}
+
+// RegisterEncoder adds a content encoding, preferred over those registered before it,
+// e.g. c.RegisterEncoder("zstd", func(w io.Writer) io.WriteCloser { return newZstdWriter(w) })
+func (c *Compression) RegisterEncoder(name string, encoder CompressionEncoder) *Compression {
+	name = strings.ToLower(name)
+	if _, exists := c.encoders[name]; !exists {
+		c.preference = append([]string{name}, c.preference...)
+	}
+	c.encoders[name] = encoder
+	return c
+}
+
+// Negotiate returns the encoding to use for an Accept-Encoding header, or "" for none
+func (c *Compression) Negotiate(acceptEncoding string) string {
+	qualities := make(map[string]float64)
+	for _, part := range strings.Split(acceptEncoding, ",") {
+		fields := strings.Split(strings.TrimSpace(part), ";")
+		name := strings.ToLower(strings.TrimSpace(fields[0]))
+		if name == "" {
+			continue
+		}
+
+		quality := 1.0
+		for _, param := range fields[1:] {
+			param = strings.TrimSpace(param)
+			if strings.HasPrefix(param, "q=") {
+				if q, err := strconv.ParseFloat(param[2:], 64); err == nil {
+					quality = q
+				}
+			}
+		}
+		qualities[name] = quality
+	}
+
+	best, bestQuality := "", 0.0
+	for _, name := range c.preference {
+		quality, exists := qualities[name]
+		if !exists {
+			quality, exists = qualities["*"]
+		}
+		if exists && quality > bestQuality {
+			best, bestQuality = name, quality
+		}
+	}
+	return best
+}
+
+// Middleware compresses eligible responses with the negotiated encoding
+func (c *Compression) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		encoding := c.Negotiate(r.Header.Get("Accept-Encoding"))
+
+		// WebSocket upgrades hijack the connection and must not be buffered
+		if encoding == "" || r.Method == http.MethodHead || r.Header.Get("Upgrade") != "" {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		cw := &compressionWriter{
+			ResponseWriter: w,
+			compression:    c,
+			encoding:       encoding,
+			status:         http.StatusOK,
+		}
+		defer cw.Close()
+
+		next.ServeHTTP(cw, r)
+	})
+}
+
+// compressible reports whether a response with this status and headers may be compressed
+func (c *Compression) compressible(status int, header http.Header, size int) bool {
+	if size < c.MinSize || status < http.StatusOK || status == http.StatusNoContent ||
+		status == http.StatusPartialContent || status == http.StatusNotModified {
+		return false
+	}
+	if header.Get("Content-Encoding") != "" || header.Get("Content-Range") != "" {
+		return false
+	}
+
+	mediaType := strings.ToLower(strings.TrimSpace(strings.Split(header.Get("Content-Type"), ";")[0]))
+	for _, contentType := range c.ContentTypes {
+		if mediaType == contentType {
+			return true
+		}
+	}
+	return false
+}
+
+// compressionWriter buffers the start of a response until it knows whether to compress it
+type compressionWriter struct {
+	http.ResponseWriter
+	compression *Compression
+	encoding    string
+	status      int
+	buffer      []byte
+	decided     bool
+	writer      io.WriteCloser
+}
+
+// WriteHeader records the status code until the compression decision is made
+func (cw *compressionWriter) WriteHeader(code int) {
+	if !cw.decided {
+		cw.status = code
+	}
+}
+
+// Write buffers up to MinSize bytes, then streams through the encoder or directly
+func (cw *compressionWriter) Write(b []byte) (int, error) {
+	if !cw.decided {
+		cw.buffer = append(cw.buffer, b...)
+		if len(cw.buffer) < cw.compression.MinSize {
+			return len(b), nil
+		}
+		if err := cw.decide(); err != nil {
+			return 0, err
+		}
+		return len(b), nil
+	}
+
+	if cw.writer != nil {
+		return cw.writer.Write(b)
+	}
+	return cw.ResponseWriter.Write(b)
+}
+
+// decide writes the header, choosing compression from the buffered body, then flushes the buffer
+func (cw *compressionWriter) decide() error {
+	cw.decided = true
+	header := cw.ResponseWriter.Header()
+
+	if header.Get("Content-Type") == "" && len(cw.buffer) > 0 {
+		header.Set("Content-Type", http.DetectContentType(cw.buffer))
+	}
+
+	if cw.compression.compressible(cw.status, header, len(cw.buffer)) {
+		header.Set("Content-Encoding", cw.encoding)
+		header.Add("Vary", "Accept-Encoding")
+		header.Del("Content-Length")
+		cw.ResponseWriter.WriteHeader(cw.status)
+		cw.writer = cw.compression.encoders[cw.encoding](cw.ResponseWriter)
+	} else {
+		cw.ResponseWriter.WriteHeader(cw.status)
+	}
+
+	buffered := cw.buffer
+	cw.buffer = nil
+	if len(buffered) == 0 {
+		return nil
+	}
+	if cw.writer != nil {
+		_, err := cw.writer.Write(buffered)
+		return err
+	}
+	_, err := cw.ResponseWriter.Write(buffered)
+	return err
+}
+
+// Close sends any buffered body and finishes the encoded stream
+func (cw *compressionWriter) Close() error {
+	if !cw.decided {
+		if err := cw.decide(); err != nil {
+			return err
+		}
+	}
+	if cw.writer != nil {
+		return cw.writer.Close()
+	}
+	return nil
+}
+
+// Flush sends buffered data so streamed responses still reach the client promptly
+func (cw *compressionWriter) Flush() {
+	if !cw.decided {
+		cw.decide()
+	}
+	if flusher, ok := cw.writer.(interface{ Flush() error }); ok {
+		flusher.Flush()
+	}
+	if flusher, ok := cw.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+// Hijack forwards hijacking to the underlying writer
+func (cw *compressionWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	hijacker, ok := cw.ResponseWriter.(http.Hijacker)
+	if !ok {
+		return nil, nil, fmt.Errorf("response writer does not support hijacking")
+	}
+	return hijacker.Hijack()
+}
@@ -0,0 +1,137 @@
+package core
+
+import (
+	"compress/gzip"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/andybalholm/brotli"
+)
+
+func TestCompressionNegotiate(t *testing.T) {
+	c := NewCompression()
+
+	tests := []struct {
+		acceptEncoding string
+		expected       string
+	}{
+		{"", ""},
+		{"identity", ""},
+		{"gzip", "gzip"},
+		{"gzip, deflate, br", "br"},
+		{"GZIP", "gzip"},
+		// The client's qualities win over the server's preference
+		{"br;q=0.5, gzip", "gzip"},
+		{"br;q=0, gzip;q=0", ""},
+		{"*", "br"},
+		{"*;q=0.5, gzip;q=0.8", "gzip"},
+	}
+
+	for _, test := range tests {
+		if actual := c.Negotiate(test.acceptEncoding); actual != test.expected {
+			t.Errorf("Negotiate(%q) = %q, expected %q", test.acceptEncoding, actual, test.expected)
+		}
+	}
+}
+
+func TestCompressionRegisterEncoderIsPreferred(t *testing.T) {
+	c := NewCompression()
+	c.RegisterEncoder("test", func(w io.Writer) io.WriteCloser {
+		return gzip.NewWriter(w)
+	})
+
+	if encoding := c.Negotiate("gzip, br, test"); encoding != "test" {
+		t.Errorf("Expected the last registered encoding to be preferred, got %q", encoding)
+	}
+}
+
+// serveCompressed runs a handler behind the compression middleware
+func serveCompressed(c *Compression, acceptEncoding string, handler http.HandlerFunc) *httptest.ResponseRecorder {
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+	if acceptEncoding != "" {
+		r.Header.Set("Accept-Encoding", acceptEncoding)
+	}
+	recorder := httptest.NewRecorder()
+	c.Middleware(handler).ServeHTTP(recorder, r)
+	return recorder
+}
+
+func TestCompressionMiddleware(t *testing.T) {
+	page := "<html><body>" + strings.Repeat("<p>Hello, world</p>", 200) + "</body></html>"
+	writePage := func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		io.WriteString(w, page)
+	}
+
+	t.Run("gzip", func(t *testing.T) {
+		recorder := serveCompressed(NewCompression(), "gzip", writePage)
+		if recorder.Header().Get("Content-Encoding") != "gzip" {
+			t.Fatalf("Expected a gzip response, got Content-Encoding %q", recorder.Header().Get("Content-Encoding"))
+		}
+		if recorder.Header().Get("Vary") != "Accept-Encoding" {
+			t.Errorf("Expected Vary: Accept-Encoding, got %q", recorder.Header().Get("Vary"))
+		}
+		reader, err := gzip.NewReader(recorder.Body)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if body, _ := io.ReadAll(reader); string(body) != page {
+			t.Error("Expected the decompressed body to be the page")
+		}
+	})
+
+	t.Run("br", func(t *testing.T) {
+		recorder := serveCompressed(NewCompression(), "gzip, br", writePage)
+		if recorder.Header().Get("Content-Encoding") != "br" {
+			t.Fatalf("Expected a br response, got Content-Encoding %q", recorder.Header().Get("Content-Encoding"))
+		}
+		if body, _ := io.ReadAll(brotli.NewReader(recorder.Body)); string(body) != page {
+			t.Error("Expected the decompressed body to be the page")
+		}
+	})
+
+	t.Run("not accepted", func(t *testing.T) {
+		recorder := serveCompressed(NewCompression(), "", writePage)
+		if recorder.Header().Get("Content-Encoding") != "" || recorder.Body.String() != page {
+			t.Error("Expected an uncompressed response without Accept-Encoding")
+		}
+	})
+
+	t.Run("small", func(t *testing.T) {
+		recorder := serveCompressed(NewCompression(), "gzip", func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "text/html")
+			io.WriteString(w, "<p>Hi</p>")
+		})
+		if recorder.Header().Get("Content-Encoding") != "" || recorder.Body.String() != "<p>Hi</p>" {
+			t.Error("Expected a response under MinSize to be sent uncompressed")
+		}
+	})
+
+	t.Run("content type", func(t *testing.T) {
+		image := strings.Repeat("\x89PNG", 1000)
+		recorder := serveCompressed(NewCompression(), "gzip", func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "image/png")
+			io.WriteString(w, image)
+		})
+		if recorder.Header().Get("Content-Encoding") != "" || recorder.Body.String() != image {
+			t.Error("Expected a type outside ContentTypes to be sent uncompressed")
+		}
+	})
+
+	t.Run("status", func(t *testing.T) {
+		recorder := serveCompressed(NewCompression(), "gzip", func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "text/html")
+			w.WriteHeader(http.StatusNotFound)
+			io.WriteString(w, page)
+		})
+		if recorder.Code != http.StatusNotFound {
+			t.Errorf("Expected the handler's status 404, got %d", recorder.Code)
+		}
+		if recorder.Header().Get("Content-Encoding") != "gzip" {
+			t.Error("Expected an error page to be compressed too")
+		}
+	})
+}
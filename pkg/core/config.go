@@ -0,0 +1,276 @@
+package core
+
+import (
+	"fmt"
+	"log"
+	"net"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// DefaultEnv is the config profile used when GODIN_ENV is not set; `godin build
+// --prod` sets it to "production" in the executables it builds
+var DefaultEnv = "development"
+
+// Config holds application configuration
+type Config struct {
+	Env string `yaml:"-"` // Profile merged over the base config, e.g. "development" or "production"
+
+	Server struct {
+		Port string `yaml:"port"`
+		Host string `yaml:"host"`
+	} `yaml:"server"`
+	WebSocket struct {
+		Enabled      bool          `yaml:"enabled"`
+		Path         string        `yaml:"path"`
+		PingInterval time.Duration `yaml:"ping_interval"` // How often the server pings each connection
+		IdleTimeout  time.Duration `yaml:"idle_timeout"`  // Connections silent for this long are closed
+	} `yaml:"websocket"`
+	Static struct {
+		Dir   string `yaml:"dir"`
+		Cache bool   `yaml:"cache"`
+	} `yaml:"static"`
+	Debug struct {
+		Enabled   bool   `yaml:"enabled"`
+		DevMode   bool   `yaml:"dev_mode"`
+		HotReload bool   `yaml:"hot_reload"`
+		LogLevel  string `yaml:"log_level"`
+		EditorURL string `yaml:"editor_url"` // Link for file:line on the debug panic page, with {file} and {line} placeholders
+		// Report middleware, handler, render and template durations in a Server-Timing
+		// header outside dev mode too
+		ServerTiming bool `yaml:"server_timing"`
+	} `yaml:"debug"`
+	Page struct {
+		Title       string `yaml:"title"`       // Default <title> for pages that do not call ctx.SetTitle
+		Description string `yaml:"description"` // Default meta description
+	} `yaml:"page"`
+	Render struct {
+		MaxNodes int           `yaml:"max_nodes"` // Nodes a response may render before it is aborted (0 disables)
+		MaxBytes int           `yaml:"max_bytes"` // Largest HTML a response may render (0 disables)
+		Timeout  time.Duration `yaml:"timeout"`   // Longest a response may take to render (0 disables)
+		Swap     string        `yaml:"swap"`      // Default HTMX swap style (default "morph:innerHTML"; "innerHTML" replaces content)
+	} `yaml:"render"`
+	I18n struct {
+		Dir           string `yaml:"dir"`            // Directory of message catalogs such as en.json and fr.yaml
+		DefaultLocale string `yaml:"default_locale"` // Locale used when a request matches no catalog
+	} `yaml:"i18n"`
+	Upload struct {
+		MaxSize     int64 `yaml:"max_size"`     // Largest multipart request body in bytes, answered with 413 beyond it (0 disables)
+		MemoryLimit int64 `yaml:"memory_limit"` // Upload bytes kept in memory; larger files are streamed to temp files
+	} `yaml:"upload"`
+	Database DatabaseConfig `yaml:"database"` // Optional pooled database returned by ctx.DB()
+}
+
+// DefaultConfig returns the configuration used when nothing is set
+func DefaultConfig() *Config {
+	config := &Config{}
+	config.Env = DefaultEnv
+	config.Server.Port = "8080"
+	config.WebSocket.Path = "/ws"
+	config.WebSocket.PingInterval = DefaultWebSocketPingInterval
+	config.WebSocket.IdleTimeout = DefaultWebSocketIdleTimeout
+	config.Static.Dir = "web/static"
+	config.Static.Cache = true
+	config.Debug.LogLevel = "info"
+	config.Debug.EditorURL = DefaultEditorURL
+	config.Page.Title = "Godin App"
+	config.Render.MaxNodes = DefaultRenderMaxNodes
+	config.Render.MaxBytes = DefaultRenderMaxBytes
+	config.Render.Timeout = DefaultRenderTimeout
+	config.Upload.MaxSize = DefaultUploadMaxSize
+	config.Upload.MemoryLimit = DefaultUploadMemoryLimit
+	config.I18n.Dir = "locales"
+	config.I18n.DefaultLocale = "en"
+	config.Database.MaxOpenConns = 10
+	config.Database.MaxIdleConns = 5
+	config.Database.ConnMaxLifetime = 30 * time.Minute
+	return config
+}
+
+// LoadConfig builds the configuration from defaults, the config section of
+// package.yaml in dir, the profile in that section for the environment (e.g.
+// config.production when GODIN_ENV=production), and GODIN_* environment
+// variables, in that order of precedence
+func LoadConfig(dir string) *Config {
+	config := DefaultConfig()
+	config.Env = configEnv()
+
+	if err := config.loadPackageYAML(filepath.Join(dir, "package.yaml")); err != nil {
+		log.Printf("Ignoring package.yaml config: %v", err)
+	}
+
+	config.loadEnv()
+	return config
+}
+
+// loadPackageYAML merges the config section of a package.yaml file
+func (c *Config) loadPackageYAML(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+
+	var pkg struct {
+		Config yaml.Node `yaml:"config"`
+	}
+	if err := yaml.Unmarshal(data, &pkg); err != nil {
+		return err
+	}
+	if pkg.Config.Kind != yaml.MappingNode {
+		return nil
+	}
+	if err := pkg.Config.Decode(c); err != nil {
+		return err
+	}
+
+	// Only the settings the profile sets replace the base ones
+	for i := 0; i+1 < len(pkg.Config.Content); i += 2 {
+		if pkg.Config.Content[i].Value == c.Env {
+			if err := pkg.Config.Content[i+1].Decode(c); err != nil {
+				return fmt.Errorf("profile %s: %w", c.Env, err)
+			}
+		}
+	}
+	return nil
+}
+
+// configEnv returns the config profile to load, from GODIN_ENV or DefaultEnv
+func configEnv() string {
+	if env := os.Getenv("GODIN_ENV"); env != "" {
+		return env
+	}
+	return DefaultEnv
+}
+
+// IsProduction reports whether the production profile is loaded
+func (c *Config) IsProduction() bool {
+	return c.Env == "production"
+}
+
+// IsDevelopment reports whether the development profile is loaded
+func (c *Config) IsDevelopment() bool {
+	return c.Env == "development"
+}
+
+// loadEnv overrides configuration from environment variables
+func (c *Config) loadEnv() {
+	if port := os.Getenv("GODIN_PORT"); port != "" {
+		c.Server.Port = port
+	}
+	if port := os.Getenv("PORT"); port != "" {
+		c.Server.Port = port
+	}
+	c.Server.Port = strings.TrimPrefix(c.Server.Port, ":")
+
+	if host, ok := os.LookupEnv("GODIN_HOST"); ok {
+		c.Server.Host = host
+	}
+
+	envBool("GODIN_WEBSOCKET_ENABLED", &c.WebSocket.Enabled)
+	if path := os.Getenv("GODIN_WEBSOCKET_PATH"); path != "" {
+		c.WebSocket.Path = path
+	}
+	envDuration("GODIN_WEBSOCKET_PING_INTERVAL", &c.WebSocket.PingInterval)
+	envDuration("GODIN_WEBSOCKET_IDLE_TIMEOUT", &c.WebSocket.IdleTimeout)
+
+	if dir := os.Getenv("GODIN_STATIC_DIR"); dir != "" {
+		c.Static.Dir = dir
+	}
+	envBool("GODIN_STATIC_CACHE", &c.Static.Cache)
+
+	envBool("GODIN_DEBUG", &c.Debug.Enabled)
+	envBool("GODIN_DEV_MODE", &c.Debug.DevMode)
+	envBool("GODIN_HOT_RELOAD", &c.Debug.HotReload)
+	envBool("GODIN_SERVER_TIMING", &c.Debug.ServerTiming)
+	if level := os.Getenv("GODIN_LOG_LEVEL"); level != "" {
+		c.Debug.LogLevel = level
+	}
+	if editor := os.Getenv("GODIN_EDITOR_URL"); editor != "" {
+		c.Debug.EditorURL = editor
+	}
+
+	if title := os.Getenv("GODIN_PAGE_TITLE"); title != "" {
+		c.Page.Title = title
+	}
+
+	envInt("GODIN_RENDER_MAX_NODES", &c.Render.MaxNodes)
+	envInt("GODIN_RENDER_MAX_BYTES", &c.Render.MaxBytes)
+	envDuration("GODIN_RENDER_TIMEOUT", &c.Render.Timeout)
+	if swap := os.Getenv("GODIN_RENDER_SWAP"); swap != "" {
+		c.Render.Swap = swap
+	}
+
+	envInt64("GODIN_UPLOAD_MAX_SIZE", &c.Upload.MaxSize)
+	envInt64("GODIN_UPLOAD_MEMORY_LIMIT", &c.Upload.MemoryLimit)
+
+	if dir := os.Getenv("GODIN_I18N_DIR"); dir != "" {
+		c.I18n.Dir = dir
+	}
+	if locale := os.Getenv("GODIN_DEFAULT_LOCALE"); locale != "" {
+		c.I18n.DefaultLocale = locale
+	}
+
+	if driver := os.Getenv("GODIN_DB_DRIVER"); driver != "" {
+		c.Database.Driver = driver
+	}
+	if dsn := os.Getenv("GODIN_DB_DSN"); dsn != "" {
+		c.Database.DSN = dsn
+	}
+}
+
+// Addr returns the listen address built from the server host and port
+func (c *Config) Addr() string {
+	return net.JoinHostPort(c.Server.Host, c.Server.Port)
+}
+
+// envBool sets target from a boolean environment variable when it is set and valid
+func envBool(name string, target *bool) {
+	value := os.Getenv(name)
+	if value == "" {
+		return
+	}
+	if parsed, err := strconv.ParseBool(value); err == nil {
+		*target = parsed
+	}
+}
+
+// envInt sets target from an integer environment variable when it is set and valid
+func envInt(name string, target *int) {
+	value := os.Getenv(name)
+	if value == "" {
+		return
+	}
+	if parsed, err := strconv.Atoi(value); err == nil {
+		*target = parsed
+	}
+}
+
+// envInt64 sets target from a 64-bit integer environment variable when it is set and valid
+func envInt64(name string, target *int64) {
+	value := os.Getenv(name)
+	if value == "" {
+		return
+	}
+	if parsed, err := strconv.ParseInt(value, 10, 64); err == nil {
+		*target = parsed
+	}
+}
+
+// envDuration sets target from a duration environment variable such as "30s" when it is set and valid
+func envDuration(name string, target *time.Duration) {
+	value := os.Getenv(name)
+	if value == "" {
+		return
+	}
+	if parsed, err := time.ParseDuration(value); err == nil {
+		*target = parsed
+	}
+}
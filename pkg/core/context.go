@@ -2,13 +2,20 @@ package core
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"html/template"
+	"net"
 	"net/http"
 	"os"
 	"path/filepath"
+	"sort"
 	"strconv"
+	"strings"
+	"sync"
+	"time"
 
+	"github.com/gideonsigilai/godin/pkg/state"
 	"github.com/gorilla/mux"
 )
 
@@ -21,6 +28,10 @@ type Context struct {
 	params   map[string]interface{}
 	handlers map[string]Handler
 	state    map[string]interface{} // Local state for this context
+
+	batch      *state.Batch // Open batch collecting this context's state changes, see Batch
+	batchDepth int          // Nesting depth of Batch calls
+	batchMutex sync.Mutex   // Guards batch and batchDepth; callbacks may share a context
 }
 
 // NewContext creates a new request context
@@ -36,6 +47,53 @@ func NewContext(w http.ResponseWriter, r *http.Request, app *App) *Context {
 	}
 }
 
+// Detach returns a context for rendering widgets after the request has been
+// answered, e.g. to push updates over WebSocket. It keeps the request's URL,
+// headers, parameters and session, but anything written to its response is dropped.
+func (c *Context) Detach() *Context {
+	var request *http.Request
+	if c.Request != nil {
+		request = c.Request.Clone(context.Background())
+	}
+	detached := &Context{
+		Request:  request,
+		Response: &detachedResponse{header: make(http.Header)},
+		App:      c.App,
+		vars:     c.vars,
+		params:   make(map[string]interface{}),
+		handlers: make(map[string]Handler),
+		state:    make(map[string]interface{}),
+	}
+	// A session started by this request has no cookie in it yet
+	if session, ok := c.Get(sessionKey).(*Session); ok {
+		detached.Set(sessionKey, session)
+	}
+	return detached
+}
+
+// DetachShared returns a detached context for rendering output broadcast to every
+// client, such as list patches. It leaves out the request's cookies and
+// credentials and has an empty session of its own, so what it renders cannot
+// depend on, or leak, the user whose request happened to trigger it.
+func (c *Context) DetachShared() *Context {
+	shared := c.Detach()
+	if shared.Request != nil {
+		shared.Request.Header.Del("Cookie")
+		shared.Request.Header.Del("Authorization")
+	}
+	shared.Set(sessionKey, &Session{values: make(map[string]interface{})})
+	return shared
+}
+
+// detachedResponse is the response of a detached context, discarding what is written to it
+type detachedResponse struct {
+	header http.Header
+}
+
+func (d *detachedResponse) Header() http.Header         { return d.header }
+func (d *detachedResponse) Write(b []byte) (int, error) { return len(b), nil }
+func (d *detachedResponse) WriteHeader(statusCode int)  {}
+
 // Param gets a URL parameter by name
 func (c *Context) Param(name string) string {
 	return c.vars[name]
@@ -47,11 +105,24 @@ func (c *Context) ParamInt(name string) (int, error) {
 	return strconv.Atoi(value)
 }
 
+// ParamInt64 gets a URL parameter as a 64-bit integer
+func (c *Context) ParamInt64(name string) (int64, error) {
+	return strconv.ParseInt(c.vars[name], 10, 64)
+}
+
 // Query gets a query parameter by name
 func (c *Context) Query(name string) string {
 	return c.Request.URL.Query().Get(name)
 }
 
+// QueryDefault gets a query parameter by name, returning fallback when it is missing
+func (c *Context) QueryDefault(name, fallback string) string {
+	if value := c.Query(name); value != "" {
+		return value
+	}
+	return fallback
+}
+
 // QueryInt gets a query parameter as integer
 func (c *Context) QueryInt(name string) (int, error) {
 	value := c.Query(name)
@@ -73,6 +144,9 @@ func (c *Context) JSON(v interface{}) error {
 
 // Set stores a value in the context
 func (c *Context) Set(key string, value interface{}) {
+	if c.params == nil {
+		c.params = make(map[string]interface{})
+	}
 	c.params[key] = value
 }
 
@@ -107,15 +181,84 @@ func (c *Context) GetBool(key string) bool {
 
 // SetState sets a value in the local state and triggers UI updates
 func (c *Context) SetState(key string, value interface{}) {
+	c.verifyStateKey(key, value)
+
 	// Set in local context state
 	c.state[key] = value
+	c.recordStateChange(key)
 
-	// Also set in global state manager for persistence and WebSocket broadcasting
+	// Also set in global state manager for persistence and WebSocket broadcasting,
+	// deferring the broadcast while the context has a batch open
+	if batch := c.openBatch(); batch != nil {
+		batch.Set(key, value)
+		return
+	}
 	c.App.State().Set(key, value)
 }
 
+// Batch runs fn and coalesces the state changes this context makes into one
+// WebSocket broadcast and one watcher notification per key, sent when the
+// outermost Batch returns. Batches nest. Only changes made through this
+// context join the batch; other requests publish theirs independently.
+func (c *Context) Batch(fn func()) {
+	if c.App == nil {
+		fn()
+		return
+	}
+
+	c.batchMutex.Lock()
+	if c.batch == nil {
+		c.batch = c.App.State().NewBatch()
+	}
+	c.batchDepth++
+	c.batchMutex.Unlock()
+
+	defer c.endBatch()
+	fn()
+}
+
+// openBatch returns the context's open batch, or nil outside Batch
+func (c *Context) openBatch() *state.Batch {
+	c.batchMutex.Lock()
+	defer c.batchMutex.Unlock()
+	return c.batch
+}
+
+// endBatch closes one level of batching and publishes the changes once the outermost batch ends
+func (c *Context) endBatch() {
+	c.batchMutex.Lock()
+	c.batchDepth--
+	if c.batchDepth > 0 {
+		c.batchMutex.Unlock()
+		return
+	}
+	batch := c.batch
+	c.batch = nil
+	c.batchMutex.Unlock()
+
+	batch.Flush()
+}
+
+// SetStates sets several state values at once; clients receive the changes as
+// one coalesced broadcast and refresh each affected Consumer once
+func (c *Context) SetStates(values map[string]interface{}) {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	c.Batch(func() {
+		for _, key := range keys {
+			c.SetState(key, values[key])
+		}
+	})
+}
+
 // GetState retrieves a value from the local state
 func (c *Context) GetState(key string) interface{} {
+	c.verifyStateKey(key, nil)
+
 	if value, exists := c.state[key]; exists {
 		return value
 	}
@@ -177,6 +320,27 @@ func (c *Context) HTMXCurrentURL() string {
 	return c.Header("HX-Current-URL")
 }
 
+// TriggerAfterSettle asks HTMX to dispatch a client event once the response has
+// been swapped in and settled, keeping events other code already queued
+func (c *Context) TriggerAfterSettle(event string, detail interface{}) {
+	if c.Response == nil {
+		return
+	}
+
+	header := c.Response.Header()
+	events := make(map[string]interface{})
+	if existing := header.Get("HX-Trigger-After-Settle"); existing != "" {
+		if err := json.Unmarshal([]byte(existing), &events); err != nil {
+			events = map[string]interface{}{existing: nil}
+		}
+	}
+	events[event] = detail
+
+	if data, err := json.Marshal(events); err == nil {
+		header.Set("HX-Trigger-After-Settle", string(data))
+	}
+}
+
 // Method returns the HTTP method
 func (c *Context) Method() string {
 	return c.Request.Method
@@ -197,6 +361,62 @@ func (c *Context) RemoteAddr() string {
 	return c.Request.RemoteAddr
 }
 
+// ClientIP returns the IP address of the client. Behind a proxy on the local
+// machine or a private network, it is taken from X-Forwarded-For (the last
+// address not itself a proxy) or X-Real-IP; otherwise, and when those are
+// missing, it is the address the request came from, so clients cannot spoof it.
+func (c *Context) ClientIP() string {
+	remoteIP := c.Request.RemoteAddr
+	if host, _, err := net.SplitHostPort(remoteIP); err == nil {
+		remoteIP = host
+	}
+	if !isProxyIP(remoteIP) {
+		return remoteIP
+	}
+
+	if forwarded := c.Header("X-Forwarded-For"); forwarded != "" {
+		client := ""
+		addresses := strings.Split(forwarded, ",")
+		for i := len(addresses) - 1; i >= 0; i-- {
+			address := strings.TrimSpace(addresses[i])
+			if net.ParseIP(address) == nil {
+				break
+			}
+			client = address
+			if !isProxyIP(address) {
+				break
+			}
+		}
+		if client != "" {
+			return client
+		}
+	}
+	if realIP := strings.TrimSpace(c.Header("X-Real-IP")); net.ParseIP(realIP) != nil {
+		return realIP
+	}
+	return remoteIP
+}
+
+// isProxyIP reports whether an address is loopback or private, as a reverse proxy's would be
+func isProxyIP(address string) bool {
+	ip := net.ParseIP(address)
+	return ip != nil && (ip.IsLoopback() || ip.IsPrivate())
+}
+
+// Cookie returns the named request cookie, or http.ErrNoCookie
+func (c *Context) Cookie(name string) (*http.Cookie, error) {
+	return c.Request.Cookie(name)
+}
+
+// SetCookie adds a Set-Cookie header to the response; cookies without a Path
+// are set for the whole site
+func (c *Context) SetCookie(cookie *http.Cookie) {
+	if cookie.Path == "" {
+		cookie.Path = "/"
+	}
+	http.SetCookie(c.Response, cookie)
+}
+
 // IsSecure returns true if the request is HTTPS
 func (c *Context) IsSecure() bool {
 	return c.Request.TLS != nil
@@ -218,34 +438,64 @@ func (c *Context) WriteJSON(data interface{}) error {
 	return json.NewEncoder(c.Response).Encode(data)
 }
 
-// WriteHTML writes an HTML response
+// WriteHTML writes an HTML response, or 304 Not Modified when the handler called
+// ctx.Cache and the client already has this HTML
 func (c *Context) WriteHTML(html string) {
 	c.SetHeader("Content-Type", "text/html")
+	if c.writeNotModified(html) {
+		return
+	}
 	c.Response.Write([]byte(html))
 }
 
 // TemplateData represents data for template rendering
 type TemplateData struct {
 	Title   string
+	Lang    string        // Locale of the request, used as the <html lang> attribute
+	Meta    []MetaTag     // Meta tags set with ctx.SetMeta and ctx.SetDescription
 	Content template.HTML // Use template.HTML to prevent escaping
-	CSS     template.CSS  // Use template.CSS for CSS content
-	JS      template.JS   // Use template.JS for JavaScript content
+	CSS     template.CSS  // Styles added with ctx.AddCSS
+	JS      template.JS   // Scripts added with ctx.AddScript
+	Head    template.HTML // HTML added to the head with ctx.AddHeadHTML
+	Assets  string        // IDs of the CSS, JS and head HTML above, so partials don't add them again
 }
 
-// RenderTemplate renders a widget using the base HTML template
+// RenderTemplate renders a widget using the base HTML template; a title set
+// with ctx.SetTitle takes precedence over the title argument
 func (c *Context) RenderTemplate(widget Widget, title string) {
-	// Render the widget content
-	content := widget.Render(c)
+	// Render the widget content within the app's render limits, followed by the
+	// refreshes of Consumers whose state it changed when there is no WebSocket
+	start := time.Now()
+	content := c.renderWidget(widget) + c.stateFragmentsHTML()
+	c.recordTiming(timingRender, start)
+	start = time.Now()
+
+	// Messages for one user, such as dialogs, go to the connections of their session,
+	// so the page's socket must carry a session cookie
+	if c.App != nil && c.App.websocket.IsEnabled() {
+		c.Session()
+	}
+
+	// Widgets may set the title and add styles or scripts while rendering, so read them afterwards
+	if pageTitle := c.Title(); pageTitle != "" {
+		title = pageTitle
+	}
 
 	// Prepare template data
 	data := TemplateData{
 		Title:   title,
+		Lang:    c.Locale(),
+		Meta:    c.MetaTags(),
 		Content: template.HTML(content),
+		CSS:     c.pageCSS(),
+		JS:      c.pageScripts(),
+		Head:    c.pageHead(),
+		Assets:  c.pageAssetIDs(),
 	}
 
 	// Find the correct path to the base template
 	templatePath := c.findTemplatePath()
-	tmpl, err := template.ParseFiles(templatePath)
+	tmpl, err := template.New(filepath.Base(templatePath)).Funcs(c.templateFuncs()).ParseFiles(templatePath)
 	if err != nil {
 		// Fallback to simple HTML if template fails
 		c.WriteHTML(content)
@@ -262,9 +512,51 @@ func (c *Context) RenderTemplate(widget Widget, title string) {
 	}
 
 	// Write the complete HTML document
+	c.recordTiming(timingTemplate, start)
 	c.WriteHTML(buf.String())
 }
 
+// templateFuncs returns the helper functions available to page templates
+func (c *Context) templateFuncs() template.FuncMap {
+	return template.FuncMap{
+		"asset": func(assetPath string) string {
+			if c.App != nil && c.App.assets != nil {
+				return c.App.AssetURL(assetPath)
+			}
+			return "/static/" + strings.TrimPrefix(assetPath, "/")
+		},
+		"themeCSS": func() template.CSS {
+			if c.App != nil {
+				return template.CSS(c.App.ThemeVariablesCSS())
+			}
+			return ""
+		},
+		"csrfToken": func() string {
+			if c.App != nil && c.App.CSRFEnabled() {
+				return c.CSRFToken()
+			}
+			return ""
+		},
+		"flashes": func() []FlashMessage {
+			return c.unrenderedFlashes()
+		},
+		"focusRequest": c.FocusRequest,
+		"snackBars":    c.pageSnackBars,
+		"snackBarMax":  c.snackBarMaxVisible,
+		"themeMode":    c.themeMode,
+		"colorScheme":  c.colorScheme,
+		"devBanner":    c.devBanner,
+		"morphSwaps":   c.morphSwaps,
+		"htmxConfig":   c.htmxConfig,
+		"basePath": func() string {
+			if c.App != nil {
+				return c.App.BasePath()
+			}
+			return ""
+		},
+	}
+}
+
 // findTemplatePath finds the correct path to the base.html template
 func (c *Context) findTemplatePath() string {
 	// Try current directory first
@@ -301,14 +593,26 @@ func (c *Context) RegisterHandler(handler Handler) string {
 	return c.App.RegisterHandler(handler)
 }
 
-// Theme returns the current theme data
+// Theme returns the theme to render this request with, matching the client's color
+// scheme when the app follows the system theme
 func (c *Context) Theme() *ThemeData {
-	if c.App != nil {
+	if c != nil && c.App != nil {
+		if provider := c.App.ThemeProvider(); provider != nil {
+			return provider.ThemeFor(c.PrefersDarkMode())
+		}
 		return c.App.GetTheme()
 	}
 	return DefaultLightTheme
 }
 
+// Breakpoints returns the responsive breakpoints active for this request
+func (c *Context) Breakpoints() Breakpoints {
+	if c != nil && c.App != nil {
+		return c.App.Breakpoints()
+	}
+	return DefaultBreakpoints
+}
+
 // MediaQuery returns the current MediaQuery data
 func (c *Context) MediaQuery() *MediaQueryData {
 	// First check if MediaQuery data is stored in context
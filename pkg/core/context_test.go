@@ -0,0 +1,64 @@
+package core
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestClientIP(t *testing.T) {
+	tests := []struct {
+		name          string
+		remoteAddr    string
+		xForwardedFor string
+		xRealIP       string
+		expected      string
+	}{
+		{"direct", "203.0.113.7:51234", "", "", "203.0.113.7"},
+		{"direct ignores forwarded headers", "203.0.113.7:51234", "198.51.100.1", "198.51.100.2", "203.0.113.7"},
+		{"local proxy", "127.0.0.1:8080", "198.51.100.1", "", "198.51.100.1"},
+		{"private proxy", "10.0.0.5:8080", "198.51.100.1", "", "198.51.100.1"},
+		// The client may send its own X-Forwarded-For; only the last untrusted address counts
+		{"spoofed chain", "127.0.0.1:8080", "1.2.3.4, 198.51.100.1", "", "198.51.100.1"},
+		{"proxy chain", "127.0.0.1:8080", "198.51.100.1, 10.0.0.2, 192.168.1.3", "", "198.51.100.1"},
+		{"invalid address", "127.0.0.1:8080", "bogus, 198.51.100.1", "", "198.51.100.1"},
+		{"x-real-ip", "127.0.0.1:8080", "", "198.51.100.2", "198.51.100.2"},
+		{"invalid x-real-ip", "127.0.0.1:8080", "", "bogus", "127.0.0.1"},
+		{"proxy without headers", "127.0.0.1:8080", "", "", "127.0.0.1"},
+		{"ipv6", "[2001:db8::1]:443", "", "", "2001:db8::1"},
+		{"ipv6 local proxy", "[::1]:8080", "2001:db8::2", "", "2001:db8::2"},
+	}
+
+	for _, test := range tests {
+		r := httptest.NewRequest(http.MethodGet, "/", nil)
+		r.RemoteAddr = test.remoteAddr
+		if test.xForwardedFor != "" {
+			r.Header.Set("X-Forwarded-For", test.xForwardedFor)
+		}
+		if test.xRealIP != "" {
+			r.Header.Set("X-Real-IP", test.xRealIP)
+		}
+
+		ctx := NewTestContextFor(New(), r)
+		if actual := ctx.ClientIP(); actual != test.expected {
+			t.Errorf("%s: ClientIP() = %q, expected %q", test.name, actual, test.expected)
+		}
+	}
+}
+
+func TestSetCookieDefaultsPath(t *testing.T) {
+	ctx := NewTestContext()
+	ctx.SetCookie(&http.Cookie{Name: "theme", Value: "dark"})
+
+	cookies := ctx.Recorder.Result().Cookies()
+	if len(cookies) != 1 || cookies[0].Path != "/" {
+		t.Fatalf("Expected one cookie for the whole site, got %v", cookies)
+	}
+
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+	r.AddCookie(cookies[0])
+	cookie, err := NewTestContextFor(New(), r).Cookie("theme")
+	if err != nil || cookie.Value != "dark" {
+		t.Errorf("Expected to read the cookie back, got %v, %v", cookie, err)
+	}
+}
@@ -0,0 +1,210 @@
+package core
+
+import (
+	"log"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/gorilla/mux"
+)
+
+// Route is a registered route that can be further configured
+type Route struct {
+	app   *App
+	path  string
+	route *mux.Route
+}
+
+// Public marks the route as exempt from CSRF (and app-level auth) checks,
+// e.g. for webhook receivers and public APIs
+func (r *Route) Public() *Route {
+	r.app.Exempt(r.path)
+	return r
+}
+
+// MuxRoute returns the underlying gorilla/mux route
+func (r *Route) MuxRoute() *mux.Route {
+	return r.route
+}
+
+// CSRFGuard validates CSRF tokens on state-changing requests
+type CSRFGuard struct {
+	enabled bool
+	exempt  map[string]bool // Route templates, or prefixes ending in "*"
+	mutex   sync.RWMutex
+}
+
+// NewCSRFGuard creates a new, disabled CSRF guard
+func NewCSRFGuard() *CSRFGuard {
+	return &CSRFGuard{
+		exempt: make(map[string]bool),
+	}
+}
+
+// csrfSessionKey is the session value holding the session's CSRF token
+const csrfSessionKey = "godin.csrf"
+
+// EnableCSRF turns on CSRF validation for POST, PUT, PATCH and DELETE requests.
+// Each session gets its own token (see Context.CSRFToken), which clients must
+// send in the X-CSRF-Token header or a csrf_token form field.
+func (app *App) EnableCSRF() {
+	app.csrf.mutex.Lock()
+	app.csrf.enabled = true
+	app.csrf.mutex.Unlock()
+}
+
+// CSRFEnabled returns whether CSRF validation is active
+func (app *App) CSRFEnabled() bool {
+	if app.parent != nil {
+		return app.parent.CSRFEnabled()
+	}
+
+	app.csrf.mutex.RLock()
+	defer app.csrf.mutex.RUnlock()
+	return app.csrf.enabled
+}
+
+// CSRFToken returns the CSRF token of the browser's session, generating it on first use
+func (c *Context) CSRFToken() string {
+	session := c.Session()
+	session.mutex.Lock()
+	defer session.mutex.Unlock()
+
+	// Concurrent first requests of a session must agree on one token
+	if token, ok := session.values[csrfSessionKey].(string); ok && token != "" {
+		return token
+	}
+	token := GenerateCSRFToken()
+	session.values[csrfSessionKey] = token
+	return token
+}
+
+// sessionCSRFToken returns the CSRF token stored in the request's session,
+// or "" when the request has no session or the session has no token
+func (app *App) sessionCSRFToken(r *http.Request) string {
+	cookie, err := r.Cookie(SessionCookieName)
+	if err != nil {
+		return ""
+	}
+	session, ok := app.Sessions().Get(cookie.Value)
+	if !ok {
+		return ""
+	}
+	return session.GetString(csrfSessionKey)
+}
+
+// Exempt marks a route as public so it bypasses CSRF and auth checks.
+// The path may be a route pattern ("/webhooks/{id:int}") or a prefix ("/public/*").
+func (app *App) Exempt(path string) {
+	if !strings.HasSuffix(path, "*") {
+		path = expandRouteParams(path)
+	}
+
+	app.csrf.mutex.Lock()
+	app.csrf.exempt[path] = true
+	app.csrf.mutex.Unlock()
+
+	log.Printf("Route marked public (CSRF/auth exempt): %s", app.basePath+path)
+	app.forwardExempt(path)
+}
+
+// forwardExempt lists a mounted app's public route on the top-level app, whose
+// middleware runs the CSRF check, under its full path
+func (app *App) forwardExempt(path string) {
+	if app.parent == nil {
+		return
+	}
+	root := app.parent
+	for root.parent != nil {
+		root = root.parent
+	}
+
+	root.csrf.mutex.Lock()
+	root.csrf.exempt[app.basePath+path] = true
+	root.csrf.mutex.Unlock()
+}
+
+// ExemptRoutes returns all routes marked as public
+func (app *App) ExemptRoutes() []string {
+	app.csrf.mutex.RLock()
+	defer app.csrf.mutex.RUnlock()
+
+	routes := make([]string, 0, len(app.csrf.exempt))
+	for path := range app.csrf.exempt {
+		routes = append(routes, path)
+	}
+	return routes
+}
+
+// IsExempt reports whether a request targets a public route, including public
+// routes of mounted apps the request is forwarded to
+func (app *App) IsExempt(r *http.Request) bool {
+	return app.isExemptRoute(mux.CurrentRoute(r), r)
+}
+
+// isExemptRoute reports whether a request matched to route targets a public route
+func (app *App) isExemptRoute(route *mux.Route, r *http.Request) bool {
+	app.csrf.mutex.RLock()
+	exempt := false
+	if route != nil {
+		if template, err := route.GetPathTemplate(); err == nil && app.csrf.exempt[template] {
+			exempt = true
+		}
+	}
+	for path := range app.csrf.exempt {
+		if strings.HasSuffix(path, "*") && strings.HasPrefix(r.URL.Path, strings.TrimSuffix(path, "*")) {
+			exempt = true
+		}
+	}
+	app.csrf.mutex.RUnlock()
+	if exempt {
+		return true
+	}
+
+	// The parent only sees its prefix route; the mounted app knows which of its routes is public
+	if sub, subRequest, subRoute, ok := app.forwardedRoute(route, r); ok {
+		return sub.isExemptRoute(subRoute, subRequest)
+	}
+	return false
+}
+
+// IsPublic reports whether the current route was marked public
+func (c *Context) IsPublic() bool {
+	return c.App != nil && c.App.IsExempt(c.Request)
+}
+
+// csrfMiddleware rejects state-changing requests without a valid CSRF token
+func (app *App) csrfMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet, http.MethodHead, http.MethodOptions:
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		if !app.CSRFEnabled() {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		if app.IsExempt(r) {
+			log.Printf("CSRF check skipped for public route: %s %s", r.Method, r.URL.Path)
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		provided := r.Header.Get("X-CSRF-Token")
+		if provided == "" {
+			provided = r.FormValue("csrf_token")
+		}
+
+		if !ValidateCSRFToken(provided, app.sessionCSRFToken(r)) {
+			log.Printf("CSRF validation failed: %s %s", r.Method, r.URL.Path)
+			http.Error(w, "Invalid CSRF token", http.StatusForbidden)
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
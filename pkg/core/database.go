@@ -0,0 +1,148 @@
+package core
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log"
+	"time"
+)
+
+// DatabaseConfig configures the app's pooled database connection. The driver
+// is not bundled: import one for its side effects, e.g. _ "github.com/lib/pq".
+type DatabaseConfig struct {
+	Driver          string        `yaml:"driver"`             // database/sql driver name, e.g. "postgres" or "sqlite3"
+	DSN             string        `yaml:"dsn"`                // Driver-specific data source name
+	MaxOpenConns    int           `yaml:"max_open_conns"`     // 0 means unlimited
+	MaxIdleConns    int           `yaml:"max_idle_conns"`     // Idle connections kept in the pool
+	ConnMaxLifetime time.Duration `yaml:"conn_max_lifetime"`  // Connections older than this are closed; 0 keeps them
+	ConnMaxIdleTime time.Duration `yaml:"conn_max_idle_time"` // Idle connections older than this are closed; 0 keeps them
+}
+
+// DB is the app's pooled database handle: a *sql.DB with helpers for the
+// common handler pattern of querying rows and building a widget per row.
+// It is not an ORM; use the embedded *sql.DB for anything else.
+type DB struct {
+	*sql.DB
+}
+
+// OpenDB opens a connection pool from the database config and checks it with a ping
+func OpenDB(config DatabaseConfig) (*DB, error) {
+	if config.Driver == "" {
+		return nil, fmt.Errorf("no database driver configured")
+	}
+
+	db, err := sql.Open(config.Driver, config.DSN)
+	if err != nil {
+		return nil, err
+	}
+
+	db.SetMaxOpenConns(config.MaxOpenConns)
+	db.SetMaxIdleConns(config.MaxIdleConns)
+	db.SetConnMaxLifetime(config.ConnMaxLifetime)
+	db.SetConnMaxIdleTime(config.ConnMaxIdleTime)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := db.PingContext(ctx); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("database %s unreachable: %w", config.Driver, err)
+	}
+
+	return &DB{DB: db}, nil
+}
+
+// dbRetryDelay is how long DB waits after a failed open before trying again
+const dbRetryDelay = 5 * time.Second
+
+// UseDB plugs in a database opened elsewhere, replacing the one from package.yaml
+func (app *App) UseDB(db *sql.DB) *DB {
+	app.dbMutex.Lock()
+	defer app.dbMutex.Unlock()
+	app.db = &DB{DB: db}
+	return app.db
+}
+
+// DB returns the app's database, opening it from the database config on first use.
+// A failed open is retried on a later call, at most once every few seconds, so
+// the app recovers once the database is reachable. Mounted apps share their
+// parent's database unless they have their own. It returns nil when no database
+// is configured or it could not be opened.
+func (app *App) DB() *DB {
+	app.dbMutex.Lock()
+	if app.db == nil && app.config.Database.Driver != "" && time.Now().After(app.dbRetryAt) {
+		db, err := OpenDB(app.config.Database)
+		if err != nil {
+			log.Printf("Failed to open database: %v", err)
+			app.dbRetryAt = time.Now().Add(dbRetryDelay)
+		} else {
+			app.db = db
+		}
+	}
+	db := app.db
+	app.dbMutex.Unlock()
+
+	if db == nil && app.parent != nil {
+		return app.parent.DB()
+	}
+	return db
+}
+
+// DB returns the app's database for use in handlers, or nil when none is configured
+func (c *Context) DB() *DB {
+	if c.App == nil {
+		return nil
+	}
+	return c.App.DB()
+}
+
+// QueryAll runs a query and scans every row with scan, closing the rows afterwards
+func QueryAll[T any](ctx context.Context, db *DB, scan func(rows *sql.Rows) (T, error), query string, args ...interface{}) ([]T, error) {
+	if db == nil {
+		return nil, fmt.Errorf("no database configured")
+	}
+
+	rows, err := db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var results []T
+	for rows.Next() {
+		result, err := scan(rows)
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, result)
+	}
+	return results, rows.Err()
+}
+
+// QueryWidgets runs a query for the current request and builds a widget from each row,
+// e.g. db.QueryWidgets(ctx, todoItem, "SELECT id, text FROM todos")
+func (db *DB) QueryWidgets(ctx *Context, build func(rows *sql.Rows) (Widget, error), query string, args ...interface{}) ([]Widget, error) {
+	return QueryAll(requestContext(ctx), db, build, query, args...)
+}
+
+// Transaction runs fn in a transaction, committing when it returns nil and rolling back otherwise
+func (db *DB) Transaction(ctx context.Context, fn func(tx *sql.Tx) error) error {
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	if err := fn(tx); err != nil {
+		tx.Rollback()
+		return err
+	}
+	return tx.Commit()
+}
+
+// requestContext returns the request's context, so queries stop when the client goes away
+func requestContext(ctx *Context) context.Context {
+	if ctx != nil && ctx.Request != nil {
+		return ctx.Request.Context()
+	}
+	return context.Background()
+}
@@ -0,0 +1,188 @@
+package core
+
+import (
+	"fmt"
+	"net/http"
+	"reflect"
+	"strings"
+)
+
+// DebugTreeParam is the query parameter that replaces a page with its widget tree
+// when debug mode is enabled, e.g. /?__debug=tree
+const DebugTreeParam = "__debug"
+
+// maxDebugTreeDepth guards against self-referencing widget values
+const maxDebugTreeDepth = 64
+
+// maxDebugPropLength truncates long string props in the tree dump
+const maxDebugPropLength = 40
+
+var widgetInterface = reflect.TypeOf((*Widget)(nil)).Elem()
+
+// DebugTree returns the widget type hierarchy of widget as indented text, listing
+// the key props of each widget. It returns an empty string unless GODIN_DEBUG is enabled.
+func (c *Context) DebugTree(widget Widget) string {
+	if c.App == nil || !c.App.config.Debug.Enabled {
+		return ""
+	}
+	return FormatWidgetTree(widget)
+}
+
+// FormatWidgetTree returns the widget type hierarchy of widget as indented text
+func FormatWidgetTree(widget Widget) string {
+	var tree strings.Builder
+	writeWidgetTree(&tree, "", reflect.ValueOf(widget), 0)
+	return tree.String()
+}
+
+// writeDebugTree writes the widget tree in place of the page when it was requested with ?__debug=tree
+func (c *Context) writeDebugTree(widget Widget) bool {
+	if c.Query(DebugTreeParam) != "tree" {
+		return false
+	}
+
+	tree := c.DebugTree(widget)
+	if tree == "" {
+		return false
+	}
+
+	c.SetHeader("Content-Type", "text/plain; charset=utf-8")
+	c.Response.WriteHeader(http.StatusOK)
+	c.Response.Write([]byte(tree))
+	return true
+}
+
+// writeWidgetTree writes one widget line followed by its children
+func writeWidgetTree(tree *strings.Builder, label string, value reflect.Value, depth int) {
+	for value.Kind() == reflect.Interface || value.Kind() == reflect.Ptr {
+		if value.IsNil() {
+			return
+		}
+		value = value.Elem()
+	}
+
+	tree.WriteString(strings.Repeat("  ", depth))
+	if label != "" {
+		tree.WriteString(label + ": ")
+	}
+	tree.WriteString(widgetTypeName(value.Type()))
+
+	if value.Kind() != reflect.Struct {
+		tree.WriteString("\n")
+		return
+	}
+
+	if props := widgetProps(value); len(props) > 0 {
+		tree.WriteString(" " + strings.Join(props, " "))
+	}
+	tree.WriteString("\n")
+
+	if depth >= maxDebugTreeDepth {
+		tree.WriteString(strings.Repeat("  ", depth+1) + "...\n")
+		return
+	}
+
+	for i := 0; i < value.NumField(); i++ {
+		field := value.Type().Field(i)
+		if !field.IsExported() || field.Anonymous {
+			continue
+		}
+
+		fieldValue := value.Field(i)
+		switch {
+		case isWidgetField(fieldValue):
+			childLabel := ""
+			if field.Name != "Child" {
+				childLabel = lowerFirst(field.Name)
+			}
+			writeWidgetTree(tree, childLabel, fieldValue, depth+1)
+
+		case fieldValue.Kind() == reflect.Slice && fieldValue.Type().Elem().Implements(widgetInterface):
+			childLabel := ""
+			if field.Name != "Children" {
+				childLabel = lowerFirst(field.Name)
+			}
+			for j := 0; j < fieldValue.Len(); j++ {
+				writeWidgetTree(tree, childLabel, fieldValue.Index(j), depth+1)
+			}
+		}
+	}
+}
+
+// isWidgetField reports whether a struct field holds a single child widget
+func isWidgetField(value reflect.Value) bool {
+	switch value.Kind() {
+	case reflect.Interface, reflect.Ptr:
+		return !value.IsNil() && value.Type().Implements(widgetInterface)
+	case reflect.Struct:
+		return value.Type().Implements(widgetInterface) || reflect.PointerTo(value.Type()).Implements(widgetInterface)
+	}
+	return false
+}
+
+// widgetProps returns the set scalar fields of a widget formatted as name=value
+func widgetProps(value reflect.Value) []string {
+	var props []string
+	for i := 0; i < value.NumField(); i++ {
+		field := value.Type().Field(i)
+		if !field.IsExported() || field.Anonymous {
+			continue
+		}
+
+		fieldValue := value.Field(i)
+		if fieldValue.Kind() == reflect.Ptr {
+			if fieldValue.IsNil() {
+				continue
+			}
+			fieldValue = fieldValue.Elem()
+		} else if fieldValue.IsZero() {
+			continue
+		}
+
+		var formatted string
+		switch fieldValue.Kind() {
+		case reflect.String:
+			text := fieldValue.String()
+			if len(text) > maxDebugPropLength {
+				text = text[:maxDebugPropLength] + "..."
+			}
+			formatted = fmt.Sprintf("%q", text)
+		case reflect.Bool, reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+			reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+			reflect.Float32, reflect.Float64:
+			formatted = fmt.Sprintf("%v", fieldValue.Interface())
+		default:
+			continue
+		}
+
+		props = append(props, lowerFirst(field.Name)+"="+formatted)
+	}
+	return props
+}
+
+// widgetTypeName returns the package-qualified type name without generic noise
+func widgetTypeName(t reflect.Type) string {
+	name := t.String()
+	if index := strings.Index(name, "["); index >= 0 {
+		name = name[:index]
+	}
+	return name
+}
+
+// lowerFirst converts an exported field name to lowerCamelCase, e.g. "ID" to "id"
+func lowerFirst(name string) string {
+	upper := 0
+	for upper < len(name) && name[upper] >= 'A' && name[upper] <= 'Z' {
+		upper++
+	}
+
+	switch {
+	case upper == 0:
+		return name
+	case upper == 1 || upper == len(name):
+		return strings.ToLower(name[:upper]) + name[upper:]
+	default:
+		// Keep the last capital of an initialism as the start of the next word
+		return strings.ToLower(name[:upper-1]) + name[upper-1:]
+	}
+}
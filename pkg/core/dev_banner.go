@@ -0,0 +1,20 @@
+package core
+
+import (
+	"html/template"
+)
+
+// devBannerHTML is the corner banner injected into pages in dev mode; godin.js fills it in
+const devBannerHTML = `<div id="godin-debug-banner" class="godin-debug-banner" data-mode="dev" role="status" aria-live="polite">` +
+	`<span class="godin-debug-banner-mode">DEV</span>` +
+	`<span class="godin-debug-banner-reloaded" data-debug-reloaded></span>` +
+	`<span class="godin-debug-banner-render" data-debug-render></span>` +
+	`</div>`
+
+// devBanner returns the dev mode banner, or nothing outside dev mode
+func (c *Context) devBanner() template.HTML {
+	if c.App == nil || !c.App.config.Debug.DevMode {
+		return ""
+	}
+	return template.HTML(devBannerHTML)
+}
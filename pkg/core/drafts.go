@@ -0,0 +1,183 @@
+package core
+
+import (
+	"net/http"
+	"net/url"
+	"sync"
+)
+
+// Draft limits, so autosave cannot fill the store: the body of one save, and the
+// number and combined size of the drafts a session may keep
+const (
+	maxDraftSize            = 1 << 20
+	maxDraftsPerSession     = 16
+	maxDraftBytesPerSession = 2 << 20
+)
+
+// draftIndexKey is the session value holding the session's draftIndex
+const draftIndexKey = "godin.drafts"
+
+// draftIndex tracks the size of each draft a session has saved, to enforce the
+// per-session limits whichever DraftStore keeps the drafts
+type draftIndex struct {
+	sizes map[string]int
+	mutex sync.Mutex
+}
+
+// reserve records a draft's new size, reporting false when it would take the
+// session over its draft count or size limit
+func (di *draftIndex) reserve(key string, size int) bool {
+	di.mutex.Lock()
+	defer di.mutex.Unlock()
+
+	previous, exists := di.sizes[key]
+	if !exists && len(di.sizes) >= maxDraftsPerSession {
+		return false
+	}
+	total := size - previous
+	for _, draftSize := range di.sizes {
+		total += draftSize
+	}
+	if total > maxDraftBytesPerSession {
+		return false
+	}
+	di.sizes[key] = size
+	return true
+}
+
+// release forgets a deleted draft
+func (di *draftIndex) release(key string) {
+	di.mutex.Lock()
+	defer di.mutex.Unlock()
+	delete(di.sizes, key)
+}
+
+// draftIndex returns the draft index of the browser's session
+func (c *Context) draftIndex() *draftIndex {
+	return c.Session().GetOrSet(draftIndexKey, func() interface{} {
+		return &draftIndex{sizes: make(map[string]int)}
+	}).(*draftIndex)
+}
+
+// draftSessionPrefix namespaces drafts among the other session values
+const draftSessionPrefix = "godin.draft."
+
+// DraftStore keeps the form drafts saved by forms with an AutoSaveKey, per
+// browser session. The default keeps them in the session, in memory; set another
+// with app.SetDraftStore to keep them in a database so they survive restarts.
+type DraftStore interface {
+	// Load returns the draft saved under key, or nil when there is none
+	Load(sessionID, key string) (url.Values, error)
+	// Save replaces the draft saved under key
+	Save(sessionID, key string, values url.Values) error
+	// Delete removes the draft saved under key
+	Delete(sessionID, key string) error
+}
+
+// sessionDraftStore is the default DraftStore, keeping drafts as session values
+// so they expire with the session
+type sessionDraftStore struct {
+	sessions *SessionStore
+}
+
+// Load returns the draft saved under key in the session
+func (s *sessionDraftStore) Load(sessionID, key string) (url.Values, error) {
+	session, exists := s.sessions.Get(sessionID)
+	if !exists {
+		return nil, nil
+	}
+	values, _ := session.Get(draftSessionPrefix + key).(url.Values)
+	return values, nil
+}
+
+// Save stores the draft under key in the session
+func (s *sessionDraftStore) Save(sessionID, key string, values url.Values) error {
+	if session, exists := s.sessions.Get(sessionID); exists {
+		session.Set(draftSessionPrefix+key, values)
+	}
+	return nil
+}
+
+// Delete removes the draft saved under key from the session
+func (s *sessionDraftStore) Delete(sessionID, key string) error {
+	if session, exists := s.sessions.Get(sessionID); exists {
+		session.Delete(draftSessionPrefix + key)
+	}
+	return nil
+}
+
+// SetDraftStore replaces where form drafts are kept
+func (app *App) SetDraftStore(store DraftStore) *App {
+	app.drafts = store
+	return app
+}
+
+// DraftStore returns the app's draft store; mounted apps share their parent's
+func (app *App) DraftStore() DraftStore {
+	if app.parent != nil {
+		return app.parent.DraftStore()
+	}
+	return app.drafts
+}
+
+// Draft returns the values autosaved for the form with the given AutoSaveKey, or
+// nil when the user has no draft
+func (c *Context) Draft(key string) url.Values {
+	if c.App == nil {
+		return nil
+	}
+	values, err := c.App.DraftStore().Load(c.Session().ID(), key)
+	if err != nil {
+		c.Logf("Failed to load draft %q: %v", key, err)
+		return nil
+	}
+	return values
+}
+
+// DiscardDraft removes the autosaved draft for a form, typically once its
+// submission has been saved
+func (c *Context) DiscardDraft(key string) {
+	if c.App == nil {
+		return
+	}
+	if err := c.App.DraftStore().Delete(c.Session().ID(), key); err != nil {
+		c.Logf("Failed to discard draft %q: %v", key, err)
+		return
+	}
+	c.draftIndex().release(key)
+}
+
+// setupDraftAPI serves the endpoint godin.js posts form drafts to. A session may
+// keep maxDraftsPerSession drafts of at most maxDraftBytesPerSession in total.
+func (app *App) setupDraftAPI() {
+	app.router.HandleFunc("/api/drafts/{key}", func(w http.ResponseWriter, r *http.Request) {
+		ctx := NewContext(w, r, app)
+		key := ctx.Param("key")
+
+		if r.Method == http.MethodDelete {
+			ctx.DiscardDraft(key)
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+
+		r.Body = http.MaxBytesReader(w, r.Body, maxDraftSize)
+		if err := r.ParseForm(); err != nil {
+			http.Error(w, "Invalid draft", http.StatusBadRequest)
+			return
+		}
+		values := r.PostForm
+		values.Del("csrf_token")
+
+		if !ctx.draftIndex().reserve(key, len(values.Encode())) {
+			http.Error(w, "Too many drafts", http.StatusRequestEntityTooLarge)
+			return
+		}
+
+		if err := app.DraftStore().Save(ctx.Session().ID(), key, values); err != nil {
+			ctx.Logf("Failed to save draft %q: %v", key, err)
+			http.Error(w, "Failed to save draft", http.StatusInternalServerError)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}).Methods("POST", "DELETE")
+}
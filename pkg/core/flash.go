@@ -0,0 +1,92 @@
+package core
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+)
+
+// FlashCookieName is the cookie that carries flash messages to the next request
+const FlashCookieName = "godin_flash"
+
+// Context keys for flash messages within a request
+const (
+	flashPendingKey  = "godin.flash.pending"
+	flashReadKey     = "godin.flash.read"
+	flashRenderedKey = "godin.flash.rendered"
+)
+
+// FlashMessage is a one-time message shown on the next page render,
+// e.g. "Todo created successfully" after a post-redirect-get
+type FlashMessage struct {
+	Category string `json:"category"` // e.g. "success", "error", "warning" or "info"
+	Message  string `json:"message"`
+}
+
+// Flash stores a one-time message for the next request, typically before ctx.Redirect
+func (c *Context) Flash(category, message string) {
+	pending, _ := c.Get(flashPendingKey).([]FlashMessage)
+	pending = append(pending, FlashMessage{Category: category, Message: message})
+	c.Set(flashPendingKey, pending)
+
+	data, err := json.Marshal(pending)
+	if err != nil {
+		return
+	}
+
+	http.SetCookie(c.Response, &http.Cookie{
+		Name:     FlashCookieName,
+		Value:    base64.URLEncoding.EncodeToString(data),
+		Path:     "/",
+		HttpOnly: true,
+		SameSite: http.SameSiteLaxMode,
+	})
+}
+
+// Flashes returns the flash messages stored by the previous request and clears them,
+// so they are shown only once. Repeated calls within a request return the same messages.
+func (c *Context) Flashes() []FlashMessage {
+	if messages, read := c.Get(flashReadKey).([]FlashMessage); read {
+		return messages
+	}
+
+	messages := []FlashMessage{}
+	if c.Request != nil {
+		if cookie, err := c.Request.Cookie(FlashCookieName); err == nil {
+			if data, err := base64.URLEncoding.DecodeString(cookie.Value); err == nil {
+				json.Unmarshal(data, &messages)
+			}
+
+			// Keep the cookie when this request flashed new messages for the next one
+			if c.Get(flashPendingKey) == nil {
+				http.SetCookie(c.Response, &http.Cookie{
+					Name:   FlashCookieName,
+					Value:  "",
+					Path:   "/",
+					MaxAge: -1,
+				})
+			}
+		}
+	}
+
+	c.Set(flashReadKey, messages)
+	return messages
+}
+
+// MarkFlashesRendered records that a flash container rendered this request's messages,
+// so the page template does not show them again as snackbars
+func (c *Context) MarkFlashesRendered() {
+	c.Set(flashRenderedKey, true)
+}
+
+// unrenderedFlashes returns the flash messages no flash container has rendered yet
+func (c *Context) unrenderedFlashes() []FlashMessage {
+	if c.GetBool(flashRenderedKey) {
+		return nil
+	}
+	messages := c.Flashes()
+	if len(messages) == 0 {
+		return nil
+	}
+	return messages
+}
@@ -0,0 +1,37 @@
+package core
+
+// FocusEvent is the client event godin.js handles by focusing the element with detail.id
+const FocusEvent = "godin:focus"
+
+// Context keys for focus handling within a request
+const (
+	focusRequestKey   = "godin.focus.request"
+	focusAutofocusKey = "godin.focus.autofocus"
+)
+
+// RequestFocus moves keyboard focus to the element with the given ID once the
+// response is shown, e.g. to refocus a cleared input after adding a todo.
+// HTMX responses focus it after the swap settles; full pages focus it on load.
+func (c *Context) RequestFocus(elementID string) {
+	c.Set(focusRequestKey, elementID)
+	c.TriggerAfterSettle(FocusEvent, map[string]string{"id": elementID})
+}
+
+// FocusRequest returns the element ID passed to RequestFocus during this request
+func (c *Context) FocusRequest() string {
+	return c.GetString(focusRequestKey)
+}
+
+// ClaimAutofocus reports whether a widget may render the autofocus attribute. Only
+// the first widget of a request to ask gets it, and none do after RequestFocus,
+// so focus does not depend on which of several autofocus fields the browser picks.
+func (c *Context) ClaimAutofocus() bool {
+	if c == nil {
+		return true
+	}
+	if c.GetBool(focusAutofocusKey) || c.FocusRequest() != "" {
+		return false
+	}
+	c.Set(focusAutofocusKey, true)
+	return true
+}
@@ -2,6 +2,7 @@ package core
 
 import (
 	"crypto/rand"
+	"crypto/subtle"
 	"encoding/hex"
 	"fmt"
 	"net/url"
@@ -449,9 +450,9 @@ func GenerateCSRFToken() string {
 	return hex.EncodeToString(bytes)
 }
 
-// ValidateCSRFToken validates a CSRF token (simplified implementation)
+// ValidateCSRFToken reports whether a provided CSRF token matches the expected one
 func ValidateCSRFToken(provided, expected string) bool {
-	return provided == expected && provided != ""
+	return provided != "" && subtle.ConstantTimeCompare([]byte(provided), []byte(expected)) == 1
 }
 
 // HTMXErrorHandler represents an error handler for HTMX requests
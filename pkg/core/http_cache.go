@@ -0,0 +1,194 @@
+package core
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// httpCacheKey is the context key holding the caching policy set with ctx.Cache
+const httpCacheKey = "godin.httpCache"
+
+// cachePolicy describes how browsers and proxies may cache a handler's response
+type cachePolicy struct {
+	maxAge               time.Duration
+	public               bool
+	immutable            bool
+	staleWhileRevalidate time.Duration
+	etag                 bool
+	etagKey              string
+}
+
+// generatedIDPattern matches IDs that are generated anew on every render: handler
+// and callback endpoints, random widget IDs and IDs built from timestamps
+var generatedIDPattern = regexp.MustCompile(`/(?:handlers|api/callbacks)/[A-Za-z0-9_]+|\bwidget_[0-9a-f]{16}\b|\b[a-z]+(?:_[a-z0-9]+)*_\d{16,}(?:_\d+)?\b`)
+
+// CacheOption adjusts the caching policy set with ctx.Cache
+type CacheOption func(*cachePolicy)
+
+// CachePublic lets shared caches such as CDNs store the response. Only use it
+// for pages that are the same for every user; a response that starts a session
+// or renders the session's CSRF token is cached privately regardless.
+func CachePublic() CacheOption {
+	return func(p *cachePolicy) { p.public = true }
+}
+
+// CacheImmutable tells browsers the response never changes while it is fresh
+func CacheImmutable() CacheOption {
+	return func(p *cachePolicy) { p.immutable = true }
+}
+
+// CacheStaleWhileRevalidate lets caches serve a stale response for up to d while they refetch it
+func CacheStaleWhileRevalidate(d time.Duration) CacheOption {
+	return func(p *cachePolicy) { p.staleWhileRevalidate = d }
+}
+
+// CacheWithoutETag skips the ETag, e.g. for pages that render a per-request nonce
+func CacheWithoutETag() CacheOption {
+	return func(p *cachePolicy) { p.etag = false }
+}
+
+// CacheETag derives the ETag from key instead of the rendered HTML, e.g. a
+// record's version, so the page is revalidated without depending on its markup
+func CacheETag(key string) CacheOption {
+	return func(p *cachePolicy) {
+		p.etag = true
+		p.etagKey = key
+	}
+}
+
+// Cache lets clients cache the handler's rendered output for maxAge, privately
+// unless CachePublic is given. The output also gets an ETag from its content, so
+// once it is stale, an unchanged render is answered with 304 Not Modified; IDs
+// generated on every render, such as callback endpoints, and the session's CSRF
+// token are left out of it. A maxAge of 0 makes clients revalidate on every request.
+func (c *Context) Cache(maxAge time.Duration, opts ...CacheOption) {
+	policy := &cachePolicy{maxAge: maxAge, etag: true}
+	for _, opt := range opts {
+		opt(policy)
+	}
+	c.Set(httpCacheKey, policy)
+
+	if c.Response == nil {
+		return
+	}
+	c.SetHeader("Cache-Control", policy.header())
+	// Full pages and HTMX fragments share URLs, so keep them apart in caches
+	c.Response.Header().Add("Vary", "HX-Request")
+}
+
+// NoCache stops browsers and proxies from storing the handler's output, e.g. for
+// pages showing account details
+func (c *Context) NoCache() {
+	c.Set(httpCacheKey, nil)
+	if c.Response != nil {
+		c.SetHeader("Cache-Control", "no-store")
+	}
+}
+
+// header returns the Cache-Control header value for the policy
+func (p *cachePolicy) header() string {
+	directives := []string{"private"}
+	if p.public {
+		directives[0] = "public"
+	}
+
+	if p.maxAge > 0 {
+		directives = append(directives, fmt.Sprintf("max-age=%d", int(p.maxAge.Seconds())))
+	} else {
+		directives = append(directives, "no-cache")
+	}
+	if p.immutable {
+		directives = append(directives, "immutable")
+	}
+	if p.staleWhileRevalidate > 0 {
+		directives = append(directives, fmt.Sprintf("stale-while-revalidate=%d", int(p.staleWhileRevalidate.Seconds())))
+	}
+	return strings.Join(directives, ", ")
+}
+
+// etagFor returns the hash identifying html: of the policy's key when the handler
+// set one, otherwise of html without the parts that differ between equal renders
+func (c *Context) etagFor(policy *cachePolicy, html string) string {
+	input := policy.etagKey
+	if input == "" {
+		if session, ok := c.Get(sessionKey).(*Session); ok {
+			if token := session.GetString(csrfSessionKey); token != "" {
+				html = strings.ReplaceAll(html, token, "")
+			}
+		}
+		input = generatedIDPattern.ReplaceAllString(html, "")
+		// The browser keeps using the IDs of the render it has, and callbacks are
+		// dropped after two unused hours, so such ETags only last the hour
+		if len(input) != len(html) {
+			input += "\x00" + time.Now().Truncate(time.Hour).Format(time.RFC3339)
+		}
+	}
+	// Full pages and HTMX fragments of one URL share a key, so tell them apart
+	input += "\x00" + c.Request.Header.Get("HX-Request")
+
+	sum := sha256.Sum256([]byte(input))
+	return hex.EncodeToString(sum[:16])
+}
+
+// sharedCacheUnsafe reports whether html belongs to one browser's session: the
+// response starts a session or the page carries the session's CSRF token
+func (c *Context) sharedCacheUnsafe(html string) bool {
+	for _, cookie := range c.Response.Header().Values("Set-Cookie") {
+		if strings.HasPrefix(cookie, SessionCookieName+"=") {
+			return true
+		}
+	}
+	return strings.Contains(html, `<meta name="csrf-token"`)
+}
+
+// writeNotModified sets the ETag for html when the handler enabled caching and
+// answers 304 Not Modified, returning true, when the client already has it.
+// A public policy falls back to private for responses tied to a session.
+func (c *Context) writeNotModified(html string) bool {
+	policy, _ := c.Get(httpCacheKey).(*cachePolicy)
+	if policy == nil || c.Request == nil || c.Response == nil {
+		return false
+	}
+	// Shared caches would hand one user's session to everyone
+	if policy.public && c.sharedCacheUnsafe(html) {
+		private := *policy
+		private.public = false
+		c.SetHeader("Cache-Control", private.header())
+	}
+	if !policy.etag {
+		return false
+	}
+	if c.Request.Method != http.MethodGet && c.Request.Method != http.MethodHead {
+		return false
+	}
+
+	// Weak, since compression changes the bytes but not the content
+	etag := `W/"` + c.etagFor(policy, html) + `"`
+	c.SetHeader("ETag", etag)
+
+	if !etagMatches(c.Request.Header.Get("If-None-Match"), etag) {
+		return false
+	}
+	c.Response.WriteHeader(http.StatusNotModified)
+	return true
+}
+
+// etagMatches reports whether an If-None-Match header lists etag, comparing weakly
+func etagMatches(ifNoneMatch, etag string) bool {
+	if ifNoneMatch == "" {
+		return false
+	}
+	etag = strings.TrimPrefix(etag, "W/")
+	for _, candidate := range strings.Split(ifNoneMatch, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" || strings.TrimPrefix(candidate, "W/") == etag {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,174 @@
+package core
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestETagMatches(t *testing.T) {
+	etag := `W/"abc"`
+
+	tests := []struct {
+		ifNoneMatch string
+		expected    bool
+	}{
+		{"", false},
+		{`W/"abc"`, true},
+		// Weak comparison ignores the W/ prefix
+		{`"abc"`, true},
+		{`"xyz"`, false},
+		{`"xyz", W/"abc"`, true},
+		{` "xyz" ,  "abc" `, true},
+		{"*", true},
+		{`"ab"`, false},
+	}
+
+	for _, test := range tests {
+		if actual := etagMatches(test.ifNoneMatch, etag); actual != test.expected {
+			t.Errorf("etagMatches(%q, %q) = %v, expected %v", test.ifNoneMatch, etag, actual, test.expected)
+		}
+	}
+}
+
+func TestCachePolicyHeader(t *testing.T) {
+	tests := []struct {
+		maxAge   time.Duration
+		opts     []CacheOption
+		expected string
+	}{
+		{time.Minute, nil, "private, max-age=60"},
+		{0, nil, "private, no-cache"},
+		{time.Hour, []CacheOption{CachePublic(), CacheImmutable()}, "public, max-age=3600, immutable"},
+		{time.Minute, []CacheOption{CacheStaleWhileRevalidate(30 * time.Second)}, "private, max-age=60, stale-while-revalidate=30"},
+	}
+
+	for _, test := range tests {
+		ctx := NewTestContext()
+		ctx.Cache(test.maxAge, test.opts...)
+		if actual := ctx.Recorder.Header().Get("Cache-Control"); actual != test.expected {
+			t.Errorf("Cache(%v) set Cache-Control %q, expected %q", test.maxAge, actual, test.expected)
+		}
+	}
+}
+
+// cachedApp serves body at /page with ctx.Cache and the given options
+func cachedApp(body *string, opts ...CacheOption) *App {
+	app := New()
+	app.GET("/page", func(ctx *Context) Widget {
+		ctx.Cache(time.Minute, opts...)
+		ctx.WriteHTML(*body)
+		return nil
+	})
+	return app
+}
+
+// getPage requests /page, revalidating with ifNoneMatch when it is set
+func getPage(app *App, ifNoneMatch string) *httptest.ResponseRecorder {
+	r := httptest.NewRequest(http.MethodGet, "/page", nil)
+	if ifNoneMatch != "" {
+		r.Header.Set("If-None-Match", ifNoneMatch)
+	}
+	recorder := httptest.NewRecorder()
+	app.Router().ServeHTTP(recorder, r)
+	return recorder
+}
+
+func TestCacheAnswersNotModified(t *testing.T) {
+	body := "<p>Unchanged</p>"
+	app := cachedApp(&body)
+
+	first := getPage(app, "")
+	etag := first.Header().Get("ETag")
+	if first.Code != http.StatusOK || etag == "" {
+		t.Fatalf("Expected a 200 with an ETag, got %d and ETag %q", first.Code, etag)
+	}
+
+	revalidated := getPage(app, etag)
+	if revalidated.Code != http.StatusNotModified {
+		t.Errorf("Expected 304 for an unchanged render, got %d", revalidated.Code)
+	}
+	if revalidated.Body.Len() != 0 {
+		t.Errorf("Expected no body with 304, got %q", revalidated.Body.String())
+	}
+
+	body = "<p>Changed</p>"
+	changed := getPage(app, etag)
+	if changed.Code != http.StatusOK || changed.Body.String() != body {
+		t.Errorf("Expected 200 with the new render once it changed, got %d %q", changed.Code, changed.Body.String())
+	}
+	if changed.Header().Get("ETag") == etag {
+		t.Error("Expected a new ETag for the changed render")
+	}
+}
+
+func TestCacheWithoutETag(t *testing.T) {
+	body := "<p>Unchanged</p>"
+	app := cachedApp(&body, CacheWithoutETag())
+
+	recorder := getPage(app, "*")
+	if recorder.Code != http.StatusOK {
+		t.Errorf("Expected 200 without an ETag, got %d", recorder.Code)
+	}
+	if etag := recorder.Header().Get("ETag"); etag != "" {
+		t.Errorf("Expected no ETag, got %q", etag)
+	}
+}
+
+func TestNoCache(t *testing.T) {
+	ctx := NewTestContext()
+	ctx.Cache(time.Minute)
+	ctx.NoCache()
+	ctx.WriteHTML("<p>Account</p>")
+
+	if cacheControl := ctx.Recorder.Header().Get("Cache-Control"); cacheControl != "no-store" {
+		t.Errorf("Expected Cache-Control no-store, got %q", cacheControl)
+	}
+	if etag := ctx.Recorder.Header().Get("ETag"); etag != "" {
+		t.Errorf("Expected NoCache to drop the ETag, got %q", etag)
+	}
+}
+
+func TestCacheETagIgnoresGeneratedIDs(t *testing.T) {
+	renders := 0
+	app := New()
+	app.GET("/page", func(ctx *Context) Widget {
+		renders++
+		ctx.Cache(time.Minute)
+		ctx.WriteHTML(fmt.Sprintf(`<button id="widget_%016x" hx-post="/api/callbacks/%016x">Save</button>`, renders, renders))
+		return nil
+	})
+
+	etag := getPage(app, "").Header().Get("ETag")
+	if recorder := getPage(app, etag); recorder.Code != http.StatusNotModified {
+		t.Errorf("Expected 304 when only generated IDs changed, got %d", recorder.Code)
+	}
+}
+
+func TestCacheETagKey(t *testing.T) {
+	body := "<p>Version 1</p>"
+	app := cachedApp(&body, CacheETag("post-1"))
+
+	etag := getPage(app, "").Header().Get("ETag")
+	body = "<p>Rendered differently</p>"
+	if recorder := getPage(app, etag); recorder.Code != http.StatusNotModified {
+		t.Errorf("Expected 304 while the key is unchanged, got %d", recorder.Code)
+	}
+}
+
+func TestCachePublicWithSessionIsPrivate(t *testing.T) {
+	app := New()
+	app.GET("/page", func(ctx *Context) Widget {
+		ctx.Cache(time.Minute, CachePublic())
+		ctx.Session().Set("visited", true)
+		ctx.WriteHTML("<p>Hello</p>")
+		return nil
+	})
+
+	recorder := getPage(app, "")
+	if cacheControl := recorder.Header().Get("Cache-Control"); cacheControl != "private, max-age=60" {
+		t.Errorf("Expected a response starting a session to be private, got %q", cacheControl)
+	}
+}
@@ -0,0 +1,105 @@
+package core
+
+import (
+	"net/http"
+	"sync"
+)
+
+// ListenerRegistry tracks the server-side subscriptions of rendered Consumer
+// and ValueListener widgets so they can be released when their element leaves the page.
+// A listener ID may be rendered for many sessions; it is only released once every
+// session that rendered it has reported the element gone.
+type ListenerRegistry struct {
+	listeners map[string]*listenerSubscribers
+	mutex     sync.Mutex
+}
+
+// listenerSubscribers is a listener's cleanup and the sessions still showing it
+type listenerSubscribers struct {
+	cleanup  func()
+	sessions map[string]bool
+}
+
+// NewListenerRegistry creates a new listener registry
+func NewListenerRegistry() *ListenerRegistry {
+	return &ListenerRegistry{
+		listeners: make(map[string]*listenerSubscribers),
+	}
+}
+
+// Register subscribes a session to a listener ID and records its cleanup, replacing any previous one
+func (lr *ListenerRegistry) Register(id, sessionID string, cleanup func()) {
+	lr.mutex.Lock()
+	defer lr.mutex.Unlock()
+
+	subscribers, exists := lr.listeners[id]
+	if !exists {
+		subscribers = &listenerSubscribers{sessions: make(map[string]bool)}
+		lr.listeners[id] = subscribers
+	}
+	subscribers.cleanup = cleanup
+	subscribers.sessions[sessionID] = true
+}
+
+// Unsubscribe removes a session's subscription to a listener ID, running and forgetting
+// the cleanup when it was the last one. It reports whether the session was subscribed.
+func (lr *ListenerRegistry) Unsubscribe(id, sessionID string) bool {
+	lr.mutex.Lock()
+	subscribers, exists := lr.listeners[id]
+	if !exists || !subscribers.sessions[sessionID] {
+		lr.mutex.Unlock()
+		return false
+	}
+
+	delete(subscribers.sessions, sessionID)
+	var cleanup func()
+	if len(subscribers.sessions) == 0 {
+		delete(lr.listeners, id)
+		cleanup = subscribers.cleanup
+	}
+	lr.mutex.Unlock()
+
+	if cleanup != nil {
+		cleanup()
+	}
+	return true
+}
+
+// Count returns the number of registered listeners
+func (lr *ListenerRegistry) Count() int {
+	lr.mutex.Lock()
+	defer lr.mutex.Unlock()
+	return len(lr.listeners)
+}
+
+// Listeners returns the listener registry
+func (app *App) Listeners() *ListenerRegistry {
+	return app.listeners
+}
+
+// setupListenerAPI lets clients release their session's listeners whose elements were removed from the DOM
+func (app *App) setupListenerAPI() {
+	app.router.HandleFunc("/api/listeners/unsubscribe", func(w http.ResponseWriter, r *http.Request) {
+		ctx := NewContext(w, r, app)
+
+		var request struct {
+			IDs []string `json:"ids"`
+		}
+		if err := ctx.DecodeJSON(&request); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		sessionID := ctx.Session().ID()
+		removed := 0
+		for _, id := range request.IDs {
+			if app.listeners.Unsubscribe(id, sessionID) {
+				removed++
+			}
+		}
+
+		ctx.WriteJSON(map[string]interface{}{
+			"removed": removed,
+		})
+	}).Methods("POST")
+}
@@ -0,0 +1,468 @@
+package core
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"gopkg.in/yaml.v3"
+)
+
+// LocaleCookieName is the cookie that remembers the locale chosen with ctx.SetLocale
+const LocaleCookieName = "godin_locale"
+
+// localeKey is the context key caching the locale of the current request
+const localeKey = "godin.locale"
+
+// pluralCategories are the CLDR plural categories a plural message may define
+var pluralCategories = map[string]bool{
+	"zero": true, "one": true, "two": true, "few": true, "many": true, "other": true,
+}
+
+// Localizations holds the message catalogs of every supported locale.
+// Catalogs are JSON or YAML files named after their locale, e.g. locales/en.json
+// and locales/fr.yaml; nested keys are joined with dots ("cart.title").
+// A message may be a string with {name} placeholders, or a map of plural
+// forms ("one", "other", ...) chosen by the "count" argument.
+type Localizations struct {
+	fallback string
+	catalogs map[string]map[string]interface{} // locale -> key -> string or plural forms
+	mutex    sync.RWMutex
+}
+
+// NewLocalizations creates an empty set of catalogs that falls back to the given locale
+func NewLocalizations(fallback string) *Localizations {
+	if fallback == "" {
+		fallback = "en"
+	}
+	return &Localizations{
+		fallback: normalizeLocale(fallback),
+		catalogs: make(map[string]map[string]interface{}),
+	}
+}
+
+// Fallback returns the locale used when a request matches no catalog
+func (l *Localizations) Fallback() string {
+	return l.fallback
+}
+
+// LoadDir loads every .json, .yaml and .yml catalog in dir, using the file name as the locale
+func (l *Localizations) LoadDir(dir string) error {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return err
+	}
+
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		switch strings.ToLower(filepath.Ext(entry.Name())) {
+		case ".json", ".yaml", ".yml":
+			if err := l.LoadFile(filepath.Join(dir, entry.Name())); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// LoadFile loads one catalog, choosing JSON or YAML by extension
+func (l *Localizations) LoadFile(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	messages := make(map[string]interface{})
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json":
+		err = json.Unmarshal(data, &messages)
+	case ".yaml", ".yml":
+		err = yaml.Unmarshal(data, &messages)
+	default:
+		return fmt.Errorf("unsupported message catalog type: %s", path)
+	}
+	if err != nil {
+		return fmt.Errorf("invalid message catalog %s: %w", path, err)
+	}
+
+	locale := strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+	l.AddMessages(locale, messages)
+	return nil
+}
+
+// AddMessages merges messages into a locale's catalog, replacing existing keys
+func (l *Localizations) AddMessages(locale string, messages map[string]interface{}) {
+	locale = normalizeLocale(locale)
+
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	catalog, exists := l.catalogs[locale]
+	if !exists {
+		catalog = make(map[string]interface{})
+		l.catalogs[locale] = catalog
+	}
+	flattenMessages("", messages, catalog)
+}
+
+// Locales returns the locales that have a catalog, sorted
+func (l *Localizations) Locales() []string {
+	l.mutex.RLock()
+	defer l.mutex.RUnlock()
+
+	locales := make([]string, 0, len(l.catalogs))
+	for locale := range l.catalogs {
+		locales = append(locales, locale)
+	}
+	sort.Strings(locales)
+	return locales
+}
+
+// Match returns the supported locale for a language tag, trying "pt-BR" before "pt",
+// or "" when neither has a catalog
+func (l *Localizations) Match(locale string) string {
+	l.mutex.RLock()
+	defer l.mutex.RUnlock()
+
+	for _, candidate := range localeChain(normalizeLocale(locale)) {
+		if _, exists := l.catalogs[candidate]; exists {
+			return candidate
+		}
+	}
+	return ""
+}
+
+// Negotiate picks the best supported locale for an Accept-Language header, or "" for none
+func (l *Localizations) Negotiate(acceptLanguage string) string {
+	type weightedTag struct {
+		tag     string
+		quality float64
+	}
+
+	var tags []weightedTag
+	for _, part := range strings.Split(acceptLanguage, ",") {
+		fields := strings.Split(strings.TrimSpace(part), ";")
+		tag := strings.TrimSpace(fields[0])
+		if tag == "" || tag == "*" {
+			continue
+		}
+
+		quality := 1.0
+		for _, param := range fields[1:] {
+			param = strings.TrimSpace(param)
+			if strings.HasPrefix(param, "q=") {
+				if q, err := strconv.ParseFloat(param[2:], 64); err == nil {
+					quality = q
+				}
+			}
+		}
+		if quality > 0 {
+			tags = append(tags, weightedTag{tag: tag, quality: quality})
+		}
+	}
+	sort.SliceStable(tags, func(i, j int) bool { return tags[i].quality > tags[j].quality })
+
+	for _, tag := range tags {
+		if locale := l.Match(tag.tag); locale != "" {
+			return locale
+		}
+	}
+	return ""
+}
+
+// DetectLocale returns the locale for a request: the locale cookie set by
+// ctx.SetLocale, then the Accept-Language header, then the fallback locale
+func (l *Localizations) DetectLocale(r *http.Request) string {
+	if r != nil {
+		if cookie, err := r.Cookie(LocaleCookieName); err == nil {
+			if locale := l.Match(cookie.Value); locale != "" {
+				return locale
+			}
+		}
+		if locale := l.Negotiate(r.Header.Get("Accept-Language")); locale != "" {
+			return locale
+		}
+	}
+	return l.fallback
+}
+
+// Translate returns the message for key in locale, falling back to the base
+// language and then the fallback locale, or the key itself when no catalog has it.
+// args are name/value pairs substituted for {name} placeholders; a "count"
+// argument selects the plural form of a plural message.
+func (l *Localizations) Translate(locale, key string, args ...interface{}) string {
+	values := translationArgs(args)
+	message, found := l.lookup(normalizeLocale(locale), key)
+	if !found {
+		return interpolateMessage(key, values)
+	}
+
+	switch message := message.(type) {
+	case string:
+		return interpolateMessage(message, values)
+	case map[string]string:
+		return interpolateMessage(pluralForm(message, locale, values["count"]), values)
+	}
+	return key
+}
+
+// lookup finds a message along the locale chain and then the fallback locale
+func (l *Localizations) lookup(locale, key string) (interface{}, bool) {
+	l.mutex.RLock()
+	defer l.mutex.RUnlock()
+
+	for _, candidate := range append(localeChain(locale), l.fallback) {
+		if message, exists := l.catalogs[candidate][key]; exists {
+			return message, true
+		}
+	}
+	return nil, false
+}
+
+// Localizations returns the app's message catalogs, loaded from the i18n directory at startup
+func (app *App) Localizations() *Localizations {
+	return app.localizations
+}
+
+// Locale returns the locale of the current request, detected once per request
+func (c *Context) Locale() string {
+	if locale, ok := c.Get(localeKey).(string); ok {
+		return locale
+	}
+	if c.App == nil || c.App.localizations == nil {
+		return "en"
+	}
+
+	locale := c.App.localizations.DetectLocale(c.Request)
+	c.Set(localeKey, locale)
+	return locale
+}
+
+// SetLocale switches the locale for this request and remembers it in a cookie for later ones
+func (c *Context) SetLocale(locale string) {
+	locale = normalizeLocale(locale)
+	c.Set(localeKey, locale)
+
+	if c.Response != nil {
+		http.SetCookie(c.Response, &http.Cookie{
+			Name:     LocaleCookieName,
+			Value:    locale,
+			Path:     "/",
+			MaxAge:   365 * 24 * 60 * 60,
+			SameSite: http.SameSiteLaxMode,
+		})
+	}
+}
+
+// T translates key into the request's locale, e.g. ctx.T("cart.items", "count", 3)
+func (c *Context) T(key string, args ...interface{}) string {
+	if c == nil || c.App == nil || c.App.localizations == nil {
+		return interpolateMessage(key, translationArgs(args))
+	}
+	return c.App.localizations.Translate(c.Locale(), key, args...)
+}
+
+// flattenMessages copies nested catalog entries into catalog under dotted keys
+func flattenMessages(prefix string, messages map[string]interface{}, catalog map[string]interface{}) {
+	for key, value := range messages {
+		if prefix != "" {
+			key = prefix + "." + key
+		}
+
+		switch value := value.(type) {
+		case map[string]interface{}:
+			if forms, ok := pluralForms(value); ok {
+				catalog[key] = forms
+			} else {
+				flattenMessages(key, value, catalog)
+			}
+		case string:
+			catalog[key] = value
+		default:
+			catalog[key] = fmt.Sprint(value)
+		}
+	}
+}
+
+// pluralForms converts a map whose keys are all plural categories into plural forms
+func pluralForms(value map[string]interface{}) (map[string]string, bool) {
+	if len(value) == 0 {
+		return nil, false
+	}
+
+	forms := make(map[string]string, len(value))
+	for category, form := range value {
+		text, ok := form.(string)
+		if !ok || !pluralCategories[category] {
+			return nil, false
+		}
+		forms[category] = text
+	}
+	return forms, true
+}
+
+// pluralForm chooses the form of a plural message for count, defaulting to "other"
+func pluralForm(forms map[string]string, locale string, count interface{}) string {
+	n, ok := toFloat(count)
+	if !ok {
+		return forms["other"]
+	}
+
+	// An explicit zero form reads better than the language's rule, e.g. "No items"
+	if n == 0 {
+		if form, exists := forms["zero"]; exists {
+			return form
+		}
+	}
+	if form, exists := forms[pluralCategory(locale, n)]; exists {
+		return form
+	}
+	return forms["other"]
+}
+
+// pluralCategory returns the CLDR plural category of n for the locale's language
+func pluralCategory(locale string, n float64) string {
+	language := strings.SplitN(normalizeLocale(locale), "-", 2)[0]
+	integer := n == float64(int64(n))
+
+	switch language {
+	case "ja", "zh", "ko", "th", "vi", "id", "ms":
+		return "other"
+	case "fr", "pt":
+		if n >= 0 && n < 2 {
+			return "one"
+		}
+		return "other"
+	case "ru", "uk", "be":
+		if !integer {
+			return "other"
+		}
+		mod10, mod100 := int64(n)%10, int64(n)%100
+		switch {
+		case mod10 == 1 && mod100 != 11:
+			return "one"
+		case mod10 >= 2 && mod10 <= 4 && (mod100 < 12 || mod100 > 14):
+			return "few"
+		default:
+			return "many"
+		}
+	case "pl":
+		if !integer {
+			return "other"
+		}
+		mod10, mod100 := int64(n)%10, int64(n)%100
+		switch {
+		case n == 1:
+			return "one"
+		case mod10 >= 2 && mod10 <= 4 && (mod100 < 12 || mod100 > 14):
+			return "few"
+		default:
+			return "many"
+		}
+	case "ar":
+		switch {
+		case n == 0:
+			return "zero"
+		case n == 1:
+			return "one"
+		case n == 2:
+			return "two"
+		case integer && int64(n)%100 >= 3 && int64(n)%100 <= 10:
+			return "few"
+		case integer && int64(n)%100 >= 11:
+			return "many"
+		default:
+			return "other"
+		}
+	default:
+		if n == 1 {
+			return "one"
+		}
+		return "other"
+	}
+}
+
+// translationArgs converts name/value pairs into a placeholder map
+func translationArgs(args []interface{}) map[string]interface{} {
+	values := make(map[string]interface{}, len(args)/2)
+	for i := 0; i+1 < len(args); i += 2 {
+		values[fmt.Sprint(args[i])] = args[i+1]
+	}
+	return values
+}
+
+// interpolateMessage replaces {name} placeholders with their values, leaving unknown ones as is
+func interpolateMessage(message string, values map[string]interface{}) string {
+	if len(values) == 0 || !strings.Contains(message, "{") {
+		return message
+	}
+
+	pairs := make([]string, 0, len(values)*2)
+	for name, value := range values {
+		pairs = append(pairs, "{"+name+"}", fmt.Sprint(value))
+	}
+	return strings.NewReplacer(pairs...).Replace(message)
+}
+
+// localeChain returns a locale followed by its base language, e.g. "pt-BR", "pt"
+func localeChain(locale string) []string {
+	chain := []string{locale}
+	if index := strings.Index(locale, "-"); index > 0 {
+		chain = append(chain, locale[:index])
+	}
+	return chain
+}
+
+// normalizeLocale canonicalizes a language tag, e.g. "pt_br" becomes "pt-BR"
+func normalizeLocale(locale string) string {
+	parts := strings.Split(strings.ReplaceAll(strings.TrimSpace(locale), "_", "-"), "-")
+	parts[0] = strings.ToLower(parts[0])
+	for i := 1; i < len(parts); i++ {
+		if len(parts[i]) == 2 {
+			parts[i] = strings.ToUpper(parts[i])
+		}
+	}
+	return strings.Join(parts, "-")
+}
+
+// toFloat converts a numeric plural count to float64
+func toFloat(value interface{}) (float64, bool) {
+	switch value := value.(type) {
+	case int:
+		return float64(value), true
+	case int8:
+		return float64(value), true
+	case int16:
+		return float64(value), true
+	case int32:
+		return float64(value), true
+	case int64:
+		return float64(value), true
+	case uint:
+		return float64(value), true
+	case uint8:
+		return float64(value), true
+	case uint16:
+		return float64(value), true
+	case uint32:
+		return float64(value), true
+	case uint64:
+		return float64(value), true
+	case float32:
+		return float64(value), true
+	case float64:
+		return value, true
+	case string:
+		parsed, err := strconv.ParseFloat(value, 64)
+		return parsed, err == nil
+	}
+	return 0, false
+}
@@ -65,6 +65,7 @@ type MediaQueryProvider struct {
 	mutex         sync.RWMutex
 	updateChannel chan *MediaQueryData
 	isListening   bool
+	breakpoints   Breakpoints
 }
 
 // NewMediaQueryProvider creates a new MediaQueryProvider
@@ -74,9 +75,27 @@ func NewMediaQueryProvider() *MediaQueryProvider {
 		listeners:     make([]func(*MediaQueryData), 0),
 		updateChannel: make(chan *MediaQueryData, 10),
 		isListening:   false,
+		breakpoints:   DefaultBreakpoints,
 	}
 }
 
+// SetBreakpoints sets the breakpoints used to classify screen widths
+func (mqp *MediaQueryProvider) SetBreakpoints(breakpoints Breakpoints) {
+	mqp.mutex.Lock()
+	defer mqp.mutex.Unlock()
+
+	mqp.breakpoints = breakpoints.withDefaults()
+	mqp.currentData.Breakpoint = mqp.breakpoints.Resolve(mqp.currentData.Size.Width)
+	mqp.notifyListeners()
+}
+
+// Breakpoints returns the breakpoints used by this provider
+func (mqp *MediaQueryProvider) Breakpoints() Breakpoints {
+	mqp.mutex.RLock()
+	defer mqp.mutex.RUnlock()
+	return mqp.breakpoints
+}
+
 // NewDefaultMediaQueryData creates default MediaQueryData
 func NewDefaultMediaQueryData() *MediaQueryData {
 	return &MediaQueryData{
@@ -115,7 +134,7 @@ func (mqp *MediaQueryProvider) UpdateData(data *MediaQueryData) {
 	defer mqp.mutex.Unlock()
 
 	// Update breakpoint based on size
-	data.Breakpoint = GetBreakpoint(data.Size.Width)
+	data.Breakpoint = mqp.breakpoints.Resolve(data.Size.Width)
 
 	// Update orientation based on size
 	if data.Size.Width > data.Size.Height {
@@ -134,7 +153,7 @@ func (mqp *MediaQueryProvider) UpdateSize(width, height float64) {
 	defer mqp.mutex.Unlock()
 
 	mqp.currentData.Size = NewSize(width, height)
-	mqp.currentData.Breakpoint = GetBreakpoint(width)
+	mqp.currentData.Breakpoint = mqp.breakpoints.Resolve(width)
 
 	if width > height {
 		mqp.currentData.Orientation = OrientationLandscape
@@ -409,7 +428,8 @@ func (rb ResponsiveBuilder) Render(ctx *Context) string {
 
 	var widget Widget
 
-	switch mediaQuery.Breakpoint {
+	// Resolve against the app's configured breakpoints rather than the defaults
+	switch ctx.Breakpoints().Resolve(mediaQuery.Size.Width) {
 	case BreakpointXS:
 		widget = rb.XS
 	case BreakpointSM:
@@ -475,6 +495,11 @@ type BoxConstraints struct {
 	MaxHeight float64
 }
 
+// Breakpoint returns the breakpoint matching the maximum width of these constraints
+func (bc BoxConstraints) Breakpoint(breakpoints Breakpoints) Breakpoint {
+	return breakpoints.Resolve(bc.MaxWidth)
+}
+
 // Render renders the LayoutBuilder
 func (lb LayoutBuilder) Render(ctx *Context) string {
 	if lb.Builder == nil {
@@ -0,0 +1,228 @@
+package core
+
+import (
+	"bufio"
+	"fmt"
+	"net"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gorilla/mux"
+)
+
+// metricsDurationBuckets are the histogram upper bounds (seconds) for handler durations
+var metricsDurationBuckets = []float64{0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10}
+
+// Metrics collects request and runtime metrics and exposes them in Prometheus text format
+type Metrics struct {
+	app       *App
+	requests  map[metricsRequestKey]uint64
+	durations map[metricsDurationKey]*metricsHistogram
+	startTime time.Time
+	mutex     sync.RWMutex
+}
+
+// metricsRequestKey identifies a request counter series
+type metricsRequestKey struct {
+	method string
+	route  string
+	status int
+}
+
+// metricsDurationKey identifies a duration histogram series
+type metricsDurationKey struct {
+	method string
+	route  string
+}
+
+// metricsHistogram is a cumulative histogram of observed durations
+type metricsHistogram struct {
+	buckets []uint64
+	count   uint64
+	sum     float64
+}
+
+// NewMetrics creates a new metrics collector for the app
+func NewMetrics(app *App) *Metrics {
+	return &Metrics{
+		app:       app,
+		requests:  make(map[metricsRequestKey]uint64),
+		durations: make(map[metricsDurationKey]*metricsHistogram),
+		startTime: time.Now(),
+	}
+}
+
+// EnableMetrics exposes Prometheus metrics at the given path (defaults to /metrics)
+func (app *App) EnableMetrics(path string) *Metrics {
+	if path == "" {
+		path = "/metrics"
+	}
+
+	if app.metrics == nil {
+		app.metrics = NewMetrics(app)
+	}
+
+	app.router.HandleFunc(path, app.metrics.ServeHTTP).Methods("GET")
+	return app.metrics
+}
+
+// Metrics returns the metrics collector, or nil when metrics are disabled
+func (app *App) Metrics() *Metrics {
+	return app.metrics
+}
+
+// Observe records one completed request
+func (m *Metrics) Observe(method, route string, status int, duration time.Duration) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	m.requests[metricsRequestKey{method: method, route: route, status: status}]++
+
+	key := metricsDurationKey{method: method, route: route}
+	histogram, exists := m.durations[key]
+	if !exists {
+		histogram = &metricsHistogram{buckets: make([]uint64, len(metricsDurationBuckets))}
+		m.durations[key] = histogram
+	}
+
+	seconds := duration.Seconds()
+	for i, bound := range metricsDurationBuckets {
+		if seconds <= bound {
+			histogram.buckets[i]++
+		}
+	}
+	histogram.count++
+	histogram.sum += seconds
+}
+
+// Middleware records request counts and durations for every matched route
+func (m *Metrics) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+		recorder := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+
+		next.ServeHTTP(recorder, r)
+
+		route := r.URL.Path
+		if current := mux.CurrentRoute(r); current != nil {
+			if template, err := current.GetPathTemplate(); err == nil {
+				route = template
+			}
+		}
+
+		m.Observe(r.Method, route, recorder.status, time.Since(start))
+	})
+}
+
+// ServeHTTP writes all metrics in the Prometheus text exposition format
+func (m *Metrics) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+
+	var b strings.Builder
+	m.mutex.RLock()
+
+	b.WriteString("# HELP godin_http_requests_total Total HTTP requests by route and status.\n")
+	b.WriteString("# TYPE godin_http_requests_total counter\n")
+	requestKeys := make([]metricsRequestKey, 0, len(m.requests))
+	for key := range m.requests {
+		requestKeys = append(requestKeys, key)
+	}
+	sort.Slice(requestKeys, func(i, j int) bool {
+		if requestKeys[i].route != requestKeys[j].route {
+			return requestKeys[i].route < requestKeys[j].route
+		}
+		if requestKeys[i].method != requestKeys[j].method {
+			return requestKeys[i].method < requestKeys[j].method
+		}
+		return requestKeys[i].status < requestKeys[j].status
+	})
+	for _, key := range requestKeys {
+		fmt.Fprintf(&b, "godin_http_requests_total{method=%q,route=%q,status=\"%d\"} %d\n",
+			key.method, key.route, key.status, m.requests[key])
+	}
+
+	b.WriteString("# HELP godin_http_request_duration_seconds Handler duration by route.\n")
+	b.WriteString("# TYPE godin_http_request_duration_seconds histogram\n")
+	durationKeys := make([]metricsDurationKey, 0, len(m.durations))
+	for key := range m.durations {
+		durationKeys = append(durationKeys, key)
+	}
+	sort.Slice(durationKeys, func(i, j int) bool {
+		if durationKeys[i].route != durationKeys[j].route {
+			return durationKeys[i].route < durationKeys[j].route
+		}
+		return durationKeys[i].method < durationKeys[j].method
+	})
+	for _, key := range durationKeys {
+		histogram := m.durations[key]
+		labels := fmt.Sprintf("method=%q,route=%q", key.method, key.route)
+		for i, bound := range metricsDurationBuckets {
+			fmt.Fprintf(&b, "godin_http_request_duration_seconds_bucket{%s,le=\"%s\"} %d\n",
+				labels, strconv.FormatFloat(bound, 'f', -1, 64), histogram.buckets[i])
+		}
+		fmt.Fprintf(&b, "godin_http_request_duration_seconds_bucket{%s,le=\"+Inf\"} %d\n", labels, histogram.count)
+		fmt.Fprintf(&b, "godin_http_request_duration_seconds_sum{%s} %g\n", labels, histogram.sum)
+		fmt.Fprintf(&b, "godin_http_request_duration_seconds_count{%s} %d\n", labels, histogram.count)
+	}
+
+	m.mutex.RUnlock()
+
+	if m.app != nil {
+		b.WriteString("# HELP godin_websocket_connections Active WebSocket connections.\n")
+		b.WriteString("# TYPE godin_websocket_connections gauge\n")
+		fmt.Fprintf(&b, "godin_websocket_connections %d\n", m.app.websocket.GetConnectionCount())
+
+		b.WriteString("# HELP godin_state_updates_total Total state updates applied.\n")
+		b.WriteString("# TYPE godin_state_updates_total counter\n")
+		fmt.Fprintf(&b, "godin_state_updates_total %d\n", m.app.state.UpdateCount())
+	}
+
+	b.WriteString("# HELP godin_uptime_seconds Seconds since metrics were enabled.\n")
+	b.WriteString("# TYPE godin_uptime_seconds gauge\n")
+	fmt.Fprintf(&b, "godin_uptime_seconds %g\n", time.Since(m.startTime).Seconds())
+
+	w.Write([]byte(b.String()))
+}
+
+// statusRecorder captures the status code written by a handler
+type statusRecorder struct {
+	http.ResponseWriter
+	status      int
+	wroteHeader bool
+}
+
+// WriteHeader records the status code before writing it
+func (sr *statusRecorder) WriteHeader(code int) {
+	if !sr.wroteHeader {
+		sr.status = code
+		sr.wroteHeader = true
+	}
+	sr.ResponseWriter.WriteHeader(code)
+}
+
+// Write marks the header as written with the default status
+func (sr *statusRecorder) Write(b []byte) (int, error) {
+	sr.wroteHeader = true
+	return sr.ResponseWriter.Write(b)
+}
+
+// Flush forwards flushes to the underlying writer when supported
+func (sr *statusRecorder) Flush() {
+	if flusher, ok := sr.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+// Hijack forwards hijacking so WebSocket upgrades keep working behind the middleware
+func (sr *statusRecorder) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	hijacker, ok := sr.ResponseWriter.(http.Hijacker)
+	if !ok {
+		return nil, nil, fmt.Errorf("response writer does not support hijacking")
+	}
+	sr.status = http.StatusSwitchingProtocols
+	return hijacker.Hijack()
+}
@@ -0,0 +1,100 @@
+package core
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/gorilla/mux"
+)
+
+// mountedApp is a sub-application served under a path prefix
+type mountedApp struct {
+	prefix string
+	app    *App
+	routes []*mux.Route // Parent routes that forward to the sub-application
+}
+
+// Mount serves sub under prefix, so a feature module built as its own App, with its
+// own routes, middleware and handlers, can be plugged into a larger app, e.g.
+// app.Mount("/admin", admin.New()). The sub-application's handler, callback, state
+// and static endpoints move under the prefix, so they cannot collide with the
+// parent's. It shares the parent's WebSocket connection and CSRF protection, and
+// its requests pass through the parent's middleware before its own; routes it marks
+// public, before or after mounting, are exempt from the parent's CSRF check and
+// listed in the parent's ExemptRoutes with the full path.
+func (app *App) Mount(prefix string, sub *App) {
+	prefix = "/" + strings.Trim(prefix, "/")
+	sub.basePath = app.basePath + prefix
+
+	// Pages of the sub-application load their assets from its own static mount
+	sub.assets.SetPrefix(sub.basePath + "/static/")
+	sub.server.setupStaticFiles()
+
+	// State broadcasts reach the client over the one WebSocket a page opens
+	sub.websocket = app.websocket
+	sub.state.SetBroadcaster(app.websocket)
+
+	// Requests pass the parent's CSRF check, so sub-application pages render the parent's token
+	sub.parent = app
+
+	handler := http.StripPrefix(prefix, rootPathHandler(sub.router))
+	mount := &mountedApp{prefix: prefix, app: sub}
+	mount.routes = append(mount.routes,
+		app.router.Path(prefix).Handler(handler),
+		app.router.PathPrefix(prefix+"/").Handler(handler),
+	)
+	app.mounts = append(app.mounts, mount)
+
+	// Routes marked public before mounting show up on the parent too
+	for _, path := range sub.ExemptRoutes() {
+		sub.forwardExempt(path)
+	}
+}
+
+// forwardedRoute resolves a request the parent forwards to a mounted app: it
+// returns the mounted app, the request as that app sees it, without the prefix,
+// and the app's route it matches (nil when none does)
+func (app *App) forwardedRoute(route *mux.Route, r *http.Request) (*App, *http.Request, *mux.Route, bool) {
+	if route == nil {
+		return nil, nil, nil, false
+	}
+	for _, mount := range app.mounts {
+		for _, forward := range mount.routes {
+			if forward != route {
+				continue
+			}
+			sub := r.Clone(r.Context())
+			sub.URL.Path = strings.TrimPrefix(r.URL.Path, mount.prefix)
+			if sub.URL.Path == "" {
+				sub.URL.Path = "/"
+			}
+			var match mux.RouteMatch
+			if !mount.app.router.Match(sub, &match) {
+				match.Route = nil
+			}
+			return mount.app, sub, match.Route, true
+		}
+	}
+	return nil, nil, nil, false
+}
+
+// BasePath returns the prefix the app is mounted under, or "" for the top-level app
+func (app *App) BasePath() string {
+	return app.basePath
+}
+
+// Path returns an absolute path within the app, including its mount prefix,
+// e.g. "/handlers/handler_0" -> "/admin/handlers/handler_0"
+func (app *App) Path(path string) string {
+	return app.basePath + path
+}
+
+// rootPathHandler serves the prefix itself, which StripPrefix leaves empty, as "/"
+func rootPathHandler(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "" {
+			r.URL.Path = "/"
+		}
+		next.ServeHTTP(w, r)
+	})
+}
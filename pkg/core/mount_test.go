@@ -0,0 +1,63 @@
+package core
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// csrfApp returns an app with CSRF protection behind its middleware, as the server sets it up
+func csrfApp() *App {
+	app := New()
+	app.EnableCSRF()
+	app.Router().Use(app.csrfMiddleware)
+	return app
+}
+
+// post sends a POST without a CSRF token and returns the status code
+func post(app *App, path string) int {
+	recorder := httptest.NewRecorder()
+	app.Router().ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, path, nil))
+	return recorder.Code
+}
+
+func TestMountedPublicRoutesSkipCSRF(t *testing.T) {
+	ok := func(ctx *Context) Widget {
+		ctx.WriteHTML("ok")
+		return nil
+	}
+
+	app := csrfApp()
+	sub := New()
+	sub.POST("/before", ok).Public()
+	sub.POST("/private", ok)
+	sub.POST("/files/upload", ok)
+	app.Mount("/admin", sub)
+	sub.POST("/hook", ok).Public()
+	sub.Exempt("/files/*")
+
+	tests := []struct {
+		path string
+		code int
+	}{
+		{"/admin/before", http.StatusOK},
+		{"/admin/hook", http.StatusOK},
+		{"/admin/files/upload", http.StatusOK},
+		{"/admin/private", http.StatusForbidden},
+	}
+	for _, test := range tests {
+		if code := post(app, test.path); code != test.code {
+			t.Errorf("Expected POST %s to get %d, got %d", test.path, test.code, code)
+		}
+	}
+
+	exempt := map[string]bool{}
+	for _, path := range app.ExemptRoutes() {
+		exempt[path] = true
+	}
+	for _, path := range []string{"/admin/before", "/admin/hook", "/admin/files/*"} {
+		if !exempt[path] {
+			t.Errorf("Expected the parent to list %s as public, got %v", path, app.ExemptRoutes())
+		}
+	}
+}
@@ -0,0 +1,115 @@
+package core
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"html/template"
+	"strings"
+)
+
+// Context keys for the styles, scripts and head HTML contributed to the page
+const (
+	pageCSSKey     = "godin.page.css"
+	pageScriptsKey = "godin.page.scripts"
+	pageHeadKey    = "godin.page.head"
+)
+
+// AddCSS adds styles to the page rendered by this handler. Handlers and widgets
+// can call it on every render: each distinct stylesheet is included once, in a
+// <style> element in the page head. The CSS is not escaped, so never pass user input.
+func (c *Context) AddCSS(css string) {
+	c.addPageAsset(pageCSSKey, css)
+}
+
+// AddScript adds JavaScript to the page rendered by this handler, run after
+// godin.js is loaded. Like AddCSS, each distinct script is included once, so a
+// widget that needs a one-off script, such as a chart, can register it whenever
+// it renders. The script is not escaped, so never pass user input.
+func (c *Context) AddScript(js string) {
+	c.addPageAsset(pageScriptsKey, js)
+}
+
+// AddHeadHTML adds HTML to the page head, e.g. a <link> to a stylesheet from a
+// CDN. Each distinct snippet is included once. The HTML is not escaped, so never
+// pass user input.
+func (c *Context) AddHeadHTML(html string) {
+	c.addPageAsset(pageHeadKey, html)
+}
+
+// addPageAsset appends a contribution to the list under key unless it is already there
+func (c *Context) addPageAsset(key, asset string) {
+	if strings.TrimSpace(asset) == "" {
+		return
+	}
+	assets, _ := c.Get(key).([]string)
+	for _, existing := range assets {
+		if existing == asset {
+			return
+		}
+	}
+	c.Set(key, append(assets, asset))
+}
+
+// pageAssets returns the contributions under key joined for the page template
+func (c *Context) pageAssets(key string) string {
+	assets, _ := c.Get(key).([]string)
+	return strings.Join(assets, "\n")
+}
+
+// pageAssetID identifies a contribution, so godin.js adds each one to a page only once
+func pageAssetID(asset string) string {
+	sum := sha256.Sum256([]byte(asset))
+	return hex.EncodeToString(sum[:8])
+}
+
+// pageAssetIDs returns the IDs of every contribution, space separated, for the page template
+func (c *Context) pageAssetIDs() string {
+	var ids []string
+	for _, key := range []string{pageCSSKey, pageScriptsKey, pageHeadKey} {
+		assets, _ := c.Get(key).([]string)
+		for _, asset := range assets {
+			ids = append(ids, pageAssetID(asset))
+		}
+	}
+	return strings.Join(ids, " ")
+}
+
+// pageAssetsFragment returns the contributions added while rendering an HTMX
+// partial as an out-of-band swap: inert templates that godin.js moves into the
+// page head, skipping those the page already has. Without it, widgets that call
+// AddCSS, AddScript or AddHeadHTML would render unstyled in partial updates.
+func (c *Context) pageAssetsFragment() string {
+	kinds := []struct{ key, format string }{
+		{pageCSSKey, "<style>%s</style>"},
+		{pageScriptsKey, "<script>%s</script>"},
+		{pageHeadKey, "%s"},
+	}
+
+	var fragment strings.Builder
+	for _, k := range kinds {
+		assets, _ := c.Get(k.key).([]string)
+		for _, asset := range assets {
+			fragment.WriteString(fmt.Sprintf(`<template data-godin-asset="%s">`+k.format+`</template>`, pageAssetID(asset), asset))
+		}
+	}
+	if fragment.Len() == 0 {
+		return ""
+	}
+	return `<div class="godin-page-assets" hx-swap-oob="beforeend:body" hidden>` + fragment.String() + `</div>`
+}
+
+// pageCSS returns the styles added with AddCSS
+func (c *Context) pageCSS() template.CSS {
+	return template.CSS(c.pageAssets(pageCSSKey))
+}
+
+// pageScripts returns the scripts added with AddScript
+func (c *Context) pageScripts() template.JS {
+	return template.JS(c.pageAssets(pageScriptsKey))
+}
+
+// pageHead returns the head HTML added with AddHeadHTML
+func (c *Context) pageHead() template.HTML {
+	return template.HTML(c.pageAssets(pageHeadKey))
+}
@@ -0,0 +1,66 @@
+package core
+
+import "strings"
+
+// Context keys for the page head set by handlers
+const (
+	pageTitleKey = "godin.page.title"
+	pageMetaKey  = "godin.page.meta"
+)
+
+// MetaTag is a <meta> element rendered in the page head
+type MetaTag struct {
+	Name     string // name attribute, e.g. "description"
+	Property string // property attribute for Open Graph tags, e.g. "og:title"
+	Content  string
+}
+
+// SetTitle sets the <title> of the page rendered by this handler
+func (c *Context) SetTitle(title string) {
+	c.Set(pageTitleKey, title)
+}
+
+// Title returns the page title set with SetTitle
+func (c *Context) Title() string {
+	return c.GetString(pageTitleKey)
+}
+
+// SetDescription sets the page's meta description, used by search engines and link previews
+func (c *Context) SetDescription(description string) {
+	c.SetMeta("description", description)
+}
+
+// SetMeta adds a meta tag to the page head, replacing one with the same name.
+// Open Graph names ("og:*", "article:*") are rendered with the property attribute.
+func (c *Context) SetMeta(name, content string) {
+	tag := MetaTag{Name: name, Content: content}
+	if strings.HasPrefix(name, "og:") || strings.HasPrefix(name, "article:") {
+		tag = MetaTag{Property: name, Content: content}
+	}
+
+	tags, _ := c.Get(pageMetaKey).([]MetaTag)
+	for i, existing := range tags {
+		if existing.Name == tag.Name && existing.Property == tag.Property {
+			tags[i] = tag
+			c.Set(pageMetaKey, tags)
+			return
+		}
+	}
+	c.Set(pageMetaKey, append(tags, tag))
+}
+
+// MetaTags returns the meta tags set for this page, including the configured
+// default description when the handler did not set one
+func (c *Context) MetaTags() []MetaTag {
+	tags, _ := c.Get(pageMetaKey).([]MetaTag)
+	if c.App == nil || c.App.config.Page.Description == "" {
+		return tags
+	}
+
+	for _, tag := range tags {
+		if tag.Name == "description" {
+			return tags
+		}
+	}
+	return append([]MetaTag{{Name: "description", Content: c.App.config.Page.Description}}, tags...)
+}
@@ -0,0 +1,79 @@
+package core
+
+import (
+	"log"
+	"net/http"
+	"runtime/debug"
+)
+
+// PanicHandler is told about a panic that escaped a request or callback, with the
+// stack where it happened. ctx is nil for panics outside a request, e.g. in a
+// WebSocket callback.
+type PanicHandler func(err interface{}, stack []byte, ctx *Context)
+
+// OnPanic adds a handler called for every panic a request, route handler or callback
+// does not recover itself, e.g. to report it to Sentry or post it to Slack. The
+// panic is still logged and answered with a 500; handlers only add reporting.
+func (app *App) OnPanic(handler PanicHandler) *App {
+	app.panicMutex.Lock()
+	defer app.panicMutex.Unlock()
+	app.panicHandlers = append(app.panicHandlers, handler)
+	return app
+}
+
+// reportPanic logs a recovered panic and passes it to the OnPanic handlers.
+// Mounted apps without handlers of their own report to their parent's.
+func (app *App) reportPanic(err interface{}, stack []byte, ctx *Context) {
+	if ctx != nil {
+		ctx.Logf("panic: %v\n%s", err, stack)
+	} else {
+		log.Printf("panic: %v\n%s", err, stack)
+	}
+
+	for a := app; a != nil; a = a.parent {
+		a.panicMutex.RLock()
+		handlers := append([]PanicHandler(nil), a.panicHandlers...)
+		a.panicMutex.RUnlock()
+
+		if len(handlers) == 0 {
+			continue
+		}
+		for _, handler := range handlers {
+			runPanicHandler(handler, err, stack, ctx)
+		}
+		return
+	}
+}
+
+// runPanicHandler calls one handler, so a failing alert cannot take down the server
+func runPanicHandler(handler PanicHandler, err interface{}, stack []byte, ctx *Context) {
+	defer func() {
+		if r := recover(); r != nil {
+			log.Printf("OnPanic handler panicked: %v", r)
+		}
+	}()
+	handler(err, stack, ctx)
+}
+
+// recoverMiddleware turns a panic in any later middleware or handler into a 500
+// response and reports it, instead of dropping the connection. The response is
+// the debug panic page with GODIN_DEBUG set, otherwise the app's panic page.
+func (app *App) recoverMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		defer func() {
+			err := recover()
+			if err == nil {
+				return
+			}
+			// net/http uses this panic to abort a response on purpose
+			if err == http.ErrAbortHandler {
+				panic(err)
+			}
+
+			stack := debug.Stack()
+			app.reportPanic(err, stack, NewContext(w, r, app))
+			app.writePanicResponse(w, r, err, stack)
+		}()
+		next.ServeHTTP(w, r)
+	})
+}
@@ -0,0 +1,288 @@
+package core
+
+import (
+	"fmt"
+	"html"
+	"log"
+	"net/http"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/gorilla/mux"
+)
+
+// DefaultEditorURL opens the file:line links on the debug panic page in VS Code
+const DefaultEditorURL = "vscode://file/{file}:{line}"
+
+// PanicHeader marks responses answering a panic, so godin.js can show the
+// debug panic page over the current page after an HTMX request
+const PanicHeader = "X-Godin-Panic"
+
+// StackFrame is one call in a parsed Go stack trace
+type StackFrame struct {
+	Function string
+	File     string
+	Line     int
+	App      bool // Whether the frame is in the application rather than Go or a dependency
+}
+
+// ParseStack parses a stack trace from runtime/debug.Stack into frames,
+// starting at the call that panicked when the stack passed through panic
+func ParseStack(stack []byte) []StackFrame {
+	lines := strings.Split(strings.TrimSpace(string(stack)), "\n")
+
+	var frames []StackFrame
+	for i := 1; i+1 < len(lines); i += 2 {
+		function := strings.TrimSpace(lines[i])
+		location := strings.TrimSpace(lines[i+1])
+
+		// "/path/to/file.go:42 +0x1d"
+		if space := strings.LastIndex(location, " +"); space != -1 {
+			location = location[:space]
+		}
+		file, line := location, 0
+		if colon := strings.LastIndex(location, ":"); colon != -1 {
+			file = location[:colon]
+			line, _ = strconv.Atoi(location[colon+1:])
+		}
+
+		function = strings.TrimPrefix(function, "created by ")
+		if paren := strings.LastIndex(function, "("); paren > 0 {
+			function = function[:paren]
+		}
+		if function == "panic" {
+			// Everything before this frame is the recovery, not the failure
+			frames = frames[:0]
+			continue
+		}
+		frames = append(frames, StackFrame{
+			Function: function,
+			File:     file,
+			Line:     line,
+			App:      isAppFrame(file),
+		})
+	}
+	return frames
+}
+
+// isAppFrame reports whether a source file belongs to the application
+func isAppFrame(file string) bool {
+	file = filepath.ToSlash(file)
+	if root := filepath.ToSlash(runtime.GOROOT()); root != "" && strings.HasPrefix(file, root+"/") {
+		return false
+	}
+	return !strings.Contains(file, "/pkg/mod/") && !strings.Contains(file, "/godin/pkg/")
+}
+
+// SetPanicPage sets the page shown when a request panics outside debug mode,
+// replacing the generic "Something went wrong" page. It is rendered with a 500
+// status; with GODIN_DEBUG set, the debug panic page is shown instead.
+func (app *App) SetPanicPage(handler Handler) *App {
+	app.panicPage = handler
+	return app
+}
+
+// PanicPage returns the page set with SetPanicPage; mounted apps without one use their parent's
+func (app *App) PanicPage() Handler {
+	if app.panicPage == nil && app.parent != nil {
+		return app.parent.PanicPage()
+	}
+	return app.panicPage
+}
+
+// writePanicResponse answers a request whose handler panicked: the debug panic
+// page in debug mode, otherwise the app's panic page
+func (app *App) writePanicResponse(w http.ResponseWriter, r *http.Request, err interface{}, stack []byte) {
+	defer func() {
+		// The error page must not take the connection down with it
+		if r := recover(); r != nil {
+			log.Printf("Panic page failed: %v", r)
+			http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		}
+	}()
+
+	w.Header().Set(PanicHeader, "true")
+
+	if app.config.Debug.Enabled {
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte(app.debugPanicPage(r, err, stack)))
+		return
+	}
+
+	ctx := NewContext(&panicResponse{ResponseWriter: w}, r, app)
+	var page Widget = panicPageWidget{}
+	if handler := app.PanicPage(); handler != nil {
+		if custom := handler(ctx); custom != nil {
+			page = custom
+		}
+	}
+
+	if ctx.IsHTMX() {
+		ctx.WriteHTML(ctx.renderWidget(page))
+		return
+	}
+	ctx.RenderTemplate(page, "Something went wrong")
+}
+
+// panicResponse sends a 500 status with whatever the panic page writes first
+type panicResponse struct {
+	http.ResponseWriter
+	wroteHeader bool
+}
+
+// WriteHeader replaces the page's status with 500
+func (pr *panicResponse) WriteHeader(code int) {
+	if !pr.wroteHeader {
+		pr.wroteHeader = true
+		pr.ResponseWriter.WriteHeader(http.StatusInternalServerError)
+	}
+}
+
+// Write sends the 500 status before the body
+func (pr *panicResponse) Write(b []byte) (int, error) {
+	pr.WriteHeader(http.StatusInternalServerError)
+	return pr.ResponseWriter.Write(b)
+}
+
+// panicPageWidget is the generic page shown for panics outside debug mode
+type panicPageWidget struct{}
+
+// Render renders the generic panic page
+func (panicPageWidget) Render(ctx *Context) string {
+	message := `<div class="godin-error-page" role="alert"><h1>Something went wrong</h1><p>An unexpected error occurred. Please try again.</p>`
+	if id := ctx.RequestID(); id != "" {
+		message += `<p class="godin-error-page-reference">Reference: ` + html.EscapeString(id) + `</p>`
+	}
+	return message + `</div>`
+}
+
+// debugPanicPage renders the panic message, stack and request as a standalone
+// page, so it works even when the app's templates are what failed
+func (app *App) debugPanicPage(r *http.Request, err interface{}, stack []byte) string {
+	var b strings.Builder
+
+	route := r.URL.Path
+	if current := mux.CurrentRoute(r); current != nil {
+		if template, tmplErr := current.GetPathTemplate(); tmplErr == nil {
+			route = template
+		}
+	}
+
+	b.WriteString(`<!DOCTYPE html><html lang="en"><head><meta charset="utf-8"><title>panic: `)
+	b.WriteString(html.EscapeString(fmt.Sprint(err)))
+	b.WriteString(`</title><style>` + debugPanicCSS + `</style></head><body><main class="godin-panic">`)
+	fmt.Fprintf(&b, `<p class="godin-panic-kind">panic in %s %s</p>`, html.EscapeString(r.Method), html.EscapeString(route))
+	fmt.Fprintf(&b, `<h1>%s</h1>`, html.EscapeString(fmt.Sprint(err)))
+
+	// Stack, with the application's own frames highlighted
+	b.WriteString(`<h2>Stack</h2><ol class="godin-panic-stack">`)
+	for _, frame := range ParseStack(stack) {
+		class := ""
+		if frame.App {
+			class = ` class="godin-panic-app"`
+		}
+		location := fmt.Sprintf("%s:%d", frame.File, frame.Line)
+		if link := app.editorLink(frame); link != "" {
+			location = fmt.Sprintf(`<a href="%s">%s</a>`, html.EscapeString(link), html.EscapeString(location))
+		} else {
+			location = html.EscapeString(location)
+		}
+		fmt.Fprintf(&b, `<li%s><code>%s</code><span>%s</span></li>`, class, html.EscapeString(frame.Function), location)
+	}
+	b.WriteString(`</ol><details><summary>Raw stack</summary><pre>`)
+	b.WriteString(html.EscapeString(string(stack)))
+	b.WriteString(`</pre></details>`)
+
+	// Request
+	b.WriteString(`<h2>Request</h2><table>`)
+	writeRow := func(name, value string) {
+		fmt.Fprintf(&b, `<tr><th>%s</th><td>%s</td></tr>`, html.EscapeString(name), html.EscapeString(value))
+	}
+	writeRow("URL", r.Method+" "+r.URL.String())
+	writeRow("Route", route)
+	writeRow("Remote address", r.RemoteAddr)
+	if id := RequestIDFromContext(r.Context()); id != "" {
+		writeRow("Request ID", id)
+	}
+	for _, name := range sortedKeys(mux.Vars(r)) {
+		writeRow("Param "+name, mux.Vars(r)[name])
+	}
+	if r.PostForm != nil {
+		for _, name := range sortedKeys(r.PostForm) {
+			value := strings.Join(r.PostForm[name], ", ")
+			if isSecretField(name) {
+				value = "[hidden]"
+			}
+			writeRow("Form "+name, value)
+		}
+	}
+	for _, name := range sortedKeys(r.Header) {
+		value := strings.Join(r.Header[name], ", ")
+		switch name {
+		case "Authorization", "Cookie", "Proxy-Authorization", http.CanonicalHeaderKey("X-CSRF-Token"):
+			value = "[hidden]"
+		}
+		writeRow(name, value)
+	}
+	b.WriteString(`</table></main></body></html>`)
+	return b.String()
+}
+
+// isSecretField reports whether a form field likely holds a secret not to show
+func isSecretField(name string) bool {
+	name = strings.ToLower(name)
+	for _, secret := range []string{"password", "secret", "token"} {
+		if strings.Contains(name, secret) {
+			return true
+		}
+	}
+	return false
+}
+
+// editorLink builds the link opening a frame's file in the configured editor
+func (app *App) editorLink(frame StackFrame) string {
+	editor := app.config.Debug.EditorURL
+	if editor == "" || frame.File == "" {
+		return ""
+	}
+	file := filepath.ToSlash(frame.File)
+	if strings.HasPrefix(file, "/") {
+		// vscode://file/{file} takes the absolute path without doubling its slash
+		editor = strings.ReplaceAll(editor, "/{file}", "{file}")
+	}
+	link := strings.ReplaceAll(editor, "{file}", file)
+	return strings.ReplaceAll(link, "{line}", strconv.Itoa(frame.Line))
+}
+
+// sortedKeys returns a map's keys in order
+func sortedKeys[V any](values map[string]V) []string {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// debugPanicCSS styles the debug panic page
+const debugPanicCSS = `
+body { margin: 0; background: #1e1e1e; color: #e0e0e0; font: 14px/1.5 -apple-system, BlinkMacSystemFont, "Segoe UI", sans-serif; }
+.godin-panic { max-width: 1100px; margin: 0 auto; padding: 32px 24px; }
+.godin-panic-kind { margin: 0; color: #ef9a9a; text-transform: uppercase; font-size: 12px; letter-spacing: 0.05em; }
+h1 { margin: 8px 0 24px; color: #ff8a80; font-size: 22px; white-space: pre-wrap; word-break: break-word; }
+h2 { margin: 32px 0 8px; font-size: 15px; color: #bdbdbd; }
+ol { margin: 0; padding: 0; list-style: none; }
+li { display: flex; flex-wrap: wrap; gap: 4px 16px; justify-content: space-between; padding: 6px 10px; border-left: 3px solid transparent; color: #9e9e9e; }
+li.godin-panic-app { border-left-color: #ff8a80; background: #2a2a2a; color: #fff; }
+code, pre { font-family: ui-monospace, SFMono-Regular, Menlo, monospace; font-size: 13px; }
+a { color: #90caf9; }
+pre { overflow: auto; padding: 12px; background: #121212; }
+summary { margin-top: 12px; cursor: pointer; color: #9e9e9e; }
+table { width: 100%; border-collapse: collapse; }
+th, td { padding: 4px 10px; text-align: left; vertical-align: top; border-bottom: 1px solid #333; word-break: break-all; }
+th { width: 200px; color: #9e9e9e; font-weight: normal; }
+`
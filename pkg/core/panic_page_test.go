@@ -0,0 +1,27 @@
+package core
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestDebugPanicPageHidesCredentials(t *testing.T) {
+	app := New()
+	r := httptest.NewRequest(http.MethodPost, "/save", nil)
+	r.Header.Set("Authorization", "Bearer secret-auth")
+	r.Header.Set("Cookie", "godin_session=secret-session")
+	r.Header.Set("X-CSRF-Token", "secret-csrf")
+	r.Header.Set("Accept", "text/html")
+
+	page := app.debugPanicPage(r, "boom", nil)
+	for _, secret := range []string{"secret-auth", "secret-session", "secret-csrf"} {
+		if strings.Contains(page, secret) {
+			t.Errorf("Expected %q to be hidden on the panic page", secret)
+		}
+	}
+	if !strings.Contains(page, "text/html") {
+		t.Error("Expected other headers to be shown")
+	}
+}
@@ -0,0 +1,201 @@
+package core
+
+import (
+	"encoding/json"
+	"log"
+)
+
+// Limits on presence set from godin.js: the JSON size of a client's data, and how
+// many topics one connection may be in; updates beyond them are dropped
+const (
+	MaxPresenceDataSize = 4 << 10
+	MaxPresenceTopics   = 16
+)
+
+// SetPresence sets a connection's ephemeral data in a topic, such as its cursor
+// position, the page it is on or whether it is typing. A connection joins a
+// topic with its first SetPresence, from Go or from godin.js's setPresence, and
+// is sent the topic's members; after that every member is sent only the changes.
+// It leaves with ClearPresence or when it disconnects.
+func (wsm *WebSocketManager) SetPresence(connID, topic string, data interface{}) {
+	if topic == "" || data == nil {
+		return
+	}
+
+	wsm.mutex.Lock()
+	if _, exists := wsm.connections[connID]; !exists {
+		wsm.mutex.Unlock()
+		return
+	}
+	members, exists := wsm.presence[topic]
+	if !exists {
+		members = make(map[string]interface{})
+		wsm.presence[topic] = members
+	}
+	_, joined := members[connID]
+	joined = !joined
+	members[connID] = data
+	onPresence := wsm.onPresence
+	wsm.mutex.Unlock()
+
+	if joined {
+		wsm.sendPresenceMembers(connID, topic)
+	}
+	wsm.sendPresenceChange(topic, connID, data)
+	if onPresence != nil {
+		onPresence(topic, connID, data)
+	}
+}
+
+// ClearPresence removes a connection from a topic and tells the members left
+func (wsm *WebSocketManager) ClearPresence(connID, topic string) {
+	wsm.mutex.Lock()
+	_, exists := wsm.presence[topic][connID]
+	if exists {
+		delete(wsm.presence[topic], connID)
+		if len(wsm.presence[topic]) == 0 {
+			delete(wsm.presence, topic)
+		}
+	}
+	onPresence := wsm.onPresence
+	wsm.mutex.Unlock()
+
+	if !exists {
+		return
+	}
+	wsm.sendPresenceChange(topic, connID, nil)
+	if onPresence != nil {
+		onPresence(topic, connID, nil)
+	}
+}
+
+// Presence returns the data of every connection in a topic, by connection ID
+func (wsm *WebSocketManager) Presence(topic string) map[string]interface{} {
+	wsm.mutex.RLock()
+	defer wsm.mutex.RUnlock()
+
+	members := make(map[string]interface{}, len(wsm.presence[topic]))
+	for connID, data := range wsm.presence[topic] {
+		members[connID] = data
+	}
+	return members
+}
+
+// AuthorizePresence sets a check run before a client joins or updates its presence
+// in a topic from godin.js; updates it rejects are dropped. It is called with the
+// client's ID (see ClientID), so apps can limit topics such as "doc:42" to the
+// users allowed to see them. Presence from godin.js is denied until a check is
+// set; return true to let clients join any topic. SetPresence called from Go is
+// not checked.
+func (wsm *WebSocketManager) AuthorizePresence(authorize func(clientID, topic string) bool) {
+	wsm.mutex.Lock()
+	defer wsm.mutex.Unlock()
+	wsm.authorizePresence = authorize
+}
+
+// OnPresenceUpdate sets a callback run whenever a connection's presence in a topic
+// changes; data is nil when the connection left the topic or disconnected
+func (wsm *WebSocketManager) OnPresenceUpdate(callback func(topic, connID string, data interface{})) {
+	wsm.mutex.Lock()
+	defer wsm.mutex.Unlock()
+	wsm.onPresence = callback
+}
+
+// clearAllPresence removes a closed connection from every topic it was in
+func (wsm *WebSocketManager) clearAllPresence(connID string) {
+	wsm.mutex.RLock()
+	var topics []string
+	for topic, members := range wsm.presence {
+		if _, exists := members[connID]; exists {
+			topics = append(topics, topic)
+		}
+	}
+	wsm.mutex.RUnlock()
+
+	for _, topic := range topics {
+		wsm.ClearPresence(connID, topic)
+	}
+}
+
+// presenceTopicCount returns how many topics a connection is in; the caller holds the mutex
+func (wsm *WebSocketManager) presenceTopicCount(connID string) int {
+	count := 0
+	for _, members := range wsm.presence {
+		if _, exists := members[connID]; exists {
+			count++
+		}
+	}
+	return count
+}
+
+// handlePresence applies a presence update sent by godin.js, after checking the
+// topic with the AuthorizePresence hook and the data and topic count against the limits
+func (wsm *WebSocketManager) handlePresence(connID string, message WebSocketMessage) {
+	if message.Data == nil {
+		wsm.ClearPresence(connID, message.Channel)
+		return
+	}
+
+	encoded, err := json.Marshal(message.Data)
+	if err != nil || len(encoded) > MaxPresenceDataSize {
+		log.Printf("Dropping presence update for %s from %s: data exceeds %d bytes", message.Channel, connID, MaxPresenceDataSize)
+		return
+	}
+
+	wsm.mutex.RLock()
+	authorize := wsm.authorizePresence
+	_, joined := wsm.presence[message.Channel][connID]
+	topics := wsm.presenceTopicCount(connID)
+	wsm.mutex.RUnlock()
+	if authorize == nil {
+		log.Printf("Presence in %s denied for %s: no AuthorizePresence check is set", message.Channel, connID)
+		return
+	}
+	if !authorize(wsm.ClientID(connID), message.Channel) {
+		log.Printf("Presence in %s denied for %s", message.Channel, connID)
+		return
+	}
+	if !joined && topics >= MaxPresenceTopics {
+		log.Printf("Dropping presence update for %s from %s: already in %d topics", message.Channel, connID, MaxPresenceTopics)
+		return
+	}
+
+	wsm.SetPresence(connID, message.Channel, message.Data)
+}
+
+// sendPresenceMembers sends a connection joining a topic the topic's members,
+// telling it which member it is so godin.js can leave itself out
+func (wsm *WebSocketManager) sendPresenceMembers(connID, topic string) {
+	wsm.sendToConnection(connID, WebSocketMessage{
+		Type:    "presence",
+		Channel: topic,
+		Data: map[string]interface{}{
+			"self":    connID,
+			"members": wsm.Presence(topic),
+		},
+	})
+}
+
+// sendPresenceChange sends the other members of a topic one member's new data,
+// or nil when it left, so each change costs one message per member
+func (wsm *WebSocketManager) sendPresenceChange(topic, changedID string, data interface{}) {
+	wsm.mutex.RLock()
+	recipients := make([]string, 0, len(wsm.presence[topic]))
+	for connID := range wsm.presence[topic] {
+		if connID != changedID {
+			recipients = append(recipients, connID)
+		}
+	}
+	wsm.mutex.RUnlock()
+
+	for _, connID := range recipients {
+		wsm.sendToConnection(connID, WebSocketMessage{
+			Type:    "presence",
+			Channel: topic,
+			Data: map[string]interface{}{
+				"id":   changedID,
+				"data": data,
+			},
+		})
+	}
+}
@@ -0,0 +1,87 @@
+package core
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gorilla/websocket"
+)
+
+// dialPresence connects a client to wsm and returns a function that sends a
+// presence update and reports whether the connection joined the topic
+func dialPresence(t *testing.T, wsm *WebSocketManager) func(topic string) bool {
+	server := httptest.NewServer(http.HandlerFunc(wsm.HandleConnection))
+	t.Cleanup(server.Close)
+	conn, _, err := websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(server.URL, "http"), nil)
+	if err != nil {
+		t.Fatalf("Expected to connect, got %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+
+	return func(topic string) bool {
+		conn.WriteJSON(WebSocketMessage{Type: "presence", Channel: topic, Data: map[string]interface{}{"typing": true}})
+		// Messages are handled in order, so the pong comes after any presence reply
+		conn.WriteJSON(WebSocketMessage{Type: "ping"})
+		joined := false
+		for {
+			var message WebSocketMessage
+			if err := conn.ReadJSON(&message); err != nil {
+				t.Fatalf("Expected a reply, got %v", err)
+			}
+			switch message.Type {
+			case "presence":
+				joined = message.Channel == topic
+			case "pong":
+				return joined
+			}
+		}
+	}
+}
+
+func TestPresenceDeniedWithoutAuthorizer(t *testing.T) {
+	wsm := NewWebSocketManager()
+	setPresence := dialPresence(t, wsm)
+
+	if setPresence("doc:1") {
+		t.Error("Expected presence to be denied without AuthorizePresence")
+	}
+	if members := wsm.Presence("doc:1"); len(members) != 0 {
+		t.Errorf("Expected no members, got %v", members)
+	}
+}
+
+func TestPresenceAuthorizer(t *testing.T) {
+	wsm := NewWebSocketManager()
+	wsm.AuthorizePresence(func(clientID, topic string) bool {
+		return topic != "doc:secret"
+	})
+	setPresence := dialPresence(t, wsm)
+
+	if !setPresence("doc:1") {
+		t.Error("Expected an authorized topic to be joined")
+	}
+	if setPresence("doc:secret") {
+		t.Error("Expected a rejected topic not to be joined")
+	}
+}
+
+func TestPresenceTopicLimit(t *testing.T) {
+	wsm := NewWebSocketManager()
+	wsm.AuthorizePresence(func(clientID, topic string) bool { return true })
+	setPresence := dialPresence(t, wsm)
+
+	for i := 0; i < MaxPresenceTopics; i++ {
+		if !setPresence(fmt.Sprintf("doc:%d", i)) {
+			t.Fatalf("Expected topic %d to be joined", i)
+		}
+	}
+	if setPresence("doc:extra") {
+		t.Errorf("Expected a connection to be limited to %d topics", MaxPresenceTopics)
+	}
+	if len(wsm.Presence("doc:0")) != 1 {
+		t.Error("Expected updates in a joined topic to still apply")
+	}
+}
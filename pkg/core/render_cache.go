@@ -0,0 +1,138 @@
+package core
+
+import (
+	"sync"
+	"time"
+)
+
+// RenderCache stores rendered widget HTML keyed by a cache key. A key can hold
+// several variants of the render, such as one per theme and locale, which are
+// invalidated together.
+type RenderCache struct {
+	entries map[string]map[string]renderCacheEntry
+	mutex   sync.RWMutex
+}
+
+// renderCacheEntry holds a cached render and its expiry time
+type renderCacheEntry struct {
+	html      string
+	expiresAt time.Time
+}
+
+// expired reports whether the entry's TTL has passed; a zero expiry never expires
+func (e renderCacheEntry) expired(now time.Time) bool {
+	return !e.expiresAt.IsZero() && now.After(e.expiresAt)
+}
+
+// Global render cache shared by Cached widgets
+var globalRenderCache = NewRenderCache()
+
+// NewRenderCache creates a new render cache
+func NewRenderCache() *RenderCache {
+	return &RenderCache{
+		entries: make(map[string]map[string]renderCacheEntry),
+	}
+}
+
+// Get returns the cached HTML for a key if it exists and has not expired
+func (rc *RenderCache) Get(key string) (string, bool) {
+	return rc.GetVariant(key, "")
+}
+
+// GetVariant returns the cached HTML for one variant of a key if it exists and
+// has not expired
+func (rc *RenderCache) GetVariant(key, variant string) (string, bool) {
+	rc.mutex.RLock()
+	entry, exists := rc.entries[key][variant]
+	rc.mutex.RUnlock()
+
+	if !exists {
+		return "", false
+	}
+
+	if entry.expired(time.Now()) {
+		rc.mutex.Lock()
+		// Another request may have stored a fresh render in the meantime
+		if current, ok := rc.entries[key][variant]; ok && current.expired(time.Now()) {
+			delete(rc.entries[key], variant)
+			if len(rc.entries[key]) == 0 {
+				delete(rc.entries, key)
+			}
+		}
+		rc.mutex.Unlock()
+		return "", false
+	}
+
+	return entry.html, true
+}
+
+// Set stores rendered HTML for a key; a TTL of zero caches until invalidated
+func (rc *RenderCache) Set(key, html string, ttl time.Duration) {
+	rc.SetVariant(key, "", html, ttl)
+}
+
+// SetVariant stores rendered HTML for one variant of a key
+func (rc *RenderCache) SetVariant(key, variant, html string, ttl time.Duration) {
+	entry := renderCacheEntry{html: html}
+	if ttl > 0 {
+		entry.expiresAt = time.Now().Add(ttl)
+	}
+
+	rc.mutex.Lock()
+	if rc.entries[key] == nil {
+		rc.entries[key] = make(map[string]renderCacheEntry)
+	}
+	rc.entries[key][variant] = entry
+	rc.mutex.Unlock()
+}
+
+// Invalidate removes a key, with all its variants, from the cache
+func (rc *RenderCache) Invalidate(key string) {
+	rc.mutex.Lock()
+	delete(rc.entries, key)
+	rc.mutex.Unlock()
+}
+
+// Clear removes all entries from the cache
+func (rc *RenderCache) Clear() {
+	rc.mutex.Lock()
+	rc.entries = make(map[string]map[string]renderCacheEntry)
+	rc.mutex.Unlock()
+}
+
+// Len returns the number of renders in the cache, counting each variant
+func (rc *RenderCache) Len() int {
+	rc.mutex.RLock()
+	defer rc.mutex.RUnlock()
+
+	count := 0
+	for _, variants := range rc.entries {
+		count += len(variants)
+	}
+	return count
+}
+
+// GetRenderCache returns the global render cache
+func GetRenderCache() *RenderCache {
+	return globalRenderCache
+}
+
+// InvalidateCache removes a cached render from the global render cache
+func InvalidateCache(key string) {
+	globalRenderCache.Invalidate(key)
+}
+
+// ClearRenderCache removes all cached renders from the global render cache
+func ClearRenderCache() {
+	globalRenderCache.Clear()
+}
+
+// RenderVariant identifies what makes renders of the same widget differ
+// between requests: the theme brightness and the locale. Cached widgets keep a
+// render per variant, so users never receive another theme's or locale's HTML.
+func (c *Context) RenderVariant() string {
+	if c == nil {
+		return ""
+	}
+	return string(c.Theme().Brightness) + "|" + c.Locale()
+}
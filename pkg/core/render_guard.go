@@ -0,0 +1,113 @@
+package core
+
+import (
+	"fmt"
+	"html"
+	"time"
+
+	"github.com/gorilla/mux"
+)
+
+// Default render limits, generous enough that only a runaway widget tree hits them
+const (
+	DefaultRenderMaxNodes = 200000
+	DefaultRenderMaxBytes = 20 << 20
+	DefaultRenderTimeout  = 10 * time.Second
+)
+
+// renderBudgetKey is the context key holding what the current render has used up
+const renderBudgetKey = "godin.renderBudget"
+
+// RenderLimitError reports a render aborted for exceeding the app's render limits
+type RenderLimitError struct {
+	Limit string // "nodes", "size" or "timeout"
+	Route string // Route whose handler returned the widget tree
+	Value string // What the render had used when it was aborted
+	Max   string // The configured limit
+}
+
+// Error implements the error interface
+func (e *RenderLimitError) Error() string {
+	return fmt.Sprintf("render of %s aborted: %s limit exceeded (%s, limit %s)", e.Route, e.Limit, e.Value, e.Max)
+}
+
+// renderBudget tracks how much of the render limits a request has used
+type renderBudget struct {
+	started time.Time
+	nodes   int
+}
+
+// TrackRender counts nodes a widget renders towards the request's render limits,
+// and aborts the render once it has rendered more than render.max_nodes or run
+// longer than render.timeout. Widgets that render lists of children or build them
+// from callbacks call it, so a runaway loop or a recursive builder is cut short
+// with an error instead of stalling the server.
+func (c *Context) TrackRender(nodes int) {
+	if c == nil || c.App == nil {
+		return
+	}
+	budget, _ := c.Get(renderBudgetKey).(*renderBudget)
+	if budget == nil {
+		return
+	}
+	budget.nodes += nodes
+
+	limits := c.App.config.Render
+	if limits.MaxNodes > 0 && budget.nodes > limits.MaxNodes {
+		panic(c.renderLimitError("nodes", fmt.Sprintf("%d nodes", budget.nodes), fmt.Sprintf("%d", limits.MaxNodes)))
+	}
+	if elapsed := time.Since(budget.started); limits.Timeout > 0 && elapsed > limits.Timeout {
+		panic(c.renderLimitError("timeout", elapsed.Round(time.Millisecond).String(), limits.Timeout.String()))
+	}
+}
+
+// renderWidget renders a handler's widget tree within the app's render limits,
+// replacing it with an error when it exceeds one
+func (c *Context) renderWidget(widget Widget) (content string) {
+	previous := c.Get(renderBudgetKey)
+	c.Set(renderBudgetKey, &renderBudget{started: time.Now()})
+	defer c.Set(renderBudgetKey, previous)
+	defer func() {
+		if r := recover(); r != nil {
+			err, ok := r.(*RenderLimitError)
+			if !ok {
+				panic(r)
+			}
+			content = c.renderLimitExceeded(err)
+		}
+	}()
+
+	content = widget.Render(c)
+	if c.App != nil {
+		if max := c.App.config.Render.MaxBytes; max > 0 && len(content) > max {
+			content = c.renderLimitExceeded(c.renderLimitError("size", fmt.Sprintf("%d bytes", len(content)), fmt.Sprintf("%d bytes", max)))
+		}
+	}
+	return content
+}
+
+// renderLimitError builds the error for an exceeded limit, naming the route
+func (c *Context) renderLimitError(limit, value, max string) *RenderLimitError {
+	route := ""
+	if c.Request != nil {
+		route = c.Request.Method + " " + c.Request.URL.Path
+		if current := mux.CurrentRoute(c.Request); current != nil {
+			if template, err := current.GetPathTemplate(); err == nil {
+				route = c.Request.Method + " " + template
+			}
+		}
+	}
+	return &RenderLimitError{Limit: limit, Route: route, Value: value, Max: max}
+}
+
+// renderLimitExceeded logs an aborted render and returns the error shown in its
+// place; dev mode shows which limit was hit
+func (c *Context) renderLimitExceeded(err *RenderLimitError) string {
+	c.Logf("%v", err)
+
+	message := "This content is too large to display."
+	if c.App != nil && c.App.config.Debug.DevMode {
+		message = err.Error()
+	}
+	return `<div class="godin-render-error" role="alert">` + html.EscapeString(message) + `</div>`
+}
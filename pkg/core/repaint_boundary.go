@@ -0,0 +1,116 @@
+package core
+
+import (
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// boundariesSessionKey is the session value holding the session's boundary registry
+const boundariesSessionKey = "godin.boundaries"
+
+// BoundaryRegistry tracks the widget subtrees marked as independently updatable.
+// Each browser session has its own registry, so a boundary ID only ever resolves
+// to a subtree rendered for that session.
+type BoundaryRegistry struct {
+	boundaries map[string]Widget
+	mutex      sync.RWMutex
+}
+
+// NewBoundaryRegistry creates a new boundary registry
+func NewBoundaryRegistry() *BoundaryRegistry {
+	return &BoundaryRegistry{
+		boundaries: make(map[string]Widget),
+	}
+}
+
+// Register records the child widget for a boundary ID, replacing any previous one
+func (br *BoundaryRegistry) Register(id string, child Widget) {
+	br.mutex.Lock()
+	defer br.mutex.Unlock()
+	br.boundaries[id] = child
+}
+
+// Get returns the child widget registered for a boundary ID
+func (br *BoundaryRegistry) Get(id string) (Widget, bool) {
+	br.mutex.RLock()
+	defer br.mutex.RUnlock()
+	child, exists := br.boundaries[id]
+	return child, exists
+}
+
+// Remove forgets a boundary
+func (br *BoundaryRegistry) Remove(id string) {
+	br.mutex.Lock()
+	defer br.mutex.Unlock()
+	delete(br.boundaries, id)
+}
+
+// Boundaries returns the repaint boundary registry of the browser's session
+func (c *Context) Boundaries() *BoundaryRegistry {
+	session := c.Session()
+	session.mutex.Lock()
+	defer session.mutex.Unlock()
+
+	if registry, ok := session.values[boundariesSessionKey].(*BoundaryRegistry); ok {
+		return registry
+	}
+	registry := NewBoundaryRegistry()
+	session.values[boundariesSessionKey] = registry
+	return registry
+}
+
+// RenderBoundary re-renders a single repaint boundary's subtree
+func (c *Context) RenderBoundary(boundaryID string) (string, error) {
+	if c.App == nil {
+		return "", fmt.Errorf("no app available to render boundary %s", boundaryID)
+	}
+
+	child, exists := c.Boundaries().Get(boundaryID)
+	if !exists {
+		return "", fmt.Errorf("repaint boundary %s not found", boundaryID)
+	}
+	if child == nil {
+		return "", nil
+	}
+
+	return c.renderWidget(child), nil
+}
+
+// Rebuild re-runs a repaint boundary with this context and swaps its subtree on the
+// session's own connections, without re-rendering the rest of the page
+func (c *Context) Rebuild(boundaryID string) error {
+	html, err := c.RenderBoundary(boundaryID)
+	if err != nil {
+		return err
+	}
+
+	if !c.App.WebSocket().IsEnabled() {
+		return fmt.Errorf("websocket is disabled; boundary %s can only be refreshed via /api/boundary/%s", boundaryID, boundaryID)
+	}
+
+	c.App.WebSocket().SendToClient(c.Session().ID(), "boundary:"+boundaryID, map[string]interface{}{
+		"id":        boundaryID,
+		"html":      html,
+		"requestId": c.RequestID(),
+		"timestamp": time.Now().Unix(),
+	})
+	return nil
+}
+
+// setupBoundaryAPI serves individual repaint boundaries for HTMX-driven partial updates,
+// from the registry of the requesting session
+func (app *App) setupBoundaryAPI() {
+	app.router.HandleFunc("/api/boundary/{id}", func(w http.ResponseWriter, r *http.Request) {
+		ctx := NewContext(w, r, app)
+
+		html, err := ctx.RenderBoundary(ctx.Param("id"))
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusNotFound)
+			return
+		}
+
+		ctx.WriteHTML(html)
+	}).Methods("GET")
+}
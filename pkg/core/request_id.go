@@ -0,0 +1,79 @@
+package core
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"log"
+	"net/http"
+)
+
+// RequestIDHeader carries the request ID in requests and responses
+const RequestIDHeader = "X-Request-ID"
+
+// maxRequestIDLength bounds incoming request IDs so they stay safe to log
+const maxRequestIDLength = 128
+
+// requestIDKey is the request context key holding the request ID
+type requestIDKey struct{}
+
+// RequestIDFromContext returns the request ID stored in a request context
+func RequestIDFromContext(ctx context.Context) string {
+	if ctx == nil {
+		return ""
+	}
+	id, _ := ctx.Value(requestIDKey{}).(string)
+	return id
+}
+
+// RequestID returns the ID of the current request, as sent in the X-Request-ID header
+func (c *Context) RequestID() string {
+	if c.Request == nil {
+		return ""
+	}
+	return RequestIDFromContext(c.Request.Context())
+}
+
+// Logf logs a message prefixed with the request ID, so it can be correlated
+// with the access log line and the X-Request-ID the client received
+func (c *Context) Logf(format string, args ...interface{}) {
+	message := fmt.Sprintf(format, args...)
+	if id := c.RequestID(); id != "" {
+		message = "[" + id + "] " + message
+	}
+	log.Print(message)
+}
+
+// requestIDMiddleware assigns every request an ID, honoring a valid incoming X-Request-ID
+func requestIDMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id := r.Header.Get(RequestIDHeader)
+		if !validRequestID(id) {
+			id = generateRequestID()
+		}
+
+		w.Header().Set(RequestIDHeader, id)
+		next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), requestIDKey{}, id)))
+	})
+}
+
+// validRequestID reports whether an incoming request ID is short and printable
+func validRequestID(id string) bool {
+	if id == "" || len(id) > maxRequestIDLength {
+		return false
+	}
+	for i := 0; i < len(id); i++ {
+		if id[i] < 0x21 || id[i] > 0x7e {
+			return false
+		}
+	}
+	return true
+}
+
+// generateRequestID returns a random 128-bit request ID
+func generateRequestID() string {
+	bytes := make([]byte, 16)
+	rand.Read(bytes)
+	return hex.EncodeToString(bytes)
+}
@@ -0,0 +1,52 @@
+package core
+
+import "net/http"
+
+// maxRestorationSize caps the body of a restoration save, so fields cannot fill the session
+const maxRestorationSize = 1 << 16
+
+// restorationSessionPrefix namespaces restoration values among the other session values
+const restorationSessionPrefix = "godin.restoration."
+
+// RestorationValue returns the value godin.js last saved for the field with the
+// given RestorationID in this session, and whether there is one. Fields with a
+// RestorationID render it in place of their initial value, so what the user
+// typed survives navigating away and back.
+func (c *Context) RestorationValue(restorationID string) (string, bool) {
+	value, exists := c.Session().Get(restorationSessionPrefix + restorationID).(string)
+	return value, exists
+}
+
+// SetRestorationValue replaces the value restored into the field with the given RestorationID
+func (c *Context) SetRestorationValue(restorationID, value string) {
+	c.Session().Set(restorationSessionPrefix+restorationID, value)
+}
+
+// DiscardRestorationValue forgets the value saved for the field with the given
+// RestorationID, typically once the form it belongs to has been submitted
+func (c *Context) DiscardRestorationValue(restorationID string) {
+	c.Session().Delete(restorationSessionPrefix + restorationID)
+}
+
+// setupRestorationAPI serves the endpoint godin.js posts restorable field values to
+func (app *App) setupRestorationAPI() {
+	app.router.HandleFunc("/api/restoration/{id}", func(w http.ResponseWriter, r *http.Request) {
+		ctx := NewContext(w, r, app)
+		restorationID := ctx.Param("id")
+
+		if r.Method == http.MethodDelete {
+			ctx.DiscardRestorationValue(restorationID)
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+
+		r.Body = http.MaxBytesReader(w, r.Body, maxRestorationSize)
+		if err := r.ParseForm(); err != nil {
+			http.Error(w, "Invalid restoration value", http.StatusBadRequest)
+			return
+		}
+
+		ctx.SetRestorationValue(restorationID, r.PostForm.Get("value"))
+		w.WriteHeader(http.StatusNoContent)
+	}).Methods("POST", "DELETE")
+}
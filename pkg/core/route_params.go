@@ -0,0 +1,97 @@
+package core
+
+import (
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/gorilla/mux"
+)
+
+// routeParamTypes maps typed route parameter constraints to the regular expressions mux understands
+var routeParamTypes = map[string]string{
+	"int":   `-?[0-9]{1,19}`,
+	"uint":  `[0-9]{1,20}`,
+	"uuid":  `[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}`,
+	"alpha": `[a-zA-Z]+`,
+	"slug":  `[a-z0-9]+(?:-[a-z0-9]+)*`,
+}
+
+// routeParamTypesMutex guards routeParamTypes, which routes read as they are registered
+var routeParamTypesMutex sync.RWMutex
+
+// routeParamParsers check the values of numeric parameter types, whose patterns
+// also match digits out of range of ctx.ParamInt
+var routeParamParsers = map[string]func(value string) error{
+	"int": func(value string) error {
+		_, err := strconv.Atoi(value)
+		return err
+	},
+	"uint": func(value string) error {
+		_, err := strconv.ParseUint(value, 10, 0)
+		return err
+	},
+}
+
+// routeParamPattern matches {name:type} placeholders in a route path
+var routeParamPattern = regexp.MustCompile(`\{([a-zA-Z_][a-zA-Z0-9_]*):([a-zA-Z]+)\}`)
+
+// RegisterRouteParamType registers a named route parameter constraint, e.g. "year" -> `[0-9]{4}`
+func RegisterRouteParamType(name, pattern string) {
+	routeParamTypesMutex.Lock()
+	defer routeParamTypesMutex.Unlock()
+	routeParamTypes[name] = pattern
+}
+
+// expandRouteParams rewrites typed placeholders like {id:int} into mux regex placeholders.
+// Placeholders whose constraint is not a known type name are passed through untouched,
+// so the regex form {id:[0-9]{3}} keeps working as mux expects.
+func expandRouteParams(path string) string {
+	if !strings.Contains(path, ":") {
+		return path
+	}
+
+	routeParamTypesMutex.RLock()
+	defer routeParamTypesMutex.RUnlock()
+
+	return routeParamPattern.ReplaceAllStringFunc(path, func(match string) string {
+		parts := routeParamPattern.FindStringSubmatch(match)
+		if pattern, ok := routeParamTypes[parts[2]]; ok {
+			return "{" + parts[1] + ":" + pattern + "}"
+		}
+		return match
+	})
+}
+
+// numericRouteParams returns the parameters of path typed int or uint, by name
+func numericRouteParams(path string) map[string]string {
+	params := make(map[string]string)
+	for _, parts := range routeParamPattern.FindAllStringSubmatch(path, -1) {
+		if _, ok := routeParamParsers[parts[2]]; ok {
+			params[parts[1]] = parts[2]
+		}
+	}
+	return params
+}
+
+// checkRouteParams answers 404 instead of running handler when a numeric
+// parameter is out of range, so handlers can rely on ctx.ParamInt succeeding
+func checkRouteParams(path string, handler http.HandlerFunc) http.HandlerFunc {
+	params := numericRouteParams(path)
+	if len(params) == 0 {
+		return handler
+	}
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		vars := mux.Vars(r)
+		for name, kind := range params {
+			if err := routeParamParsers[kind](vars[name]); err != nil {
+				http.NotFound(w, r)
+				return
+			}
+		}
+		handler(w, r)
+	}
+}
@@ -0,0 +1,94 @@
+package core
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestExpandRouteParams(t *testing.T) {
+	tests := []struct {
+		path     string
+		expected string
+	}{
+		{"/users", "/users"},
+		{"/users/{id}", "/users/{id}"},
+		{"/users/{id:int}", "/users/{id:" + routeParamTypes["int"] + "}"},
+		{"/files/{id:uuid}/{name:slug}", "/files/{id:" + routeParamTypes["uuid"] + "}/{name:" + routeParamTypes["slug"] + "}"},
+		// Regex constraints are left for mux
+		{"/codes/{code:[0-9]{3}}", "/codes/{code:[0-9]{3}}"},
+		// So are unknown type names
+		{"/items/{id:unknown}", "/items/{id:unknown}"},
+	}
+
+	for _, test := range tests {
+		if actual := expandRouteParams(test.path); actual != test.expected {
+			t.Errorf("expandRouteParams(%q) = %q, expected %q", test.path, actual, test.expected)
+		}
+	}
+}
+
+func TestTypedRouteParams(t *testing.T) {
+	app := New()
+	app.GET("/users/{id:int}", func(ctx *Context) Widget {
+		id, err := ctx.ParamInt("id")
+		if err != nil {
+			t.Errorf("Expected ParamInt to succeed for a matched {id:int}, got %v", err)
+		}
+		ctx.WriteJSON(id)
+		return nil
+	})
+	app.GET("/files/{id:uuid}", func(ctx *Context) Widget {
+		ctx.WriteHTML(ctx.Param("id"))
+		return nil
+	})
+
+	tests := []struct {
+		path   string
+		status int
+	}{
+		{"/users/42", http.StatusOK},
+		{"/users/-7", http.StatusOK},
+		{"/users/abc", http.StatusNotFound},
+		{"/users/4.2", http.StatusNotFound},
+		// Matches the digits pattern but overflows an int
+		{"/users/9999999999999999999", http.StatusNotFound},
+		{"/files/123e4567-e89b-12d3-a456-426614174000", http.StatusOK},
+		{"/files/123e4567", http.StatusNotFound},
+	}
+
+	for _, test := range tests {
+		recorder := httptest.NewRecorder()
+		app.Router().ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, test.path, nil))
+		if recorder.Code != test.status {
+			t.Errorf("GET %s: expected %d, got %d", test.path, test.status, recorder.Code)
+		}
+	}
+}
+
+func TestRegisterRouteParamType(t *testing.T) {
+	RegisterRouteParamType("year", `[0-9]{4}`)
+	defer func() {
+		routeParamTypesMutex.Lock()
+		delete(routeParamTypes, "year")
+		routeParamTypesMutex.Unlock()
+	}()
+
+	app := New()
+	app.GET("/archive/{year:year}", func(ctx *Context) Widget {
+		ctx.WriteHTML(ctx.Param("year"))
+		return nil
+	})
+
+	recorder := httptest.NewRecorder()
+	app.Router().ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/archive/2024", nil))
+	if recorder.Code != http.StatusOK || recorder.Body.String() != "2024" {
+		t.Errorf("Expected /archive/2024 to match, got %d %q", recorder.Code, recorder.Body.String())
+	}
+
+	recorder = httptest.NewRecorder()
+	app.Router().ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/archive/24", nil))
+	if recorder.Code != http.StatusNotFound {
+		t.Errorf("Expected /archive/24 not to match, got %d", recorder.Code)
+	}
+}
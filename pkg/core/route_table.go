@@ -0,0 +1,96 @@
+package core
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// RouteTableFile is the routes file `godin generate routes` writes
+const RouteTableFile = "routes.yaml"
+
+// RouteTable lists routes declaratively, as in routes.yaml or the routes
+// section of package.yaml:
+//
+//	routes:
+//	  - path: /
+//	    handler: HomeHandler
+//	  - method: POST
+//	    path: /todos
+//	    handler: AddTodoHandler
+type RouteTable struct {
+	Routes []RouteEntry `yaml:"routes"`
+}
+
+// RouteEntry maps a method and path to a handler function by name
+type RouteEntry struct {
+	Method  string `yaml:"method,omitempty"` // GET, POST, PUT or DELETE (default GET)
+	Path    string `yaml:"path"`             // Path template, e.g. "/users/{id:int}"
+	Handler string `yaml:"handler"`          // Name of the handler function, e.g. "HomeHandler"
+	Public  bool   `yaml:"public,omitempty"` // Exempt from CSRF checks, see Route.Public
+}
+
+// ReadRouteTable parses a routes file
+func ReadRouteTable(path string) (*RouteTable, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var table RouteTable
+	if err := yaml.Unmarshal(data, &table); err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", path, err)
+	}
+	return &table, nil
+}
+
+// LoadRoutes registers the routes declared in a routes file, such as routes.yaml
+// or package.yaml. Go cannot look functions up by name at runtime, so handlers
+// maps each handler name to its function; `godin generate routes` writes that map
+// alongside routes.yaml. Nothing is registered when an entry is invalid or names
+// a handler missing from the map.
+func (app *App) LoadRoutes(path string, handlers map[string]Handler) error {
+	table, err := ReadRouteTable(path)
+	if err != nil {
+		return err
+	}
+
+	// Check every entry first so a typo cannot leave the app half routed
+	var problems []string
+	for i, entry := range table.Routes {
+		if entry.Path == "" {
+			problems = append(problems, fmt.Sprintf("route %d has no path", i+1))
+		}
+		switch routeEntryMethod(entry) {
+		case "GET", "POST", "PUT", "DELETE":
+		default:
+			problems = append(problems, fmt.Sprintf("%s: unsupported method %q", entry.Path, entry.Method))
+		}
+		if _, exists := handlers[entry.Handler]; !exists {
+			problems = append(problems, fmt.Sprintf("%s: unknown handler %q", entry.Path, entry.Handler))
+		}
+	}
+	if len(problems) > 0 {
+		sort.Strings(problems)
+		return fmt.Errorf("invalid routes in %s: %s", path, strings.Join(problems, "; "))
+	}
+
+	for _, entry := range table.Routes {
+		route := app.handle(routeEntryMethod(entry), entry.Path, handlers[entry.Handler])
+		if entry.Public {
+			route.Public()
+		}
+	}
+	return nil
+}
+
+// routeEntryMethod returns an entry's method in upper case, defaulting to GET
+func routeEntryMethod(entry RouteEntry) string {
+	if entry.Method == "" {
+		return "GET"
+	}
+	return strings.ToUpper(entry.Method)
+}
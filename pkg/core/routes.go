@@ -0,0 +1,114 @@
+package core
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"reflect"
+	"runtime"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/gorilla/mux"
+)
+
+// ListRoutesEnv makes Serve print the registered routes and exit instead of
+// listening; `godin routes` runs the app with it set
+const ListRoutesEnv = "GODIN_LIST_ROUTES"
+
+// RouteInfo describes one registered route
+type RouteInfo struct {
+	Method  string // HTTP method, or "ANY" when the route matches every method
+	Path    string // Path template, e.g. "/users/{id:[0-9]+}"
+	Handler string // Name of the handler function
+}
+
+// Routes returns the routes registered on the app's router, sorted by path and method
+func (app *App) Routes() []RouteInfo {
+	var routes []RouteInfo
+
+	forwarded := make(map[*mux.Route]bool)
+	for _, mount := range app.mounts {
+		for _, route := range mount.routes {
+			forwarded[route] = true
+		}
+
+		// List mounted apps' routes under their prefix instead of the forwarding routes
+		for _, route := range mount.app.Routes() {
+			route.Path = mount.prefix + route.Path
+			routes = append(routes, route)
+		}
+	}
+
+	app.router.Walk(func(route *mux.Route, router *mux.Router, ancestors []*mux.Route) error {
+		if forwarded[route] {
+			return nil
+		}
+
+		path, err := route.GetPathTemplate()
+		if err != nil {
+			return nil
+		}
+
+		methods, err := route.GetMethods()
+		if err != nil {
+			methods = []string{"ANY"}
+		}
+
+		handler, exists := app.routeHandlers[route]
+		if !exists {
+			handler = handlerName(route.GetHandler())
+		}
+
+		for _, method := range methods {
+			routes = append(routes, RouteInfo{Method: method, Path: path, Handler: handler})
+		}
+		return nil
+	})
+
+	sort.SliceStable(routes, func(i, j int) bool {
+		if routes[i].Path != routes[j].Path {
+			return routes[i].Path < routes[j].Path
+		}
+		return routes[i].Method < routes[j].Method
+	})
+	return routes
+}
+
+// PrintRoutes writes the registered routes as a METHOD/PATH/HANDLER table
+func (app *App) PrintRoutes(w io.Writer) error {
+	table := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(table, "METHOD\tPATH\tHANDLER")
+	for _, route := range app.Routes() {
+		fmt.Fprintf(table, "%s\t%s\t%s\n", route.Method, route.Path, route.Handler)
+	}
+	return table.Flush()
+}
+
+// handlerName returns the function name behind a handler, unwrapping http.HandlerFunc
+func handlerName(handler interface{}) string {
+	if handler == nil {
+		return ""
+	}
+
+	value := reflect.ValueOf(handler)
+	if value.Kind() != reflect.Func {
+		if h, ok := handler.(http.Handler); ok {
+			return reflect.TypeOf(h).String()
+		}
+		return value.Type().String()
+	}
+
+	fn := runtime.FuncForPC(value.Pointer())
+	if fn == nil {
+		return ""
+	}
+
+	// Trim the module path, keeping the package-qualified name
+	name := fn.Name()
+	if index := strings.LastIndex(name, "/"); index >= 0 {
+		name = name[index+1:]
+	}
+	return name
+}
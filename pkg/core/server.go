@@ -57,9 +57,10 @@ func (s *Server) setupStaticFiles() {
 	log.Printf("Serving static files from: %s", webStaticPath)
 	log.Printf("Serving web assets from: %s", webPath)
 
-	// Serve static files from web/static
+	// Serve static files from web/static, resolving fingerprinted asset URLs
+	s.app.assets.SetRoot(webStaticPath)
 	s.router.PathPrefix("/static/").Handler(
-		http.StripPrefix("/static/", http.FileServer(http.Dir(webStaticPath))),
+		http.StripPrefix("/static/", s.app.assets.Handler(http.FileServer(http.Dir(webStaticPath)))),
 	)
 
 	// Serve web assets
@@ -118,12 +119,24 @@ func (s *Server) setupWebSocket() {
 
 // setupMiddleware configures HTTP middleware
 func (s *Server) setupMiddleware() {
+	// Request ID middleware runs first so every later log line can include the ID
+	s.router.Use(requestIDMiddleware)
+
+	// Recover panics from the middleware after it, reporting them to OnPanic handlers
+	s.router.Use(s.app.recoverMiddleware)
+
+	// Report phase durations for devtools and the dev mode banner
+	if s.app.serverTimingEnabled() {
+		s.router.Use(serverTimingMiddleware)
+	}
+
 	// CORS middleware
 	s.router.Use(func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			w.Header().Set("Access-Control-Allow-Origin", "*")
 			w.Header().Set("Access-Control-Allow-Methods", "GET, POST, PUT, DELETE, OPTIONS")
-			w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization")
+			w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization, "+RequestIDHeader)
+			w.Header().Set("Access-Control-Expose-Headers", RequestIDHeader+", "+ServerTimingHeader)
 
 			if r.Method == "OPTIONS" {
 				w.WriteHeader(http.StatusOK)
@@ -134,10 +147,30 @@ func (s *Server) setupMiddleware() {
 		})
 	})
 
+	// Upload limits apply before anything reads the body, including the CSRF form field
+	s.router.Use(s.app.uploadMiddleware)
+
+	// CSRF middleware (no-op until EnableCSRF is called)
+	s.router.Use(s.app.csrfMiddleware)
+
+	// Metrics middleware
+	if s.app.metrics != nil {
+		s.router.Use(s.app.metrics.Middleware)
+	}
+
+	// Compression middleware
+	if s.app.compression != nil {
+		s.router.Use(s.app.compression.Middleware)
+	}
+
+	// Recover handler panics inside compression and metrics, so the panic page is
+	// sent before compression would commit the half-written response as a 200
+	s.router.Use(s.app.recoverMiddleware)
+
 	// Logging middleware
 	s.router.Use(func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-			log.Printf("%s %s", r.Method, r.URL.Path)
+			log.Printf("[%s] %s %s", RequestIDFromContext(r.Context()), r.Method, r.URL.Path)
 			next.ServeHTTP(w, r)
 		})
 	})
@@ -291,19 +324,33 @@ func (fw *FileWatcher) shouldProcessEvent(event fsnotify.Event) bool {
 		return true
 	}
 
+	// Theme files are reloaded in place
+	if IsThemeFile(event.Name) {
+		return true
+	}
+
 	return false
 }
 
 // handleFileChange processes a file change and triggers appropriate actions
 func (fw *FileWatcher) handleFileChange(event fsnotify.Event) {
+	if IsThemeFile(event.Name) {
+		// Theme changes are pushed as CSS variables (no restart)
+		if err := fw.app.LoadThemeFile(event.Name); err != nil {
+			log.Printf("Theme reload failed: %v", err)
+		}
+		return
+	}
+
 	ext := strings.ToLower(filepath.Ext(event.Name))
 
 	switch ext {
 	case ".go", ".yaml", ".yml":
 		// Go files or config changes require hot reload (restart)
 		fw.triggerHotReload()
-	case ".html", ".css", ".js":
+	case ".html", ".css", ".js", ".svg":
 		// Static files can use hot refresh (no restart)
+		fw.app.assets.Invalidate()
 		fw.triggerHotRefresh()
 	default:
 		// Default to hot reload for unknown files
@@ -0,0 +1,153 @@
+package core
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ServerTimingHeader carries the server-side durations of a request's phases,
+// shown in the browser devtools and, as the total, in the dev mode banner
+const ServerTimingHeader = "Server-Timing"
+
+// Phases reported in the Server-Timing header
+const (
+	timingMiddleware = "mw"       // Middleware and routing before the handler runs
+	timingHandler    = "handler"  // The app's handler
+	timingRender     = "render"   // Rendering the returned widget
+	timingTemplate   = "template" // Wrapping the rendered widget in the page template
+	timingTotal      = "total"    // Everything up to the response header
+)
+
+// timingDescriptions label the phases in devtools
+var timingDescriptions = map[string]string{
+	timingMiddleware: "Middleware",
+	timingHandler:    "Handler",
+	timingRender:     "Widget rendering",
+	timingTemplate:   "Page template",
+	timingTotal:      "Total",
+}
+
+// serverTimingKey is the request context key of a request's serverTiming
+type serverTimingKey struct{}
+
+// serverTiming collects the phase durations of one request
+type serverTiming struct {
+	mutex     sync.Mutex
+	start     time.Time
+	phases    []string
+	durations map[string]time.Duration
+}
+
+// add adds to a phase's duration; phases are reported in the order first added
+func (st *serverTiming) add(phase string, duration time.Duration) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+
+	if _, ok := st.durations[phase]; !ok {
+		st.phases = append(st.phases, phase)
+	}
+	st.durations[phase] += duration
+}
+
+// header formats the phases and the total so far as a Server-Timing header value
+func (st *serverTiming) header() string {
+	total := time.Since(st.start)
+
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+
+	metrics := make([]string, 0, len(st.phases)+1)
+	for _, phase := range append(st.phases, timingTotal) {
+		duration := st.durations[phase]
+		if phase == timingTotal {
+			duration = total
+		}
+		metrics = append(metrics, fmt.Sprintf(`%s;desc="%s";dur=%.1f`,
+			phase, timingDescriptions[phase], float64(duration.Microseconds())/1000))
+	}
+	return strings.Join(metrics, ", ")
+}
+
+// serverTimingEnabled reports whether responses carry a Server-Timing header
+func (app *App) serverTimingEnabled() bool {
+	return app.config.Debug.DevMode || app.config.Debug.ServerTiming
+}
+
+// startHandlerTiming ends the middleware phase of the request; it returns the
+// handler's start time for recordTiming
+func (c *Context) startHandlerTiming() time.Time {
+	now := time.Now()
+	if timing := c.serverTiming(); timing != nil {
+		timing.add(timingMiddleware, now.Sub(timing.start))
+	}
+	return now
+}
+
+// recordTiming adds the time since start to a phase of the request's Server-Timing
+func (c *Context) recordTiming(phase string, start time.Time) {
+	if timing := c.serverTiming(); timing != nil {
+		timing.add(phase, time.Since(start))
+	}
+}
+
+// serverTiming returns the request's timings, or nil when they are not reported
+func (c *Context) serverTiming() *serverTiming {
+	if c.Request == nil {
+		return nil
+	}
+	timing, _ := c.Request.Context().Value(serverTimingKey{}).(*serverTiming)
+	return timing
+}
+
+// serverTimingMiddleware reports where each request spent its time in a
+// Server-Timing header: middleware, handler, widget rendering, page template
+// and the total. Handlers render before writing, so all phases have ended by
+// the first write.
+func serverTimingMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// WebSocket upgrades hijack the connection and have no render time
+		if r.Header.Get("Upgrade") != "" {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		timing := &serverTiming{start: time.Now(), durations: make(map[string]time.Duration)}
+		r = r.WithContext(context.WithValue(r.Context(), serverTimingKey{}, timing))
+		next.ServeHTTP(&serverTimingWriter{ResponseWriter: w, timing: timing}, r)
+	})
+}
+
+// serverTimingWriter adds the Server-Timing header just before the response header is sent
+type serverTimingWriter struct {
+	http.ResponseWriter
+	timing      *serverTiming
+	wroteHeader bool
+}
+
+// WriteHeader sets the Server-Timing header and sends the response header
+func (tw *serverTimingWriter) WriteHeader(code int) {
+	if !tw.wroteHeader {
+		tw.wroteHeader = true
+		tw.Header().Add(ServerTimingHeader, tw.timing.header())
+	}
+	tw.ResponseWriter.WriteHeader(code)
+}
+
+// Write sends the response header first when the handler did not
+func (tw *serverTimingWriter) Write(b []byte) (int, error) {
+	if !tw.wroteHeader {
+		tw.WriteHeader(http.StatusOK)
+	}
+	return tw.ResponseWriter.Write(b)
+}
+
+// Flush forwards flushing to the underlying writer
+func (tw *serverTimingWriter) Flush() {
+	if flusher, ok := tw.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
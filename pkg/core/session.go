@@ -0,0 +1,247 @@
+package core
+
+import (
+	"container/list"
+	"crypto/rand"
+	"encoding/hex"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// SessionCookieName is the cookie that ties a browser to its server-side session
+const SessionCookieName = "godin_session"
+
+// Default session limits. A session the browser hasn't sent back yet, such as one
+// started for a bot or a client without cookies, only lasts a few minutes, and
+// past the session limit the least recently used sessions are dropped.
+const (
+	DefaultSessionIdleTimeout        = 24 * time.Hour
+	DefaultUnconfirmedSessionTimeout = 5 * time.Minute
+	DefaultMaxSessions               = 100000
+)
+
+// sessionKey is the context key caching the request's session
+const sessionKey = "godin.session"
+
+// Session holds per-browser values on the server, such as a list's selected items,
+// so they survive partial updates and page reloads. Values live in memory and are
+// lost when the server restarts.
+type Session struct {
+	id        string
+	values    map[string]interface{}
+	lastSeen  time.Time
+	confirmed bool          // The browser has sent the session's cookie back
+	element   *list.Element // Position in the store's recency list
+	mutex     sync.RWMutex
+}
+
+// ID returns the session's identifier, as stored in the session cookie
+func (s *Session) ID() string {
+	return s.id
+}
+
+// Get returns a session value, or nil when it is not set
+func (s *Session) Get(key string) interface{} {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	return s.values[key]
+}
+
+// GetString returns a session value as a string
+func (s *Session) GetString(key string) string {
+	value, _ := s.Get(key).(string)
+	return value
+}
+
+// Set stores a session value
+func (s *Session) Set(key string, value interface{}) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.values[key] = value
+}
+
+// GetOrSet returns a session value, first storing the result of create when it is
+// not set; concurrent requests of the session all get the same value
+func (s *Session) GetOrSet(key string, create func() interface{}) interface{} {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if value, exists := s.values[key]; exists {
+		return value
+	}
+	value := create()
+	s.values[key] = value
+	return value
+}
+
+// Delete removes a session value
+func (s *Session) Delete(key string) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	delete(s.values, key)
+}
+
+// SessionStore keeps sessions in memory, dropping those idle for longer than
+// IdleTimeout, and those whose cookie never came back after UnconfirmedTimeout
+// (0 uses IdleTimeout). Beyond MaxSessions (0 for no limit), starting a session
+// drops the oldest unconfirmed one, or else the least recently used.
+type SessionStore struct {
+	IdleTimeout        time.Duration
+	UnconfirmedTimeout time.Duration
+	MaxSessions        int
+	sessions           map[string]*Session
+	unconfirmed        *list.List // Sessions not yet sent back, newest first
+	confirmed          *list.List // Sessions sent back, most recently used first
+	mutex              sync.Mutex
+}
+
+// NewSessionStore creates an empty session store
+func NewSessionStore() *SessionStore {
+	return &SessionStore{
+		IdleTimeout:        DefaultSessionIdleTimeout,
+		UnconfirmedTimeout: DefaultUnconfirmedSessionTimeout,
+		MaxSessions:        DefaultMaxSessions,
+		sessions:           make(map[string]*Session),
+		unconfirmed:        list.New(),
+		confirmed:          list.New(),
+	}
+}
+
+// Get returns the live session with the given ID
+func (ss *SessionStore) Get(id string) (*Session, bool) {
+	ss.mutex.Lock()
+	defer ss.mutex.Unlock()
+
+	session, exists := ss.sessions[id]
+	if !exists || ss.expired(session) {
+		return nil, false
+	}
+	session.lastSeen = time.Now()
+	if session.confirmed {
+		ss.confirmed.MoveToFront(session.element)
+	} else {
+		ss.unconfirmed.Remove(session.element)
+		session.confirmed = true
+		session.element = ss.confirmed.PushFront(session)
+	}
+	return session, true
+}
+
+// New starts a session with a random ID
+func (ss *SessionStore) New() *Session {
+	bytes := make([]byte, 16)
+	rand.Read(bytes)
+	session := &Session{
+		id:       hex.EncodeToString(bytes),
+		values:   make(map[string]interface{}),
+		lastSeen: time.Now(),
+	}
+
+	ss.mutex.Lock()
+	defer ss.mutex.Unlock()
+	ss.sweep()
+	for ss.MaxSessions > 0 && len(ss.sessions) >= ss.MaxSessions {
+		oldest := ss.unconfirmed.Back()
+		if oldest == nil {
+			oldest = ss.confirmed.Back()
+		}
+		ss.remove(oldest.Value.(*Session))
+	}
+	session.element = ss.unconfirmed.PushFront(session)
+	ss.sessions[session.id] = session
+	return session
+}
+
+// Len returns the number of sessions in the store
+func (ss *SessionStore) Len() int {
+	ss.mutex.Lock()
+	defer ss.mutex.Unlock()
+	return len(ss.sessions)
+}
+
+// Destroy removes a session, e.g. on logout
+func (ss *SessionStore) Destroy(id string) {
+	ss.mutex.Lock()
+	defer ss.mutex.Unlock()
+	if session, exists := ss.sessions[id]; exists {
+		ss.remove(session)
+	}
+}
+
+// remove drops a session from the store
+func (ss *SessionStore) remove(session *Session) {
+	if session.confirmed {
+		ss.confirmed.Remove(session.element)
+	} else {
+		ss.unconfirmed.Remove(session.element)
+	}
+	delete(ss.sessions, session.id)
+}
+
+// expired reports whether a session has been idle too long
+func (ss *SessionStore) expired(session *Session) bool {
+	timeout := ss.IdleTimeout
+	if !session.confirmed && ss.UnconfirmedTimeout > 0 {
+		timeout = ss.UnconfirmedTimeout
+	}
+	return timeout > 0 && time.Since(session.lastSeen) > timeout
+}
+
+// sweep drops expired sessions; both lists are ordered by last use, so it stops
+// at the first live session from the back of each
+func (ss *SessionStore) sweep() {
+	for _, sessions := range []*list.List{ss.unconfirmed, ss.confirmed} {
+		for oldest := sessions.Back(); oldest != nil; oldest = sessions.Back() {
+			session := oldest.Value.(*Session)
+			if !ss.expired(session) {
+				break
+			}
+			ss.remove(session)
+		}
+	}
+}
+
+// Sessions returns the app's session store; mounted apps share their parent's
+func (app *App) Sessions() *SessionStore {
+	if app.parent != nil {
+		return app.parent.Sessions()
+	}
+	return app.sessions
+}
+
+// Session returns the browser's session, starting one and setting its cookie on first use
+func (c *Context) Session() *Session {
+	if session, ok := c.Get(sessionKey).(*Session); ok {
+		return session
+	}
+	if c.App == nil {
+		session := &Session{values: make(map[string]interface{})}
+		c.Set(sessionKey, session)
+		return session
+	}
+
+	store := c.App.Sessions()
+	var session *Session
+	if c.Request != nil {
+		if cookie, err := c.Request.Cookie(SessionCookieName); err == nil {
+			session, _ = store.Get(cookie.Value)
+		}
+	}
+
+	if session == nil {
+		session = store.New()
+		if c.Response != nil {
+			http.SetCookie(c.Response, &http.Cookie{
+				Name:     SessionCookieName,
+				Value:    session.id,
+				Path:     "/",
+				HttpOnly: true,
+				Secure:   c.Request != nil && c.Request.TLS != nil,
+				SameSite: http.SameSiteLaxMode,
+			})
+		}
+	}
+
+	c.Set(sessionKey, session)
+	return session
+}
@@ -0,0 +1,74 @@
+package core
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSessionStoreEvictsUnconfirmedFirst(t *testing.T) {
+	store := NewSessionStore()
+	store.MaxSessions = 3
+
+	returning := store.New()
+	store.Get(returning.ID())
+	first := store.New()
+	second := store.New()
+
+	// A fourth session drops the oldest one whose cookie never came back
+	store.New()
+	if store.Len() != 3 {
+		t.Errorf("Expected the store to stay at 3 sessions, got %d", store.Len())
+	}
+	if _, ok := store.Get(first.ID()); ok {
+		t.Error("Expected the oldest unconfirmed session to be dropped")
+	}
+	if _, ok := store.Get(second.ID()); !ok {
+		t.Error("Expected the newer unconfirmed session to be kept")
+	}
+	if _, ok := store.Get(returning.ID()); !ok {
+		t.Error("Expected the confirmed session to be kept")
+	}
+}
+
+func TestSessionStoreEvictsLeastRecentlyUsed(t *testing.T) {
+	store := NewSessionStore()
+	store.MaxSessions = 2
+
+	old := store.New()
+	recent := store.New()
+	store.Get(old.ID())
+	store.Get(recent.ID())
+	store.Get(old.ID())
+
+	store.New()
+	if _, ok := store.Get(recent.ID()); ok {
+		t.Error("Expected the least recently used session to be dropped")
+	}
+	if _, ok := store.Get(old.ID()); !ok {
+		t.Error("Expected the most recently used session to be kept")
+	}
+}
+
+func TestSessionStoreUnconfirmedTimeout(t *testing.T) {
+	store := NewSessionStore()
+	store.UnconfirmedTimeout = time.Minute
+
+	unconfirmed := store.New()
+	confirmed := store.New()
+	store.Get(confirmed.ID())
+	unconfirmed.lastSeen = time.Now().Add(-2 * time.Minute)
+	confirmed.lastSeen = time.Now().Add(-2 * time.Minute)
+
+	if _, ok := store.Get(unconfirmed.ID()); ok {
+		t.Error("Expected a session never sent back to expire after UnconfirmedTimeout")
+	}
+	if _, ok := store.Get(confirmed.ID()); !ok {
+		t.Error("Expected a confirmed session to last until IdleTimeout")
+	}
+
+	// Starting a session sweeps the expired ones
+	store.New()
+	if store.Len() != 2 {
+		t.Errorf("Expected the expired session to be swept, got %d sessions", store.Len())
+	}
+}
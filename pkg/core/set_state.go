@@ -2,6 +2,7 @@ package core
 
 import (
 	"fmt"
+	"runtime/debug"
 	"sync"
 	"time"
 )
@@ -165,7 +166,7 @@ func (su *StateUpdater) processBatch(batch []StateUpdate) {
 			func() {
 				defer func() {
 					if r := recover(); r != nil {
-						fmt.Printf("setState update function panic: %v\n", r)
+						reportUpdatePanic(update.Context, r)
 					}
 				}()
 				update.UpdateFunc()
@@ -196,6 +197,15 @@ func (su *StateUpdater) processBatch(batch []StateUpdate) {
 	su.broadcastStateChange(batch, widgetIDs)
 }
 
+// reportUpdatePanic reports a panic in a setState update function to the app's OnPanic handlers
+func reportUpdatePanic(ctx *Context, err interface{}) {
+	if ctx == nil || ctx.App == nil {
+		fmt.Printf("setState update function panic: %v\n", err)
+		return
+	}
+	ctx.App.reportPanic(err, debug.Stack(), ctx)
+}
+
 // processUpdate processes a single state update immediately
 func (su *StateUpdater) processUpdate(update StateUpdate) error {
 	// Set global context for the update
@@ -209,7 +219,7 @@ func (su *StateUpdater) processUpdate(update StateUpdate) error {
 		func() {
 			defer func() {
 				if r := recover(); r != nil {
-					fmt.Printf("setState update function panic: %v\n", r)
+					reportUpdatePanic(update.Context, r)
 				}
 			}()
 			update.UpdateFunc()
@@ -317,6 +327,7 @@ func SetGlobalStateUpdater(updater *StateUpdater) {
 
 // SetStateValue is a convenience function for setting a single state value
 func SetStateValue(ctx *Context, key string, value interface{}) error {
+	ctx.verifyStateKey(key, value)
 	return SetStateFunc(ctx, func() {
 		if ctx != nil && ctx.App != nil {
 			ctx.App.State().Set(key, value)
@@ -326,6 +337,9 @@ func SetStateValue(ctx *Context, key string, value interface{}) error {
 
 // SetStateValues is a convenience function for setting multiple state values
 func SetStateValues(ctx *Context, values map[string]interface{}) error {
+	for key, value := range values {
+		ctx.verifyStateKey(key, value)
+	}
 	return SetStateFunc(ctx, func() {
 		if ctx != nil && ctx.App != nil {
 			stateManager := ctx.App.State()
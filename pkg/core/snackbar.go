@@ -0,0 +1,142 @@
+package core
+
+import "time"
+
+// SnackBarEvent is the client event godin.js handles by queueing the snackbars in detail
+const SnackBarEvent = "godin:snackbar"
+
+// snackBarKey is the context key holding the snackbars a request has shown
+const snackBarKey = "godin.snackbars"
+
+// SnackBarPriority orders queued snackbars. Higher priorities are shown first and
+// replace a visible snackbar of lower priority when no more fit on screen.
+type SnackBarPriority int
+
+const (
+	SnackBarPriorityNormal  SnackBarPriority = iota // Info and success messages
+	SnackBarPriorityWarning                         // Warnings
+	SnackBarPriorityError                           // Errors, which preempt everything else
+)
+
+// SnackBarMessage is a snackbar shown with SnackBarController.Show
+type SnackBarMessage struct {
+	Message  string
+	Category string           // "info", "success", "warning" or "error"
+	Priority SnackBarPriority // Defaults from Category
+	Duration time.Duration    // How long it stays visible; the controller's DefaultDuration when 0
+}
+
+// snackBar is a snackbar as sent to godin.js
+type snackBar struct {
+	Message  string           `json:"message"`
+	Category string           `json:"category"`
+	Priority SnackBarPriority `json:"priority"`
+	Duration int64            `json:"duration"` // Milliseconds
+}
+
+// snackBarUpdate is the detail of a SnackBarEvent
+type snackBarUpdate struct {
+	Clear    bool       `json:"clear,omitempty"` // Drop snackbars still waiting in the queue first
+	Messages []snackBar `json:"messages"`
+}
+
+// SnackBarController shows snackbars from handlers. The browser queues them, so
+// snackbars triggered in quick succession appear one after another instead of
+// piling up, identical consecutive messages are shown once, and errors jump
+// ahead of info messages.
+type SnackBarController struct {
+	MaxVisible      int           // Snackbars visible at once, 1 by default
+	DefaultDuration time.Duration // How long a snackbar stays visible, 4 seconds by default
+}
+
+// NewSnackBarController creates a snackbar controller with the default settings
+func NewSnackBarController() *SnackBarController {
+	return &SnackBarController{
+		MaxVisible:      1,
+		DefaultDuration: 4 * time.Second,
+	}
+}
+
+// SnackBars returns the app's snackbar controller; mounted apps share their parent's
+func (app *App) SnackBars() *SnackBarController {
+	if app.parent != nil {
+		return app.parent.SnackBars()
+	}
+	return app.snackBars
+}
+
+// Show queues a snackbar on the client that made the request
+func (s *SnackBarController) Show(ctx *Context, message SnackBarMessage) {
+	duration := message.Duration
+	if duration <= 0 {
+		duration = s.DefaultDuration
+	}
+	priority := message.Priority
+	if priority == SnackBarPriorityNormal {
+		switch message.Category {
+		case "error":
+			priority = SnackBarPriorityError
+		case "warning":
+			priority = SnackBarPriorityWarning
+		}
+	}
+	category := message.Category
+	if category == "" {
+		category = "info"
+	}
+
+	update := ctx.snackBarUpdate()
+	update.Messages = append(update.Messages, snackBar{
+		Message:  message.Message,
+		Category: category,
+		Priority: priority,
+		Duration: duration.Milliseconds(),
+	})
+	ctx.TriggerAfterSettle(SnackBarEvent, update)
+}
+
+// ClearQueue drops the snackbars waiting on the client, including those shown
+// earlier in this request; visible snackbars run out as usual
+func (s *SnackBarController) ClearQueue(ctx *Context) {
+	update := ctx.snackBarUpdate()
+	update.Clear = true
+	update.Messages = []snackBar{}
+	ctx.TriggerAfterSettle(SnackBarEvent, update)
+}
+
+// ShowSnackBar queues a snackbar with the app's snackbar controller, e.g.
+// ctx.ShowSnackBar("success", "Todo saved")
+func (c *Context) ShowSnackBar(category, message string) {
+	if c.App == nil {
+		return
+	}
+	c.App.SnackBars().Show(c, SnackBarMessage{Category: category, Message: message})
+}
+
+// snackBarUpdate returns the snackbars this request has shown so far
+func (c *Context) snackBarUpdate() *snackBarUpdate {
+	if update, ok := c.Get(snackBarKey).(*snackBarUpdate); ok {
+		return update
+	}
+	update := &snackBarUpdate{Messages: []snackBar{}}
+	c.Set(snackBarKey, update)
+	return update
+}
+
+// pageSnackBars returns the snackbars for a full page render, which godin.js
+// queues on load since HTMX events only reach partial updates
+func (c *Context) pageSnackBars() *snackBarUpdate {
+	update, _ := c.Get(snackBarKey).(*snackBarUpdate)
+	if update == nil || (!update.Clear && len(update.Messages) == 0) {
+		return nil
+	}
+	return update
+}
+
+// snackBarMaxVisible returns the app's MaxVisible for the page's data attribute
+func (c *Context) snackBarMaxVisible() int {
+	if c.App == nil {
+		return 1
+	}
+	return c.App.SnackBars().MaxVisible
+}
@@ -73,6 +73,19 @@ func SetState(key string, value interface{}) {
 	}
 }
 
+// SetStates is the global form of ctx.SetStates, setting several state values
+// in one coalesced broadcast
+func SetStates(values map[string]interface{}) {
+	globalStateMutex.RLock()
+	defer globalStateMutex.RUnlock()
+
+	if globalStateManager != nil {
+		if ctx := globalStateManager.GetCurrentContext(); ctx != nil {
+			ctx.SetStates(values)
+		}
+	}
+}
+
 // GetState is the global function to get state values
 func GetState(key string) interface{} {
 	globalStateMutex.RLock()
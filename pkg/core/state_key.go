@@ -0,0 +1,111 @@
+package core
+
+import (
+	"fmt"
+	"reflect"
+	"sync"
+
+	"github.com/gideonsigilai/godin/pkg/state"
+)
+
+// stateDeclaration is the type and initial value of a key declared with DefineState
+type stateDeclaration struct {
+	valueType reflect.Type
+	initial   interface{}
+}
+
+// stateRegistry holds every key declared with DefineState
+var stateRegistry = struct {
+	keys  map[string]stateDeclaration
+	mutex sync.RWMutex
+}{
+	keys: make(map[string]stateDeclaration),
+}
+
+// StateKey is a declared, typed app state key. Declare each key once at package
+// level and share the variable between the widgets that read and write it, so a
+// Consumer and the button updating it cannot disagree on the name or type:
+//
+//	var Counter = core.DefineState[int]("counter", 0)
+//
+//	Counter.Set(ctx, Counter.Get(ctx)+1)
+type StateKey[T any] struct {
+	name    string
+	initial T
+}
+
+// DefineState declares a state key with its type and initial value. Declaring a
+// name again with the same type returns a key for it, so code that is initialized
+// again, e.g. by live reload or tests, keeps working; a different type panics.
+func DefineState[T any](name string, initial T) StateKey[T] {
+	valueType := reflect.TypeOf((*T)(nil)).Elem()
+
+	stateRegistry.mutex.Lock()
+	defer stateRegistry.mutex.Unlock()
+
+	if existing, exists := stateRegistry.keys[name]; exists && existing.valueType != valueType {
+		panic(fmt.Sprintf("state %q is already declared as %s, not %s", name, existing.valueType, valueType))
+	}
+	stateRegistry.keys[name] = stateDeclaration{valueType: valueType, initial: initial}
+	return StateKey[T]{name: name, initial: initial}
+}
+
+// Name returns the key's name, e.g. for a Consumer's StateKey
+func (k StateKey[T]) Name() string {
+	return k.name
+}
+
+// Get returns the key's current value, or its initial value when it was never set
+func (k StateKey[T]) Get(ctx *Context) T {
+	if value, ok := ctx.GetState(k.name).(T); ok {
+		return value
+	}
+	return k.initial
+}
+
+// Set stores a new value for the key, updating Consumers that watch it
+func (k StateKey[T]) Set(ctx *Context, value T) {
+	ctx.SetState(k.name, value)
+}
+
+// checkStateKey returns an error when key was not declared with DefineState, or
+// value does not have its declared type. Apps that declare no keys are not checked.
+func checkStateKey(key string, value interface{}) error {
+	stateRegistry.mutex.RLock()
+	defer stateRegistry.mutex.RUnlock()
+
+	if len(stateRegistry.keys) == 0 {
+		return nil
+	}
+	declaration, exists := stateRegistry.keys[key]
+	if !exists {
+		return fmt.Errorf("state %q is not declared; declare it with core.DefineState", key)
+	}
+	if value != nil && !reflect.TypeOf(value).AssignableTo(declaration.valueType) {
+		return fmt.Errorf("state %q is declared as %s but was given a %T", key, declaration.valueType, value)
+	}
+	return nil
+}
+
+// verifyStateKey panics in dev mode when checkStateKey finds a problem, so a
+// mistyped key fails the request instead of silently creating a new key
+func (c *Context) verifyStateKey(key string, value interface{}) {
+	if c == nil || c.App == nil || !c.App.config.Debug.DevMode {
+		return
+	}
+	if err := checkStateKey(key, value); err != nil {
+		panic(err)
+	}
+}
+
+// seedDeclaredState gives declared keys that have no value their initial value
+func seedDeclaredState(stateManager *state.StateManager) {
+	stateRegistry.mutex.RLock()
+	defer stateRegistry.mutex.RUnlock()
+
+	for name, declaration := range stateRegistry.keys {
+		if stateManager.Get(name) == nil && declaration.initial != nil {
+			stateManager.Set(name, declaration.initial)
+		}
+	}
+}
@@ -0,0 +1,84 @@
+package core
+
+import "strings"
+
+// stateChangesKey is the context key holding the state keys a request has set
+const stateChangesKey = "godin.stateChanges"
+
+// PageListenersHeader carries the listener IDs (data-listener-id) on the page
+// making an HTMX request, sent by godin.js while it has no WebSocket, so state
+// refreshes re-render only what that page shows
+const PageListenersHeader = "X-Godin-Listeners"
+
+// StateRefresher returns the out-of-band swaps (hx-swap-oob elements) that
+// re-render the elements showing a state key, e.g. its Consumers, on the page
+// making the request (see PageListeners)
+type StateRefresher func(ctx *Context, key string) string
+
+// AddStateRefresher adds a refresher used when WebSocket is disabled: HTMX
+// responses then carry out-of-band swaps for every state key the handler set
+// with ctx.SetState, so the page updates without a socket
+func (app *App) AddStateRefresher(refresher StateRefresher) *App {
+	app.stateRefreshMutex.Lock()
+	defer app.stateRefreshMutex.Unlock()
+	app.stateRefreshers = append(app.stateRefreshers, refresher)
+	return app
+}
+
+// PageListeners returns the listener IDs on the page making the request, as sent
+// by godin.js in PageListenersHeader; it is empty for other requests
+func (c *Context) PageListeners() []string {
+	if c.Request == nil {
+		return nil
+	}
+	return strings.Fields(c.Request.Header.Get(PageListenersHeader))
+}
+
+// recordStateChange remembers a key set during the request, in the order first set
+func (c *Context) recordStateChange(key string) {
+	keys, _ := c.Get(stateChangesKey).([]string)
+	for _, existing := range keys {
+		if existing == key {
+			return
+		}
+	}
+	c.Set(stateChangesKey, append(keys, key))
+}
+
+// stateFragmentsHTML returns the out-of-band swaps refreshing the state set so far,
+// once; it is empty unless this is an HTMX request and WebSocket, which pushes
+// state changes itself, is disabled
+func (c *Context) stateFragmentsHTML() string {
+	if c.App == nil || c.App.websocket.IsEnabled() || !c.IsHTMX() {
+		return ""
+	}
+	keys, _ := c.Get(stateChangesKey).([]string)
+	if len(keys) == 0 {
+		return ""
+	}
+	c.Set(stateChangesKey, nil)
+
+	c.App.stateRefreshMutex.RLock()
+	refreshers := append([]StateRefresher(nil), c.App.stateRefreshers...)
+	c.App.stateRefreshMutex.RUnlock()
+
+	html := ""
+	for _, key := range keys {
+		for _, refresher := range refreshers {
+			html += refresher(c, key)
+		}
+	}
+	return html
+}
+
+// writeStateFragments answers a handler that returned no widget with its state
+// refreshes, unless the handler has already written a response of its own
+func (c *Context) writeStateFragments() {
+	header := c.Response.Header()
+	if header.Get("Content-Type") != "" || header.Get("Location") != "" {
+		return
+	}
+	if html := c.stateFragmentsHTML(); html != "" {
+		c.WriteHTML(html)
+	}
+}
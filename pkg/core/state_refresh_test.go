@@ -0,0 +1,63 @@
+package core
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// refreshingApp returns an app whose state refresher swaps in the new value of count
+func refreshingApp() *App {
+	app := New()
+	app.AddStateRefresher(func(ctx *Context, key string) string {
+		return fmt.Sprintf(`<span id="%s" hx-swap-oob="true">%v</span>`, key, ctx.App.State().Get(key))
+	})
+	return app
+}
+
+// postHTMX sends an HTMX POST to path
+func postHTMX(app *App, path string) *httptest.ResponseRecorder {
+	r := httptest.NewRequest(http.MethodPost, path, nil)
+	r.Header.Set("HX-Request", "true")
+	recorder := httptest.NewRecorder()
+	app.Router().ServeHTTP(recorder, r)
+	return recorder
+}
+
+func TestButtonCallbackRefreshesState(t *testing.T) {
+	app := refreshingApp()
+	app.RegisterButtonCallback("increment", func() {
+		SetState("count", 1)
+	})
+
+	recorder := postHTMX(app, "/api/button-click/increment")
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("Expected 200, got %d", recorder.Code)
+	}
+	if body := recorder.Body.String(); !strings.Contains(body, `<span id="count" hx-swap-oob="true">1</span>`) {
+		t.Errorf("Expected the out-of-band refresh of count, got %q", body)
+	}
+}
+
+func TestCallbackEndpointRefreshesState(t *testing.T) {
+	app := refreshingApp()
+	ctx := NewTestContextFor(app, httptest.NewRequest(http.MethodGet, "/", nil))
+	callbackID := app.RegisterCallback("button", "Button", "OnPressed", func() {
+		SetState("count", 2)
+	}, ctx.Context)
+
+	recorder := postHTMX(app, "/api/callbacks/"+callbackID)
+	if body := recorder.Body.String(); !strings.Contains(body, `<span id="count" hx-swap-oob="true">2</span>`) {
+		t.Errorf("Expected the out-of-band refresh of count, got %q", body)
+	}
+
+	// Requests that aren't from HTMX get the JSON status
+	r := httptest.NewRequest(http.MethodPost, "/api/callbacks/"+callbackID, nil)
+	plain := httptest.NewRecorder()
+	app.Router().ServeHTTP(plain, r)
+	if body := plain.Body.String(); !strings.Contains(body, `"success"`) {
+		t.Errorf("Expected the JSON status without HX-Request, got %q", body)
+	}
+}
@@ -0,0 +1,74 @@
+package core
+
+import (
+	"io"
+	"net/http"
+)
+
+// Stream writes the response incrementally instead of buffering it, e.g. to
+// export a large report or render a very long page progressively. Every write
+// to w is flushed to the client straight away; without a Content-Length, HTTP/1.1
+// sends the body with Transfer-Encoding: chunked. Content-Type defaults to HTML.
+//
+//	ctx.Stream(func(w io.Writer) error {
+//		for _, order := range orders {
+//			if _, err := io.WriteString(w, OrderRow{Order: order}.Render(ctx)); err != nil {
+//				return err
+//			}
+//		}
+//		return nil
+//	})
+//	return nil
+//
+// Writes fail once the client disconnects, ending the stream early. Headers
+// must be set before calling Stream, and the handler should return nil.
+func (c *Context) Stream(write func(w io.Writer) error) error {
+	header := c.Response.Header()
+	if header.Get("Content-Type") == "" {
+		header.Set("Content-Type", "text/html; charset=utf-8")
+	}
+	header.Del("Content-Length")
+	// Keep reverse proxies such as nginx from buffering the stream
+	header.Set("X-Accel-Buffering", "no")
+
+	stream := &streamWriter{
+		request:    c.Request,
+		response:   c.Response,
+		controller: http.NewResponseController(c.Response),
+	}
+
+	// Send the header right away so the client starts receiving the response
+	c.Response.WriteHeader(http.StatusOK)
+	stream.flush()
+
+	return write(stream)
+}
+
+// streamWriter flushes each write to the client, failing once the client has gone
+type streamWriter struct {
+	request    *http.Request
+	response   http.ResponseWriter
+	controller *http.ResponseController
+}
+
+// Write sends b to the client immediately
+func (sw *streamWriter) Write(b []byte) (int, error) {
+	if sw.request != nil {
+		if err := sw.request.Context().Err(); err != nil {
+			return 0, err
+		}
+	}
+
+	n, err := sw.response.Write(b)
+	if err != nil {
+		return n, err
+	}
+	sw.flush()
+	return n, nil
+}
+
+// flush pushes buffered output to the client; writers that cannot flush still
+// receive the whole response, just not incrementally
+func (sw *streamWriter) flush() {
+	sw.controller.Flush()
+}
@@ -0,0 +1,33 @@
+package core
+
+import "net/http"
+
+// StyleGuidePath is the dev mode page showing the app's widgets, typography and
+// colors rendered with its theme, light and dark
+const StyleGuidePath = "/__godin/styleguide"
+
+// styleGuidePage builds the style guide; the widgets package registers it, as
+// core cannot import the widgets it shows
+var styleGuidePage Handler
+
+// RegisterStyleGuide sets the page served at StyleGuidePath in dev mode. The
+// widgets package registers its StyleGuide; apps may register their own page to
+// add their custom widgets to it.
+func RegisterStyleGuide(page Handler) {
+	styleGuidePage = page
+}
+
+// setupStyleGuide serves the style guide in dev mode
+func (app *App) setupStyleGuide() {
+	if !app.config.Debug.DevMode {
+		return
+	}
+
+	app.GET(StyleGuidePath, func(ctx *Context) Widget {
+		if styleGuidePage == nil {
+			http.NotFound(ctx.Response, ctx.Request)
+			return nil
+		}
+		return styleGuidePage(ctx)
+	})
+}
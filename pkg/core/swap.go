@@ -0,0 +1,41 @@
+package core
+
+import (
+	"encoding/json"
+	"strings"
+)
+
+// DefaultSwapStyle is how HTMX applies a response to an element without its
+// own hx-swap: morphing the element's content in place with idiomorph, which
+// keeps the focus, scroll positions and CSS transitions of elements that stay.
+// Elements are matched by id, so widgets given a stable ID morph smoothly.
+const DefaultSwapStyle = "morph:innerHTML"
+
+// SwapStyle returns the default HTMX swap style of the app's pages, from
+// render.swap: "morph:innerHTML", or an HTMX style such as "innerHTML"
+func (app *App) SwapStyle() string {
+	if app.config.Render.Swap != "" {
+		return app.config.Render.Swap
+	}
+	return DefaultSwapStyle
+}
+
+// MorphSwaps reports whether the app's pages morph responses into place by default
+func (app *App) MorphSwaps() bool {
+	return strings.HasPrefix(app.SwapStyle(), "morph")
+}
+
+// morphSwaps reports whether the page loads the idiomorph extension
+func (c *Context) morphSwaps() bool {
+	return c.App == nil || c.App.MorphSwaps()
+}
+
+// htmxConfig returns the page's htmx-config meta content
+func (c *Context) htmxConfig() string {
+	style := DefaultSwapStyle
+	if c.App != nil {
+		style = c.App.SwapStyle()
+	}
+	data, _ := json.Marshal(map[string]string{"defaultSwapStyle": style})
+	return string(data)
+}
@@ -0,0 +1,89 @@
+package core
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+// handlerURLPattern matches the handler and callback URLs widgets render into hx-* attributes
+var handlerURLPattern = regexp.MustCompile(`/(?:handlers|api/callbacks)/([A-Za-z0-9_]+)`)
+
+// TestContext is a Context backed by a response recorder, for asserting what
+// widgets render and what handlers do in unit tests without starting a server:
+//
+//	ctx := core.NewTestContext()
+//	html := ctx.Render(widgets.Text{Data: "Hello"})
+//	if !strings.Contains(html, "Hello") { ... }
+type TestContext struct {
+	*Context
+	Recorder *httptest.ResponseRecorder // Receives what the context writes
+}
+
+// NewTestContext creates a TestContext for a GET / request to a new app
+func NewTestContext() *TestContext {
+	return NewTestContextFor(New(), httptest.NewRequest(http.MethodGet, "/", nil))
+}
+
+// NewTestContextFor creates a TestContext for a request to an app, e.g. one
+// with routes, themes or state already set up
+func NewTestContextFor(app *App, r *http.Request) *TestContext {
+	recorder := httptest.NewRecorder()
+	return &TestContext{
+		Context:  NewContext(recorder, r, app),
+		Recorder: recorder,
+	}
+}
+
+// Render renders a widget as a request would, including the render limits
+func (tc *TestContext) Render(widget Widget) string {
+	if widget == nil {
+		return ""
+	}
+	return tc.renderWidget(widget)
+}
+
+// Invoke calls a handler or callback registered while rendering, e.g. a
+// button's OnPressed, the way a click from the page would, and returns the
+// recorded response:
+//
+//	html := ctx.Render(widgets.ElevatedButton{OnPressed: increment})
+//	ctx.Invoke(core.HandlerIDs(html)[0])
+func (tc *TestContext) Invoke(handlerID string) *httptest.ResponseRecorder {
+	return tc.InvokeWith(handlerID, nil)
+}
+
+// InvokeWith is Invoke with form values posted to the handler, e.g. a field's new value
+func (tc *TestContext) InvokeWith(handlerID string, form url.Values) *httptest.ResponseRecorder {
+	path := "/api/callbacks/" + handlerID
+	if _, ok := tc.App.handlers[handlerID]; ok {
+		path = "/handlers/" + handlerID
+	}
+
+	r := httptest.NewRequest(http.MethodPost, path, strings.NewReader(form.Encode()))
+	r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	r.Header.Set("HX-Request", "true")
+	for _, cookie := range tc.Request.Cookies() {
+		r.AddCookie(cookie)
+	}
+
+	recorder := httptest.NewRecorder()
+	tc.App.Router().ServeHTTP(recorder, r)
+	return recorder
+}
+
+// HandlerIDs returns the IDs of the handlers and callbacks a rendered page calls,
+// in the order they appear, for passing to Invoke
+func HandlerIDs(html string) []string {
+	var ids []string
+	seen := make(map[string]bool)
+	for _, match := range handlerURLPattern.FindAllStringSubmatch(html, -1) {
+		if !seen[match[1]] {
+			seen[match[1]] = true
+			ids = append(ids, match[1])
+		}
+	}
+	return ids
+}
@@ -0,0 +1,152 @@
+package core
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+)
+
+// widgetFunc renders with a function, for widgets defined inline in tests
+type widgetFunc func(ctx *Context) string
+
+func (w widgetFunc) Render(ctx *Context) string {
+	return w(ctx)
+}
+
+// textWidget renders fixed HTML
+func textWidget(html string) Widget {
+	return widgetFunc(func(ctx *Context) string { return html })
+}
+
+func TestTestContextRender(t *testing.T) {
+	ctx := NewTestContext()
+
+	html := ctx.Render(widgetFunc(func(ctx *Context) string {
+		return "<p>" + ctx.Request.URL.Path + "</p>"
+	}))
+	if html != "<p>/</p>" {
+		t.Errorf("Expected the widget to render for GET /, got %q", html)
+	}
+
+	if html := ctx.Render(nil); html != "" {
+		t.Errorf("Expected a nil widget to render nothing, got %q", html)
+	}
+}
+
+func TestTestContextRenderAppliesRenderLimits(t *testing.T) {
+	app := New()
+	app.Config().Render.MaxNodes = 2
+	ctx := NewTestContextFor(app, httptest.NewRequest(http.MethodGet, "/", nil))
+
+	html := ctx.Render(widgetFunc(func(ctx *Context) string {
+		for i := 0; i < 5; i++ {
+			ctx.TrackRender(1)
+		}
+		return "<p>Too many</p>"
+	}))
+	if !strings.Contains(html, "godin-render-error") {
+		t.Errorf("Expected the render to be replaced with an error past the node limit, got %q", html)
+	}
+}
+
+func TestHandlerIDs(t *testing.T) {
+	html := `<button hx-post="/handlers/handler_0"></button>
+<button hx-post="/api/callbacks/cb_1"></button>
+<form hx-post="/handlers/handler_0"></form>`
+
+	ids := HandlerIDs(html)
+	if len(ids) != 2 || ids[0] != "handler_0" || ids[1] != "cb_1" {
+		t.Errorf("Expected [handler_0 cb_1] in page order without duplicates, got %v", ids)
+	}
+
+	if ids := HandlerIDs("<p>No handlers</p>"); len(ids) != 0 {
+		t.Errorf("Expected no IDs, got %v", ids)
+	}
+}
+
+func TestTestContextInvokeHandler(t *testing.T) {
+	ctx := NewTestContext()
+	count := 0
+	handlerID := ctx.App.RegisterHandler(func(ctx *Context) Widget {
+		count++
+		return textWidget(fmt.Sprintf("<span>%d</span>", count))
+	})
+
+	html := ctx.Render(textWidget(`<button hx-post="/handlers/` + handlerID + `">+</button>`))
+	response := ctx.Invoke(HandlerIDs(html)[0])
+
+	if response.Code != http.StatusOK {
+		t.Fatalf("Expected 200, got %d", response.Code)
+	}
+	if count != 1 {
+		t.Errorf("Expected the handler to run once, ran %d times", count)
+	}
+	if !strings.Contains(response.Body.String(), "<span>1</span>") {
+		t.Errorf("Expected the handler's widget in the response, got %q", response.Body.String())
+	}
+}
+
+func TestTestContextInvokeWithForm(t *testing.T) {
+	ctx := NewTestContext()
+	handlerID := ctx.App.RegisterHandler(func(ctx *Context) Widget {
+		if ctx.Header("HX-Request") != "true" {
+			t.Error("Expected Invoke to send an HTMX request")
+		}
+		return textWidget(ctx.FormValue("name"))
+	})
+
+	response := ctx.InvokeWith(handlerID, url.Values{"name": {"Ada"}})
+	if response.Body.String() != "Ada" {
+		t.Errorf("Expected the posted form value back, got %q", response.Body.String())
+	}
+}
+
+func TestTestContextInvokeKeepsSession(t *testing.T) {
+	ctx := NewTestContext()
+	ctx.Session().Set("user", "ada")
+	ctx.Request.AddCookie(ctx.Recorder.Result().Cookies()[0])
+
+	handlerID := ctx.App.RegisterHandler(func(ctx *Context) Widget {
+		user, _ := ctx.Session().Get("user").(string)
+		return textWidget(user)
+	})
+
+	if body := ctx.Invoke(handlerID).Body.String(); body != "ada" {
+		t.Errorf("Expected the handler to see the test context's session, got %q", body)
+	}
+}
+
+func TestContextBatchPublishesOnce(t *testing.T) {
+	ctx := NewTestContext()
+	updates := make(chan interface{}, 4)
+	ctx.App.State().AddWatcher("count", func(value interface{}) {
+		updates <- value
+	})
+
+	ctx.Batch(func() {
+		ctx.SetState("count", 1)
+		ctx.Batch(func() {
+			ctx.SetState("count", 2)
+		})
+		if len(updates) != 0 {
+			t.Error("Expected no notification while the outer batch is open")
+		}
+		ctx.SetState("count", 3)
+	})
+
+	select {
+	case value := <-updates:
+		if value != 3 {
+			t.Errorf("Expected one notification with the last value 3, got %v", value)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Expected the batch to notify the watcher")
+	}
+	if ctx.GetState("count") != 3 {
+		t.Errorf("Expected count to be 3, got %v", ctx.GetState("count"))
+	}
+}
@@ -0,0 +1,112 @@
+package core
+
+import (
+	"sort"
+	"strings"
+)
+
+// TextThemeVariant names a style of the theme's typography scale
+type TextThemeVariant string
+
+const (
+	TextThemeDisplayLarge   TextThemeVariant = "display-large"
+	TextThemeDisplayMedium  TextThemeVariant = "display-medium"
+	TextThemeDisplaySmall   TextThemeVariant = "display-small"
+	TextThemeHeadlineLarge  TextThemeVariant = "headline-large"
+	TextThemeHeadlineMedium TextThemeVariant = "headline-medium"
+	TextThemeHeadlineSmall  TextThemeVariant = "headline-small"
+	TextThemeTitleLarge     TextThemeVariant = "title-large"
+	TextThemeTitleMedium    TextThemeVariant = "title-medium"
+	TextThemeTitleSmall     TextThemeVariant = "title-small"
+	TextThemeBodyLarge      TextThemeVariant = "body-large"
+	TextThemeBodyMedium     TextThemeVariant = "body-medium"
+	TextThemeBodySmall      TextThemeVariant = "body-small"
+	TextThemeLabelLarge     TextThemeVariant = "label-large"
+	TextThemeLabelMedium    TextThemeVariant = "label-medium"
+	TextThemeLabelSmall     TextThemeVariant = "label-small"
+
+	// Shorthands for the medium size of each group
+	TextThemeDisplay  = TextThemeDisplayMedium
+	TextThemeHeadline = TextThemeHeadlineMedium
+	TextThemeTitle    = TextThemeTitleMedium
+	TextThemeBody     = TextThemeBodyMedium
+	TextThemeLabel    = TextThemeLabelMedium
+)
+
+// TextThemeVariants lists the styles of the typography scale, largest first
+var TextThemeVariants = []TextThemeVariant{
+	TextThemeDisplayLarge, TextThemeDisplayMedium, TextThemeDisplaySmall,
+	TextThemeHeadlineLarge, TextThemeHeadlineMedium, TextThemeHeadlineSmall,
+	TextThemeTitleLarge, TextThemeTitleMedium, TextThemeTitleSmall,
+	TextThemeBodyLarge, TextThemeBodyMedium, TextThemeBodySmall,
+	TextThemeLabelLarge, TextThemeLabelMedium, TextThemeLabelSmall,
+}
+
+// TextTheme returns the theme's typography scale, falling back to the default scale
+func (t *ThemeData) TextTheme() *Typography {
+	if t == nil || t.Typography == nil {
+		return NewDefaultTypography()
+	}
+	return t.Typography
+}
+
+// Style returns the text style for a variant, or nil when the scale does not define it
+func (ty *Typography) Style(variant TextThemeVariant) *TextStyle {
+	if ty == nil {
+		return nil
+	}
+
+	switch variant {
+	case TextThemeDisplayLarge:
+		return ty.DisplayLarge
+	case TextThemeDisplayMedium:
+		return ty.DisplayMedium
+	case TextThemeDisplaySmall:
+		return ty.DisplaySmall
+	case TextThemeHeadlineLarge:
+		return ty.HeadlineLarge
+	case TextThemeHeadlineMedium:
+		return ty.HeadlineMedium
+	case TextThemeHeadlineSmall:
+		return ty.HeadlineSmall
+	case TextThemeTitleLarge:
+		return ty.TitleLarge
+	case TextThemeTitleMedium:
+		return ty.TitleMedium
+	case TextThemeTitleSmall:
+		return ty.TitleSmall
+	case TextThemeBodyLarge:
+		return ty.BodyLarge
+	case TextThemeBodyMedium:
+		return ty.BodyMedium
+	case TextThemeBodySmall:
+		return ty.BodySmall
+	case TextThemeLabelLarge:
+		return ty.LabelLarge
+	case TextThemeLabelMedium:
+		return ty.LabelMedium
+	case TextThemeLabelSmall:
+		return ty.LabelSmall
+	}
+	return nil
+}
+
+// ToCSSString converts the text style to an inline style string with stable property order
+func (ts *TextStyle) ToCSSString() string {
+	if ts == nil {
+		return ""
+	}
+
+	css := ts.ToCSS()
+	properties := make([]string, 0, len(css))
+	for property := range css {
+		properties = append(properties, property)
+	}
+	sort.Strings(properties)
+
+	declarations := make([]string, 0, len(properties))
+	for _, property := range properties {
+		declarations = append(declarations, property+": "+css[property])
+	}
+	return strings.Join(declarations, "; ")
+}
@@ -296,14 +296,36 @@ func (tp *ThemeProvider) SetThemeMode(mode ThemeMode) {
 	case ThemeModeDark:
 		tp.currentTheme = tp.darkTheme
 	case ThemeModeSystem:
-		// For now, default to light theme
-		// In a real implementation, this would check system preference
+		// The light theme until a request reports its color scheme, see ThemeFor
 		tp.currentTheme = tp.lightTheme
 	}
 
 	tp.notifyListeners()
 }
 
+// ThemeMode returns the theme mode
+func (tp *ThemeProvider) ThemeMode() ThemeMode {
+	tp.mutex.RLock()
+	defer tp.mutex.RUnlock()
+
+	return tp.themeMode
+}
+
+// ThemeFor returns the theme for a client: in ThemeModeSystem the light or dark
+// theme matching its color scheme, otherwise the current theme
+func (tp *ThemeProvider) ThemeFor(prefersDark bool) *ThemeData {
+	tp.mutex.RLock()
+	defer tp.mutex.RUnlock()
+
+	if tp.themeMode != ThemeModeSystem {
+		return tp.copyTheme(tp.currentTheme)
+	}
+	if prefersDark {
+		return tp.copyTheme(tp.darkTheme)
+	}
+	return tp.copyTheme(tp.lightTheme)
+}
+
 // GetTheme returns the current theme
 func (tp *ThemeProvider) GetTheme() *ThemeData {
 	tp.mutex.RLock()
@@ -384,6 +406,24 @@ func (tp *ThemeProvider) GenerateCSS() string {
 	return tp.cssGenerator.GenerateCSS(tp.currentTheme)
 }
 
+// GenerateVariablesCSS generates only the CSS custom properties of the current theme.
+// In ThemeModeSystem it generates both themes, switching with prefers-color-scheme,
+// so pages follow the OS live when it toggles dark mode.
+func (tp *ThemeProvider) GenerateVariablesCSS() string {
+	tp.mutex.RLock()
+	defer tp.mutex.RUnlock()
+
+	if tp.themeMode == ThemeModeSystem {
+		// color-scheme lets native form controls and scrollbars follow too
+		return ":root { color-scheme: light dark; }\n" +
+			tp.cssGenerator.GenerateVariablesCSS(tp.lightTheme) +
+			"@media (prefers-color-scheme: dark) {\n" +
+			tp.cssGenerator.GenerateVariablesCSS(tp.darkTheme) +
+			"}\n"
+	}
+	return tp.cssGenerator.GenerateVariablesCSS(tp.currentTheme)
+}
+
 // notifyListeners notifies all listeners of theme changes
 func (tp *ThemeProvider) notifyListeners() {
 	for _, listener := range tp.listeners {
@@ -393,45 +433,7 @@ func (tp *ThemeProvider) notifyListeners() {
 
 // copyTheme creates a deep copy of a theme
 func (tp *ThemeProvider) copyTheme(theme *ThemeData) *ThemeData {
-	if theme == nil {
-		return nil
-	}
-
-	copy := &ThemeData{
-		Brightness:    theme.Brightness,
-		UseMaterial3:  theme.UseMaterial3,
-		VisualDensity: theme.VisualDensity,
-	}
-
-	// Copy ColorScheme
-	if theme.ColorScheme != nil {
-		colorScheme := *theme.ColorScheme
-		copy.ColorScheme = &colorScheme
-	}
-
-	// Copy Typography
-	if theme.Typography != nil {
-		typography := *theme.Typography
-		copy.Typography = &typography
-	}
-
-	// Copy maps
-	copy.ComponentThemes = make(map[string]interface{})
-	for k, v := range theme.ComponentThemes {
-		copy.ComponentThemes[k] = v
-	}
-
-	copy.Extensions = make(map[string]interface{})
-	for k, v := range theme.Extensions {
-		copy.Extensions[k] = v
-	}
-
-	copy.CSS = make(map[string]string)
-	for k, v := range theme.CSS {
-		copy.CSS[k] = v
-	}
-
-	return copy
+	return theme.Copy()
 }
 
 // NewCSSGenerator creates a new CSS generator
@@ -448,16 +450,37 @@ func (cg *CSSGenerator) GenerateCSS(theme *ThemeData) string {
 	}
 
 	var css strings.Builder
+	cg.writeVariablesCSS(&css, theme)
+
+	// Add component-specific CSS
+	cg.writeComponentCSS(&css, theme)
+
+	return css.String()
+}
+
+// GenerateVariablesCSS generates only the :root block of CSS custom properties
+func (cg *CSSGenerator) GenerateVariablesCSS(theme *ThemeData) string {
+	if theme == nil {
+		return ""
+	}
+
+	var css strings.Builder
+	cg.writeVariablesCSS(&css, theme)
+	return css.String()
+}
+
+// writeVariablesCSS writes the :root block of CSS custom properties
+func (cg *CSSGenerator) writeVariablesCSS(css *strings.Builder, theme *ThemeData) {
 	css.WriteString(":root {\n")
 
 	// Generate color scheme CSS variables
 	if theme.ColorScheme != nil {
-		cg.writeColorSchemeCSS(&css, theme.ColorScheme)
+		cg.writeColorSchemeCSS(css, theme.ColorScheme)
 	}
 
 	// Generate typography CSS variables
 	if theme.Typography != nil {
-		cg.writeTypographyCSS(&css, theme.Typography)
+		cg.writeTypographyCSS(css, theme.Typography)
 	}
 
 	// Add custom CSS properties
@@ -466,51 +489,81 @@ func (cg *CSSGenerator) GenerateCSS(theme *ThemeData) string {
 	}
 
 	css.WriteString("}\n")
-
-	// Add component-specific CSS
-	cg.writeComponentCSS(&css, theme)
-
-	return css.String()
 }
 
 // writeColorSchemeCSS writes color scheme CSS variables
 func (cg *CSSGenerator) writeColorSchemeCSS(css *strings.Builder, colorScheme *ColorScheme) {
-	colors := map[string]Color{
-		"primary":                colorScheme.Primary,
-		"on-primary":             colorScheme.OnPrimary,
-		"primary-container":      colorScheme.PrimaryContainer,
-		"on-primary-container":   colorScheme.OnPrimaryContainer,
-		"secondary":              colorScheme.Secondary,
-		"on-secondary":           colorScheme.OnSecondary,
-		"secondary-container":    colorScheme.SecondaryContainer,
-		"on-secondary-container": colorScheme.OnSecondaryContainer,
-		"tertiary":               colorScheme.Tertiary,
-		"on-tertiary":            colorScheme.OnTertiary,
-		"tertiary-container":     colorScheme.TertiaryContainer,
-		"on-tertiary-container":  colorScheme.OnTertiaryContainer,
-		"error":                  colorScheme.Error,
-		"on-error":               colorScheme.OnError,
-		"error-container":        colorScheme.ErrorContainer,
-		"on-error-container":     colorScheme.OnErrorContainer,
-		"surface":                colorScheme.Surface,
-		"on-surface":             colorScheme.OnSurface,
-		"surface-variant":        colorScheme.SurfaceVariant,
-		"on-surface-variant":     colorScheme.OnSurfaceVariant,
-		"surface-tint":           colorScheme.SurfaceTint,
-		"background":             colorScheme.Background,
-		"on-background":          colorScheme.OnBackground,
-		"outline":                colorScheme.Outline,
-		"outline-variant":        colorScheme.OutlineVariant,
-		"shadow":                 colorScheme.Shadow,
-		"scrim":                  colorScheme.Scrim,
-		"inverse-surface":        colorScheme.InverseSurface,
-		"inverse-on-surface":     colorScheme.InverseOnSurface,
-		"inverse-primary":        colorScheme.InversePrimary,
+	for name, color := range colorSchemeFields(colorScheme) {
+		css.WriteString(fmt.Sprintf("  --%s-color-%s: %s;\n", cg.prefix, name, color.ToCSS()))
 	}
+}
 
-	for name, color := range colors {
-		css.WriteString(fmt.Sprintf("  --%s-color-%s: %s;\n", cg.prefix, name, color.ToCSS()))
+// colorSchemeFields maps CSS color names to the color scheme fields they are generated from
+func colorSchemeFields(colorScheme *ColorScheme) map[string]*Color {
+	return map[string]*Color{
+		"primary":                &colorScheme.Primary,
+		"on-primary":             &colorScheme.OnPrimary,
+		"primary-container":      &colorScheme.PrimaryContainer,
+		"on-primary-container":   &colorScheme.OnPrimaryContainer,
+		"secondary":              &colorScheme.Secondary,
+		"on-secondary":           &colorScheme.OnSecondary,
+		"secondary-container":    &colorScheme.SecondaryContainer,
+		"on-secondary-container": &colorScheme.OnSecondaryContainer,
+		"tertiary":               &colorScheme.Tertiary,
+		"on-tertiary":            &colorScheme.OnTertiary,
+		"tertiary-container":     &colorScheme.TertiaryContainer,
+		"on-tertiary-container":  &colorScheme.OnTertiaryContainer,
+		"error":                  &colorScheme.Error,
+		"on-error":               &colorScheme.OnError,
+		"error-container":        &colorScheme.ErrorContainer,
+		"on-error-container":     &colorScheme.OnErrorContainer,
+		"surface":                &colorScheme.Surface,
+		"on-surface":             &colorScheme.OnSurface,
+		"surface-variant":        &colorScheme.SurfaceVariant,
+		"on-surface-variant":     &colorScheme.OnSurfaceVariant,
+		"surface-tint":           &colorScheme.SurfaceTint,
+		"background":             &colorScheme.Background,
+		"on-background":          &colorScheme.OnBackground,
+		"outline":                &colorScheme.Outline,
+		"outline-variant":        &colorScheme.OutlineVariant,
+		"shadow":                 &colorScheme.Shadow,
+		"scrim":                  &colorScheme.Scrim,
+		"inverse-surface":        &colorScheme.InverseSurface,
+		"inverse-on-surface":     &colorScheme.InverseOnSurface,
+		"inverse-primary":        &colorScheme.InversePrimary,
+	}
+}
+
+// ColorRole is one named color of a color scheme; Name is the CSS name used in
+// its --godin-color-<name> variable
+type ColorRole struct {
+	Name  string
+	Color Color
+}
+
+// colorRoleNames orders the color scheme's roles, each color next to its "on" color
+var colorRoleNames = []string{
+	"primary", "on-primary", "primary-container", "on-primary-container",
+	"secondary", "on-secondary", "secondary-container", "on-secondary-container",
+	"tertiary", "on-tertiary", "tertiary-container", "on-tertiary-container",
+	"error", "on-error", "error-container", "on-error-container",
+	"surface", "on-surface", "surface-variant", "on-surface-variant", "surface-tint",
+	"background", "on-background", "outline", "outline-variant", "shadow", "scrim",
+	"inverse-surface", "inverse-on-surface", "inverse-primary",
+}
+
+// Roles returns every color of the scheme with its CSS name, in palette order
+func (cs *ColorScheme) Roles() []ColorRole {
+	if cs == nil {
+		return nil
+	}
+
+	fields := colorSchemeFields(cs)
+	roles := make([]ColorRole, 0, len(colorRoleNames))
+	for _, name := range colorRoleNames {
+		roles = append(roles, ColorRole{Name: name, Color: *fields[name]})
 	}
+	return roles
 }
 
 // writeTypographyCSS writes typography CSS variables
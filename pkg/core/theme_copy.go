@@ -0,0 +1,271 @@
+package core
+
+// ThemeOverrides lists the fields ThemeData.CopyWith changes; fields left nil
+// keep the original theme's value
+type ThemeOverrides struct {
+	ColorScheme     *ColorSchemeOverrides  // Colors to replace in the color scheme
+	Typography      *Typography            // Non-nil text styles replace the theme's
+	ComponentThemes map[string]interface{} // Merged into the theme's component themes
+	Extensions      map[string]interface{} // Merged into the theme's extensions
+	CSS             map[string]string      // Merged into the theme's CSS custom properties
+	Brightness      *Brightness
+	UseMaterial3    *bool
+	VisualDensity   *VisualDensity
+}
+
+// ColorSchemeOverrides lists the colors ColorScheme.CopyWith changes; colors
+// left nil keep the original scheme's value
+type ColorSchemeOverrides struct {
+	Primary            *Color
+	OnPrimary          *Color
+	PrimaryContainer   *Color
+	OnPrimaryContainer *Color
+
+	Secondary            *Color
+	OnSecondary          *Color
+	SecondaryContainer   *Color
+	OnSecondaryContainer *Color
+
+	Tertiary            *Color
+	OnTertiary          *Color
+	TertiaryContainer   *Color
+	OnTertiaryContainer *Color
+
+	Error            *Color
+	OnError          *Color
+	ErrorContainer   *Color
+	OnErrorContainer *Color
+
+	Surface          *Color
+	OnSurface        *Color
+	SurfaceVariant   *Color
+	OnSurfaceVariant *Color
+	SurfaceTint      *Color
+
+	Background   *Color
+	OnBackground *Color
+
+	Outline        *Color
+	OutlineVariant *Color
+
+	Shadow           *Color
+	Scrim            *Color
+	InverseSurface   *Color
+	InverseOnSurface *Color
+	InversePrimary   *Color
+
+	Brightness *Brightness
+}
+
+// Copy returns a deep copy of the theme, so changing the copy's color scheme,
+// text styles or maps leaves the original untouched
+func (theme *ThemeData) Copy() *ThemeData {
+	if theme == nil {
+		return nil
+	}
+
+	copy := &ThemeData{
+		Brightness:      theme.Brightness,
+		UseMaterial3:    theme.UseMaterial3,
+		VisualDensity:   theme.VisualDensity,
+		ColorScheme:     theme.ColorScheme.Copy(),
+		Typography:      theme.Typography.Copy(),
+		ComponentThemes: make(map[string]interface{}, len(theme.ComponentThemes)),
+		Extensions:      make(map[string]interface{}, len(theme.Extensions)),
+		CSS:             make(map[string]string, len(theme.CSS)),
+	}
+	for k, v := range theme.ComponentThemes {
+		copy.ComponentThemes[k] = v
+	}
+	for k, v := range theme.Extensions {
+		copy.Extensions[k] = v
+	}
+	for k, v := range theme.CSS {
+		copy.CSS[k] = v
+	}
+	return copy
+}
+
+// CopyWith returns a deep copy of the theme with only the given fields changed,
+// e.g. a high-contrast variant of the app's theme:
+//
+//	highContrast := theme.CopyWith(core.ThemeOverrides{
+//		ColorScheme: &core.ColorSchemeOverrides{
+//			Primary:   &core.ColorBlack,
+//			OnPrimary: &core.ColorWhite,
+//		},
+//	})
+func (theme *ThemeData) CopyWith(overrides ThemeOverrides) *ThemeData {
+	copy := theme.Copy()
+	if copy == nil {
+		copy = NewThemeData()
+	}
+
+	if overrides.ColorScheme != nil {
+		if copy.ColorScheme == nil {
+			copy.ColorScheme = NewLightColorScheme()
+		}
+		copy.ColorScheme = copy.ColorScheme.CopyWith(*overrides.ColorScheme)
+	}
+	if overrides.Typography != nil {
+		if copy.Typography == nil {
+			copy.Typography = &Typography{}
+		}
+		copy.Typography = copy.Typography.merge(overrides.Typography)
+	}
+
+	for k, v := range overrides.ComponentThemes {
+		copy.ComponentThemes[k] = v
+	}
+	for k, v := range overrides.Extensions {
+		copy.Extensions[k] = v
+	}
+	for k, v := range overrides.CSS {
+		copy.CSS[k] = v
+	}
+
+	if overrides.Brightness != nil {
+		copy.Brightness = *overrides.Brightness
+	}
+	if overrides.UseMaterial3 != nil {
+		copy.UseMaterial3 = *overrides.UseMaterial3
+	}
+	if overrides.VisualDensity != nil {
+		copy.VisualDensity = *overrides.VisualDensity
+	}
+	return copy
+}
+
+// Copy returns a copy of the color scheme
+func (cs *ColorScheme) Copy() *ColorScheme {
+	if cs == nil {
+		return nil
+	}
+	copy := *cs
+	return &copy
+}
+
+// CopyWith returns a copy of the color scheme with only the given colors changed
+func (cs *ColorScheme) CopyWith(overrides ColorSchemeOverrides) *ColorScheme {
+	copy := cs.Copy()
+	if copy == nil {
+		copy = NewLightColorScheme()
+	}
+
+	setColor := func(target *Color, value *Color) {
+		if value != nil {
+			*target = *value
+		}
+	}
+	setColor(&copy.Primary, overrides.Primary)
+	setColor(&copy.OnPrimary, overrides.OnPrimary)
+	setColor(&copy.PrimaryContainer, overrides.PrimaryContainer)
+	setColor(&copy.OnPrimaryContainer, overrides.OnPrimaryContainer)
+	setColor(&copy.Secondary, overrides.Secondary)
+	setColor(&copy.OnSecondary, overrides.OnSecondary)
+	setColor(&copy.SecondaryContainer, overrides.SecondaryContainer)
+	setColor(&copy.OnSecondaryContainer, overrides.OnSecondaryContainer)
+	setColor(&copy.Tertiary, overrides.Tertiary)
+	setColor(&copy.OnTertiary, overrides.OnTertiary)
+	setColor(&copy.TertiaryContainer, overrides.TertiaryContainer)
+	setColor(&copy.OnTertiaryContainer, overrides.OnTertiaryContainer)
+	setColor(&copy.Error, overrides.Error)
+	setColor(&copy.OnError, overrides.OnError)
+	setColor(&copy.ErrorContainer, overrides.ErrorContainer)
+	setColor(&copy.OnErrorContainer, overrides.OnErrorContainer)
+	setColor(&copy.Surface, overrides.Surface)
+	setColor(&copy.OnSurface, overrides.OnSurface)
+	setColor(&copy.SurfaceVariant, overrides.SurfaceVariant)
+	setColor(&copy.OnSurfaceVariant, overrides.OnSurfaceVariant)
+	setColor(&copy.SurfaceTint, overrides.SurfaceTint)
+	setColor(&copy.Background, overrides.Background)
+	setColor(&copy.OnBackground, overrides.OnBackground)
+	setColor(&copy.Outline, overrides.Outline)
+	setColor(&copy.OutlineVariant, overrides.OutlineVariant)
+	setColor(&copy.Shadow, overrides.Shadow)
+	setColor(&copy.Scrim, overrides.Scrim)
+	setColor(&copy.InverseSurface, overrides.InverseSurface)
+	setColor(&copy.InverseOnSurface, overrides.InverseOnSurface)
+	setColor(&copy.InversePrimary, overrides.InversePrimary)
+	if overrides.Brightness != nil {
+		copy.Brightness = *overrides.Brightness
+	}
+	return copy
+}
+
+// Copy returns a deep copy of the typography
+func (t *Typography) Copy() *Typography {
+	if t == nil {
+		return nil
+	}
+	return &Typography{
+		DisplayLarge:   t.DisplayLarge.Copy(),
+		DisplayMedium:  t.DisplayMedium.Copy(),
+		DisplaySmall:   t.DisplaySmall.Copy(),
+		HeadlineLarge:  t.HeadlineLarge.Copy(),
+		HeadlineMedium: t.HeadlineMedium.Copy(),
+		HeadlineSmall:  t.HeadlineSmall.Copy(),
+		TitleLarge:     t.TitleLarge.Copy(),
+		TitleMedium:    t.TitleMedium.Copy(),
+		TitleSmall:     t.TitleSmall.Copy(),
+		BodyLarge:      t.BodyLarge.Copy(),
+		BodyMedium:     t.BodyMedium.Copy(),
+		BodySmall:      t.BodySmall.Copy(),
+		LabelLarge:     t.LabelLarge.Copy(),
+		LabelMedium:    t.LabelMedium.Copy(),
+		LabelSmall:     t.LabelSmall.Copy(),
+	}
+}
+
+// merge returns t with the non-nil styles of overrides copied over it
+func (t *Typography) merge(overrides *Typography) *Typography {
+	pick := func(current, override *TextStyle) *TextStyle {
+		if override != nil {
+			return override.Copy()
+		}
+		return current
+	}
+	return &Typography{
+		DisplayLarge:   pick(t.DisplayLarge, overrides.DisplayLarge),
+		DisplayMedium:  pick(t.DisplayMedium, overrides.DisplayMedium),
+		DisplaySmall:   pick(t.DisplaySmall, overrides.DisplaySmall),
+		HeadlineLarge:  pick(t.HeadlineLarge, overrides.HeadlineLarge),
+		HeadlineMedium: pick(t.HeadlineMedium, overrides.HeadlineMedium),
+		HeadlineSmall:  pick(t.HeadlineSmall, overrides.HeadlineSmall),
+		TitleLarge:     pick(t.TitleLarge, overrides.TitleLarge),
+		TitleMedium:    pick(t.TitleMedium, overrides.TitleMedium),
+		TitleSmall:     pick(t.TitleSmall, overrides.TitleSmall),
+		BodyLarge:      pick(t.BodyLarge, overrides.BodyLarge),
+		BodyMedium:     pick(t.BodyMedium, overrides.BodyMedium),
+		BodySmall:      pick(t.BodySmall, overrides.BodySmall),
+		LabelLarge:     pick(t.LabelLarge, overrides.LabelLarge),
+		LabelMedium:    pick(t.LabelMedium, overrides.LabelMedium),
+		LabelSmall:     pick(t.LabelSmall, overrides.LabelSmall),
+	}
+}
+
+// Copy returns a deep copy of the text style
+func (ts *TextStyle) Copy() *TextStyle {
+	if ts == nil {
+		return nil
+	}
+	return &TextStyle{
+		Color:          clonePtr(ts.Color),
+		FontSize:       clonePtr(ts.FontSize),
+		FontWeight:     clonePtr(ts.FontWeight),
+		FontFamily:     clonePtr(ts.FontFamily),
+		LetterSpacing:  clonePtr(ts.LetterSpacing),
+		LineHeight:     clonePtr(ts.LineHeight),
+		TextAlign:      clonePtr(ts.TextAlign),
+		TextDecoration: clonePtr(ts.TextDecoration),
+	}
+}
+
+// clonePtr returns a pointer to a copy of *p, or nil
+func clonePtr[T any](p *T) *T {
+	if p == nil {
+		return nil
+	}
+	value := *p
+	return &value
+}
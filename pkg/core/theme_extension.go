@@ -0,0 +1,33 @@
+package core
+
+// Extension attaches custom design tokens to the theme under key, such as a
+// brand spacing scale or extra semantic colors, and returns the theme for chaining.
+// Like Flutter's ThemeExtension, this lets design systems build on the built-in
+// ColorScheme without forking ThemeData.
+func (t *ThemeData) Extension(key string, value interface{}) *ThemeData {
+	if t.Extensions == nil {
+		t.Extensions = make(map[string]interface{})
+	}
+	t.Extensions[key] = value
+	return t
+}
+
+// GetExtension returns the theme extension stored under key, reporting false
+// when it is missing or not of type T
+func GetExtension[T any](theme *ThemeData, key string) (T, bool) {
+	var zero T
+	if theme == nil {
+		return zero, false
+	}
+
+	value, ok := theme.Extensions[key].(T)
+	if !ok {
+		return zero, false
+	}
+	return value, true
+}
+
+// ThemeExtension returns an extension of the current theme, for use in widget Render methods
+func ThemeExtension[T any](ctx *Context, key string) (T, bool) {
+	return GetExtension[T](ctx.Theme(), key)
+}
@@ -0,0 +1,234 @@
+package core
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+	"gopkg.in/yaml.v3"
+)
+
+// ThemeFileNames are the theme files looked up in the working directory when the app starts serving
+var ThemeFileNames = []string{"theme.json", "theme.yaml", "theme.yml"}
+
+// themeUpdateChannel is the hot-reload channel theme CSS updates are pushed on
+const themeUpdateChannel = "hot-reload"
+
+// ThemeFile holds theme overrides loaded from theme.json or theme.yaml.
+// Color keys use the CSS variable names, e.g. "primary" or "on-surface",
+// so a color set here is available to widgets as var(--godin-color-primary).
+type ThemeFile struct {
+	Brightness string            `json:"brightness" yaml:"brightness"`
+	Colors     map[string]string `json:"colors" yaml:"colors"`
+	CSS        map[string]string `json:"css" yaml:"css"`
+}
+
+// ReadThemeFile parses a theme file, choosing JSON or YAML by extension
+func ReadThemeFile(path string) (*ThemeFile, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	file := &ThemeFile{}
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json":
+		err = json.Unmarshal(data, file)
+	case ".yaml", ".yml":
+		err = yaml.Unmarshal(data, file)
+	default:
+		return nil, fmt.Errorf("unsupported theme file type: %s", path)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("invalid theme file %s: %w", path, err)
+	}
+
+	return file, nil
+}
+
+// Apply returns a copy of theme with the file's overrides applied
+func (tf *ThemeFile) Apply(theme *ThemeData) (*ThemeData, error) {
+	if theme == nil {
+		theme = NewThemeData()
+	}
+
+	result := (&ThemeProvider{}).copyTheme(theme)
+	if result.ColorScheme == nil {
+		result.ColorScheme = NewLightColorScheme()
+	}
+
+	switch Brightness(tf.Brightness) {
+	case "":
+	case BrightnessLight, BrightnessDark:
+		result.Brightness = Brightness(tf.Brightness)
+		result.ColorScheme.Brightness = Brightness(tf.Brightness)
+	default:
+		return nil, fmt.Errorf("invalid brightness %q", tf.Brightness)
+	}
+
+	fields := colorSchemeFields(result.ColorScheme)
+	for name, value := range tf.Colors {
+		field, exists := fields[name]
+		if !exists {
+			return nil, fmt.Errorf("unknown color %q", name)
+		}
+
+		color, err := NewColorFromHex(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid color %q: %w", name, err)
+		}
+		*field = color
+	}
+
+	for key, value := range tf.CSS {
+		result.CSS[key] = value
+	}
+
+	return result, nil
+}
+
+// LoadThemeFile applies a theme file to the app's base theme, the one in use
+// before the first theme file was loaded, so a reload never keeps overrides
+// that were removed from the file. The new CSS variables are pushed to
+// connected clients, which then reload the page: widgets inline theme colors
+// when they render, so only a fresh render picks up every change.
+func (app *App) LoadThemeFile(path string) error {
+	file, err := ReadThemeFile(path)
+	if err != nil {
+		return err
+	}
+
+	app.themeFileMutex.Lock()
+	defer app.themeFileMutex.Unlock()
+
+	if app.themeFileBase == nil {
+		app.themeFileBase = app.GetTheme().Copy()
+	}
+
+	theme, err := file.Apply(app.themeFileBase)
+	if err != nil {
+		return fmt.Errorf("invalid theme file %s: %w", path, err)
+	}
+
+	app.SetTheme(theme)
+	app.pushThemeCSS()
+	return nil
+}
+
+// WatchThemeFile loads a theme file and reloads it whenever it changes on disk
+func (app *App) WatchThemeFile(path string) error {
+	if err := app.LoadThemeFile(path); err != nil {
+		return err
+	}
+
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return err
+	}
+
+	// Watch the directory so editors that save by renaming are still picked up
+	if err := watcher.Add(filepath.Dir(path)); err != nil {
+		watcher.Close()
+		return err
+	}
+
+	go func() {
+		defer watcher.Close()
+
+		target := filepath.Clean(path)
+		var debounce *time.Timer
+
+		for {
+			select {
+			case event, ok := <-watcher.Events:
+				if !ok {
+					return
+				}
+				if filepath.Clean(event.Name) != target || event.Op&(fsnotify.Write|fsnotify.Create) == 0 {
+					continue
+				}
+
+				// Debounce editors that write the file in several steps
+				if debounce != nil {
+					debounce.Stop()
+				}
+				debounce = time.AfterFunc(100*time.Millisecond, func() {
+					if err := app.LoadThemeFile(path); err != nil {
+						log.Printf("Theme reload failed: %v", err)
+						return
+					}
+					log.Printf("🎨 Theme reloaded from %s", path)
+				})
+
+			case err, ok := <-watcher.Errors:
+				if !ok {
+					return
+				}
+				log.Printf("Theme watcher error: %v", err)
+			}
+		}
+	}()
+
+	log.Printf("Watching theme file %s", path)
+	return nil
+}
+
+// ThemeVariablesCSS returns the CSS custom properties of the current theme
+func (app *App) ThemeVariablesCSS() string {
+	if app.themeProvider != nil {
+		return app.themeProvider.GenerateVariablesCSS()
+	}
+	return ""
+}
+
+// pushThemeCSS sends the current theme variables to connected clients and asks
+// them to reload, since colors inlined by widgets only change on a new render
+func (app *App) pushThemeCSS() {
+	if !app.websocket.IsEnabled() {
+		return
+	}
+
+	app.websocket.Broadcast(themeUpdateChannel, map[string]interface{}{
+		"type":      "theme-update",
+		"css":       app.ThemeVariablesCSS(),
+		"reload":    true,
+		"timestamp": time.Now().Unix(),
+	})
+}
+
+// loadThemeFiles applies the first theme file found in the working directory,
+// watching it for changes when hot reload is enabled
+func (app *App) loadThemeFiles() {
+	for _, name := range ThemeFileNames {
+		if _, err := os.Stat(name); err != nil {
+			continue
+		}
+
+		var err error
+		if app.config.Debug.DevMode || app.config.Debug.HotReload {
+			err = app.WatchThemeFile(name)
+		} else {
+			err = app.LoadThemeFile(name)
+		}
+		if err != nil {
+			log.Printf("Ignoring theme file: %v", err)
+		}
+		return
+	}
+}
+
+// IsThemeFile reports whether a path names one of the theme files in ThemeFileNames
+func IsThemeFile(path string) bool {
+	base := filepath.Base(path)
+	for _, name := range ThemeFileNames {
+		if base == name {
+			return true
+		}
+	}
+	return false
+}
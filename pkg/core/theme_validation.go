@@ -0,0 +1,82 @@
+package core
+
+import "fmt"
+
+// WCAG AA minimum contrast ratios
+const (
+	ContrastAANormalText = 4.5
+	ContrastAALargeText  = 3.0
+)
+
+// ThemeWarning describes an accessibility problem found in a theme
+type ThemeWarning struct {
+	Pair       string  // Color pair checked, e.g. "onPrimary/primary"
+	Foreground Color   // Text color
+	Background Color   // Background color
+	Ratio      float64 // Actual contrast ratio
+	Minimum    float64 // Required contrast ratio
+	Message    string
+}
+
+// String returns the warning message
+func (w ThemeWarning) String() string {
+	return w.Message
+}
+
+// Validate checks key foreground/background pairs against WCAG AA contrast
+// and returns a warning for each pair that falls below the threshold
+func (t *ThemeData) Validate() []ThemeWarning {
+	var warnings []ThemeWarning
+	if t == nil || t.ColorScheme == nil {
+		return append(warnings, ThemeWarning{Message: "theme has no color scheme"})
+	}
+
+	cs := t.ColorScheme
+	pairs := []struct {
+		name       string
+		foreground Color
+		background Color
+	}{
+		{"onPrimary/primary", cs.OnPrimary, cs.Primary},
+		{"onPrimaryContainer/primaryContainer", cs.OnPrimaryContainer, cs.PrimaryContainer},
+		{"onSecondary/secondary", cs.OnSecondary, cs.Secondary},
+		{"onSecondaryContainer/secondaryContainer", cs.OnSecondaryContainer, cs.SecondaryContainer},
+		{"onTertiary/tertiary", cs.OnTertiary, cs.Tertiary},
+		{"onTertiaryContainer/tertiaryContainer", cs.OnTertiaryContainer, cs.TertiaryContainer},
+		{"onError/error", cs.OnError, cs.Error},
+		{"onErrorContainer/errorContainer", cs.OnErrorContainer, cs.ErrorContainer},
+		{"onSurface/surface", cs.OnSurface, cs.Surface},
+		{"onSurfaceVariant/surfaceVariant", cs.OnSurfaceVariant, cs.SurfaceVariant},
+		{"onBackground/background", cs.OnBackground, cs.Background},
+		{"inverseOnSurface/inverseSurface", cs.InverseOnSurface, cs.InverseSurface},
+	}
+
+	for _, pair := range pairs {
+		ratio := ContrastRatio(pair.foreground, pair.background)
+		if ratio >= ContrastAANormalText {
+			continue
+		}
+
+		warnings = append(warnings, ThemeWarning{
+			Pair:       pair.name,
+			Foreground: pair.foreground,
+			Background: pair.background,
+			Ratio:      ratio,
+			Minimum:    ContrastAANormalText,
+			Message: fmt.Sprintf("%s contrast %.2f:1 is below WCAG AA (%.1f:1): %s on %s",
+				pair.name, ratio, ContrastAANormalText, pair.foreground.ToHex(), pair.background.ToHex()),
+		})
+	}
+
+	return warnings
+}
+
+// ContrastColor returns black or white, whichever reads better on the given background
+func (cs *ColorScheme) ContrastColor(background Color) Color {
+	black := NewColor(0, 0, 0, 255)
+	white := NewColor(255, 255, 255, 255)
+	if ContrastRatio(black, background) >= ContrastRatio(white, background) {
+		return black
+	}
+	return white
+}
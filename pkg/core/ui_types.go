@@ -101,6 +101,27 @@ func (c Color) Lighten(amount float64) Color {
 	}
 }
 
+// Luminance returns the WCAG relative luminance of the color (0.0 to 1.0)
+func (c Color) Luminance() float64 {
+	channel := func(v uint8) float64 {
+		s := float64(v) / 255.0
+		if s <= 0.03928 {
+			return s / 12.92
+		}
+		return math.Pow((s+0.055)/1.055, 2.4)
+	}
+	return 0.2126*channel(c.R) + 0.7152*channel(c.G) + 0.0722*channel(c.B)
+}
+
+// ContrastRatio returns the WCAG contrast ratio between two colors (1.0 to 21.0)
+func ContrastRatio(a, b Color) float64 {
+	la, lb := a.Luminance(), b.Luminance()
+	if la < lb {
+		la, lb = lb, la
+	}
+	return (la + 0.05) / (lb + 0.05)
+}
+
 // Size represents width and height dimensions
 type Size struct {
 	Width  float64
@@ -166,6 +187,11 @@ func NewEdgeInsetsOnly(top, right, bottom, left *float64) EdgeInsets {
 	return insets
 }
 
+// NewEdgeInsetsLTRB creates EdgeInsets from left, top, right and bottom values, in Flutter's order
+func NewEdgeInsetsLTRB(left, top, right, bottom float64) EdgeInsets {
+	return EdgeInsets{Top: top, Right: right, Bottom: bottom, Left: left}
+}
+
 // ToCSS converts EdgeInsets to CSS padding/margin format
 func (e EdgeInsets) ToCSS() string {
 	return fmt.Sprintf("%.1fpx %.1fpx %.1fpx %.1fpx", e.Top, e.Right, e.Bottom, e.Left)
@@ -240,6 +266,68 @@ func GetBreakpoint(width float64) Breakpoint {
 	return BreakpointXS
 }
 
+// Breakpoints holds the minimum widths at which each breakpoint starts (XS always starts at 0)
+type Breakpoints struct {
+	SM float64 `yaml:"sm" json:"sm"`
+	MD float64 `yaml:"md" json:"md"`
+	LG float64 `yaml:"lg" json:"lg"`
+	XL float64 `yaml:"xl" json:"xl"`
+}
+
+// DefaultBreakpoints are the built-in breakpoints used when none are configured
+var DefaultBreakpoints = Breakpoints{
+	SM: BreakpointValues[BreakpointSM],
+	MD: BreakpointValues[BreakpointMD],
+	LG: BreakpointValues[BreakpointLG],
+	XL: BreakpointValues[BreakpointXL],
+}
+
+// Resolve returns the breakpoint for a given width using these breakpoint values
+func (b Breakpoints) Resolve(width float64) Breakpoint {
+	if width >= b.XL {
+		return BreakpointXL
+	} else if width >= b.LG {
+		return BreakpointLG
+	} else if width >= b.MD {
+		return BreakpointMD
+	} else if width >= b.SM {
+		return BreakpointSM
+	}
+	return BreakpointXS
+}
+
+// MinWidth returns the minimum width at which a breakpoint starts
+func (b Breakpoints) MinWidth(breakpoint Breakpoint) float64 {
+	switch breakpoint {
+	case BreakpointSM:
+		return b.SM
+	case BreakpointMD:
+		return b.MD
+	case BreakpointLG:
+		return b.LG
+	case BreakpointXL:
+		return b.XL
+	}
+	return 0
+}
+
+// withDefaults fills any unset breakpoint values from DefaultBreakpoints
+func (b Breakpoints) withDefaults() Breakpoints {
+	if b.SM == 0 {
+		b.SM = DefaultBreakpoints.SM
+	}
+	if b.MD == 0 {
+		b.MD = DefaultBreakpoints.MD
+	}
+	if b.LG == 0 {
+		b.LG = DefaultBreakpoints.LG
+	}
+	if b.XL == 0 {
+		b.XL = DefaultBreakpoints.XL
+	}
+	return b
+}
+
 // TextAlign represents text alignment options
 type TextAlign string
 
@@ -0,0 +1,193 @@
+package core
+
+import (
+	"errors"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"os"
+	"path/filepath"
+
+	"github.com/gorilla/mux"
+)
+
+// Default upload limits; multipart bodies beyond the memory limit are streamed
+// to temp files instead of being held in memory
+const (
+	DefaultUploadMaxSize     = 32 << 20
+	DefaultUploadMemoryLimit = 1 << 20
+)
+
+// ErrUploadTooLarge is returned for uploads larger than the route's maximum upload size
+var ErrUploadTooLarge = errors.New("upload exceeds the maximum upload size")
+
+// UploadedFile is a file sent in a multipart form. Files larger than the
+// upload memory limit are kept in a temp file that is removed when the request ends.
+type UploadedFile struct {
+	Filename    string // Name of the file on the client, without any directory
+	Size        int64  // Size in bytes
+	ContentType string // Content type the client declared
+	header      *multipart.FileHeader
+}
+
+// Open opens the file's contents for reading
+func (f *UploadedFile) Open() (multipart.File, error) {
+	return f.header.Open()
+}
+
+// SaveTo copies the file to path, creating the directory if needed, without
+// reading it into memory
+func (f *UploadedFile) SaveTo(path string) error {
+	src, err := f.Open()
+	if err != nil {
+		return err
+	}
+	defer src.Close()
+
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	dst, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(dst, src); err != nil {
+		dst.Close()
+		os.Remove(path)
+		return err
+	}
+	return dst.Close()
+}
+
+// FormFile returns the first file uploaded in a multipart form field
+func (c *Context) FormFile(name string) (*UploadedFile, error) {
+	files, err := c.FormFiles(name)
+	if err != nil {
+		return nil, err
+	}
+	if len(files) == 0 {
+		return nil, http.ErrMissingFile
+	}
+	return files[0], nil
+}
+
+// FormFiles returns every file uploaded in a multipart form field, e.g. from an
+// <input type="file" multiple>
+func (c *Context) FormFiles(name string) ([]*UploadedFile, error) {
+	if err := c.parseUploads(); err != nil {
+		return nil, err
+	}
+
+	var files []*UploadedFile
+	for _, header := range c.Request.MultipartForm.File[name] {
+		files = append(files, &UploadedFile{
+			Filename:    filepath.Base(header.Filename),
+			Size:        header.Size,
+			ContentType: header.Header.Get("Content-Type"),
+			header:      header,
+		})
+	}
+	return files, nil
+}
+
+// parseUploads parses the multipart form unless the upload middleware already did
+func (c *Context) parseUploads() error {
+	if c.Request.MultipartForm != nil {
+		return nil
+	}
+	memory := int64(DefaultUploadMemoryLimit)
+	if c.App != nil {
+		memory = c.App.uploadMemoryLimit()
+		if limit := c.App.uploadLimit(c.Request); limit > 0 {
+			c.Request.Body = http.MaxBytesReader(c.Response, c.Request.Body, limit)
+		}
+	}
+	return uploadError(c.Request.ParseMultipartForm(memory))
+}
+
+// MaxUploadSize sets the largest multipart body the route accepts in bytes,
+// replacing the app's upload.max_size, e.g. for a route taking video uploads;
+// 0 removes the limit for the route
+func (r *Route) MaxUploadSize(bytes int64) *Route {
+	r.app.uploadMutex.Lock()
+	r.app.uploadLimits[r.route] = bytes
+	r.app.uploadMutex.Unlock()
+	return r
+}
+
+// uploadLimit returns the maximum upload size for a request: its route's, or the app's
+func (app *App) uploadLimit(r *http.Request) int64 {
+	return app.routeUploadLimit(mux.CurrentRoute(r), r)
+}
+
+// routeUploadLimit returns the maximum upload size for a request matched to route
+func (app *App) routeUploadLimit(route *mux.Route, r *http.Request) int64 {
+	if route == nil {
+		return app.config.Upload.MaxSize
+	}
+
+	app.uploadMutex.RLock()
+	limit, ok := app.uploadLimits[route]
+	app.uploadMutex.RUnlock()
+	if ok {
+		return limit
+	}
+
+	// Requests forwarded to a mounted app use the limits of its routes
+	if sub, subRequest, subRoute, ok := app.forwardedRoute(route, r); ok {
+		return sub.routeUploadLimit(subRoute, subRequest)
+	}
+	return app.config.Upload.MaxSize
+}
+
+// uploadMemoryLimit returns how many upload bytes are kept in memory before streaming to temp files
+func (app *App) uploadMemoryLimit() int64 {
+	if app.config.Upload.MemoryLimit > 0 {
+		return app.config.Upload.MemoryLimit
+	}
+	return DefaultUploadMemoryLimit
+}
+
+// uploadMiddleware enforces the maximum upload size of multipart requests,
+// answering oversized ones with 413 before reading them where the client sent a
+// Content-Length, and parses the files to temp files that are removed once the
+// request has been handled
+func (app *App) uploadMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+		if mediaType != "multipart/form-data" {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		if limit := app.uploadLimit(r); limit > 0 {
+			if r.ContentLength > limit {
+				http.Error(w, ErrUploadTooLarge.Error(), http.StatusRequestEntityTooLarge)
+				return
+			}
+			r.Body = http.MaxBytesReader(w, r.Body, limit)
+		}
+
+		if err := uploadError(r.ParseMultipartForm(app.uploadMemoryLimit())); err != nil {
+			status := http.StatusBadRequest
+			if errors.Is(err, ErrUploadTooLarge) {
+				status = http.StatusRequestEntityTooLarge
+			}
+			http.Error(w, err.Error(), status)
+			return
+		}
+		defer r.MultipartForm.RemoveAll()
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+// uploadError reports a body cut off by the upload limit as ErrUploadTooLarge
+func uploadError(err error) error {
+	var tooLarge *http.MaxBytesError
+	if errors.As(err, &tooLarge) {
+		return ErrUploadTooLarge
+	}
+	return err
+}
@@ -0,0 +1,134 @@
+package core
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// uploadApp serves /upload, answering with the uploaded file's name and size,
+// behind the upload middleware as the server sets it up
+func uploadApp(maxSize int64) (*App, *Route) {
+	app := New()
+	app.config.Upload.MaxSize = maxSize
+	app.Router().Use(app.uploadMiddleware)
+	route := app.POST("/upload", func(ctx *Context) Widget {
+		file, err := ctx.FormFile("file")
+		if err != nil {
+			ctx.Error(err.Error(), http.StatusBadRequest)
+			return nil
+		}
+		ctx.WriteHTML(fmt.Sprintf("%s %d", file.Filename, file.Size))
+		return nil
+	})
+	return app, route
+}
+
+// postFile uploads size bytes as a file named name to /upload
+func postFile(app *App, name string, size int) *httptest.ResponseRecorder {
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+	part, _ := writer.CreateFormFile("file", name)
+	part.Write(bytes.Repeat([]byte("x"), size))
+	writer.Close()
+
+	r := httptest.NewRequest(http.MethodPost, "/upload", &body)
+	r.Header.Set("Content-Type", writer.FormDataContentType())
+	recorder := httptest.NewRecorder()
+	app.Router().ServeHTTP(recorder, r)
+	return recorder
+}
+
+func TestUploadWithinLimit(t *testing.T) {
+	app, _ := uploadApp(64 << 10)
+
+	recorder := postFile(app, "../../notes.txt", 1000)
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("Expected 200, got %d: %s", recorder.Code, recorder.Body.String())
+	}
+	// The client's directories are dropped from the name
+	if recorder.Body.String() != "notes.txt 1000" {
+		t.Errorf("Expected \"notes.txt 1000\", got %q", recorder.Body.String())
+	}
+}
+
+func TestUploadTooLarge(t *testing.T) {
+	app, _ := uploadApp(1 << 10)
+
+	recorder := postFile(app, "big.bin", 4<<10)
+	if recorder.Code != http.StatusRequestEntityTooLarge {
+		t.Errorf("Expected 413 for an upload over the limit, got %d", recorder.Code)
+	}
+}
+
+func TestUploadTooLargeWithoutContentLength(t *testing.T) {
+	app, _ := uploadApp(1 << 10)
+
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+	part, _ := writer.CreateFormFile("file", "big.bin")
+	part.Write(bytes.Repeat([]byte("x"), 4<<10))
+	writer.Close()
+
+	// A chunked body is cut off while it is read
+	r := httptest.NewRequest(http.MethodPost, "/upload", io.MultiReader(&body))
+	r.ContentLength = -1
+	r.Header.Set("Content-Type", writer.FormDataContentType())
+	recorder := httptest.NewRecorder()
+	app.Router().ServeHTTP(recorder, r)
+
+	if recorder.Code != http.StatusRequestEntityTooLarge {
+		t.Errorf("Expected 413 for a chunked upload over the limit, got %d", recorder.Code)
+	}
+}
+
+func TestRouteMaxUploadSize(t *testing.T) {
+	app, route := uploadApp(1 << 10)
+	route.MaxUploadSize(16 << 10)
+
+	if recorder := postFile(app, "video.bin", 8<<10); recorder.Code != http.StatusOK {
+		t.Errorf("Expected the route's larger limit to allow the upload, got %d", recorder.Code)
+	}
+	if recorder := postFile(app, "video.bin", 32<<10); recorder.Code != http.StatusRequestEntityTooLarge {
+		t.Errorf("Expected 413 over the route's limit, got %d", recorder.Code)
+	}
+
+	route.MaxUploadSize(0)
+	if recorder := postFile(app, "video.bin", 32<<10); recorder.Code != http.StatusOK {
+		t.Errorf("Expected no limit once the route's limit is 0, got %d", recorder.Code)
+	}
+}
+
+func TestUploadedFileSaveTo(t *testing.T) {
+	app := New()
+	app.config.Upload.MemoryLimit = 1 << 10
+	app.Router().Use(app.uploadMiddleware)
+	path := filepath.Join(t.TempDir(), "uploads", "saved.bin")
+	app.POST("/upload", func(ctx *Context) Widget {
+		file, err := ctx.FormFile("file")
+		if err != nil {
+			t.Fatalf("Expected the upload, got %v", err)
+		}
+		if err := file.SaveTo(path); err != nil {
+			t.Errorf("Expected SaveTo to succeed, got %v", err)
+		}
+		return nil
+	})
+
+	// Larger than the memory limit, so the file is streamed to a temp file
+	postFile(app, "saved.bin", 8<<10)
+
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatalf("Expected the file to be saved, got %v", err)
+	}
+	if info.Size() != 8<<10 {
+		t.Errorf("Expected %d bytes saved, got %d", 8<<10, info.Size())
+	}
+}
@@ -1,36 +1,131 @@
 package core
 
 import (
+	"crypto/rand"
+	"encoding/hex"
 	"log"
 	"net/http"
 	"sync"
+	"time"
 
 	"github.com/gorilla/websocket"
 )
 
+// Default heartbeat settings; a connection misses at least one ping before it is closed
+const (
+	DefaultWebSocketPingInterval = 30 * time.Second
+	DefaultWebSocketIdleTimeout  = 75 * time.Second
+)
+
+// webSocketWriteWait bounds how long a ping may take to write
+const webSocketWriteWait = 10 * time.Second
+
+// wsConnection is an open WebSocket connection and the lock serializing writes to it
+type wsConnection struct {
+	*websocket.Conn
+	writeMutex sync.Mutex // gorilla/websocket supports one concurrent writer per connection
+}
+
+// writeJSON serializes writes, since messages for one connection arrive from many goroutines at once
+func (c *wsConnection) writeJSON(message WebSocketMessage) error {
+	c.writeMutex.Lock()
+	defer c.writeMutex.Unlock()
+	return c.WriteJSON(message)
+}
+
 // WebSocketManager manages WebSocket connections and channels
 type WebSocketManager struct {
-	connections map[string]*websocket.Conn
-	channels    map[string][]chan interface{}
-	upgrader    websocket.Upgrader
-	mutex       sync.RWMutex
-	enabled     bool
-	path        string
+	connections  map[string]*wsConnection
+	clients      map[string]string    // Client ID of each connection, see ClientID
+	lastActivity map[string]time.Time // Last message or pong received, per connection
+	channels     map[string][]chan interface{}
+	upgrader     websocket.Upgrader
+	mutex        sync.RWMutex
+	enabled      bool
+	path         string
+	snapshot     func(keys []string) map[string]interface{} // Supplies current state values for reconnect resync
+	onResync     func(clientID string) map[string]interface{}
+	pingInterval time.Duration
+	idleTimeout  time.Duration
+	onConnect    func(connID string)
+	onDisconnect func(connID string)
+	presence     map[string]map[string]interface{} // Presence data by topic and connection, see SetPresence
+	onPresence   func(topic, connID string, data interface{})
+
+	authorizePresence func(clientID, topic string) bool
 }
 
 // NewWebSocketManager creates a new WebSocket manager
 func NewWebSocketManager() *WebSocketManager {
 	return &WebSocketManager{
-		connections: make(map[string]*websocket.Conn),
-		channels:    make(map[string][]chan interface{}),
+		connections:  make(map[string]*wsConnection),
+		clients:      make(map[string]string),
+		lastActivity: make(map[string]time.Time),
+		channels:     make(map[string][]chan interface{}),
+		presence:     make(map[string]map[string]interface{}),
 		upgrader: websocket.Upgrader{
 			CheckOrigin: func(r *http.Request) bool {
 				return true // Allow all origins in development
 			},
 		},
-		enabled: false,
-		path:    "/ws",
+		enabled:      false,
+		path:         "/ws",
+		pingInterval: DefaultWebSocketPingInterval,
+		idleTimeout:  DefaultWebSocketIdleTimeout,
+	}
+}
+
+// SetHeartbeat sets how often connections are pinged and how long a connection may stay
+// silent (no messages or pongs) before it is closed. A zero pingInterval disables pings.
+func (wsm *WebSocketManager) SetHeartbeat(pingInterval, idleTimeout time.Duration) {
+	wsm.mutex.Lock()
+	defer wsm.mutex.Unlock()
+	wsm.pingInterval = pingInterval
+	wsm.idleTimeout = idleTimeout
+}
+
+// OnConnect sets a callback run when a connection is established
+func (wsm *WebSocketManager) OnConnect(callback func(connID string)) {
+	wsm.mutex.Lock()
+	defer wsm.mutex.Unlock()
+	wsm.onConnect = callback
+}
+
+// OnDisconnect sets a callback run once for every connection that closes, whether the
+// client went away, a read failed or the connection timed out after missing pings
+func (wsm *WebSocketManager) OnDisconnect(callback func(connID string)) {
+	wsm.mutex.Lock()
+	defer wsm.mutex.Unlock()
+	wsm.onDisconnect = callback
+}
+
+// OnResync sets the state a reconnecting client rehydrates from, replacing the
+// snapshot of the state keys on its page. The hook is called with the client's
+// ID (see ClientID) and may return per-client values; returning nil falls back
+// to the snapshot.
+func (wsm *WebSocketManager) OnResync(callback func(clientID string) map[string]interface{}) {
+	wsm.mutex.Lock()
+	defer wsm.mutex.Unlock()
+	wsm.onResync = callback
+}
+
+// ClientID returns the ID identifying a connection's client across reconnects:
+// its session ID when the browser has a session, otherwise the connection ID
+func (wsm *WebSocketManager) ClientID(connID string) string {
+	wsm.mutex.RLock()
+	defer wsm.mutex.RUnlock()
+	if clientID, exists := wsm.clients[connID]; exists {
+		return clientID
 	}
+	return connID
+}
+
+// LastActivity returns when a connection last sent a message or answered a ping
+func (wsm *WebSocketManager) LastActivity(connID string) (time.Time, bool) {
+	wsm.mutex.RLock()
+	defer wsm.mutex.RUnlock()
+	lastActivity, exists := wsm.lastActivity[connID]
+	return lastActivity, exists
 }
 
 // Enable enables WebSocket support with the specified path
@@ -51,6 +146,13 @@ func (wsm *WebSocketManager) GetPath() string {
 	return wsm.path
 }
 
+// SetSnapshotProvider sets the function used to answer client "sync" requests with current state values
+func (wsm *WebSocketManager) SetSnapshotProvider(provider func(keys []string) map[string]interface{}) {
+	wsm.mutex.Lock()
+	defer wsm.mutex.Unlock()
+	wsm.snapshot = provider
+}
+
 // HandleConnection handles new WebSocket connections
 func (wsm *WebSocketManager) HandleConnection(w http.ResponseWriter, r *http.Request) {
 	conn, err := wsm.upgrader.Upgrade(w, r, nil)
@@ -63,17 +165,54 @@ func (wsm *WebSocketManager) HandleConnection(w http.ResponseWriter, r *http.Req
 	// Generate connection ID
 	connID := generateConnectionID()
 
+	// The session cookie identifies the client again when it reconnects
+	clientID := connID
+	if cookie, err := r.Cookie(SessionCookieName); err == nil && cookie.Value != "" {
+		clientID = cookie.Value
+	}
+
 	wsm.mutex.Lock()
-	wsm.connections[connID] = conn
+	wsm.connections[connID] = &wsConnection{Conn: conn}
+	wsm.clients[connID] = clientID
+	wsm.lastActivity[connID] = time.Now()
+	pingInterval, idleTimeout := wsm.pingInterval, wsm.idleTimeout
+	onConnect := wsm.onConnect
 	wsm.mutex.Unlock()
 
 	// Clean up on disconnect
+	done := make(chan struct{})
 	defer func() {
+		close(done)
+		wsm.clearAllPresence(connID)
 		wsm.mutex.Lock()
 		delete(wsm.connections, connID)
+		delete(wsm.clients, connID)
+		delete(wsm.lastActivity, connID)
+		onDisconnect := wsm.onDisconnect
 		wsm.mutex.Unlock()
+
+		if onDisconnect != nil {
+			onDisconnect(connID)
+		}
 	}()
 
+	if onConnect != nil {
+		onConnect(connID)
+	}
+
+	// Any message or pong proves the client is alive and extends the idle deadline
+	if idleTimeout > 0 {
+		conn.SetReadDeadline(time.Now().Add(idleTimeout))
+	}
+	conn.SetPongHandler(func(string) error {
+		wsm.touch(connID, conn, idleTimeout)
+		return nil
+	})
+
+	if pingInterval > 0 {
+		go wsm.heartbeat(connID, conn, pingInterval, done)
+	}
+
 	// Handle incoming messages
 	for {
 		var message WebSocketMessage
@@ -83,10 +222,46 @@ func (wsm *WebSocketManager) HandleConnection(w http.ResponseWriter, r *http.Req
 			break
 		}
 
+		wsm.touch(connID, conn, idleTimeout)
 		wsm.handleMessage(connID, message)
 	}
 }
 
+// touch records activity on a connection and pushes back its idle deadline
+func (wsm *WebSocketManager) touch(connID string, conn *websocket.Conn, idleTimeout time.Duration) {
+	now := time.Now()
+	wsm.mutex.Lock()
+	if _, exists := wsm.connections[connID]; exists {
+		wsm.lastActivity[connID] = now
+	}
+	wsm.mutex.Unlock()
+
+	if idleTimeout > 0 {
+		conn.SetReadDeadline(now.Add(idleTimeout))
+	}
+}
+
+// heartbeat pings a connection until it closes. Pings go out as control frames, which
+// gorilla/websocket allows concurrently with other writes. A failed ping closes the
+// connection, ending the read loop in HandleConnection.
+func (wsm *WebSocketManager) heartbeat(connID string, conn *websocket.Conn, interval time.Duration, done <-chan struct{}) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-done:
+			return
+		case <-ticker.C:
+			if err := conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(webSocketWriteWait)); err != nil {
+				log.Printf("WebSocket ping to connection %s failed: %v", connID, err)
+				conn.Close()
+				return
+			}
+		}
+	}
+}
+
 // WebSocketMessage represents a WebSocket message
 type WebSocketMessage struct {
 	Type    string      `json:"type"`
@@ -106,9 +281,59 @@ func (wsm *WebSocketManager) handleMessage(connID string, message WebSocketMessa
 			Type: "pong",
 			Data: "pong",
 		})
+	case "sync":
+		wsm.handleSync(connID, message)
+	case "presence":
+		wsm.handlePresence(connID, message)
 	}
 }
 
+// handleSync replies to a client's resync request with the OnResync hook's values, or a
+// snapshot of the requested state keys. Clients send this after reconnecting so Consumers
+// catch up on broadcasts missed while offline.
+func (wsm *WebSocketManager) handleSync(connID string, message WebSocketMessage) {
+	wsm.mutex.RLock()
+	provider, onResync := wsm.snapshot, wsm.onResync
+	wsm.mutex.RUnlock()
+
+	if onResync != nil {
+		if values := onResync(wsm.ClientID(connID)); values != nil {
+			wsm.sendToConnection(connID, WebSocketMessage{
+				Type: "snapshot",
+				Data: map[string]interface{}{
+					"values": values,
+				},
+			})
+			return
+		}
+	}
+
+	if provider == nil {
+		return
+	}
+
+	var keys []string
+	if data, ok := message.Data.(map[string]interface{}); ok {
+		if rawKeys, ok := data["keys"].([]interface{}); ok {
+			for _, rawKey := range rawKeys {
+				if key, ok := rawKey.(string); ok && key != "" {
+					keys = append(keys, key)
+				}
+			}
+		}
+	}
+	if len(keys) == 0 {
+		return
+	}
+
+	wsm.sendToConnection(connID, WebSocketMessage{
+		Type: "snapshot",
+		Data: map[string]interface{}{
+			"values": provider(keys),
+		},
+	})
+}
+
 // Subscribe subscribes a connection to a channel
 func (wsm *WebSocketManager) subscribe(connID, channel string) {
 	// Implementation for subscribing to channels
@@ -133,13 +358,35 @@ func (wsm *WebSocketManager) Broadcast(channel string, data interface{}) {
 	defer wsm.mutex.RUnlock()
 
 	for connID, conn := range wsm.connections {
-		err := conn.WriteJSON(message)
+		err := conn.writeJSON(message)
 		if err != nil {
 			log.Printf("Error broadcasting to connection %s: %v", connID, err)
 		}
 	}
 }
 
+// SendToClient sends data on a channel to the connections of one client (see
+// ClientID), e.g. every tab of a browser session, instead of to everyone
+func (wsm *WebSocketManager) SendToClient(clientID, channel string, data interface{}) {
+	message := WebSocketMessage{
+		Type:    "broadcast",
+		Channel: channel,
+		Data:    data,
+	}
+
+	wsm.mutex.RLock()
+	defer wsm.mutex.RUnlock()
+
+	for connID, conn := range wsm.connections {
+		if wsm.clients[connID] != clientID {
+			continue
+		}
+		if err := conn.writeJSON(message); err != nil {
+			log.Printf("Error sending to connection %s: %v", connID, err)
+		}
+	}
+}
+
 // SendToConnection sends a message to a specific connection
 func (wsm *WebSocketManager) sendToConnection(connID string, message WebSocketMessage) {
 	wsm.mutex.RLock()
@@ -151,7 +398,7 @@ func (wsm *WebSocketManager) sendToConnection(connID string, message WebSocketMe
 		return
 	}
 
-	err := conn.WriteJSON(message)
+	err := conn.writeJSON(message)
 	if err != nil {
 		log.Printf("Error sending to connection %s: %v", connID, err)
 	}
@@ -199,16 +446,7 @@ func (wsm *WebSocketManager) GetConnectionCount() int {
 
 // generateConnectionID generates a unique connection ID
 func generateConnectionID() string {
-	// Simple implementation - in production, use UUID or similar
-	return "conn_" + randomString(8)
-}
-
-// randomString generates a random string of specified length
-func randomString(length int) string {
-	const charset = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789"
-	b := make([]byte, length)
-	for i := range b {
-		b[i] = charset[len(charset)/2] // Simplified for demo
-	}
-	return string(b)
+	bytes := make([]byte, 8)
+	rand.Read(bytes)
+	return "conn_" + hex.EncodeToString(bytes)
 }
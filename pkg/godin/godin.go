@@ -40,10 +40,13 @@ type (
 	FilledButton    = widgets.FilledButton
 	OutlinedButton  = widgets.OutlinedButton
 	IconButton      = widgets.IconButton
+	CopyButton      = widgets.CopyButton
 	Checkbox        = widgets.Checkbox
 
 	// Display widgets
 	Image           = widgets.Image
+	ResponsiveImage = widgets.ResponsiveImage
+	ImageSource     = widgets.ImageSource
 	Icon            = widgets.Icon
 	IconData        = widgets.IconData
 	RichText        = widgets.RichText
@@ -60,6 +63,7 @@ type (
 	SimpleDialog    = widgets.SimpleDialog
 	SnackBar        = widgets.SnackBar
 	SnackBarAction  = widgets.SnackBarAction
+	FlashMessages   = widgets.FlashMessages
 
 	// Layout widgets (additional)
 	Stack             = widgets.Stack
@@ -71,9 +75,13 @@ type (
 	Transform         = widgets.Transform
 	AnimatedContainer = widgets.AnimatedContainer
 	BoxConstraints    = widgets.BoxConstraints
+	ConstrainedBox    = widgets.ConstrainedBox
+	KeyedSubtree      = widgets.KeyedSubtree
+	MouseRegion       = widgets.MouseRegion
 
 	// Form widgets (additional)
 	TextFormField            = widgets.TextFormField
+	Form                     = widgets.Form
 	Switch                   = widgets.Switch
 	Button                   = widgets.Button
 	Dropdown                 = widgets.Dropdown
@@ -113,6 +121,8 @@ type (
 	FutureBuilder            = widgets.FutureBuilder
 	StateBuilder             = widgets.StateBuilder
 	Consumer                 = widgets.Consumer
+	StatefulWidget           = widgets.StatefulWidget
+	WidgetState              = widgets.WidgetState
 	Provider                 = widgets.Provider
 	Selector                 = widgets.Selector
 	ChangeNotifierProvider   = widgets.ChangeNotifierProvider
@@ -122,6 +132,9 @@ type (
 
 	// Color type
 	Color = widgets.Color
+
+	// Typography scale
+	TextThemeVariant = widgets.TextThemeVariant
 )
 
 // Color constructor function to make it work like the original
@@ -136,6 +149,13 @@ var (
 	TextAlignCenter = widgets.TextAlignCenter
 	TextAlignRight  = widgets.TextAlignRight
 
+	// Text theme variants
+	TextThemeDisplay  = widgets.TextThemeDisplay
+	TextThemeHeadline = widgets.TextThemeHeadline
+	TextThemeTitle    = widgets.TextThemeTitle
+	TextThemeBody     = widgets.TextThemeBody
+	TextThemeLabel    = widgets.TextThemeLabel
+
 	// Font weights
 	FontWeightNormal = widgets.FontWeightNormal
 	FontWeightBold   = widgets.FontWeightBold
@@ -0,0 +1,39 @@
+package renderer
+
+import (
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/gideonsigilai/godin/pkg/core"
+)
+
+// RenderOptions configures RenderToString
+type RenderOptions struct {
+	App     *core.App         // App to render with (a new app when nil)
+	Method  string            // Request method (default GET)
+	Path    string            // Request path the widget is rendered for (default "/")
+	Headers map[string]string // Request headers, e.g. HX-Request or Accept-Language
+}
+
+// RenderToString renders a widget to HTML without a server, for asserting a
+// widget's output in unit tests
+func RenderToString(widget core.Widget, opts RenderOptions) string {
+	app := opts.App
+	if app == nil {
+		app = core.New()
+	}
+	method := opts.Method
+	if method == "" {
+		method = http.MethodGet
+	}
+	path := opts.Path
+	if path == "" {
+		path = "/"
+	}
+
+	r := httptest.NewRequest(method, path, nil)
+	for name, value := range opts.Headers {
+		r.Header.Set(name, value)
+	}
+	return core.NewTestContextFor(app, r).Render(widget)
+}
@@ -0,0 +1,73 @@
+package renderer
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/gideonsigilai/godin/pkg/core"
+)
+
+// requestWidget renders what it sees of the request it is rendered for
+type requestWidget struct{}
+
+func (requestWidget) Render(ctx *core.Context) string {
+	htmx := "page"
+	if ctx.IsHTMX() {
+		htmx = "htmx"
+	}
+	return ctx.Request.Method + " " + ctx.Request.URL.Path + " " + htmx
+}
+
+// stateWidget renders a state value as text
+type stateWidget struct {
+	key string
+}
+
+func (sw stateWidget) Render(ctx *core.Context) string {
+	value, _ := ctx.GetState(sw.key).(string)
+	return NewHTMLRenderer().RenderText(value)
+}
+
+// appWithState creates an app with one state value set
+func appWithState(key string, value interface{}) *core.App {
+	app := core.New()
+	app.State().Set(key, value)
+	return app
+}
+
+func TestRenderToStringDefaults(t *testing.T) {
+	html := RenderToString(requestWidget{}, RenderOptions{})
+	if html != "GET / page" {
+		t.Errorf("Expected a GET / page render, got %q", html)
+	}
+}
+
+func TestRenderToStringOptions(t *testing.T) {
+	html := RenderToString(requestWidget{}, RenderOptions{
+		Method:  http.MethodPost,
+		Path:    "/todos",
+		Headers: map[string]string{"HX-Request": "true"},
+	})
+	if html != "POST /todos htmx" {
+		t.Errorf("Expected a POST /todos HTMX render, got %q", html)
+	}
+}
+
+func TestRenderToStringUsesApp(t *testing.T) {
+	html := RenderToString(stateWidget{key: "greeting"}, RenderOptions{App: appWithState("greeting", "Hello")})
+	if html != "Hello" {
+		t.Errorf("Expected the app's state in the render, got %q", html)
+	}
+
+	html = RenderToString(stateWidget{key: "name"}, RenderOptions{App: appWithState("name", "<b>Ada</b>")})
+	if strings.Contains(html, "<b>") {
+		t.Errorf("Expected state rendered as text to be escaped, got %q", html)
+	}
+}
+
+func TestRenderToStringNilWidget(t *testing.T) {
+	if html := RenderToString(nil, RenderOptions{}); html != "" {
+		t.Errorf("Expected a nil widget to render nothing, got %q", html)
+	}
+}
@@ -0,0 +1,145 @@
+package state
+
+import "sync"
+
+// BatchChannel is the WebSocket channel carrying the coalesced changes of a batch
+const BatchChannel = "state_batch"
+
+// Batch collects state changes so watchers and clients hear about them together,
+// in one WebSocket broadcast and one watcher notification per key, when the batch
+// is flushed. Values are stored immediately, so reads see them before the flush.
+// A batch belongs to whoever created it, e.g. one request, so changes made
+// elsewhere at the same time are never held back by it.
+type Batch struct {
+	sm     *StateManager
+	keys   []string // Changed keys in first-change order
+	values map[string]interface{}
+	mutex  sync.Mutex
+}
+
+// NewBatch starts a batch of changes to this state manager
+func (sm *StateManager) NewBatch() *Batch {
+	return &Batch{
+		sm:     sm,
+		values: make(map[string]interface{}),
+	}
+}
+
+// Set stores a value now and defers its notifications until Flush
+func (b *Batch) Set(key string, value interface{}) {
+	b.sm.mutex.Lock()
+	b.sm.data[key] = value
+	b.sm.updateCount++
+	b.sm.mutex.Unlock()
+
+	b.record(key, value)
+}
+
+// record stores the latest value of a changed key
+func (b *Batch) record(key string, value interface{}) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	if _, exists := b.values[key]; !exists {
+		b.keys = append(b.keys, key)
+	}
+	b.values[key] = value
+}
+
+// Flush publishes the changes collected so far and empties the batch
+func (b *Batch) Flush() {
+	b.mutex.Lock()
+	keys, values := b.keys, b.values
+	b.keys, b.values = nil, make(map[string]interface{})
+	b.mutex.Unlock()
+
+	b.sm.publish(keys, values)
+}
+
+// Batch runs fn and coalesces the changes it makes through the batch it is
+// given into one broadcast, sent when fn returns
+func (sm *StateManager) Batch(fn func(batch *Batch)) {
+	batch := sm.NewBatch()
+	defer batch.Flush()
+	fn(batch)
+}
+
+// Tx reads and writes state inside StateManager.Update
+type Tx struct {
+	sm    *StateManager
+	batch *Batch
+}
+
+// Get returns a key's value, including changes made earlier in the transaction
+func (tx *Tx) Get(key string) interface{} {
+	return tx.sm.data[key]
+}
+
+// Set changes a key's value; watchers and clients hear about it when Update returns
+func (tx *Tx) Set(key string, value interface{}) {
+	tx.sm.data[key] = value
+	tx.sm.updateCount++
+	tx.batch.record(key, value)
+}
+
+// Update runs fn as a transaction: no other change interleaves with its reads
+// and writes, and its changes go out as one coalesced broadcast, like Batch.
+// fn must use tx rather than the StateManager, which is locked while it runs.
+//
+//	app.State().Update(func(tx *state.Tx) {
+//		count, _ := tx.Get("counter").(int)
+//		tx.Set("counter", count+1)
+//		tx.Set("message", fmt.Sprintf("Clicked %d times", count+1))
+//	})
+func (sm *StateManager) Update(fn func(tx *Tx)) {
+	batch := sm.NewBatch()
+	defer batch.Flush()
+
+	sm.mutex.Lock()
+	defer sm.mutex.Unlock()
+	fn(&Tx{sm: sm, batch: batch})
+}
+
+// publish notifies watchers and clients of changed keys: a single change on its
+// usual per-key channel, several as one message on BatchChannel
+func (sm *StateManager) publish(keys []string, values map[string]interface{}) {
+	if len(keys) == 0 {
+		return
+	}
+
+	sm.mutex.RLock()
+	watchers := make(map[string][]func(interface{}), len(keys))
+	for _, key := range keys {
+		watchers[key] = sm.watchers[key]
+	}
+	broadcaster := sm.broadcaster
+	sm.mutex.RUnlock()
+
+	for _, key := range keys {
+		for _, watcher := range watchers[key] {
+			go watcher(values[key])
+		}
+	}
+
+	if broadcaster == nil {
+		return
+	}
+
+	if len(keys) == 1 {
+		go broadcaster.Broadcast("state:"+keys[0], map[string]interface{}{
+			"key":   keys[0],
+			"value": values[keys[0]],
+		})
+		return
+	}
+
+	changes := make([]map[string]interface{}, 0, len(keys))
+	for _, key := range keys {
+		changes = append(changes, map[string]interface{}{
+			"key":   key,
+			"value": values[key],
+		})
+	}
+	go broadcaster.Broadcast(BatchChannel, map[string]interface{}{
+		"changes": changes,
+	})
+}
@@ -0,0 +1,182 @@
+package state
+
+import (
+	"testing"
+	"time"
+)
+
+// broadcast is one message sent through a recordingBroadcaster
+type broadcast struct {
+	channel string
+	data    interface{}
+}
+
+// recordingBroadcaster hands broadcasts to the test, which the state manager sends from goroutines
+type recordingBroadcaster struct {
+	messages chan broadcast
+}
+
+func newRecordingBroadcaster() *recordingBroadcaster {
+	return &recordingBroadcaster{messages: make(chan broadcast, 16)}
+}
+
+func (rb *recordingBroadcaster) Broadcast(channel string, data interface{}) {
+	rb.messages <- broadcast{channel: channel, data: data}
+}
+
+// next waits for the next broadcast
+func (rb *recordingBroadcaster) next(t *testing.T) broadcast {
+	t.Helper()
+	select {
+	case message := <-rb.messages:
+		return message
+	case <-time.After(time.Second):
+		t.Fatal("Expected a broadcast, got none")
+		return broadcast{}
+	}
+}
+
+// expectNone fails when another broadcast arrives
+func (rb *recordingBroadcaster) expectNone(t *testing.T) {
+	t.Helper()
+	select {
+	case message := <-rb.messages:
+		t.Errorf("Expected no more broadcasts, got one on %q", message.channel)
+	case <-time.After(50 * time.Millisecond):
+	}
+}
+
+func TestBatchCoalescesChanges(t *testing.T) {
+	broadcaster := newRecordingBroadcaster()
+	sm := NewStateManagerWithBroadcaster(broadcaster)
+
+	sm.Batch(func(batch *Batch) {
+		batch.Set("count", 1)
+		batch.Set("message", "one")
+		batch.Set("count", 2)
+
+		if sm.Get("count") != 2 {
+			t.Errorf("Expected batched values to be readable before the flush, got %v", sm.Get("count"))
+		}
+	})
+
+	message := broadcaster.next(t)
+	if message.channel != BatchChannel {
+		t.Fatalf("Expected a broadcast on %q, got %q", BatchChannel, message.channel)
+	}
+	changes := message.data.(map[string]interface{})["changes"].([]map[string]interface{})
+	if len(changes) != 2 {
+		t.Fatalf("Expected 2 changes, got %d", len(changes))
+	}
+	if changes[0]["key"] != "count" || changes[0]["value"] != 2 {
+		t.Errorf("Expected count to be 2 in first-change order, got %v", changes[0])
+	}
+	if changes[1]["key"] != "message" || changes[1]["value"] != "one" {
+		t.Errorf("Expected message to be \"one\", got %v", changes[1])
+	}
+	broadcaster.expectNone(t)
+}
+
+func TestBatchWithOneChangeUsesKeyChannel(t *testing.T) {
+	broadcaster := newRecordingBroadcaster()
+	sm := NewStateManagerWithBroadcaster(broadcaster)
+
+	sm.Batch(func(batch *Batch) {
+		batch.Set("count", 1)
+		batch.Set("count", 2)
+	})
+
+	message := broadcaster.next(t)
+	if message.channel != "state:count" {
+		t.Fatalf("Expected a broadcast on state:count, got %q", message.channel)
+	}
+	if value := message.data.(map[string]interface{})["value"]; value != 2 {
+		t.Errorf("Expected the latest value 2, got %v", value)
+	}
+	broadcaster.expectNone(t)
+}
+
+func TestEmptyBatchBroadcastsNothing(t *testing.T) {
+	broadcaster := newRecordingBroadcaster()
+	sm := NewStateManagerWithBroadcaster(broadcaster)
+
+	sm.Batch(func(batch *Batch) {})
+	broadcaster.expectNone(t)
+}
+
+func TestBatchesAreIndependent(t *testing.T) {
+	broadcaster := newRecordingBroadcaster()
+	sm := NewStateManagerWithBroadcaster(broadcaster)
+
+	held := sm.NewBatch()
+	held.Set("held", true)
+
+	// A change outside the open batch is published right away
+	sm.Set("other", 1)
+	if message := broadcaster.next(t); message.channel != "state:other" {
+		t.Fatalf("Expected a broadcast on state:other, got %q", message.channel)
+	}
+	broadcaster.expectNone(t)
+
+	held.Flush()
+	if message := broadcaster.next(t); message.channel != "state:held" {
+		t.Fatalf("Expected a broadcast on state:held, got %q", message.channel)
+	}
+
+	// A flushed batch is empty
+	held.Flush()
+	broadcaster.expectNone(t)
+}
+
+func TestBatchNotifiesWatchersOncePerKey(t *testing.T) {
+	sm := NewStateManager()
+	values := make(chan interface{}, 4)
+	sm.AddWatcher("count", func(value interface{}) {
+		values <- value
+	})
+
+	sm.Batch(func(batch *Batch) {
+		batch.Set("count", 1)
+		batch.Set("count", 2)
+		batch.Set("count", 3)
+	})
+
+	select {
+	case value := <-values:
+		if value != 3 {
+			t.Errorf("Expected the watcher to see 3, got %v", value)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Expected the watcher to be notified")
+	}
+	select {
+	case value := <-values:
+		t.Errorf("Expected one notification, got another with %v", value)
+	case <-time.After(50 * time.Millisecond):
+	}
+}
+
+func TestUpdateIsTransactional(t *testing.T) {
+	broadcaster := newRecordingBroadcaster()
+	sm := NewStateManagerWithBroadcaster(broadcaster)
+	sm.Set("counter", 1)
+	broadcaster.next(t)
+
+	sm.Update(func(tx *Tx) {
+		count, _ := tx.Get("counter").(int)
+		tx.Set("counter", count+1)
+		tx.Set("message", "incremented")
+
+		if tx.Get("counter") != 2 {
+			t.Errorf("Expected the transaction to read its own write, got %v", tx.Get("counter"))
+		}
+	})
+
+	if sm.GetInt("counter") != 2 {
+		t.Errorf("Expected counter to be 2, got %d", sm.GetInt("counter"))
+	}
+	if message := broadcaster.next(t); message.channel != BatchChannel {
+		t.Errorf("Expected one broadcast on %q, got %q", BatchChannel, message.channel)
+	}
+	broadcaster.expectNone(t)
+}
@@ -0,0 +1,217 @@
+package state
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+// ListChangeKind describes how a ListNotifier changed
+type ListChangeKind string
+
+const (
+	ListInsert ListChangeKind = "insert" // Value was inserted at Index
+	ListRemove ListChangeKind = "remove" // The item at Index was removed
+	ListUpdate ListChangeKind = "update" // The item at Index was replaced by Value
+	ListReset  ListChangeKind = "reset"  // The whole list was replaced by Items
+)
+
+// ListChange is a single change to a ListNotifier, passed to its listeners
+type ListChange[T any] struct {
+	Kind  ListChangeKind
+	Index int // Position of the inserted, removed or updated item
+	Value T   // The inserted or updated item
+	Items []T // The new items of a reset
+}
+
+// ListNotifier holds a list and tells listeners about each item inserted,
+// removed or updated, so views of the list can patch single items instead of
+// rebuilding the whole list. Listeners are called on the goroutine that made
+// the change, after the list has been updated, and see changes one at a time in
+// the order they were made; they must not change the list themselves.
+type ListNotifier[T any] struct {
+	items          []T
+	listeners      []func(ListChange[T])
+	listenerKeys   []uint64
+	nextListenerID uint64
+	mutex          sync.RWMutex
+	changeMutex    sync.Mutex // Held from a change until its listeners return, to keep changes in order
+	id             string
+}
+
+// NewListNotifier creates a new ListNotifier holding items
+func NewListNotifier[T any](items []T) *ListNotifier[T] {
+	return NewListNotifierWithID(generateListID(), items)
+}
+
+// NewListNotifierWithID creates a new ListNotifier with a specific ID
+func NewListNotifierWithID[T any](id string, items []T) *ListNotifier[T] {
+	return &ListNotifier[T]{
+		items:     append([]T(nil), items...),
+		listeners: make([]func(ListChange[T]), 0),
+		id:        id,
+	}
+}
+
+// Items returns a copy of the items
+func (ln *ListNotifier[T]) Items() []T {
+	ln.mutex.RLock()
+	defer ln.mutex.RUnlock()
+	return append([]T(nil), ln.items...)
+}
+
+// Len returns the number of items
+func (ln *ListNotifier[T]) Len() int {
+	ln.mutex.RLock()
+	defer ln.mutex.RUnlock()
+	return len(ln.items)
+}
+
+// At returns the item at index, reporting whether index is in range
+func (ln *ListNotifier[T]) At(index int) (T, bool) {
+	ln.mutex.RLock()
+	defer ln.mutex.RUnlock()
+
+	var item T
+	if index < 0 || index >= len(ln.items) {
+		return item, false
+	}
+	return ln.items[index], true
+}
+
+// Add appends an item to the end of the list
+func (ln *ListNotifier[T]) Add(item T) {
+	ln.changeMutex.Lock()
+	defer ln.changeMutex.Unlock()
+
+	ln.mutex.Lock()
+	ln.items = append(ln.items, item)
+	index := len(ln.items) - 1
+	ln.mutex.Unlock()
+
+	ln.notify(ListChange[T]{Kind: ListInsert, Index: index, Value: item})
+}
+
+// Insert inserts an item at index, reporting whether index is in range
+func (ln *ListNotifier[T]) Insert(index int, item T) bool {
+	ln.changeMutex.Lock()
+	defer ln.changeMutex.Unlock()
+
+	ln.mutex.Lock()
+	if index < 0 || index > len(ln.items) {
+		ln.mutex.Unlock()
+		return false
+	}
+	ln.items = append(ln.items[:index], append([]T{item}, ln.items[index:]...)...)
+	ln.mutex.Unlock()
+
+	ln.notify(ListChange[T]{Kind: ListInsert, Index: index, Value: item})
+	return true
+}
+
+// RemoveAt removes the item at index, reporting whether index is in range
+func (ln *ListNotifier[T]) RemoveAt(index int) bool {
+	ln.changeMutex.Lock()
+	defer ln.changeMutex.Unlock()
+
+	ln.mutex.Lock()
+	if index < 0 || index >= len(ln.items) {
+		ln.mutex.Unlock()
+		return false
+	}
+	ln.items = append(ln.items[:index:index], ln.items[index+1:]...)
+	ln.mutex.Unlock()
+
+	ln.notify(ListChange[T]{Kind: ListRemove, Index: index})
+	return true
+}
+
+// Update replaces the item at index, reporting whether index is in range
+func (ln *ListNotifier[T]) Update(index int, item T) bool {
+	ln.changeMutex.Lock()
+	defer ln.changeMutex.Unlock()
+
+	ln.mutex.Lock()
+	if index < 0 || index >= len(ln.items) {
+		ln.mutex.Unlock()
+		return false
+	}
+	ln.items[index] = item
+	ln.mutex.Unlock()
+
+	ln.notify(ListChange[T]{Kind: ListUpdate, Index: index, Value: item})
+	return true
+}
+
+// SetItems replaces the whole list
+func (ln *ListNotifier[T]) SetItems(items []T) {
+	ln.changeMutex.Lock()
+	defer ln.changeMutex.Unlock()
+
+	ln.mutex.Lock()
+	ln.items = append([]T(nil), items...)
+	ln.mutex.Unlock()
+
+	ln.notify(ListChange[T]{Kind: ListReset, Items: append([]T(nil), items...)})
+}
+
+// Listen adds a listener for list changes and returns a function that removes it
+func (ln *ListNotifier[T]) Listen(listener func(ListChange[T])) func() {
+	ln.mutex.Lock()
+	defer ln.mutex.Unlock()
+
+	ln.nextListenerID++
+	key := ln.nextListenerID
+	ln.listeners = append(ln.listeners, listener)
+	ln.listenerKeys = append(ln.listenerKeys, key)
+
+	return func() {
+		ln.mutex.Lock()
+		defer ln.mutex.Unlock()
+
+		for i, k := range ln.listenerKeys {
+			if k == key {
+				ln.listeners = append(ln.listeners[:i:i], ln.listeners[i+1:]...)
+				ln.listenerKeys = append(ln.listenerKeys[:i:i], ln.listenerKeys[i+1:]...)
+				return
+			}
+		}
+	}
+}
+
+// ListenerCount returns the number of active listeners
+func (ln *ListNotifier[T]) ListenerCount() int {
+	ln.mutex.RLock()
+	defer ln.mutex.RUnlock()
+	return len(ln.listeners)
+}
+
+// ID returns the unique identifier for this ListNotifier
+func (ln *ListNotifier[T]) ID() string {
+	return ln.id
+}
+
+// String returns a string representation of the list
+func (ln *ListNotifier[T]) String() string {
+	ln.mutex.RLock()
+	defer ln.mutex.RUnlock()
+	return fmt.Sprintf("ListNotifier[%s](%d items)", ln.id, len(ln.items))
+}
+
+// notify calls the listeners with a change; changes are delivered in order, so
+// views patching the list stay in step with it
+func (ln *ListNotifier[T]) notify(change ListChange[T]) {
+	ln.mutex.RLock()
+	listeners := make([]func(ListChange[T]), len(ln.listeners))
+	copy(listeners, ln.listeners)
+	ln.mutex.RUnlock()
+
+	for _, listener := range listeners {
+		listener(change)
+	}
+}
+
+// generateListID generates a unique ListNotifier ID
+func generateListID() string {
+	return fmt.Sprintf("ln_%d", time.Now().UnixNano())
+}
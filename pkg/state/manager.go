@@ -20,6 +20,7 @@ type StateManager struct {
 	mutex       sync.RWMutex
 	broadcaster WebSocketBroadcaster
 	lastUpdated map[string]time.Time
+	updateCount uint64 // Total number of Set calls, for metrics
 }
 
 // NewStateManager creates a new state manager
@@ -37,6 +38,8 @@ func NewStateManagerWithBroadcaster(broadcaster WebSocketBroadcaster) *StateMana
 	return &StateManager{
 		data:        make(map[string]interface{}),
 		watchers:    make(map[string][]func(interface{})),
+		notifiers:   make(map[string]interface{}),
+		lastUpdated: make(map[string]time.Time),
 		broadcaster: broadcaster,
 	}
 }
@@ -76,10 +79,11 @@ func (sm *StateManager) UnregisterValueNotifier(id string) {
 func (sm *StateManager) notifyValueChange(id string, value interface{}) {
 	sm.mutex.Lock()
 	sm.lastUpdated[id] = time.Now()
+	broadcaster := sm.broadcaster
 	sm.mutex.Unlock()
 
 	// Broadcast the change via WebSocket if broadcaster is available
-	if sm.broadcaster != nil {
+	if broadcaster != nil {
 		// Create the broadcast message
 		message := map[string]interface{}{
 			"type":      "value_change",
@@ -89,7 +93,7 @@ func (sm *StateManager) notifyValueChange(id string, value interface{}) {
 		}
 
 		// Broadcast on the state channel for this specific notifier
-		sm.broadcaster.Broadcast(fmt.Sprintf("state:%s", id), message)
+		broadcaster.Broadcast(fmt.Sprintf("state:%s", id), message)
 	}
 
 	// Notify local watchers
@@ -163,6 +167,7 @@ func (sm *StateManager) ListValueNotifiers() []string {
 func (sm *StateManager) Set(key string, value interface{}) {
 	sm.mutex.Lock()
 	sm.data[key] = value
+	sm.updateCount++
 	watchers := sm.watchers[key]
 	broadcaster := sm.broadcaster
 	sm.mutex.Unlock()
@@ -181,6 +186,13 @@ func (sm *StateManager) Set(key string, value interface{}) {
 	}
 }
 
+// UpdateCount returns the total number of state updates applied
+func (sm *StateManager) UpdateCount() uint64 {
+	sm.mutex.RLock()
+	defer sm.mutex.RUnlock()
+	return sm.updateCount
+}
+
 // Get retrieves a value from the state
 func (sm *StateManager) Get(key string) interface{} {
 	sm.mutex.RLock()
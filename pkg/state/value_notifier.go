@@ -10,11 +10,13 @@ import (
 
 // ValueNotifier is a generic value holder that notifies listeners when the value changes
 type ValueNotifier[T any] struct {
-	value     T
-	listeners []func(T)
-	mutex     sync.RWMutex
-	id        string
-	manager   *StateManager
+	value          T
+	listeners      []func(T)
+	listenerKeys   []uint64 // Parallel to listeners; 0 for listeners added without Listen
+	nextListenerID uint64
+	mutex          sync.RWMutex
+	id             string
+	manager        *StateManager
 }
 
 // NewValueNotifier creates a new ValueNotifier with an initial value
@@ -70,10 +72,35 @@ func (vn *ValueNotifier[T]) AddListener(listener func(T)) {
 	vn.mutex.Lock()
 	defer vn.mutex.Unlock()
 	vn.listeners = append(vn.listeners, listener)
+	vn.listenerKeys = append(vn.listenerKeys, 0)
+}
+
+// Listen adds a listener and returns a function that removes exactly that listener
+func (vn *ValueNotifier[T]) Listen(listener func(T)) func() {
+	vn.mutex.Lock()
+	defer vn.mutex.Unlock()
+
+	vn.nextListenerID++
+	key := vn.nextListenerID
+	vn.listeners = append(vn.listeners, listener)
+	vn.listenerKeys = append(vn.listenerKeys, key)
+
+	return func() {
+		vn.mutex.Lock()
+		defer vn.mutex.Unlock()
+
+		for i, k := range vn.listenerKeys {
+			if k == key {
+				vn.listeners = append(vn.listeners[:i:i], vn.listeners[i+1:]...)
+				vn.listenerKeys = append(vn.listenerKeys[:i:i], vn.listenerKeys[i+1:]...)
+				return
+			}
+		}
+	}
 }
 
 // RemoveListener removes a specific listener (not implemented for simplicity)
-// In a real implementation, you'd need to store listener IDs
+// Use the function returned by Listen to remove a single listener
 func (vn *ValueNotifier[T]) RemoveListener(listener func(T)) {
 	// This is complex to implement without listener IDs
 	// For now, we'll provide ClearListeners instead
@@ -84,6 +111,7 @@ func (vn *ValueNotifier[T]) ClearListeners() {
 	vn.mutex.Lock()
 	defer vn.mutex.Unlock()
 	vn.listeners = make([]func(T), 0)
+	vn.listenerKeys = nil
 }
 
 // ListenerCount returns the number of active listeners
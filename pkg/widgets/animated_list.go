@@ -0,0 +1,177 @@
+package widgets
+
+import (
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/gideonsigilai/godin/pkg/core"
+	"github.com/gideonsigilai/godin/pkg/renderer"
+)
+
+// animatedListEvent is the client event godin.js handles by animating items into
+// or out of an AnimatedList
+const animatedListEvent = "godin:animated-list"
+
+// animatedListChangesKey is the context key holding the list changes a request has made
+const animatedListChangesKey = "godin.animatedListChanges"
+
+// defaultAnimatedListDuration is how long items take to animate in or out
+const defaultAnimatedListDuration = 300 * time.Millisecond
+
+// animatedListChange is an insertion or removal sent to godin.js
+type animatedListChange struct {
+	ID     string `json:"id"`
+	Action string `json:"action"` // "insert" or "remove"
+	Index  int    `json:"index"`
+	HTML   string `json:"html,omitempty"` // The inserted item
+}
+
+// AnimatedListController inserts and removes AnimatedList items from handlers,
+// animating them in the browser without re-rendering the list. Pass the same
+// controller to the AnimatedList on every render.
+type AnimatedListController struct {
+	elementID string
+	list      AnimatedList
+	mutex     sync.Mutex
+}
+
+// NewAnimatedListController creates an animated list controller
+func NewAnimatedListController() *AnimatedListController {
+	return &AnimatedListController{}
+}
+
+// InsertItem slides the item now at index into the list, once the current
+// response is swapped in. Call it after adding the item to the list's data.
+func (c *AnimatedListController) InsertItem(ctx *core.Context, index int) {
+	c.mutex.Lock()
+	id, list := c.elementID, c.list
+	c.mutex.Unlock()
+
+	if id == "" || list.ItemBuilder == nil {
+		return
+	}
+	c.send(ctx, animatedListChange{
+		ID:     id,
+		Action: "insert",
+		Index:  index,
+		HTML:   list.renderItem(ctx, index),
+	})
+}
+
+// RemoveItem fades the item at index out of the list, once the current response
+// is swapped in. Call it after removing the item from the list's data.
+func (c *AnimatedListController) RemoveItem(ctx *core.Context, index int) {
+	c.mutex.Lock()
+	id := c.elementID
+	c.mutex.Unlock()
+
+	if id == "" {
+		return
+	}
+	c.send(ctx, animatedListChange{ID: id, Action: "remove", Index: index})
+}
+
+// send queues a change with the others made during the request, since a
+// response carries one detail per client event
+func (c *AnimatedListController) send(ctx *core.Context, change animatedListChange) {
+	if ctx == nil {
+		return
+	}
+	changes, _ := ctx.Get(animatedListChangesKey).([]animatedListChange)
+	changes = append(changes, change)
+	ctx.Set(animatedListChangesKey, changes)
+	ctx.TriggerAfterSettle(animatedListEvent, map[string]interface{}{"changes": changes})
+}
+
+// attach ties the controller to an AnimatedList element, giving it a stable ID,
+// and keeps the list's builders for later insertions
+func (c *AnimatedListController) attach(list AnimatedList) string {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	id := list.ID
+	if id == "" {
+		if c.elementID == "" {
+			c.elementID = generateWidgetID()
+		}
+		id = c.elementID
+	}
+	c.elementID = id
+	c.list = list
+	return id
+}
+
+// AnimatedList is a list whose items slide and fade in when they are added and
+// out when they are removed, instead of snapping into place. godin.js tells
+// items apart by ItemKey, so when the list is re-rendered, whether by an HTMX
+// swap targeting the list or a state update, only the items that are new or gone
+// animate. Handlers can also insert or remove single items with a Controller.
+type AnimatedList struct {
+	ID          string
+	Style       string
+	Class       string
+	ItemCount   int                                       // Number of items
+	ItemBuilder func(ctx *core.Context, index int) Widget // Builds the item at index
+	ItemKey     func(index int) string                    // Stable key of the item at index, e.g. a record ID (defaults to the index)
+	Controller  *AnimatedListController                   // Inserts and removes items from handlers
+	Duration    time.Duration                             // Length of the item animations (default 300ms)
+}
+
+// Render renders the animated list as HTML
+func (al AnimatedList) Render(ctx *core.Context) string {
+	htmlRenderer := renderer.NewHTMLRenderer()
+
+	id := al.ID
+	if al.Controller != nil {
+		id = al.Controller.attach(al)
+	}
+
+	duration := al.Duration
+	if duration <= 0 {
+		duration = defaultAnimatedListDuration
+	}
+
+	attrs := buildAttributes(id, al.Style, al.Class+" godin-animated-list")
+	attrs["data-animated-list"] = "true"
+	attrs["data-animation-duration"] = strconv.FormatInt(duration.Milliseconds(), 10)
+
+	// Expose the duration to the item transitions
+	style := fmt.Sprintf("--godin-list-duration: %dms", duration.Milliseconds())
+	if al.Style != "" {
+		style = al.Style + "; " + style
+	}
+	attrs["style"] = style
+
+	content := ""
+	if al.ItemBuilder != nil {
+		for i := 0; i < al.ItemCount; i++ {
+			content += al.renderItem(ctx, i)
+		}
+	}
+
+	return htmlRenderer.RenderElement("div", attrs, content, false)
+}
+
+// renderItem renders the item at index in its keyed wrapper
+func (al AnimatedList) renderItem(ctx *core.Context, index int) string {
+	htmlRenderer := renderer.NewHTMLRenderer()
+
+	key := strconv.Itoa(index)
+	if al.ItemKey != nil {
+		key = al.ItemKey(index)
+	}
+
+	ctx.TrackRender(1)
+	content := ""
+	if item := al.ItemBuilder(ctx, index); item != nil {
+		content = item.Render(ctx)
+	}
+
+	attrs := map[string]string{
+		"class":    "godin-animated-list-item",
+		"data-key": key,
+	}
+	return htmlRenderer.RenderElement("div", attrs, content, false)
+}
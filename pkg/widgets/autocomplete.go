@@ -0,0 +1,206 @@
+package widgets
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/gideonsigilai/godin/pkg/core"
+	"github.com/gideonsigilai/godin/pkg/renderer"
+)
+
+// autocompleteSource holds the server-side option builder for one Autocomplete widget
+type autocompleteSource struct {
+	optionsBuilder         func(query string) []string
+	displayStringForOption func(option string) string
+	maxOptions             int
+	minChars               int
+}
+
+// autocompleteRegistry maps widget IDs to option sources and tracks which apps serve the options endpoint
+var autocompleteRegistry = struct {
+	sources map[string]autocompleteSource
+	apps    map[*core.App]bool
+	mutex   sync.RWMutex
+}{
+	sources: make(map[string]autocompleteSource),
+	apps:    make(map[*core.App]bool),
+}
+
+// Autocomplete represents a text input that suggests options fetched from the server as the user types
+type Autocomplete struct {
+	ID                     string // Identifies the option source across renders (defaults to one derived from Name)
+	Style                  string
+	Class                  string
+	Name                   string                      // Form field name
+	InitialValue           string                      // Initial text
+	Placeholder            string                      // Placeholder text
+	OptionsBuilder         func(query string) []string // Returns the options matching the query
+	OnSelected             func(option string)         // Called when an option is chosen
+	DisplayStringForOption func(option string) string  // Formats an option for display (defaults to the option itself)
+	Debounce               int                         // Delay in milliseconds before fetching options (default 300)
+	MinChars               int                         // Minimum query length before fetching options
+	MaxOptions             int                         // Maximum options shown (0 means no limit)
+	Enabled                *bool                       // Enabled
+}
+
+// Render renders the autocomplete field as HTML
+func (a Autocomplete) Render(ctx *core.Context) string {
+	htmlRenderer := renderer.NewHTMLRenderer()
+
+	// Options are looked up by ID, so only an ID that stays the same across renders can serve them
+	id := a.ID
+	if id == "" && a.Name != "" {
+		id = "autocomplete_" + a.Name
+	}
+	stableID := id != ""
+	if !stableID {
+		id = fmt.Sprintf("autocomplete_%p", &a)
+	}
+	listboxID := id + "_listbox"
+
+	enabled := isEnabled(a.Enabled, true)
+
+	debounce := a.Debounce
+	if debounce <= 0 {
+		debounce = 300
+	}
+
+	containerAttrs := buildAttributes(id+"_container", a.Style, a.Class+" godin-autocomplete")
+	containerStyles := []string{"position: relative", "display: inline-block"}
+	if a.Style != "" {
+		containerStyles = append([]string{a.Style}, containerStyles...)
+	}
+	containerAttrs["style"] = strings.Join(containerStyles, "; ")
+
+	inputAttrs := map[string]string{
+		"type":              "text",
+		"id":                id,
+		"name":              "q",
+		"class":             "godin-autocomplete-input",
+		"value":             a.InitialValue,
+		"placeholder":       a.Placeholder,
+		"autocomplete":      "off",
+		"role":              "combobox",
+		"aria-autocomplete": "list",
+		"aria-expanded":     "false",
+		"aria-controls":     listboxID,
+		"style":             "width: 100%; padding: 8px 12px; box-sizing: border-box; font-family: inherit",
+	}
+	if !enabled {
+		applyDisabled(inputAttrs, true)
+	}
+
+	if enabled && a.OptionsBuilder != nil && ctx != nil && ctx.App != nil && !stableID {
+		log.Printf("Autocomplete without an ID or Name cannot serve options; set one of them")
+	}
+	if enabled && a.OptionsBuilder != nil && ctx != nil && ctx.App != nil && stableID {
+		registerAutocompleteSource(ctx.App, id, autocompleteSource{
+			optionsBuilder:         a.OptionsBuilder,
+			displayStringForOption: a.DisplayStringForOption,
+			maxOptions:             a.MaxOptions,
+			minChars:               a.MinChars,
+		})
+
+		inputAttrs["hx-get"] = appPath(ctx, "/api/autocomplete/"+id)
+		inputAttrs["hx-trigger"] = fmt.Sprintf("input changed delay:%dms, focus", debounce)
+		inputAttrs["hx-target"] = "#" + listboxID
+		inputAttrs["hx-swap"] = "innerHTML"
+	}
+
+	if enabled && a.OnSelected != nil && ctx != nil && ctx.App != nil {
+		onSelected := a.OnSelected
+		callbackID := ctx.App.RegisterCallback(id, "Autocomplete", "OnSelected", func(option string) {
+			onSelected(option)
+		}, ctx)
+		if callbackID != "" {
+			containerAttrs["data-on-selected"] = appPath(ctx, "/api/callbacks/"+callbackID)
+		}
+	}
+
+	content := htmlRenderer.RenderElement("input", inputAttrs, "", true)
+
+	// Hidden field submits the selected option value with forms
+	if a.Name != "" {
+		content += htmlRenderer.RenderElement("input", map[string]string{
+			"type":  "hidden",
+			"name":  a.Name,
+			"value": a.InitialValue,
+			"class": "godin-autocomplete-value",
+		}, "", true)
+	}
+
+	content += htmlRenderer.RenderElement("ul", map[string]string{
+		"id":    listboxID,
+		"class": "godin-autocomplete-options",
+		"role":  "listbox",
+		"style": "position: absolute; left: 0; right: 0; top: 100%; margin: 2px 0 0; padding: 4px 0; list-style: none; background: white; border: 1px solid rgba(0, 0, 0, 0.12); border-radius: 4px; box-shadow: 0 4px 12px rgba(0, 0, 0, 0.15); max-height: 240px; overflow-y: auto; z-index: 1000; display: none",
+	}, "", false)
+
+	return htmlRenderer.RenderElement("div", containerAttrs, content, false)
+}
+
+// registerAutocompleteSource stores an option source, replacing the one from an earlier
+// render of the same widget, and serves the options endpoint once per app
+func registerAutocompleteSource(app *core.App, id string, source autocompleteSource) {
+	autocompleteRegistry.mutex.Lock()
+	defer autocompleteRegistry.mutex.Unlock()
+
+	autocompleteRegistry.sources[id] = source
+	if autocompleteRegistry.apps[app] {
+		return
+	}
+	autocompleteRegistry.apps[app] = true
+
+	app.Router().HandleFunc("/api/autocomplete/{id}", func(w http.ResponseWriter, r *http.Request) {
+		ctx := core.NewContext(w, r, app)
+
+		autocompleteRegistry.mutex.RLock()
+		source, exists := autocompleteRegistry.sources[ctx.Param("id")]
+		autocompleteRegistry.mutex.RUnlock()
+
+		if !exists {
+			http.Error(w, "Autocomplete not found", http.StatusNotFound)
+			return
+		}
+
+		ctx.WriteHTML(renderAutocompleteOptions(ctx.Param("id"), source, ctx.Query("q")))
+	}).Methods("GET")
+}
+
+// renderAutocompleteOptions renders the option list items for a query
+func renderAutocompleteOptions(id string, source autocompleteSource, query string) string {
+	htmlRenderer := renderer.NewHTMLRenderer()
+
+	if len(strings.TrimSpace(query)) < source.minChars {
+		return ""
+	}
+
+	options := source.optionsBuilder(query)
+	if source.maxOptions > 0 && len(options) > source.maxOptions {
+		options = options[:source.maxOptions]
+	}
+
+	var items strings.Builder
+	for i, option := range options {
+		display := option
+		if source.displayStringForOption != nil {
+			display = source.displayStringForOption(option)
+		}
+
+		items.WriteString(htmlRenderer.RenderElement("li", map[string]string{
+			"id":            fmt.Sprintf("%s_option_%d", id, i),
+			"class":         "godin-autocomplete-option",
+			"role":          "option",
+			"aria-selected": "false",
+			"data-value":    option,
+			"data-display":  display,
+			"style":         "padding: 8px 12px; cursor: pointer",
+			"onmousedown":   "event.preventDefault()",
+		}, htmlRenderer.RenderText(display), false))
+	}
+
+	return items.String()
+}
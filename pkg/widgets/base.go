@@ -12,12 +12,14 @@ type Widget = core.Widget
 
 // Common Flutter-style types and enums
 
-// EdgeInsetsGeometry represents padding/margin values
+// EdgeInsetsGeometry represents padding/margin values. When Directional is set,
+// Left and Right are the start and end insets and follow the text direction.
 type EdgeInsetsGeometry struct {
-	Top    float64
-	Right  float64
-	Bottom float64
-	Left   float64
+	Top         float64
+	Right       float64
+	Bottom      float64
+	Left        float64
+	Directional bool
 }
 
 // EdgeInsets creates EdgeInsetsGeometry with all sides equal
@@ -30,16 +32,37 @@ func EdgeInsetsOnly(top, right, bottom, left float64) EdgeInsetsGeometry {
 	return EdgeInsetsGeometry{Top: top, Right: right, Bottom: bottom, Left: left}
 }
 
+// EdgeInsetsLTRB creates EdgeInsetsGeometry from left, top, right and bottom values, in Flutter's order
+func EdgeInsetsLTRB(left, top, right, bottom float64) EdgeInsetsGeometry {
+	return EdgeInsetsGeometry{Top: top, Right: right, Bottom: bottom, Left: left}
+}
+
 // EdgeInsetsSymmetric creates EdgeInsetsGeometry with symmetric values
 func EdgeInsetsSymmetric(vertical, horizontal float64) EdgeInsetsGeometry {
 	return EdgeInsetsGeometry{Top: vertical, Right: horizontal, Bottom: vertical, Left: horizontal}
 }
 
-// ToCSSString converts EdgeInsetsGeometry to CSS padding/margin string
+// EdgeInsetsDirectional creates EdgeInsetsGeometry whose start and end sides
+// swap with the text direction, so start is the right side in RTL layouts
+func EdgeInsetsDirectional(start, top, end, bottom float64) EdgeInsetsGeometry {
+	return EdgeInsetsGeometry{Top: top, Right: end, Bottom: bottom, Left: start, Directional: true}
+}
+
+// ToCSSString converts EdgeInsetsGeometry to CSS padding/margin string.
+// Directional insets are returned in their LTR form; use ToCSSDeclaration to keep them directional.
 func (e EdgeInsetsGeometry) ToCSSString() string {
 	return fmt.Sprintf("%.1fpx %.1fpx %.1fpx %.1fpx", e.Top, e.Right, e.Bottom, e.Left)
 }
 
+// ToCSSDeclaration renders the insets as a declaration for property ("padding" or "margin").
+// Directional insets use the logical block and inline properties, so they mirror in RTL.
+func (e EdgeInsetsGeometry) ToCSSDeclaration(property string) string {
+	if e.Directional {
+		return fmt.Sprintf("%s-block: %.1fpx %.1fpx; %s-inline: %.1fpx %.1fpx", property, e.Top, e.Bottom, property, e.Left, e.Right)
+	}
+	return fmt.Sprintf("%s: %s", property, e.ToCSSString())
+}
+
 // AlignmentGeometry represents alignment values
 type AlignmentGeometry string
 
@@ -116,6 +139,42 @@ const (
 	TextOverflowVisible  TextOverflow = "visible"
 )
 
+// TextThemeVariant names a style of the theme's typography scale, used by Text.Variant
+type TextThemeVariant = core.TextThemeVariant
+
+const (
+	TextThemeDisplayLarge   = core.TextThemeDisplayLarge
+	TextThemeDisplayMedium  = core.TextThemeDisplayMedium
+	TextThemeDisplaySmall   = core.TextThemeDisplaySmall
+	TextThemeHeadlineLarge  = core.TextThemeHeadlineLarge
+	TextThemeHeadlineMedium = core.TextThemeHeadlineMedium
+	TextThemeHeadlineSmall  = core.TextThemeHeadlineSmall
+	TextThemeTitleLarge     = core.TextThemeTitleLarge
+	TextThemeTitleMedium    = core.TextThemeTitleMedium
+	TextThemeTitleSmall     = core.TextThemeTitleSmall
+	TextThemeBodyLarge      = core.TextThemeBodyLarge
+	TextThemeBodyMedium     = core.TextThemeBodyMedium
+	TextThemeBodySmall      = core.TextThemeBodySmall
+	TextThemeLabelLarge     = core.TextThemeLabelLarge
+	TextThemeLabelMedium    = core.TextThemeLabelMedium
+	TextThemeLabelSmall     = core.TextThemeLabelSmall
+
+	TextThemeDisplay  = core.TextThemeDisplay
+	TextThemeHeadline = core.TextThemeHeadline
+	TextThemeTitle    = core.TextThemeTitle
+	TextThemeBody     = core.TextThemeBody
+	TextThemeLabel    = core.TextThemeLabel
+)
+
+// appPath prefixes an internal endpoint such as "/handlers/handler_0" with the
+// mount prefix of the rendering app, see core.App.Mount
+func appPath(ctx *core.Context, path string) string {
+	if ctx == nil || ctx.App == nil {
+		return path
+	}
+	return ctx.App.Path(path)
+}
+
 // buildAttributes builds HTML attributes for a widget
 func buildAttributes(id, style, class string) map[string]string {
 	attrs := make(map[string]string)
@@ -165,3 +224,15 @@ type HTMXWidget struct {
 func (w HTMXWidget) buildHTMXAttributes() map[string]string {
 	return buildHTMXAttributes(w.ID, w.Style, w.Class, w.HTMX)
 }
+
+// injectStylesOnce returns a <style> block the first time it is called for a key within a request
+func injectStylesOnce(ctx *core.Context, key, css string) string {
+	if ctx != nil {
+		flag := "godin_styles_" + key
+		if ctx.GetBool(flag) {
+			return ""
+		}
+		ctx.Set(flag, true)
+	}
+	return "<style>" + css + "</style>"
+}
@@ -407,18 +407,8 @@ func (mbs *ModalBottomSheet) calculateBoxShadow(elevation float64) string {
 	}
 }
 
-// ShowBottomSheet displays a bottom sheet and returns a sheet ID
+// ShowBottomSheet displays a bottom sheet in the overlay layer and returns a sheet ID
 func ShowBottomSheet(ctx *core.Context, bottomSheet core.Widget, options ...BottomSheetOptions) string {
-	if ctx == nil || ctx.App == nil {
-		return ""
-	}
-
-	// Get or create dialog manager
-	dialogManager := ctx.App.DialogManager()
-	if dialogManager == nil {
-		return ""
-	}
-
 	// Use default options if none provided
 	opts := BottomSheetOptions{
 		IsModal:        false,
@@ -430,21 +420,11 @@ func ShowBottomSheet(ctx *core.Context, bottomSheet core.Widget, options ...Bott
 		opts = options[0]
 	}
 
-	return ShowBottomSheet(ctx, bottomSheet, opts)
+	return showBottomSheet(ctx, bottomSheet, opts)
 }
 
 // ShowModalBottomSheet displays a modal bottom sheet and returns a sheet ID
 func ShowModalBottomSheet(ctx *core.Context, bottomSheet core.Widget, options ...BottomSheetOptions) string {
-	if ctx == nil || ctx.App == nil {
-		return ""
-	}
-
-	// Get or create dialog manager
-	dialogManager := ctx.App.DialogManager()
-	if dialogManager == nil {
-		return ""
-	}
-
 	// Use modal options
 	opts := BottomSheetOptions{
 		IsModal:        true,
@@ -457,19 +437,40 @@ func ShowModalBottomSheet(ctx *core.Context, bottomSheet core.Widget, options ..
 		opts.IsModal = true // Force modal
 	}
 
-	return ShowBottomSheet(ctx, bottomSheet, opts)
+	return showBottomSheet(ctx, bottomSheet, opts)
 }
 
-// DismissBottomSheet dismisses a bottom sheet by ID
-func DismissBottomSheet(ctx *core.Context, sheetID string) bool {
-	if ctx == nil || ctx.App == nil {
-		return false
+// showBottomSheet registers a bottom sheet and pushes it into the overlay layer
+func showBottomSheet(ctx *core.Context, bottomSheet core.Widget, opts BottomSheetOptions) string {
+	dialogManager := dialogManagerFor(ctx)
+	if dialogManager == nil || bottomSheet == nil {
+		return ""
 	}
 
-	dialogManager := ctx.App.DialogManager()
+	sheetID := dialogManager.ShowBottomSheet(bottomSheet, opts)
+	info, exists := dialogManager.GetBottomSheetInfo(sheetID)
+	if !exists {
+		return sheetID
+	}
+
+	pushOverlayEntry(ctx, overlayEntry{
+		ID:                sheetID,
+		Kind:              "bottom-sheet",
+		ZIndex:            info.ZIndex,
+		Modal:             opts.IsModal,
+		Dismissible:       opts.IsModal,
+		DismissCallbackID: info.DismissCallbackID,
+	}, bottomSheet.Render(ctx))
+
+	return sheetID
+}
+
+// DismissBottomSheet dismisses a bottom sheet by ID and removes it from the overlay layer
+func DismissBottomSheet(ctx *core.Context, sheetID string) bool {
+	dialogManager := dialogManagerFor(ctx)
 	if dialogManager == nil {
 		return false
 	}
 
-	return DismissBottomSheet(ctx, sheetID)
+	return dialogManager.DismissBottomSheet(sheetID)
 }
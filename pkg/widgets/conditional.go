@@ -0,0 +1,53 @@
+package widgets
+
+import "github.com/gideonsigilai/godin/pkg/core"
+
+// Empty is a widget that renders nothing, for places that need a widget but
+// have nothing to show
+type Empty struct{}
+
+// Render renders nothing
+func (e Empty) Render(ctx *core.Context) string {
+	return ""
+}
+
+// If returns then when cond is true, and an Empty widget otherwise:
+//
+//	widgets.If(user.IsAdmin, adminPanel)
+func If(cond bool, then Widget) Widget {
+	if cond {
+		return orEmpty(then)
+	}
+	return Empty{}
+}
+
+// IfElse returns then when cond is true, and otherwise otherwise:
+//
+//	widgets.IfElse(enabled, enabledButton, disabledButton)
+func IfElse(cond bool, then, otherwise Widget) Widget {
+	if cond {
+		return orEmpty(then)
+	}
+	return orEmpty(otherwise)
+}
+
+// SwitchOn returns the widget for value in cases, and an Empty widget when
+// there is none. It is the widgets.Switch(value, cases) helper asked for
+// alongside If and IfElse, named SwitchOn because Switch is already the
+// toggle widget and a function cannot share its name:
+//
+//	widgets.SwitchOn(status, map[string]widgets.Widget{
+//		"loading": widgets.CircularProgressIndicator{},
+//		"error":   widgets.Text{Data: "Could not load"},
+//	})
+func SwitchOn[T comparable](value T, cases map[T]Widget) Widget {
+	return orEmpty(cases[value])
+}
+
+// orEmpty returns widget, or an Empty widget in place of nil
+func orEmpty(widget Widget) Widget {
+	if widget == nil {
+		return Empty{}
+	}
+	return widget
+}
@@ -0,0 +1,68 @@
+package widgets
+
+import (
+	"github.com/gideonsigilai/godin/pkg/core"
+	"github.com/gideonsigilai/godin/pkg/renderer"
+)
+
+// ConnectionStatus shows the state of the page's WebSocket connection, so users
+// can tell when live updates are paused: connected, reconnecting (after a drop,
+// such as a dev server restart) or offline (once godin.js gives up reconnecting,
+// or the browser loses the network). Offline adds a button to retry.
+//
+// godin.js updates the widget as the connection changes; it also sets
+// data-godin-connection on <html> and fires a godin:connection event, for
+// styling or scripts of the app's own. The widget needs WebSocket enabled.
+//
+//	widgets.ConnectionStatus{HideWhenConnected: true, Class: "corner-badge"}
+type ConnectionStatus struct {
+	ID                string
+	Style             string
+	Class             string
+	ConnectedLabel    string // Text while connected (default "Connected")
+	ReconnectingLabel string // Text while reconnecting (default "Reconnecting…")
+	OfflineLabel      string // Text once offline (default "Offline")
+	RetryLabel        string // Text of the retry button shown offline (default "Retry")
+	HideWhenConnected bool   // Show the widget only while updates are paused
+}
+
+// Render renders the connection status as HTML
+func (cs ConnectionStatus) Render(ctx *core.Context) string {
+	htmlRenderer := renderer.NewHTMLRenderer()
+
+	class := cs.Class + " godin-connection-status"
+	if cs.HideWhenConnected {
+		class += " godin-connection-status-hide-connected"
+	}
+	attrs := buildAttributes(cs.ID, cs.Style, class)
+	attrs["data-connection-status"] = "true"
+	// The page starts connecting, which the widget does not show; godin.js takes over from there
+	attrs["data-state"] = "connecting"
+	attrs["data-label-connected"] = labelOr(cs.ConnectedLabel, "Connected")
+	attrs["data-label-reconnecting"] = labelOr(cs.ReconnectingLabel, "Reconnecting…")
+	attrs["data-label-offline"] = labelOr(cs.OfflineLabel, "Offline")
+	attrs["role"] = "status"
+	attrs["aria-live"] = "polite"
+
+	content := htmlRenderer.RenderElement("span", map[string]string{
+		"class":       "godin-connection-status-dot",
+		"aria-hidden": "true",
+	}, "", false)
+	content += htmlRenderer.RenderElement("span", map[string]string{"class": "godin-connection-status-label"}, "", false)
+	content += htmlRenderer.RenderElement("button", map[string]string{
+		"type":                  "button",
+		"class":                 "godin-connection-status-retry",
+		"data-connection-retry": "true",
+		"hidden":                "hidden",
+	}, htmlRenderer.RenderText(labelOr(cs.RetryLabel, "Retry")), false)
+
+	return htmlRenderer.RenderElement("div", attrs, content, false)
+}
+
+// labelOr returns label, or fallback when it is empty
+func labelOr(label, fallback string) string {
+	if label == "" {
+		return fallback
+	}
+	return label
+}
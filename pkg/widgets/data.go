@@ -2,7 +2,9 @@ package widgets
 
 import (
 	"fmt"
+	"strconv"
 	"strings"
+	"sync"
 
 	"github.com/gideonsigilai/godin/pkg/core"
 	"github.com/gideonsigilai/godin/pkg/renderer"
@@ -32,6 +34,10 @@ type ListView struct {
 	KeyboardDismissBehavior ScrollViewKeyboardDismissBehavior // Keyboard dismiss behavior
 	RestorationId           string                            // Restoration ID
 	ClipBehavior            Clip                              // Clip behavior
+	SelectionMode           bool                              // Show a checkbox per item and the selection action bar (needs an ID)
+	ItemKeys                []string                          // Key reported for each child when selected (defaults to its index)
+	OnSelectionChanged      func(selected []string)           // Called with the selected keys whenever the selection changes
+	SelectionActions        []Widget                          // Bulk actions shown in the action bar, e.g. a delete button
 }
 
 // Render renders the list view as HTML
@@ -73,7 +79,7 @@ func (lv ListView) Render(ctx *core.Context) string {
 
 	// Add padding
 	if lv.Padding != nil {
-		styles = append(styles, fmt.Sprintf("padding: %s", lv.Padding.ToCSSString()))
+		styles = append(styles, lv.Padding.ToCSSDeclaration("padding"))
 	}
 
 	// Add shrink wrap
@@ -116,10 +122,16 @@ func (lv ListView) Render(ctx *core.Context) string {
 		attrs["style"] = strings.Join(styles, "; ")
 	}
 
+	// Render the selection action bar and checkboxes in selection mode
+	if lv.SelectionMode {
+		return lv.renderSelectable(ctx, attrs)
+	}
+
 	// Render children
 	var children []string
 	for _, child := range lv.Children {
 		if child != nil {
+			ctx.TrackRender(1)
 			// Wrap each child in a list item container if item extent is specified
 			if lv.ItemExtent != nil {
 				itemAttrs := map[string]string{"class": "godin-listview-item"}
@@ -139,6 +151,213 @@ func (lv ListView) Render(ctx *core.Context) string {
 	return htmlRenderer.RenderContainer("div", attrs, children)
 }
 
+// listSelectionKey returns the session key holding a list's selected item keys
+func listSelectionKey(listID string) string {
+	return "godin.listview.selection." + listID
+}
+
+// ListSelection returns the keys selected in the ListView with the given ID, e.g.
+// for a bulk delete handler. The selection is kept in the session, so it survives
+// partial updates.
+func ListSelection(ctx *core.Context, listID string) []string {
+	selected, _ := ctx.Session().Get(listSelectionKey(listID)).([]string)
+	return selected
+}
+
+// SetListSelection replaces the selection of the ListView with the given ID;
+// pass nil to clear it after a bulk action
+func SetListSelection(ctx *core.Context, listID string, selected []string) {
+	if len(selected) == 0 {
+		ctx.Session().Delete(listSelectionKey(listID))
+		return
+	}
+	ctx.Session().Set(listSelectionKey(listID), selected)
+}
+
+// itemKey returns the selection key of the child at index
+func (lv ListView) itemKey(index int) string {
+	if index < len(lv.ItemKeys) && lv.ItemKeys[index] != "" {
+		return lv.ItemKeys[index]
+	}
+	return strconv.Itoa(index)
+}
+
+// renderSelectable renders the list with a checkbox per item and an action bar
+// showing the selected count, select all/none and the SelectionActions
+func (lv ListView) renderSelectable(ctx *core.Context, attrs map[string]string) string {
+	htmlRenderer := renderer.NewHTMLRenderer()
+
+	// The selection is stored under the list's ID; without a stable one it lasts a single render
+	listID := lv.ID
+	if listID == "" {
+		listID = generateWidgetID()
+	}
+	attrs["id"] = listID
+	attrs["class"] += " godin-listview-selecting"
+
+	// Keep only keys still in the list, e.g. after items were deleted
+	keys := make([]string, 0, len(lv.Children))
+	present := make(map[string]bool)
+	for i, child := range lv.Children {
+		if child != nil {
+			key := lv.itemKey(i)
+			keys = append(keys, key)
+			present[key] = true
+		}
+	}
+	selected := make(map[string]bool)
+	for _, key := range ListSelection(ctx, listID) {
+		if present[key] {
+			selected[key] = true
+		}
+	}
+
+	barID := listID + "-selection-bar"
+	var handlerURL string
+	handlerID := ctx.RegisterHandler(func(ctx *core.Context) Widget {
+		var keep []string
+		switch ctx.FormValue("select") {
+		case "all":
+			keep = keys
+		case "none":
+		default:
+			chosen := make(map[string]bool)
+			if err := ctx.Request.ParseForm(); err == nil {
+				for _, key := range ctx.Request.Form["selected"] {
+					chosen[key] = true
+				}
+			}
+			for _, key := range keys {
+				if chosen[key] {
+					keep = append(keep, key)
+				}
+			}
+		}
+
+		SetListSelection(ctx, listID, keep)
+		if lv.OnSelectionChanged != nil {
+			lv.OnSelectionChanged(keep)
+		}
+
+		// Checkbox changes only refresh the action bar; select all/none refresh the list
+		if ctx.FormValue("select") == "" {
+			return lv.selectionBar(listID, len(keep), handlerURL)
+		}
+		return lv
+	})
+	handlerURL = appPath(ctx, "/handlers/"+handlerID)
+
+	children := []string{lv.selectionBar(listID, len(selected), handlerURL).Render(ctx)}
+	for i, child := range lv.Children {
+		if child == nil {
+			continue
+		}
+		key := lv.itemKey(i)
+
+		checkboxAttrs := map[string]string{
+			"type":       "checkbox",
+			"class":      "godin-listview-select",
+			"name":       "selected",
+			"value":      key,
+			"aria-label": "Select item",
+			"hx-post":    handlerURL,
+			"hx-trigger": "change",
+			"hx-include": "#" + listID + " .godin-listview-select",
+			"hx-target":  "#" + barID,
+			"hx-swap":    "outerHTML",
+		}
+		if selected[key] {
+			checkboxAttrs["checked"] = "checked"
+		}
+
+		itemAttrs := map[string]string{
+			"class":         "godin-listview-item godin-listview-selectable",
+			"data-item-key": key,
+		}
+		if lv.ItemExtent != nil {
+			itemAttrs["style"] = fmt.Sprintf("min-height: %.1fpx; max-height: %.1fpx", *lv.ItemExtent, *lv.ItemExtent)
+		}
+
+		content := htmlRenderer.RenderElement("input", checkboxAttrs, "", true) +
+			htmlRenderer.RenderElement("div", map[string]string{"class": "godin-listview-item-content"}, child.Render(ctx), false)
+		children = append(children, htmlRenderer.RenderElement("div", itemAttrs, content, false))
+	}
+
+	return htmlRenderer.RenderContainer("div", attrs, children)
+}
+
+// selectionBar builds the contextual action bar of the list with the given ID
+func (lv ListView) selectionBar(listID string, count int, handlerURL string) Widget {
+	total := 0
+	for _, child := range lv.Children {
+		if child != nil {
+			total++
+		}
+	}
+
+	return listSelectionBar{
+		id:         listID + "-selection-bar",
+		listID:     listID,
+		count:      count,
+		total:      total,
+		actions:    lv.SelectionActions,
+		handlerURL: handlerURL,
+	}
+}
+
+// listSelectionBar shows a selectable ListView's selected count and bulk actions
+type listSelectionBar struct {
+	id         string
+	listID     string
+	count      int
+	total      int
+	actions    []Widget
+	handlerURL string
+}
+
+// Render renders the action bar as HTML
+func (bar listSelectionBar) Render(ctx *core.Context) string {
+	htmlRenderer := renderer.NewHTMLRenderer()
+
+	attrs := map[string]string{
+		"id":        bar.id,
+		"class":     "godin-listview-selection-bar",
+		"role":      "toolbar",
+		"aria-live": "polite",
+	}
+	if bar.count > 0 {
+		attrs["class"] += " active"
+	}
+
+	children := []string{
+		htmlRenderer.RenderElement("span", map[string]string{"class": "godin-listview-selection-count"}, fmt.Sprintf("%d selected", bar.count), false),
+	}
+
+	// Select all/none re-render the whole list so every checkbox updates
+	for _, option := range []struct{ value, label string }{{"all", "Select all"}, {"none", "Select none"}} {
+		buttonAttrs := map[string]string{
+			"type":      "button",
+			"class":     "godin-listview-select-" + option.value,
+			"hx-post":   bar.handlerURL,
+			"hx-vals":   fmt.Sprintf(`{"select": "%s"}`, option.value),
+			"hx-target": "#" + bar.listID,
+			"hx-swap":   "outerHTML",
+		}
+		if (option.value == "all" && bar.count == bar.total) || (option.value == "none" && bar.count == 0) {
+			applyDisabled(buttonAttrs, true)
+		}
+		children = append(children, htmlRenderer.RenderElement("button", buttonAttrs, option.label, false))
+	}
+
+	for _, action := range bar.actions {
+		if action != nil {
+			children = append(children, action.Render(ctx))
+		}
+	}
+
+	return htmlRenderer.RenderContainer("div", attrs, children)
+}
+
 // ListTile represents a list tile widget with full Flutter properties
 type ListTile struct {
 	ID                 string
@@ -157,7 +376,7 @@ type ListTile struct {
 	IconColor          Color                    // Icon color
 	TextColor          Color                    // Text color
 	ContentPadding     *EdgeInsetsGeometry      // Content padding
-	Enabled            bool                     // Enabled
+	Enabled            *bool                    // Enabled state; nil means enabled
 	OnTap              GestureTapCallback       // On tap callback
 	OnLongPress        GestureLongPressCallback // On long press callback
 	MouseCursor        MouseCursor              // Mouse cursor
@@ -198,11 +417,6 @@ func (lt ListTile) Render(ctx *core.Context) string {
 	styles = append(styles, "align-items: center")
 	styles = append(styles, "padding: 16px")
 
-	// Add content padding
-	if lt.ContentPadding != nil {
-		styles = append(styles, fmt.Sprintf("padding: %s", lt.ContentPadding.ToCSSString()))
-	}
-
 	// Add tile colors
 	if lt.Selected {
 		if lt.SelectedTileColor != "" {
@@ -224,16 +438,21 @@ func (lt ListTile) Render(ctx *core.Context) string {
 		styles = append(styles, "padding: 8px 16px")
 	}
 
+	// Add content padding after dense styling so an explicit padding wins
+	if lt.ContentPadding != nil {
+		styles = append(styles, lt.ContentPadding.ToCSSDeclaration("padding"))
+	}
+
 	// Add minimum vertical padding
 	if lt.MinVerticalPadding != nil {
 		styles = append(styles, fmt.Sprintf("padding-top: %.1fpx", *lt.MinVerticalPadding))
 		styles = append(styles, fmt.Sprintf("padding-bottom: %.1fpx", *lt.MinVerticalPadding))
 	}
 
-	// Add enabled/disabled styling
-	if !lt.Enabled {
-		styles = append(styles, "opacity: 0.6")
-		styles = append(styles, "pointer-events: none")
+	// Add enabled/disabled styling; tiles without callbacks just show information, so only Enabled disables them
+	enabled := isEnabled(lt.Enabled, true)
+	if !enabled {
+		applyDisabled(attrs, false)
 	}
 
 	// Combine all styles
@@ -242,26 +461,23 @@ func (lt ListTile) Render(ctx *core.Context) string {
 	}
 
 	// Add tap handler
-	if lt.OnTap != nil && lt.Enabled {
+	if enabled && lt.OnTap != nil {
 		handlerID := ctx.RegisterHandler(func(ctx *core.Context) Widget {
 			lt.OnTap()
 			return nil
 		})
 
-		attrs["hx-post"] = "/handlers/" + handlerID
+		attrs["hx-post"] = appPath(ctx, "/handlers/"+handlerID)
 		attrs["hx-trigger"] = "click"
 		styles = append(styles, "cursor: pointer")
 	}
 
 	// Add long press handler
-	if lt.OnLongPress != nil && lt.Enabled {
+	if enabled && lt.OnLongPress != nil {
 		attrs["oncontextmenu"] = "handleListTileLongPress(event, this)"
 	}
 
-	// Add autofocus
-	if lt.AutoFocus {
-		attrs["autofocus"] = "true"
-	}
+	applyFocus(ctx, attrs, lt.AutoFocus, lt.FocusNode)
 
 	// Build content
 	var content string
@@ -395,7 +611,7 @@ func (gv GridView) Render(ctx *core.Context) string {
 
 	// Add padding
 	if gv.Padding != nil {
-		styles = append(styles, fmt.Sprintf("padding: %s", gv.Padding.ToCSSString()))
+		styles = append(styles, gv.Padding.ToCSSDeclaration("padding"))
 	}
 
 	// Add shrink wrap
@@ -444,6 +660,7 @@ func (gv GridView) Render(ctx *core.Context) string {
 
 	for _, child := range gv.Children {
 		if child != nil {
+			ctx.TrackRender(1)
 			// Wrap each child in a grid item container
 			itemAttrs := map[string]string{"class": "godin-gridview-item"}
 
@@ -527,7 +744,7 @@ func (scsv SingleChildScrollView) Render(ctx *core.Context) string {
 
 	// Add padding
 	if scsv.Padding != nil {
-		styles = append(styles, fmt.Sprintf("padding: %s", scsv.Padding.ToCSSString()))
+		styles = append(styles, scsv.Padding.ToCSSDeclaration("padding"))
 	}
 
 	// Add clip behavior
@@ -574,21 +791,109 @@ func (scsv SingleChildScrollView) Render(ctx *core.Context) string {
 	return htmlRenderer.RenderElement("div", attrs, content, false)
 }
 
-// PageController represents a page controller
+// pageViewEvent is the client event godin.js handles by moving a PageView
+const pageViewEvent = "godin:page"
+
+// PageController tracks the page a PageView shows and moves it from handlers.
+// Pass the same controller to the PageView on every render.
 type PageController struct {
 	InitialPage      int
-	KeepPage         bool
-	ViewportFraction float64
+	KeepPage         bool    // Render the last page the user moved to instead of InitialPage
+	ViewportFraction float64 // Fraction of the viewport each page fills (default 1)
+	page             int
+	moved            bool
+	elementID        string
+	mutex            sync.Mutex
 }
 
-// PageView represents a page view widget with full Flutter properties
+// NewPageController creates a page controller starting at initialPage
+func NewPageController(initialPage int) *PageController {
+	return &PageController{InitialPage: initialPage, KeepPage: true}
+}
+
+// Page returns the page the user last moved to, or InitialPage before they have
+func (pc *PageController) Page() int {
+	pc.mutex.Lock()
+	defer pc.mutex.Unlock()
+	if pc.moved {
+		return pc.page
+	}
+	return pc.InitialPage
+}
+
+// JumpToPage shows page immediately once the current response is swapped in
+func (pc *PageController) JumpToPage(ctx *core.Context, page int) {
+	pc.move(ctx, map[string]interface{}{"page": page, "animate": false})
+}
+
+// AnimateToPage scrolls to page once the current response is swapped in
+func (pc *PageController) AnimateToPage(ctx *core.Context, page int) {
+	pc.move(ctx, map[string]interface{}{"page": page, "animate": true})
+}
+
+// NextPage scrolls to the following page, wrapping around when the PageView loops
+func (pc *PageController) NextPage(ctx *core.Context) {
+	pc.move(ctx, map[string]interface{}{"delta": 1, "animate": true})
+}
+
+// PreviousPage scrolls to the preceding page, wrapping around when the PageView loops
+func (pc *PageController) PreviousPage(ctx *core.Context) {
+	pc.move(ctx, map[string]interface{}{"delta": -1, "animate": true})
+}
+
+// move sends a page change to the attached PageView in the browser
+func (pc *PageController) move(ctx *core.Context, detail map[string]interface{}) {
+	pc.mutex.Lock()
+	detail["id"] = pc.elementID
+	pc.mutex.Unlock()
+
+	if ctx != nil && detail["id"] != "" {
+		ctx.TriggerAfterSettle(pageViewEvent, detail)
+	}
+}
+
+// attach ties the controller to a PageView element, giving it a stable ID, and
+// returns the ID and the page to render first
+func (pc *PageController) attach(id string, pageCount int) (string, int) {
+	pc.mutex.Lock()
+	defer pc.mutex.Unlock()
+
+	if id == "" {
+		if pc.elementID == "" {
+			pc.elementID = generateWidgetID()
+		}
+		id = pc.elementID
+	}
+	pc.elementID = id
+
+	page := pc.InitialPage
+	if pc.KeepPage && pc.moved {
+		page = pc.page
+	}
+	if page < 0 || page >= pageCount {
+		page = 0
+	}
+	return id, page
+}
+
+// setPage records the page the user moved to
+func (pc *PageController) setPage(page int) {
+	pc.mutex.Lock()
+	defer pc.mutex.Unlock()
+	pc.page = page
+	pc.moved = true
+}
+
+// PageView represents a page view widget with full Flutter properties. Pages
+// are full-width slides that snap into place when swiped or dragged, and
+// godin.js reports the settled page to OnPageChanged.
 type PageView struct {
 	ID                     string
 	Style                  string
 	Class                  string
 	Children               []Widget          // Child widgets
 	Controller             *PageController   // Page controller
-	ScrollDirection        Axis              // Scroll direction
+	ScrollDirection        Axis              // Scroll direction (default horizontal)
 	Reverse                bool              // Reverse scroll direction
 	Physics                ScrollPhysicsType // Scroll physics
 	PageSnapping           bool              // Page snapping
@@ -598,13 +903,41 @@ type PageView struct {
 	ClipBehavior           Clip              // Clip behavior
 	DragStartBehavior      DragStartBehavior // Drag start behavior
 	PadEnds                bool              // Pad ends
+	Loop                   bool              // Wrap around from the last page to the first and back
+	ShowIndicators         bool              // Show dot indicators for the current page
+	IndicatorColor         Color             // Inactive dot color
+	ActiveIndicatorColor   Color             // Current page dot color
 }
 
 // Render renders the page view as HTML
 func (pv PageView) Render(ctx *core.Context) string {
 	htmlRenderer := renderer.NewHTMLRenderer()
 
-	attrs := buildAttributes(pv.ID, pv.Style, pv.Class+" godin-page-view")
+	horizontal := pv.ScrollDirection != AxisVertical
+
+	id := pv.ID
+	initialPage := 0
+	if pv.Controller != nil {
+		id, initialPage = pv.Controller.attach(id, len(pv.Children))
+	}
+
+	attrs := buildAttributes(id, pv.Style, pv.Class+" godin-page-view")
+	attrs["data-page-view"] = "true"
+	attrs["data-initial-page"] = strconv.Itoa(initialPage)
+	if horizontal {
+		attrs["data-page-axis"] = "horizontal"
+	} else {
+		attrs["data-page-axis"] = "vertical"
+	}
+	if pv.Reverse {
+		attrs["data-page-reverse"] = "true"
+	}
+	if pv.Loop {
+		attrs["data-page-loop"] = "true"
+	}
+	if pv.Physics == ScrollPhysicsNeverScrollable {
+		attrs["data-page-draggable"] = "false"
+	}
 
 	// Build inline styles
 	var styles []string
@@ -616,100 +949,137 @@ func (pv PageView) Render(ctx *core.Context) string {
 
 	// Base page view styles
 	styles = append(styles, "position: relative")
-	styles = append(styles, "overflow: hidden")
 	styles = append(styles, "width: 100%")
 	styles = append(styles, "height: 100%")
 
-	// Add scroll direction
-	if pv.ScrollDirection == AxisHorizontal {
-		styles = append(styles, "display: flex")
-		styles = append(styles, "flex-direction: row")
-		styles = append(styles, "overflow-x: auto")
-		styles = append(styles, "overflow-y: hidden")
-		styles = append(styles, "scroll-snap-type: x mandatory")
-	} else {
-		styles = append(styles, "display: flex")
-		styles = append(styles, "flex-direction: column")
-		styles = append(styles, "overflow-y: auto")
-		styles = append(styles, "overflow-x: hidden")
-		styles = append(styles, "scroll-snap-type: y mandatory")
+	// Add indicator colors
+	if pv.IndicatorColor != "" {
+		styles = append(styles, fmt.Sprintf("--godin-page-dot-color: %s", pv.IndicatorColor))
+	}
+	if pv.ActiveIndicatorColor != "" {
+		styles = append(styles, fmt.Sprintf("--godin-page-dot-active-color: %s", pv.ActiveIndicatorColor))
 	}
 
-	// Add page snapping
-	if pv.PageSnapping {
-		styles = append(styles, "scroll-behavior: smooth")
+	// Combine all styles
+	if len(styles) > 0 {
+		attrs["style"] = strings.Join(styles, "; ")
+	}
+
+	// Report the settled page to OnPageChanged and the controller
+	if (pv.OnPageChanged != nil || pv.Controller != nil) && ctx != nil {
+		onPageChanged := pv.OnPageChanged
+		controller := pv.Controller
+		handlerID := ctx.RegisterHandler(func(ctx *core.Context) Widget {
+			page, err := strconv.Atoi(ctx.FormValue("page"))
+			if err == nil {
+				if controller != nil {
+					controller.setPage(page)
+				}
+				if onPageChanged != nil {
+					onPageChanged(page)
+				}
+			}
+			ctx.SetHeader(renderer.HXReswap, "none")
+			return nil
+		})
+		attrs["data-page-changed-url"] = appPath(ctx, "/handlers/"+handlerID)
+	}
+
+	// Set viewport fraction
+	viewportFraction := 1.0
+	if pv.Controller != nil && pv.Controller.ViewportFraction > 0 && pv.Controller.ViewportFraction <= 1 {
+		viewportFraction = pv.Controller.ViewportFraction
+	}
+
+	// Center pages narrower than the viewport when padding the ends
+	centered := pv.PadEnds && viewportFraction < 1
+	if centered {
+		attrs["data-page-align"] = "center"
+	}
+
+	// Build track styles
+	var trackStyles []string
+	trackStyles = append(trackStyles, "position: relative")
+	trackStyles = append(trackStyles, "display: flex")
+	trackStyles = append(trackStyles, "width: 100%")
+	trackStyles = append(trackStyles, "height: 100%")
+	trackStyles = append(trackStyles, "scrollbar-width: none")
+
+	// Add scroll direction
+	if horizontal {
+		trackStyles = append(trackStyles, "overflow-x: auto")
+		trackStyles = append(trackStyles, "overflow-y: hidden")
+		trackStyles = append(trackStyles, "overscroll-behavior-x: contain")
+		trackStyles = append(trackStyles, "scroll-snap-type: x mandatory")
+	} else {
+		trackStyles = append(trackStyles, "overflow-y: auto")
+		trackStyles = append(trackStyles, "overflow-x: hidden")
+		trackStyles = append(trackStyles, "overscroll-behavior-y: contain")
+		trackStyles = append(trackStyles, "scroll-snap-type: y mandatory")
 	}
 
 	// Add reverse direction
+	direction := "row"
+	if !horizontal {
+		direction = "column"
+	}
 	if pv.Reverse {
-		if pv.ScrollDirection == AxisHorizontal {
-			styles = append(styles, "flex-direction: row-reverse")
-		} else {
-			styles = append(styles, "flex-direction: column-reverse")
-		}
+		direction += "-reverse"
 	}
+	trackStyles = append(trackStyles, "flex-direction: "+direction)
 
-	// Add clip behavior
-	if pv.ClipBehavior != "" && pv.ClipBehavior != ClipNone {
-		styles = append(styles, "overflow: hidden")
+	// Add page snapping
+	if pv.PageSnapping {
+		trackStyles = append(trackStyles, "scroll-behavior: smooth")
 	}
 
-	// Add scroll physics (simplified)
-	if pv.Physics != "" {
-		switch pv.Physics {
-		case ScrollPhysicsNeverScrollable:
-			styles = append(styles, "overflow: hidden")
-		case ScrollPhysicsAlwaysScrollable:
-			// Keep default overflow behavior
-		case ScrollPhysicsBouncingScrollable:
-			styles = append(styles, "scroll-behavior: smooth")
+	// Add end padding
+	if centered {
+		padding := fmt.Sprintf("%.1f%%", (1-viewportFraction)*50)
+		if horizontal {
+			trackStyles = append(trackStyles, "padding-inline: "+padding)
+		} else {
+			trackStyles = append(trackStyles, "padding-block: "+padding)
 		}
 	}
 
-	// Combine all styles
-	if len(styles) > 0 {
-		attrs["style"] = strings.Join(styles, "; ")
+	// Add scroll physics (simplified)
+	switch pv.Physics {
+	case ScrollPhysicsNeverScrollable:
+		// Only the controller and indicators move the pages
+		trackStyles = append(trackStyles, "overflow: hidden")
+	case ScrollPhysicsBouncingScrollable:
+		trackStyles = append(trackStyles, "overscroll-behavior: auto")
 	}
 
 	// Render children as pages
-	var children []string
+	var pages []string
 	for i, child := range pv.Children {
 		pageAttrs := map[string]string{
-			"class": "godin-page-view-page",
+			"class":                "godin-page-view-page",
+			"role":                 "group",
+			"aria-roledescription": "slide",
+			"aria-label":           fmt.Sprintf("%d of %d", i+1, len(pv.Children)),
 		}
 
 		// Build page styles
 		var pageStyles []string
 		pageStyles = append(pageStyles, "flex: none")
-		pageStyles = append(pageStyles, "scroll-snap-align: start")
-
-		// Set viewport fraction
-		viewportFraction := 1.0
-		if pv.Controller != nil && pv.Controller.ViewportFraction > 0 {
-			viewportFraction = pv.Controller.ViewportFraction
+		if centered {
+			pageStyles = append(pageStyles, "scroll-snap-align: center")
+		} else {
+			pageStyles = append(pageStyles, "scroll-snap-align: start")
 		}
+		pageStyles = append(pageStyles, "scroll-snap-stop: always")
 
-		if pv.ScrollDirection == AxisHorizontal {
+		if horizontal {
 			pageStyles = append(pageStyles, fmt.Sprintf("width: %.1f%%", viewportFraction*100))
 			pageStyles = append(pageStyles, "height: 100%")
 		} else {
 			pageStyles = append(pageStyles, "width: 100%")
 			pageStyles = append(pageStyles, fmt.Sprintf("height: %.1f%%", viewportFraction*100))
 		}
-
-		if len(pageStyles) > 0 {
-			pageAttrs["style"] = strings.Join(pageStyles, "; ")
-		}
-
-		// Add page change handler
-		if pv.OnPageChanged != nil {
-			handlerID := ctx.RegisterHandler(func(ctx *core.Context) Widget {
-				pv.OnPageChanged(i)
-				return nil
-			})
-			pageAttrs["hx-post"] = "/handlers/" + handlerID
-			pageAttrs["hx-trigger"] = "intersect"
-		}
+		pageAttrs["style"] = strings.Join(pageStyles, "; ")
 
 		// Render child content
 		childContent := ""
@@ -717,7 +1087,37 @@ func (pv PageView) Render(ctx *core.Context) string {
 			childContent = child.Render(ctx)
 		}
 
-		children = append(children, htmlRenderer.RenderElement("div", pageAttrs, childContent, false))
+		pages = append(pages, htmlRenderer.RenderElement("div", pageAttrs, childContent, false))
+	}
+
+	trackAttrs := map[string]string{
+		"class":    "godin-page-view-track",
+		"style":    strings.Join(trackStyles, "; "),
+		"tabindex": "0",
+	}
+	children := []string{htmlRenderer.RenderContainer("div", trackAttrs, pages)}
+
+	// Add dot indicators
+	if pv.ShowIndicators && len(pv.Children) > 1 {
+		var dots []string
+		for i := range pv.Children {
+			dotAttrs := map[string]string{
+				"type":            "button",
+				"class":           "godin-page-view-dot",
+				"data-page-index": strconv.Itoa(i),
+				"aria-label":      fmt.Sprintf("Go to page %d", i+1),
+			}
+			if i == initialPage {
+				dotAttrs["class"] += " active"
+				dotAttrs["aria-current"] = "true"
+			}
+			dots = append(dots, htmlRenderer.RenderElement("button", dotAttrs, "", false))
+		}
+		dotsClass := "godin-page-view-dots"
+		if !horizontal {
+			dotsClass += " godin-page-view-dots-vertical"
+		}
+		children = append(children, htmlRenderer.RenderContainer("div", map[string]string{"class": dotsClass}, dots))
 	}
 
 	return htmlRenderer.RenderContainer("div", attrs, children)
@@ -878,6 +1278,7 @@ type Card struct {
 	Shape              ShapeBorder         // Shape
 	BorderOnForeground bool                // Border on foreground
 	Margin             *EdgeInsetsGeometry // Margin
+	Padding            *EdgeInsetsGeometry // Padding around child
 	ClipBehavior       Clip                // Clip behavior
 	SemanticContainer  bool                // Semantic container
 }
@@ -921,7 +1322,12 @@ func (c Card) Render(ctx *core.Context) string {
 
 	// Add margin
 	if c.Margin != nil {
-		styles = append(styles, fmt.Sprintf("margin: %s", c.Margin.ToCSSString()))
+		styles = append(styles, c.Margin.ToCSSDeclaration("margin"))
+	}
+
+	// Add padding
+	if c.Padding != nil {
+		styles = append(styles, c.Padding.ToCSSDeclaration("padding"))
 	}
 
 	// Add surface tint color (simplified as overlay)
@@ -190,15 +190,10 @@ func (d *Dialog) calculateBoxShadow(elevation float64) string {
 	}
 }
 
-// showDialog displays a modal dialog and returns a dialog ID
+// ShowDialog displays a modal dialog in the overlay layer and returns a dialog ID
 func ShowDialog(ctx *core.Context, dialog core.Widget, options ...DialogOptions) string {
-	if ctx == nil || ctx.App == nil {
-		return ""
-	}
-
-	// Get or create dialog manager
-	dialogManager := ctx.App.DialogManager()
-	if dialogManager == nil {
+	dialogManager := dialogManagerFor(ctx)
+	if dialogManager == nil || dialog == nil {
 		return ""
 	}
 
@@ -210,7 +205,22 @@ func ShowDialog(ctx *core.Context, dialog core.Widget, options ...DialogOptions)
 		opts = options[0]
 	}
 
-	return ShowDialog(ctx, dialog, opts)
+	dialogID := dialogManager.ShowDialog(dialog, opts)
+	info, exists := dialogManager.GetDialogInfo(dialogID)
+	if !exists {
+		return dialogID
+	}
+
+	pushOverlayEntry(ctx, overlayEntry{
+		ID:                dialogID,
+		Kind:              "dialog",
+		ZIndex:            info.ZIndex,
+		Modal:             true,
+		Dismissible:       opts.BarrierDismissible,
+		DismissCallbackID: info.DismissCallbackID,
+	}, dialog.Render(ctx))
+
+	return dialogID
 }
 
 // ShowAlertDialog is a convenience function for showing alert dialogs
@@ -232,16 +242,12 @@ func ShowAlertDialog(ctx *core.Context, title, content string, actions []core.Wi
 	return ShowDialog(ctx, alertDialog)
 }
 
-// DismissDialog dismisses a dialog by ID
+// DismissDialog dismisses a dialog by ID and removes it from the overlay layer
 func DismissDialog(ctx *core.Context, dialogID string) bool {
-	if ctx == nil || ctx.App == nil {
-		return false
-	}
-
-	dialogManager := ctx.App.DialogManager()
+	dialogManager := dialogManagerFor(ctx)
 	if dialogManager == nil {
 		return false
 	}
 
-	return DismissDialog(ctx, dialogID)
+	return dialogManager.DismissDialog(dialogID)
 }
@@ -28,23 +28,26 @@ type DialogInfo struct {
 	CreatedAt          time.Time
 	Result             interface{}
 	ResultCallback     func(interface{})
+	DismissCallbackID  string // Callback that dismisses the dialog from the client
 }
 
 // BottomSheetInfo contains information about an active bottom sheet
 type BottomSheetInfo struct {
-	ID             string
-	Widget         core.Widget
-	IsModal        bool
-	IsDraggable    bool
-	OnDismiss      func()
-	ZIndex         int
-	CreatedAt      time.Time
-	Result         interface{}
-	ResultCallback func(interface{})
+	ID                string
+	Widget            core.Widget
+	IsModal           bool
+	IsDraggable       bool
+	OnDismiss         func()
+	ZIndex            int
+	CreatedAt         time.Time
+	Result            interface{}
+	ResultCallback    func(interface{})
+	DismissCallbackID string // Callback that dismisses the sheet from the client
 }
 
 // DialogOptions contains options for showing dialogs
 type DialogOptions struct {
+	ID                 string // Optional dialog ID (generated when empty)
 	BarrierDismissible bool
 	OnDismiss          func()
 	ResultCallback     func(interface{})
@@ -53,6 +56,7 @@ type DialogOptions struct {
 
 // BottomSheetOptions contains options for showing bottom sheets
 type BottomSheetOptions struct {
+	ID             string // Optional sheet ID (generated when empty)
 	IsModal        bool
 	IsDraggable    bool
 	OnDismiss      func()
@@ -72,13 +76,23 @@ func NewDialogManager(ctx *core.Context) *DialogManager {
 	}
 }
 
+// bindContext points the manager at the context of the request now using it
+func (dm *DialogManager) bindContext(ctx *core.Context) {
+	dm.mutex.Lock()
+	defer dm.mutex.Unlock()
+	dm.context = ctx
+}
+
 // ShowDialog displays a modal dialog
 func (dm *DialogManager) ShowDialog(widget core.Widget, options DialogOptions) string {
 	dm.mutex.Lock()
 	defer dm.mutex.Unlock()
 
 	// Generate unique dialog ID
-	dialogID := fmt.Sprintf("dialog_%d_%d", time.Now().UnixNano(), len(dm.activeDialogs))
+	dialogID := options.ID
+	if dialogID == "" {
+		dialogID = fmt.Sprintf("dialog_%d_%d", time.Now().UnixNano(), len(dm.activeDialogs))
+	}
 
 	// Increment z-index for stacking
 	dm.zIndexCounter++
@@ -107,7 +121,7 @@ func (dm *DialogManager) ShowDialog(widget core.Widget, options DialogOptions) s
 			dismissCallback := func() {
 				dm.DismissDialog(dialogID)
 			}
-			callbackRegistry.RegisterCallback(
+			dialogInfo.DismissCallbackID = callbackRegistry.RegisterCallback(
 				dialogID,
 				"Dialog",
 				"OnDismiss",
@@ -126,7 +140,10 @@ func (dm *DialogManager) ShowBottomSheet(widget core.Widget, options BottomSheet
 	defer dm.mutex.Unlock()
 
 	// Generate unique bottom sheet ID
-	sheetID := fmt.Sprintf("bottomsheet_%d_%d", time.Now().UnixNano(), len(dm.activeBottomSheets))
+	sheetID := options.ID
+	if sheetID == "" {
+		sheetID = fmt.Sprintf("bottomsheet_%d_%d", time.Now().UnixNano(), len(dm.activeBottomSheets))
+	}
 
 	// Increment z-index for stacking
 	dm.zIndexCounter++
@@ -156,7 +173,7 @@ func (dm *DialogManager) ShowBottomSheet(widget core.Widget, options BottomSheet
 			dismissCallback := func() {
 				dm.DismissBottomSheet(sheetID)
 			}
-			callbackRegistry.RegisterCallback(
+			sheetInfo.DismissCallbackID = callbackRegistry.RegisterCallback(
 				sheetID,
 				"BottomSheet",
 				"OnDismiss",
@@ -192,12 +209,13 @@ func (dm *DialogManager) DismissDialog(dialogID string) bool {
 	// Remove from active dialogs
 	delete(dm.activeDialogs, dialogID)
 
-	// Clean up callback registry if context is available
+	// Clean up callback registry and overlay if context is available
 	if dm.context != nil && dm.context.App != nil {
 		callbackRegistry := dm.context.App.CallbackRegistry()
 		if callbackRegistry != nil {
-			callbackRegistry.CleanupCallback(dialogID)
+			callbackRegistry.CleanupCallback(dialogInfo.DismissCallbackID)
 		}
+		removeOverlayEntry(dm.context, dialogID)
 	}
 
 	return true
@@ -226,12 +244,13 @@ func (dm *DialogManager) DismissBottomSheet(sheetID string) bool {
 	// Remove from active bottom sheets
 	delete(dm.activeBottomSheets, sheetID)
 
-	// Clean up callback registry if context is available
+	// Clean up callback registry and overlay if context is available
 	if dm.context != nil && dm.context.App != nil {
 		callbackRegistry := dm.context.App.CallbackRegistry()
 		if callbackRegistry != nil {
-			callbackRegistry.CleanupCallback(sheetID)
+			callbackRegistry.CleanupCallback(sheetInfo.DismissCallbackID)
 		}
+		removeOverlayEntry(dm.context, sheetID)
 	}
 
 	return true
@@ -273,8 +292,9 @@ func (dm *DialogManager) dismissDialogUnsafe(dialogID string) {
 	if dm.context != nil && dm.context.App != nil {
 		callbackRegistry := dm.context.App.CallbackRegistry()
 		if callbackRegistry != nil {
-			callbackRegistry.CleanupCallback(dialogID)
+			callbackRegistry.CleanupCallback(dialogInfo.DismissCallbackID)
 		}
+		removeOverlayEntry(dm.context, dialogID)
 	}
 }
 
@@ -298,8 +318,9 @@ func (dm *DialogManager) dismissBottomSheetUnsafe(sheetID string) {
 	if dm.context != nil && dm.context.App != nil {
 		callbackRegistry := dm.context.App.CallbackRegistry()
 		if callbackRegistry != nil {
-			callbackRegistry.CleanupCallback(sheetID)
+			callbackRegistry.CleanupCallback(sheetInfo.DismissCallbackID)
 		}
+		removeOverlayEntry(dm.context, sheetID)
 	}
 }
 
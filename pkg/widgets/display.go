@@ -2,6 +2,7 @@ package widgets
 
 import (
 	"fmt"
+	"strconv"
 	"strings"
 
 	"github.com/gideonsigilai/godin/pkg/core"
@@ -14,7 +15,11 @@ type Text struct {
 	Style              string
 	Class              string
 	Data               string              // The text content
-	TextStyle          *TextStyle          // Text styling
+	TranslationKey     string              // Message key translated with ctx.T, replacing Data
+	TranslationArgs    []interface{}       // Name/value pairs for the message's placeholders, e.g. "count", 3
+	AutoLink           bool                // Escape the text and turn its URLs and email addresses into links
+	Variant            TextThemeVariant    // Named style from the theme's typography scale
+	TextStyle          *TextStyle          // Text styling, applied over the variant
 	StrutStyle         *StrutStyle         // Strut styling
 	TextAlign          TextAlign           // Text alignment
 	TextDirection      TextDirection       // Text direction
@@ -32,11 +37,22 @@ type Text struct {
 func (t Text) Render(ctx *core.Context) string {
 	htmlRenderer := renderer.NewHTMLRenderer()
 
-	attrs := buildAttributes(t.ID, t.Style, t.Class+" godin-text")
+	class := t.Class + " godin-text"
+	if t.Variant != "" {
+		class += " godin-text-" + string(t.Variant)
+	}
+	attrs := buildAttributes(t.ID, t.Style, class)
 
 	// Build inline styles from various sources
 	var styles []string
 
+	// Add the theme's typography for the variant, so explicit styles below override it
+	if t.Variant != "" {
+		if variantCSS := ctx.Theme().TextTheme().Style(t.Variant).ToCSSString(); variantCSS != "" {
+			styles = append(styles, variantCSS)
+		}
+	}
+
 	// Add custom style if provided
 	if t.Style != "" {
 		styles = append(styles, t.Style)
@@ -106,10 +122,13 @@ func (t Text) Render(ctx *core.Context) string {
 		attrs["lang"] = t.Locale.LanguageCode
 	}
 
-	// Use Data as the text content, fallback to empty string
+	// Use Data as the text content, or the translated message when a key is set
 	content := t.Data
-	if content == "" {
-		content = ""
+	if t.TranslationKey != "" {
+		content = ctx.T(t.TranslationKey, t.TranslationArgs...)
+	}
+	if t.AutoLink {
+		content = linkify(content)
 	}
 
 	return htmlRenderer.RenderElement("span", attrs, content, false)
@@ -192,27 +211,8 @@ func (i Image) Render(ctx *core.Context) string {
 	}
 
 	// Add object-fit based on BoxFit
-	if i.Fit != "" {
-		var objectFit string
-		switch i.Fit {
-		case BoxFitFill:
-			objectFit = "fill"
-		case BoxFitContain:
-			objectFit = "contain"
-		case BoxFitCover:
-			objectFit = "cover"
-		case BoxFitFitWidth:
-			objectFit = "scale-down"
-		case BoxFitFitHeight:
-			objectFit = "scale-down"
-		case BoxFitNone:
-			objectFit = "none"
-		case BoxFitScaleDown:
-			objectFit = "scale-down"
-		}
-		if objectFit != "" {
-			styles = append(styles, fmt.Sprintf("object-fit: %s", objectFit))
-		}
+	if objectFit := boxFitObjectFit(i.Fit); objectFit != "" {
+		styles = append(styles, fmt.Sprintf("object-fit: %s", objectFit))
 	}
 
 	// Add color blending if specified
@@ -249,6 +249,161 @@ func (i Image) Render(ctx *core.Context) string {
 	return htmlRenderer.RenderElement("img", attrs, "", true)
 }
 
+// boxFitObjectFit maps a BoxFit to the CSS object-fit value
+func boxFitObjectFit(fit BoxFit) string {
+	switch fit {
+	case BoxFitFill:
+		return "fill"
+	case BoxFitContain:
+		return "contain"
+	case BoxFitCover:
+		return "cover"
+	case BoxFitFitWidth, BoxFitFitHeight, BoxFitScaleDown:
+		return "scale-down"
+	case BoxFitNone:
+		return "none"
+	}
+	return ""
+}
+
+// ImageSource is one resolution of a ResponsiveImage
+type ImageSource struct {
+	Src     string  // Static asset path such as "images/hero-800.jpg", or an absolute URL
+	Width   int     // Intrinsic width in pixels, emitted as a "w" descriptor
+	Density float64 // Pixel density such as 2 for "2x", used when Width is zero
+}
+
+// ImageArtDirection swaps in different sources while a media query matches,
+// e.g. a tighter crop on narrow screens
+type ImageArtDirection struct {
+	Media   string        // Media query, e.g. "(max-width: 600px)"
+	Type    string        // Optional MIME type, e.g. "image/webp"
+	Sources []ImageSource // Candidates for this media query
+	Sizes   string        // Optional sizes for this media query
+}
+
+// ResponsiveImage renders an image with a srcset so the browser downloads the
+// resolution that fits the layout, and a <picture> when ArtDirection is set.
+// Static asset sources are fingerprinted like other assets.
+type ResponsiveImage struct {
+	ID           string
+	Style        string
+	Class        string
+	Src          string              // Fallback source, the first of Sources when empty
+	Sources      []ImageSource       // Candidate resolutions
+	Sizes        string              // Rendered width per viewport, e.g. "(max-width: 600px) 100vw, 50vw"
+	ArtDirection []ImageArtDirection // Sources chosen by media query, checked in order
+	Alt          string              // Alternative text
+	Width        int                 // Intrinsic width, reserves space to avoid layout shift
+	Height       int                 // Intrinsic height
+	Fit          BoxFit              // How the image fits its box
+	Eager        bool                // Load immediately instead of lazily, e.g. for hero images
+}
+
+// Render renders the responsive image as HTML
+func (ri ResponsiveImage) Render(ctx *core.Context) string {
+	htmlRenderer := renderer.NewHTMLRenderer()
+
+	imgAttrs := map[string]string{
+		"srcset": imageSrcset(ctx, ri.Sources),
+		"sizes":  ri.Sizes,
+		"alt":    ri.Alt,
+	}
+
+	src := ri.Src
+	if src == "" && len(ri.Sources) > 0 {
+		src = ri.Sources[0].Src
+	}
+	imgAttrs["src"] = imageSourceURL(ctx, src)
+
+	if ri.Width > 0 {
+		imgAttrs["width"] = strconv.Itoa(ri.Width)
+	}
+	if ri.Height > 0 {
+		imgAttrs["height"] = strconv.Itoa(ri.Height)
+	}
+
+	if ri.Eager {
+		imgAttrs["loading"] = "eager"
+	} else {
+		imgAttrs["loading"] = "lazy"
+	}
+	imgAttrs["decoding"] = "async"
+
+	// Images without alternative text are treated as decorative
+	if ri.Alt == "" {
+		imgAttrs["aria-hidden"] = "true"
+	}
+
+	var styles []string
+	if objectFit := boxFitObjectFit(ri.Fit); objectFit != "" {
+		styles = append(styles, fmt.Sprintf("object-fit: %s", objectFit))
+	}
+
+	if len(ri.ArtDirection) == 0 {
+		attrs := buildAttributes(ri.ID, ri.Style, ri.Class+" godin-image godin-responsive-image")
+		for key, value := range imgAttrs {
+			attrs[key] = value
+		}
+		if ri.Style != "" {
+			styles = append([]string{ri.Style}, styles...)
+		}
+		if len(styles) > 0 {
+			attrs["style"] = strings.Join(styles, "; ")
+		}
+		return htmlRenderer.RenderElement("img", attrs, "", true)
+	}
+
+	var content strings.Builder
+	for _, direction := range ri.ArtDirection {
+		sourceAttrs := map[string]string{
+			"media":  direction.Media,
+			"type":   direction.Type,
+			"srcset": imageSrcset(ctx, direction.Sources),
+			"sizes":  direction.Sizes,
+		}
+		content.WriteString(htmlRenderer.RenderElement("source", sourceAttrs, "", true))
+	}
+
+	imgAttrs["class"] = "godin-responsive-image-img"
+	styles = append(styles, "display: block", "width: 100%", "height: auto")
+	imgAttrs["style"] = strings.Join(styles, "; ")
+	content.WriteString(htmlRenderer.RenderElement("img", imgAttrs, "", true))
+
+	attrs := buildAttributes(ri.ID, ri.Style, ri.Class+" godin-image godin-responsive-image")
+	return htmlRenderer.RenderElement("picture", attrs, content.String(), false)
+}
+
+// imageSrcset builds a srcset attribute from image sources
+func imageSrcset(ctx *core.Context, sources []ImageSource) string {
+	candidates := make([]string, 0, len(sources))
+	for _, source := range sources {
+		if source.Src == "" {
+			continue
+		}
+
+		candidate := imageSourceURL(ctx, source.Src)
+		if source.Width > 0 {
+			candidate += fmt.Sprintf(" %dw", source.Width)
+		} else if source.Density > 0 {
+			candidate += " " + strconv.FormatFloat(source.Density, 'f', -1, 64) + "x"
+		}
+		candidates = append(candidates, candidate)
+	}
+	return strings.Join(candidates, ", ")
+}
+
+// imageSourceURL resolves a static asset path to its fingerprinted URL and leaves absolute URLs as they are
+func imageSourceURL(ctx *core.Context, src string) string {
+	if src == "" || ctx == nil || ctx.App == nil {
+		return src
+	}
+	if strings.Contains(src, "://") || strings.HasPrefix(src, "//") || strings.HasPrefix(src, "data:") {
+		return src
+	}
+	return ctx.App.AssetURL(src)
+}
+
 // Icon represents an icon widget with full Flutter properties
 type Icon struct {
 	ID             string
@@ -960,7 +1115,7 @@ func (ad AlertDialog) Render(ctx *core.Context) string {
 
 	// Add inset padding
 	if ad.InsetPadding != nil {
-		styles = append(styles, fmt.Sprintf("margin: %s", ad.InsetPadding.ToCSSString()))
+		styles = append(styles, ad.InsetPadding.ToCSSDeclaration("margin"))
 	} else {
 		styles = append(styles, "margin: 40px")
 	}
@@ -990,7 +1145,7 @@ func (ad AlertDialog) Render(ctx *core.Context) string {
 
 		var titleStyles []string
 		if ad.TitlePadding != nil {
-			titleStyles = append(titleStyles, fmt.Sprintf("padding: %s", ad.TitlePadding.ToCSSString()))
+			titleStyles = append(titleStyles, ad.TitlePadding.ToCSSDeclaration("padding"))
 		} else {
 			titleStyles = append(titleStyles, "padding: 24px 24px 20px 24px")
 		}
@@ -1023,7 +1178,7 @@ func (ad AlertDialog) Render(ctx *core.Context) string {
 
 		var contentStyles []string
 		if ad.ContentPadding != nil {
-			contentStyles = append(contentStyles, fmt.Sprintf("padding: %s", ad.ContentPadding.ToCSSString()))
+			contentStyles = append(contentStyles, ad.ContentPadding.ToCSSDeclaration("padding"))
 		} else {
 			contentStyles = append(contentStyles, "padding: 0 24px 24px 24px")
 		}
@@ -1056,7 +1211,7 @@ func (ad AlertDialog) Render(ctx *core.Context) string {
 		actionsStyles = append(actionsStyles, "gap: 8px")
 
 		if ad.ActionsPadding != nil {
-			actionsStyles = append(actionsStyles, fmt.Sprintf("padding: %s", ad.ActionsPadding.ToCSSString()))
+			actionsStyles = append(actionsStyles, ad.ActionsPadding.ToCSSDeclaration("padding"))
 		} else {
 			actionsStyles = append(actionsStyles, "padding: 8px")
 		}
@@ -1183,7 +1338,7 @@ func (sd SimpleDialog) Render(ctx *core.Context) string {
 
 	// Add inset padding
 	if sd.InsetPadding != nil {
-		styles = append(styles, fmt.Sprintf("margin: %s", sd.InsetPadding.ToCSSString()))
+		styles = append(styles, sd.InsetPadding.ToCSSDeclaration("margin"))
 	} else {
 		styles = append(styles, "margin: 40px")
 	}
@@ -1208,7 +1363,7 @@ func (sd SimpleDialog) Render(ctx *core.Context) string {
 
 		var titleStyles []string
 		if sd.TitlePadding != nil {
-			titleStyles = append(titleStyles, fmt.Sprintf("padding: %s", sd.TitlePadding.ToCSSString()))
+			titleStyles = append(titleStyles, sd.TitlePadding.ToCSSDeclaration("padding"))
 		} else {
 			titleStyles = append(titleStyles, "padding: 24px 24px 20px 24px")
 		}
@@ -1241,7 +1396,7 @@ func (sd SimpleDialog) Render(ctx *core.Context) string {
 
 		var contentStyles []string
 		if sd.ContentPadding != nil {
-			contentStyles = append(contentStyles, fmt.Sprintf("padding: %s", sd.ContentPadding.ToCSSString()))
+			contentStyles = append(contentStyles, sd.ContentPadding.ToCSSDeclaration("padding"))
 		} else {
 			contentStyles = append(contentStyles, "padding: 0 24px 24px 24px")
 		}
@@ -1253,6 +1408,7 @@ func (sd SimpleDialog) Render(ctx *core.Context) string {
 		var childElements []string
 		for _, child := range sd.Children {
 			if child != nil {
+				ctx.TrackRender(1)
 				childElements = append(childElements, child.Render(ctx))
 			}
 		}
@@ -1379,12 +1535,12 @@ func (sb SnackBar) Render(ctx *core.Context) string {
 
 	// Add margin
 	if sb.Margin != nil {
-		styles = append(styles, fmt.Sprintf("margin: %s", sb.Margin.ToCSSString()))
+		styles = append(styles, sb.Margin.ToCSSDeclaration("margin"))
 	}
 
 	// Add padding
 	if sb.Padding != nil {
-		styles = append(styles, fmt.Sprintf("padding: %s", sb.Padding.ToCSSString()))
+		styles = append(styles, sb.Padding.ToCSSDeclaration("padding"))
 	} else {
 		styles = append(styles, "padding: 14px 16px")
 	}
@@ -1454,7 +1610,7 @@ func (sb SnackBar) Render(ctx *core.Context) string {
 				sb.Action.OnPressed()
 				return nil
 			})
-			actionAttrs["hx-post"] = "/handlers/" + handlerID
+			actionAttrs["hx-post"] = appPath(ctx, "/handlers/"+handlerID)
 			actionAttrs["hx-trigger"] = "click"
 		}
 
@@ -1490,3 +1646,151 @@ func (sb SnackBar) Render(ctx *core.Context) string {
 
 	return htmlRenderer.RenderElement("div", attrs, content, false)
 }
+
+// MaterialBanner represents a persistent message bar shown at the top of a page
+type MaterialBanner struct {
+	ID               string
+	Style            string
+	Class            string
+	Content          Widget       // Message content
+	Leading          Widget       // Leading widget (usually an icon)
+	Actions          []Widget     // Action widgets
+	BackgroundColor  Color        // Background color
+	Dismissible      bool         // Show a dismiss button
+	DismissLabel     string       // Dismiss button label (defaults to "Dismiss")
+	OnDismissed      VoidCallback // Called on the server when the banner is dismissed
+	DismissCookie    string       // Cookie set on dismiss; the banner is not rendered while it is present
+	DismissCookieAge int          // Cookie max-age in seconds (defaults to one year)
+}
+
+// Render renders the material banner as HTML
+func (mb MaterialBanner) Render(ctx *core.Context) string {
+	// Respect a previous dismissal remembered by cookie
+	if mb.DismissCookie != "" && ctx != nil && ctx.Request != nil {
+		if _, err := ctx.Request.Cookie(mb.DismissCookie); err == nil {
+			return ""
+		}
+	}
+
+	htmlRenderer := renderer.NewHTMLRenderer()
+
+	attrs := buildAttributes(mb.ID, mb.Style, mb.Class+" godin-material-banner")
+	attrs["role"] = "status"
+
+	var styles []string
+	if mb.Style != "" {
+		styles = append(styles, mb.Style)
+	}
+	styles = append(styles, "display: flex")
+	styles = append(styles, "align-items: center")
+	styles = append(styles, "gap: 16px")
+	styles = append(styles, "padding: 12px 16px")
+	styles = append(styles, "border-bottom: 1px solid rgba(0, 0, 0, 0.12)")
+	if mb.BackgroundColor != "" {
+		styles = append(styles, fmt.Sprintf("background-color: %s", mb.BackgroundColor))
+	} else if theme := ctx.Theme(); theme != nil && theme.ColorScheme != nil {
+		styles = append(styles, fmt.Sprintf("background-color: %s", theme.ColorScheme.Surface.ToRGBA()))
+	}
+	attrs["style"] = strings.Join(styles, "; ")
+
+	var content string
+
+	if mb.Leading != nil {
+		leadingAttrs := map[string]string{"class": "godin-material-banner-leading", "style": "flex-shrink: 0"}
+		content += htmlRenderer.RenderElement("div", leadingAttrs, mb.Leading.Render(ctx), false)
+	}
+
+	if mb.Content != nil {
+		contentAttrs := map[string]string{"class": "godin-material-banner-content", "style": "flex: 1"}
+		content += htmlRenderer.RenderElement("div", contentAttrs, mb.Content.Render(ctx), false)
+	}
+
+	var actions []string
+	for _, action := range mb.Actions {
+		if action != nil {
+			actions = append(actions, action.Render(ctx))
+		}
+	}
+
+	if mb.Dismissible {
+		actions = append(actions, mb.renderDismissButton(ctx, htmlRenderer))
+	}
+
+	if len(actions) > 0 {
+		actionsAttrs := map[string]string{
+			"class": "godin-material-banner-actions",
+			"style": "display: flex; gap: 8px; flex-shrink: 0",
+		}
+		content += htmlRenderer.RenderElement("div", actionsAttrs, strings.Join(actions, ""), false)
+	}
+
+	return htmlRenderer.RenderElement("div", attrs, content, false)
+}
+
+// renderDismissButton renders the button that hides the banner, remembers the dismissal and notifies the server
+func (mb MaterialBanner) renderDismissButton(ctx *core.Context, htmlRenderer *renderer.HTMLRenderer) string {
+	label := mb.DismissLabel
+	if label == "" {
+		label = "Dismiss"
+	}
+
+	var script []string
+	if mb.DismissCookie != "" {
+		maxAge := mb.DismissCookieAge
+		if maxAge <= 0 {
+			maxAge = 365 * 24 * 60 * 60
+		}
+		script = append(script, fmt.Sprintf("document.cookie='%s=1; path=/; max-age=%d; SameSite=Lax'", mb.DismissCookie, maxAge))
+	}
+
+	if mb.OnDismissed != nil && ctx != nil && ctx.App != nil {
+		callbackID := ctx.App.RegisterCallback(mb.ID, "MaterialBanner", "OnDismissed", func() {
+			mb.OnDismissed()
+		}, ctx)
+		if callbackID != "" {
+			script = append(script, fmt.Sprintf("handleWidgetCallback('%s', event)", appPath(ctx, "/api/callbacks/"+callbackID)))
+		}
+	}
+
+	script = append(script, "this.closest('.godin-material-banner').remove()")
+
+	buttonAttrs := map[string]string{
+		"type":    "button",
+		"class":   "godin-material-banner-dismiss",
+		"style":   "padding: 8px 12px; border: none; background: transparent; cursor: pointer; font-weight: 500",
+		"onclick": strings.Join(script, ";"),
+	}
+
+	return htmlRenderer.RenderElement("button", buttonAttrs, htmlRenderer.RenderText(label), false)
+}
+
+// FlashMessages renders the flash messages stored by the previous request with
+// ctx.Flash as banners. It marks the page's flash container, so messages rendered
+// here are not repeated as snackbars.
+type FlashMessages struct {
+	ID    string
+	Style string
+	Class string
+}
+
+// Render renders the flash messages as HTML
+func (fm FlashMessages) Render(ctx *core.Context) string {
+	htmlRenderer := renderer.NewHTMLRenderer()
+
+	attrs := buildAttributes(fm.ID, fm.Style, fm.Class+" godin-flash-messages")
+	attrs["data-flash-container"] = "true"
+	attrs["aria-live"] = "polite"
+
+	var content strings.Builder
+	if ctx != nil {
+		for _, flash := range ctx.Flashes() {
+			content.WriteString(htmlRenderer.RenderElement("div", map[string]string{
+				"class": "godin-flash godin-flash-" + flash.Category,
+				"role":  "status",
+			}, htmlRenderer.RenderText(flash.Message), false))
+		}
+		ctx.MarkFlashesRendered()
+	}
+
+	return htmlRenderer.RenderElement("div", attrs, content.String(), false)
+}
@@ -0,0 +1,160 @@
+package widgets
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/gideonsigilai/godin/pkg/core"
+	"github.com/gideonsigilai/godin/pkg/renderer"
+)
+
+// DropdownMenuItem is one choice of a DropdownButton[T]
+type DropdownMenuItem[T comparable] struct {
+	Value   T      // Value passed to OnChanged when the item is picked
+	Child   Widget // Shown in the menu, and in the button while selected
+	Enabled *bool  // Enabled state; nil means enabled
+}
+
+// DropdownButton shows the selected item in a button that opens a menu of the
+// others. Items hold typed values, which stay on the server: picking an item
+// calls OnChanged with its value, never a string posted by the browser.
+//
+//	widgets.DropdownButton[Priority]{
+//		Value: task.Priority,
+//		Items: []widgets.DropdownMenuItem[Priority]{
+//			{Value: PriorityLow, Child: widgets.Text{Data: "Low"}},
+//			{Value: PriorityHigh, Child: widgets.Text{Data: "High"}},
+//		},
+//		OnChanged: func(priority Priority) { task.Priority = priority },
+//	}
+type DropdownButton[T comparable] struct {
+	ID         string
+	Style      string
+	Class      string
+	Value      T                     // Selected value; the item with this value is shown in the button
+	Items      []DropdownMenuItem[T] // Choices in the menu
+	OnChanged  ValueChanged[T]       // Called with the value of the item picked
+	Hint       Widget                // Shown while no item has Value
+	Enabled    *bool                 // Enabled state; nil means enabled when OnChanged is set
+	Name       string                // Form field name; submits the selected value formatted with fmt
+	IsExpanded bool                  // Fill the width of the parent
+	Semantics  string                // Accessible name of the button
+}
+
+// Render renders the dropdown button as HTML
+func (db DropdownButton[T]) Render(ctx *core.Context) string {
+	htmlRenderer := renderer.NewHTMLRenderer()
+
+	id := db.ID
+	if id == "" {
+		id = generateWidgetID()
+	}
+	listboxID := id + "_menu"
+
+	enabled := isEnabled(db.Enabled, db.OnChanged != nil)
+
+	selected := -1
+	for i, item := range db.Items {
+		if item.Value == db.Value {
+			selected = i
+			break
+		}
+	}
+
+	class := db.Class + " godin-dropdown-button"
+	if db.IsExpanded {
+		class += " godin-dropdown-button-expanded"
+	}
+	attrs := buildAttributes(id, db.Style, class)
+	attrs["data-dropdown-button"] = "true"
+
+	// The button shows the selected item, or the hint until one is picked
+	label := ""
+	if selected >= 0 && db.Items[selected].Child != nil {
+		label = db.Items[selected].Child.Render(ctx)
+	} else if db.Hint != nil {
+		label = htmlRenderer.RenderElement("span", map[string]string{"class": "godin-dropdown-button-hint"}, db.Hint.Render(ctx), false)
+	}
+
+	triggerAttrs := map[string]string{
+		"type":          "button",
+		"class":         "godin-dropdown-button-trigger",
+		"aria-haspopup": "listbox",
+		"aria-expanded": "false",
+		"aria-controls": listboxID,
+	}
+	if db.Semantics != "" {
+		triggerAttrs["aria-label"] = db.Semantics
+	}
+	if !enabled {
+		applyDisabled(triggerAttrs, true)
+	}
+	content := htmlRenderer.RenderElement("button", triggerAttrs,
+		htmlRenderer.RenderElement("span", map[string]string{"class": "godin-dropdown-button-label"}, label, false)+
+			Icon{Icon: IconKeyboardArrowDown, Class: "godin-dropdown-button-arrow"}.Render(ctx), false)
+
+	if db.Name != "" {
+		value := ""
+		if selected >= 0 {
+			value = fmt.Sprint(db.Value)
+		}
+		content += htmlRenderer.RenderElement("input", map[string]string{
+			"type":  "hidden",
+			"name":  db.Name,
+			"value": value,
+			"class": "godin-dropdown-button-value",
+		}, "", true)
+	}
+
+	items := ""
+	for i, item := range db.Items {
+		ctx.TrackRender(1)
+		items += db.renderItem(ctx, id, i, item, i == selected, enabled)
+	}
+	content += htmlRenderer.RenderElement("ul", map[string]string{
+		"id":     listboxID,
+		"class":  "godin-dropdown-button-menu",
+		"role":   "listbox",
+		"hidden": "hidden",
+	}, items, false)
+
+	return htmlRenderer.RenderElement("div", attrs, content, false)
+}
+
+// renderItem renders one menu item; picking it runs a handler that calls
+// OnChanged with the item's typed value
+func (db DropdownButton[T]) renderItem(ctx *core.Context, id string, index int, item DropdownMenuItem[T], selected, enabled bool) string {
+	htmlRenderer := renderer.NewHTMLRenderer()
+
+	attrs := map[string]string{
+		"id":            fmt.Sprintf("%s_item_%d", id, index),
+		"class":         "godin-dropdown-button-item",
+		"role":          "option",
+		"tabindex":      "-1",
+		"aria-selected": strconv.FormatBool(selected),
+	}
+	if db.Name != "" {
+		attrs["data-value"] = fmt.Sprint(item.Value)
+	}
+
+	itemEnabled := enabled && isEnabled(item.Enabled, true)
+	if !itemEnabled {
+		attrs["aria-disabled"] = "true"
+	} else if db.OnChanged != nil && ctx != nil && ctx.App != nil {
+		onChanged, value := db.OnChanged, item.Value
+		handlerID := ctx.RegisterHandler(func(ctx *core.Context) Widget {
+			onChanged(value)
+			ctx.SetHeader(renderer.HXReswap, "none")
+			return nil
+		})
+		attrs["hx-post"] = appPath(ctx, "/handlers/"+handlerID)
+		attrs["hx-trigger"] = "godin:select"
+		attrs["hx-swap"] = "none"
+	}
+
+	content := ""
+	if item.Child != nil {
+		content = item.Child.Render(ctx)
+	}
+	return htmlRenderer.RenderElement("li", attrs, content, false)
+}
@@ -0,0 +1,29 @@
+package widgets
+
+// Every interactive widget decides whether it is enabled the same way: it is
+// enabled when it has a callback to run and its Enabled field is nil or true.
+// Widgets that are useful without a callback, such as text fields, pass true
+// for hasCallback so only Enabled disables them.
+//
+// A disabled widget keeps its colors and is dimmed with the godin-disabled
+// class, carries aria-disabled for assistive technology, and registers none of
+// its callbacks, so rendering it never leaves handlers behind.
+
+// isEnabled reports whether a widget with the given Enabled field and callbacks is enabled
+func isEnabled(enabled *bool, hasCallback bool) bool {
+	return hasCallback && (enabled == nil || *enabled)
+}
+
+// applyDisabled marks an element disabled. Native form controls also get the
+// disabled attribute; other elements rely on aria-disabled and the class.
+func applyDisabled(attrs map[string]string, native bool) {
+	if native {
+		attrs["disabled"] = "disabled"
+	}
+	attrs["aria-disabled"] = "true"
+	if attrs["class"] != "" {
+		attrs["class"] += " godin-disabled"
+	} else {
+		attrs["class"] = "godin-disabled"
+	}
+}
@@ -0,0 +1,84 @@
+package widgets
+
+import (
+	"sync"
+
+	"github.com/gideonsigilai/godin/pkg/core"
+)
+
+// FocusNode moves keyboard focus to the widget it is attached to. Pass the same
+// node to the widget on every render; after RequestFocus, the widget takes focus
+// the next time it is rendered, e.g. in the fragment an action returns.
+//
+// A node is plain shared memory: one stored in a package-level variable is used
+// by every user's page, so a RequestFocus from one user's action focuses the
+// field on whichever page renders next. Keep per-user nodes in the session (or
+// in a StatefulWidget's state), or use ctx.RequestFocus, which only affects the
+// current response.
+type FocusNode struct {
+	HasFocus  bool
+	elementID string
+	requested bool
+	mutex     sync.Mutex
+}
+
+// NewFocusNode creates a focus node
+func NewFocusNode() *FocusNode {
+	return &FocusNode{}
+}
+
+// RequestFocus focuses the attached widget when it is next rendered
+func (f *FocusNode) RequestFocus() {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	f.requested = true
+}
+
+// Unfocus cancels a pending RequestFocus
+func (f *FocusNode) Unfocus() {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	f.requested = false
+	f.HasFocus = false
+}
+
+// ElementID returns the ID of the element the node was last attached to, for ctx.RequestFocus
+func (f *FocusNode) ElementID() string {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	return f.elementID
+}
+
+// attach ties the node to an element, giving it a stable ID, and marks the
+// element for focusing when a request is pending
+func (f *FocusNode) attach(attrs map[string]string) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if attrs["id"] == "" {
+		if f.elementID == "" {
+			f.elementID = generateWidgetID()
+		}
+		attrs["id"] = f.elementID
+	}
+	f.elementID = attrs["id"]
+
+	if f.requested {
+		attrs["data-request-focus"] = "true"
+		f.requested = false
+		f.HasFocus = true
+	}
+}
+
+// applyFocus adds the focus attributes of a widget's AutoFocus and FocusNode fields
+// to its element; every focusable widget emits them through here. Only the first
+// AutoFocus widget of a request gets the autofocus attribute, so focus lands
+// predictably when several fields ask for it.
+func applyFocus(ctx *core.Context, attrs map[string]string, autoFocus bool, node *FocusNode) {
+	if autoFocus && ctx.ClaimAutofocus() {
+		attrs["autofocus"] = "true"
+	}
+	if node != nil {
+		node.attach(attrs)
+	}
+}
@@ -0,0 +1,248 @@
+package widgets
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gideonsigilai/godin/pkg/core"
+	"github.com/gideonsigilai/godin/pkg/renderer"
+)
+
+// formModelKey is the context key under which Form shares its model values
+const formModelKey = "godin.formModel"
+
+// defaultAutoSaveInterval is how often an autosaving form saves its draft, in milliseconds
+const defaultAutoSaveInterval = 3000
+
+// Form groups form fields and prefills them from a bound model. Each exported
+// field of Model is matched to a descendant field by its `form:"..."` tag, or
+// by the Go field name when there is no tag, mirroring how ctx.BindQuery
+// matches `query:"..."` tags.
+//
+// With an AutoSaveKey, the form saves what the user has typed as a draft while
+// they edit and restores it when they come back, so a crash or an accidental
+// navigation does not lose their work. Call ctx.DiscardDraft(key) once the
+// submission has been saved.
+type Form struct {
+	ID                 string
+	Style              string
+	Class              string
+	Action             string                // URL the form submits to
+	Method             string                // HTTP method (default "post")
+	Model              interface{}           // Struct (or pointer to struct) whose values prefill the fields
+	Child              Widget                // Subtree containing the form fields
+	AutoSaveKey        string                // Enables draft autosave, e.g. "post-editor" or "post-editor-42"
+	AutoSaveIntervalMs int                   // How often changes are saved (default 3000)
+	OnFormChanged      func(FormChangeEvent) // Called when a field changes, with the values of every field
+}
+
+// FormChangeEvent describes a change to one field of a Form along with the
+// current values of all its fields, for checks spanning fields such as a
+// confirm-password field matching the password
+type FormChangeEvent struct {
+	FieldID   string            // ID of the field that changed, if it has one
+	FieldName string            // Name of the field that changed
+	OldValue  string            // The field's value at the previous change, or its model value
+	NewValue  string            // The field's new value
+	Values    map[string]string // Current value of every named field (the first, for fields with several)
+}
+
+// Form values godin.js adds to a Form's change requests to say which field changed
+const (
+	formChangedNameParam = "godin_changed_name"
+	formChangedIDParam   = "godin_changed_id"
+)
+
+// Render renders the form as HTML
+func (f Form) Render(ctx *core.Context) string {
+	htmlRenderer := renderer.NewHTMLRenderer()
+
+	attrs := buildAttributes(f.ID, f.Style, f.Class+" godin-form")
+
+	method := f.Method
+	if method == "" {
+		method = "post"
+	}
+	attrs["method"] = strings.ToLower(method)
+	if f.Action != "" {
+		attrs["action"] = f.Action
+	}
+	if f.AutoSaveKey != "" {
+		applyAutoSave(ctx, attrs, f.AutoSaveKey, f.AutoSaveIntervalMs)
+	}
+	if f.OnFormChanged != nil && ctx != nil && ctx.App != nil {
+		applyFormChanges(ctx, attrs, f.Model, f.OnFormChanged)
+	}
+
+	// Share the model values with descendant fields
+	content := ""
+	if f.Child != nil {
+		previous := ctx.Get(formModelKey)
+		ctx.Set(formModelKey, formModelValues(f.Model))
+		content = f.Child.Render(ctx)
+		ctx.Set(formModelKey, previous)
+	}
+
+	return htmlRenderer.RenderElement("form", attrs, content, false)
+}
+
+// applyAutoSave adds the attributes godin.js uses to save the form's draft
+// periodically and to restore a saved draft into its fields
+func applyAutoSave(ctx *core.Context, attrs map[string]string, key string, intervalMs int) {
+	if intervalMs <= 0 {
+		intervalMs = defaultAutoSaveInterval
+	}
+	attrs["data-autosave-url"] = appPath(ctx, "/api/drafts/"+url.PathEscape(key))
+	attrs["data-autosave-interval"] = fmt.Sprintf("%d", intervalMs)
+
+	if draft := ctx.Draft(key); len(draft) > 0 {
+		if data, err := json.Marshal(draft); err == nil {
+			attrs["data-autosave-draft"] = string(data)
+		}
+	}
+}
+
+// applyFormChanges posts the form's values to a handler calling onChanged
+// whenever one of its fields changes. The handler remembers the values of the
+// previous change, starting from the model's, to report each field's old value.
+func applyFormChanges(ctx *core.Context, attrs map[string]string, model interface{}, onChanged func(FormChangeEvent)) {
+	var mutex sync.Mutex
+	previous := formModelValues(model)
+
+	handlerID := ctx.RegisterHandler(func(ctx *core.Context) Widget {
+		ctx.SetHeader(renderer.HXReswap, "none")
+		if err := ctx.Request.ParseForm(); err != nil {
+			return nil
+		}
+
+		values := make(map[string]string)
+		for name, fieldValues := range ctx.Request.PostForm {
+			if name == formChangedNameParam || name == formChangedIDParam || name == "csrf_token" || len(fieldValues) == 0 {
+				continue
+			}
+			values[name] = fieldValues[0]
+		}
+
+		name := ctx.Request.PostForm.Get(formChangedNameParam)
+		mutex.Lock()
+		event := FormChangeEvent{
+			FieldID:   ctx.Request.PostForm.Get(formChangedIDParam),
+			FieldName: name,
+			OldValue:  previous[name],
+			NewValue:  values[name],
+			Values:    values,
+		}
+		previous = make(map[string]string, len(values))
+		for key, value := range values {
+			previous[key] = value
+		}
+		mutex.Unlock()
+
+		onChanged(event)
+		return nil
+	})
+
+	// hx-disinherit keeps the fields' own handlers from inheriting hx-swap and the rest
+	attrs["hx-post"] = appPath(ctx, "/handlers/"+handlerID)
+	attrs["hx-trigger"] = "change"
+	attrs["hx-swap"] = "none"
+	attrs["hx-disinherit"] = "*"
+	attrs["data-form-changes"] = "true"
+}
+
+// formModelValue returns the enclosing Form's model value for a field name
+func formModelValue(ctx *core.Context, name string) (string, bool) {
+	if ctx == nil || name == "" {
+		return "", false
+	}
+	values, _ := ctx.Get(formModelKey).(map[string]string)
+	value, exists := values[name]
+	return value, exists
+}
+
+// formModelValues flattens a model struct into form field values keyed by field name
+func formModelValues(model interface{}) map[string]string {
+	values := make(map[string]string)
+
+	rv := reflect.ValueOf(model)
+	for rv.Kind() == reflect.Ptr || rv.Kind() == reflect.Interface {
+		if rv.IsNil() {
+			return values
+		}
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Struct {
+		return values
+	}
+
+	collectFormModelValues(rv, values)
+	return values
+}
+
+// collectFormModelValues adds the fields of a struct value to values
+func collectFormModelValues(rv reflect.Value, values map[string]string) {
+	rt := rv.Type()
+	for i := 0; i < rt.NumField(); i++ {
+		field := rt.Field(i)
+		fieldValue := rv.Field(i)
+
+		// Recurse into embedded structs so shared model structs can be reused
+		if field.Anonymous && fieldValue.Kind() == reflect.Struct {
+			collectFormModelValues(fieldValue, values)
+			continue
+		}
+		if !field.IsExported() {
+			continue
+		}
+
+		name := field.Tag.Get("form")
+		if name == "-" {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		name = strings.Split(name, ",")[0]
+
+		if value, ok := formatFormValue(fieldValue); ok {
+			values[name] = value
+		}
+	}
+}
+
+// formatFormValue converts a model field into the string an input element expects
+func formatFormValue(value reflect.Value) (string, bool) {
+	if value.Kind() == reflect.Ptr {
+		if value.IsNil() {
+			return "", false
+		}
+		value = value.Elem()
+	}
+
+	if value.Type() == reflect.TypeOf(time.Duration(0)) {
+		return time.Duration(value.Int()).String(), true
+	}
+
+	if value.Type() == reflect.TypeOf(time.Time{}) {
+		t := value.Interface().(time.Time)
+		if t.IsZero() {
+			return "", false
+		}
+		// Plain dates match what <input type="date"> expects
+		return t.Format("2006-01-02"), true
+	}
+
+	switch value.Kind() {
+	case reflect.String, reflect.Bool,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return fmt.Sprintf("%v", value.Interface()), true
+	}
+	return "", false
+}
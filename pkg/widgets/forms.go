@@ -2,6 +2,9 @@ package widgets
 
 import (
 	"fmt"
+	"math"
+	"net/url"
+	"strconv"
 	"strings"
 
 	"github.com/gideonsigilai/godin/pkg/core"
@@ -42,7 +45,10 @@ type TextField struct {
 	MaxLengthEnforcement          MaxLengthEnforcement                                                                  // Max length enforcement
 	OnChanged                     ValueChanged[string]                                                                  // On changed callback
 	OnEditingComplete             VoidCallback                                                                          // On editing complete callback
-	OnSubmitted                   ValueChanged[string]                                                                  // On submitted callback
+	OnSubmitted                   ValueChanged[string]                                                                  // On submitted callback, run when Enter is pressed
+	OnSubmittedBuilder            func(ctx *core.Context, value string) Widget                                          // Runs on Enter and returns a widget that replaces SubmitTarget
+	SubmitTarget                  string                                                                                // CSS selector replaced by OnSubmittedBuilder's widget (defaults to the field)
+	ClearOnSubmit                 bool                                                                                  // Clears the field after it is submitted
 	OnAppPrivateCommand           func(string, map[string]interface{})                                                  // On app private command
 	InputFormatters               []TextInputFormatter                                                                  // Input formatters
 	Enabled                       *bool                                                                                 // Enabled
@@ -63,7 +69,7 @@ type TextField struct {
 	ScrollController              *ScrollController                                                                     // Scroll controller
 	ScrollPhysics                 ScrollPhysics                                                                         // Scroll physics
 	AutoFillHints                 []AutoFillHint                                                                        // Auto fill hints
-	RestorationID                 string                                                                                // Restoration ID
+	RestorationID                 string                                                                                // Saves the value per session and restores it when the field is rendered again
 	EnableIMEPersonalizedLearning bool                                                                                  // Enable IME personalized learning
 }
 
@@ -115,13 +121,16 @@ func (tf TextField) Render(ctx *core.Context) string {
 		attrs["type"] = inputType
 	}
 
-	// Add value from controller or direct value
-	if tf.Controller != nil && tf.Controller.Text() != "" {
-		if isTextarea {
-			// For textarea, content goes inside the element
-		} else {
-			attrs["value"] = tf.Controller.Text()
-		}
+	// Add value from controller or direct value; a restored value wins over both
+	value := ""
+	if tf.Controller != nil {
+		value = tf.Controller.Text()
+	}
+	if restored, exists := applyRestoration(ctx, attrs, tf.RestorationID, tf.ObscureText); exists {
+		value = restored
+	}
+	if value != "" && !isTextarea {
+		attrs["value"] = value
 	}
 
 	// Add decoration properties
@@ -132,7 +141,7 @@ func (tf TextField) Render(ctx *core.Context) string {
 
 		// Add padding from decoration
 		if tf.Decoration.ContentPadding != nil {
-			styles = append(styles, fmt.Sprintf("padding: %s", tf.Decoration.ContentPadding.ToCSSString()))
+			styles = append(styles, tf.Decoration.ContentPadding.ToCSSDeclaration("padding"))
 		}
 
 		// Add border styling
@@ -146,14 +155,10 @@ func (tf TextField) Render(ctx *core.Context) string {
 		}
 	}
 
-	// Handle enabled/disabled state
-	enabled := true
-	if tf.Enabled != nil {
-		enabled = *tf.Enabled
-	}
-	if !enabled || tf.ReadOnly {
-		attrs["disabled"] = "true"
-		styles = append(styles, "opacity: 0.6")
+	// Handle enabled/disabled state; fields work without callbacks, so only Enabled disables them
+	enabled := isEnabled(tf.Enabled, true) && !tf.ReadOnly
+	if !enabled {
+		applyDisabled(attrs, true)
 	}
 
 	if tf.ReadOnly {
@@ -165,6 +170,11 @@ func (tf TextField) Render(ctx *core.Context) string {
 		attrs["maxlength"] = fmt.Sprintf("%d", *tf.MaxLength)
 	}
 
+	// Apply input formatters in the browser as the user types
+	if spec := inputFormattersAttribute(tf.InputFormatters); spec != "" {
+		attrs["data-input-formatters"] = spec
+	}
+
 	// Handle obscure text (password)
 	if tf.ObscureText && !isTextarea {
 		attrs["type"] = "password"
@@ -175,10 +185,7 @@ func (tf TextField) Render(ctx *core.Context) string {
 		attrs["autocomplete"] = string(tf.AutoFillHints[0])
 	}
 
-	// Add autofocus
-	if tf.AutoFocus {
-		attrs["autofocus"] = "true"
-	}
+	applyFocus(ctx, attrs, tf.AutoFocus, tf.FocusNode)
 
 	// Handle text capitalization
 	if tf.TextCapitalization != "" {
@@ -211,23 +218,25 @@ func (tf TextField) Render(ctx *core.Context) string {
 		tf.InteractiveWidget.SetWidgetType("TextField")
 	}
 
-	// Register callbacks if provided
-	if tf.OnChanged != nil {
-		tf.InteractiveWidget.RegisterCallback("OnChanged", tf.OnChanged)
+	// Register callbacks only while enabled
+	if enabled && tf.OnChanged != nil {
+		tf.InteractiveWidget.RegisterCallback("OnChanged", formattedValueChanged(tf.InputFormatters, tf.OnChanged))
 	}
-	if tf.OnSubmitted != nil {
-		tf.InteractiveWidget.RegisterCallback("OnSubmitted", tf.OnSubmitted)
-	}
-	if tf.OnEditingComplete != nil {
+	if enabled && tf.OnEditingComplete != nil {
 		tf.InteractiveWidget.RegisterCallback("OnEditingComplete", tf.OnEditingComplete)
 	}
-	if tf.OnTap != nil {
+	if enabled && tf.OnTap != nil {
 		tf.InteractiveWidget.RegisterCallback("OnTap", tf.OnTap)
 	}
 
 	// Merge with interactive widget attributes (HTMX, event handlers, etc.)
 	attrs = tf.InteractiveWidget.MergeAttributes(attrs)
 
+	// Post the value to a handler when Enter is pressed
+	if enabled {
+		applySubmitHandler(ctx, attrs, tf.InputFormatters, tf.OnSubmitted, tf.OnSubmittedBuilder, tf.SubmitTarget, tf.ClearOnSubmit)
+	}
+
 	// Combine all styles
 	if len(styles) > 0 {
 		attrs["style"] = strings.Join(styles, "; ")
@@ -235,22 +244,64 @@ func (tf TextField) Render(ctx *core.Context) string {
 
 	// Render the appropriate element
 	if isTextarea {
-		content := ""
-		if tf.Controller != nil {
-			content = tf.Controller.Text()
-		}
-		return htmlRenderer.RenderElement("textarea", attrs, content, false)
+		return htmlRenderer.RenderElement("textarea", attrs, htmlRenderer.RenderText(value), false)
 	} else {
 		return htmlRenderer.RenderElement("input", attrs, "", true)
 	}
 }
 
+// applySubmitHandler registers a handler that runs onSubmitted and builder with the
+// submitted value, and marks the field so godin.js posts to it when Enter is pressed.
+// The builder's widget replaces target (or the field); without one nothing is swapped.
+func applySubmitHandler(ctx *core.Context, attrs map[string]string, formatters []TextInputFormatter, onSubmitted ValueChanged[string], builder func(ctx *core.Context, value string) Widget, target string, clear bool) {
+	if (onSubmitted == nil && builder == nil) || ctx == nil || ctx.App == nil {
+		return
+	}
+
+	handlerID := ctx.RegisterHandler(func(ctx *core.Context) Widget {
+		value := ApplyInputFormatters(formatters, "", ctx.FormValue("value"))
+		if onSubmitted != nil {
+			onSubmitted(value)
+		}
+
+		var widget Widget
+		if builder != nil {
+			widget = builder(ctx, value)
+		}
+		if widget == nil {
+			ctx.SetHeader(renderer.HXReswap, "none")
+		}
+		return widget
+	})
+
+	attrs["data-submit-url"] = appPath(ctx, "/handlers/"+handlerID)
+	if target != "" {
+		attrs["data-submit-target"] = target
+	}
+	if clear {
+		attrs["data-clear-on-submit"] = "true"
+	}
+}
+
+// applyRestoration marks a field with a RestorationID so godin.js saves its value
+// in the session as the user types, and returns the value saved on an earlier
+// visit. Obscured fields are never saved, keeping passwords out of the session.
+func applyRestoration(ctx *core.Context, attrs map[string]string, restorationID string, obscured bool) (string, bool) {
+	if restorationID == "" || obscured || ctx == nil || ctx.App == nil {
+		return "", false
+	}
+
+	attrs["data-restoration-url"] = appPath(ctx, "/api/restoration/"+url.PathEscape(restorationID))
+	return ctx.RestorationValue(restorationID)
+}
+
 // TextFormField represents a text form field widget with full Flutter properties
 type TextFormField struct {
 	InteractiveWidget             // Embed InteractiveWidget for callback support
 	ID                            string
 	Style                         string
 	Class                         string
+	Name                          string                                                                                // Form field name
 	Controller                    *TextEditingController                                                                // Text editing controller
 	InitialValue                  string                                                                                // Initial value
 	FocusNode                     *FocusNode                                                                            // Focus node
@@ -299,7 +350,7 @@ type TextFormField struct {
 	AutoFillHints                 []AutoFillHint                                                                        // Auto fill hints
 	AutovalidateMode              AutovalidateMode                                                                      // Auto validate mode
 	ScrollController              *ScrollController                                                                     // Scroll controller
-	RestorationId                 string                                                                                // Restoration ID
+	RestorationId                 string                                                                                // Saves the value per session and restores it when the field is rendered again
 	EnableIMEPersonalizedLearning *bool                                                                                 // Enable IME personalized learning
 	MouseCursor                   MouseCursor                                                                           // Mouse cursor
 }
@@ -312,6 +363,9 @@ func (tff TextFormField) Render(ctx *core.Context) string {
 	isTextarea := (tff.MaxLines != nil && *tff.MaxLines > 1) || tff.Expands || tff.KeyboardType == TextInputTypeMultiline
 
 	attrs := buildAttributes(tff.ID, tff.Style, tff.Class+" godin-textformfield")
+	if tff.Name != "" {
+		attrs["name"] = tff.Name
+	}
 
 	// Build inline styles
 	var styles []string
@@ -368,7 +422,7 @@ func (tff TextFormField) Render(ctx *core.Context) string {
 
 		// Add padding from decoration
 		if tff.Decoration.ContentPadding != nil {
-			styles = append(styles, fmt.Sprintf("padding: %s", tff.Decoration.ContentPadding.ToCSSString()))
+			styles = append(styles, tff.Decoration.ContentPadding.ToCSSDeclaration("padding"))
 		}
 
 		// Add border styling
@@ -382,14 +436,10 @@ func (tff TextFormField) Render(ctx *core.Context) string {
 		}
 	}
 
-	// Handle enabled/disabled state
-	enabled := true
-	if tff.Enabled != nil {
-		enabled = *tff.Enabled
-	}
-	if !enabled || tff.ReadOnly {
-		attrs["disabled"] = "true"
-		styles = append(styles, "opacity: 0.6")
+	// Handle enabled/disabled state; fields work without callbacks, so only Enabled disables them
+	enabled := isEnabled(tff.Enabled, true) && !tff.ReadOnly
+	if !enabled {
+		applyDisabled(attrs, true)
 	}
 
 	if tff.ReadOnly {
@@ -401,6 +451,11 @@ func (tff TextFormField) Render(ctx *core.Context) string {
 		attrs["maxlength"] = fmt.Sprintf("%d", *tff.MaxLength)
 	}
 
+	// Apply input formatters in the browser as the user types
+	if spec := inputFormattersAttribute(tff.InputFormatters); spec != "" {
+		attrs["data-input-formatters"] = spec
+	}
+
 	// Handle obscure text (password)
 	if tff.ObscureText && !isTextarea {
 		attrs["type"] = "password"
@@ -411,10 +466,7 @@ func (tff TextFormField) Render(ctx *core.Context) string {
 		attrs["autocomplete"] = string(tff.AutoFillHints[0])
 	}
 
-	// Add autofocus
-	if tff.AutoFocus {
-		attrs["autofocus"] = "true"
-	}
+	applyFocus(ctx, attrs, tff.AutoFocus, tff.FocusNode)
 
 	// Handle text capitalization
 	if tff.TextCapitalization != "" {
@@ -452,17 +504,14 @@ func (tff TextFormField) Render(ctx *core.Context) string {
 		tff.InteractiveWidget.SetWidgetType("TextFormField")
 	}
 
-	// Register callbacks if provided
-	if tff.OnChanged != nil {
-		tff.InteractiveWidget.RegisterCallback("OnChanged", tff.OnChanged)
-	}
-	if tff.OnFieldSubmitted != nil {
-		tff.InteractiveWidget.RegisterCallback("OnFieldSubmitted", tff.OnFieldSubmitted)
+	// Register callbacks only while enabled
+	if enabled && tff.OnChanged != nil {
+		tff.InteractiveWidget.RegisterCallback("OnChanged", formattedValueChanged(tff.InputFormatters, tff.OnChanged))
 	}
-	if tff.OnEditingComplete != nil {
+	if enabled && tff.OnEditingComplete != nil {
 		tff.InteractiveWidget.RegisterCallback("OnEditingComplete", tff.OnEditingComplete)
 	}
-	if tff.OnTap != nil {
+	if enabled && tff.OnTap != nil {
 		tff.InteractiveWidget.RegisterCallback("OnTap", tff.OnTap)
 	}
 	if tff.OnSaved != nil {
@@ -472,20 +521,37 @@ func (tff TextFormField) Render(ctx *core.Context) string {
 	// Merge with interactive widget attributes (HTMX, event handlers, etc.)
 	attrs = tff.InteractiveWidget.MergeAttributes(attrs)
 
+	// Post the value to a handler when Enter is pressed
+	if enabled {
+		applySubmitHandler(ctx, attrs, tff.InputFormatters, tff.OnFieldSubmitted, nil, "", false)
+	}
+
 	// Combine all styles
 	if len(styles) > 0 {
 		attrs["style"] = strings.Join(styles, "; ")
 	}
 
-	// Determine initial value
+	// Determine initial value, falling back to the enclosing Form's model
 	initialValue := tff.InitialValue
 	if tff.Controller != nil && tff.Controller.Text() != "" {
 		initialValue = tff.Controller.Text()
 	}
+	if initialValue == "" {
+		name := tff.Name
+		if name == "" {
+			name = tff.ID
+		}
+		if value, exists := formModelValue(ctx, name); exists {
+			initialValue = value
+		}
+	}
+	if restored, exists := applyRestoration(ctx, attrs, tff.RestorationId, tff.ObscureText); exists {
+		initialValue = restored
+	}
 
 	// Render the appropriate element
 	if isTextarea {
-		return htmlRenderer.RenderElement("textarea", attrs, initialValue, false)
+		return htmlRenderer.RenderElement("textarea", attrs, htmlRenderer.RenderText(initialValue), false)
 	} else {
 		if initialValue != "" {
 			attrs["value"] = initialValue
@@ -502,6 +568,7 @@ type Switch struct {
 	Class                     string
 	Value                     bool                          // Switch value
 	OnChanged                 ValueChanged[bool]            // On changed callback
+	Enabled                   *bool                         // Enabled state; nil means enabled when OnChanged is set
 	ActiveColor               Color                         // Active color
 	ActiveTrackColor          Color                         // Active track color
 	InactiveThumbColor        Color                         // Inactive thumb color
@@ -567,11 +634,14 @@ func (s Switch) Render(ctx *core.Context) string {
 		inputAttrs["checked"] = "checked"
 	}
 
-	// Add autofocus
-	if s.AutoFocus {
-		inputAttrs["autofocus"] = "true"
+	// Handle disabled state (the switch is disabled if OnChanged is nil)
+	enabled := isEnabled(s.Enabled, s.OnChanged != nil)
+	if !enabled {
+		applyDisabled(inputAttrs, true)
 	}
 
+	applyFocus(ctx, inputAttrs, s.AutoFocus, s.FocusNode)
+
 	// Build input styles
 	var inputStyles []string
 
@@ -619,8 +689,8 @@ func (s Switch) Render(ctx *core.Context) string {
 		s.InteractiveWidget.SetWidgetType("Switch")
 	}
 
-	// Register callbacks if provided
-	if s.OnChanged != nil {
+	// Register callbacks only while enabled
+	if enabled {
 		s.InteractiveWidget.RegisterCallback("OnChanged", s.OnChanged)
 	}
 
@@ -671,6 +741,47 @@ func (s Switch) Render(ctx *core.Context) string {
 	return htmlRenderer.RenderElement("div", containerAttrs, content, false)
 }
 
+// buttonSizeClass returns the class sizing a button: by default it hugs its content,
+// even in a stretching flex column; FullWidth makes it span its container, e.g. for
+// the submit button of a mobile form
+func buttonSizeClass(fullWidth bool) string {
+	if fullWidth {
+		return " godin-button-full-width"
+	}
+	return " godin-button-hug"
+}
+
+// ConfirmStyle selects how a button asks the user to confirm before its callback runs
+type ConfirmStyle string
+
+const (
+	ConfirmStyleNative ConfirmStyle = "native" // Browser confirm() via hx-confirm
+	ConfirmStyleDialog ConfirmStyle = "dialog" // Styled AlertDialog-like confirmation in the overlay layer
+)
+
+// DefaultConfirmStyle is used by buttons with a Confirm message and no ConfirmStyle
+var DefaultConfirmStyle = ConfirmStyleNative
+
+// applyConfirm asks for confirmation before the button's HTMX request is issued
+func applyConfirm(attrs map[string]string, message string, style ConfirmStyle) {
+	if message == "" || attrs["hx-post"] == "" {
+		return
+	}
+
+	attrs["hx-confirm"] = message
+	if style == "" {
+		style = DefaultConfirmStyle
+	}
+	if style == ConfirmStyleDialog {
+		attrs["data-confirm-style"] = string(ConfirmStyleDialog)
+	}
+
+	// The fetch fallback would run the callback without confirming, so HTMX alone sends it
+	if strings.HasPrefix(attrs["onclick"], "handleWidgetCallback(") {
+		delete(attrs, "onclick")
+	}
+}
+
 // Button represents a button widget
 type Button struct {
 	InteractiveWidget // Embed InteractiveWidget for callback support
@@ -678,9 +789,13 @@ type Button struct {
 	Style             string
 	Class             string
 	Text              string
-	OnPressed         func() // Go function callback (Flutter-style)
-	Type              string // "primary", "secondary", "danger"
-	Disabled          bool
+	OnPressed         func()       // Go function callback (Flutter-style)
+	Type              string       // "primary", "secondary", "danger"
+	FullWidth         bool         // Span the container's width instead of hugging the content
+	Enabled           *bool        // Enabled state; nil means enabled
+	Disabled          bool         // Deprecated: set Enabled instead
+	Confirm           string       // Confirmation message shown before OnPressed runs
+	ConfirmStyle      ConfirmStyle // How the confirmation is shown (defaults to DefaultConfirmStyle)
 }
 
 // Render renders the button as HTML
@@ -693,24 +808,28 @@ func (b Button) Render(ctx *core.Context) string {
 		b.InteractiveWidget.SetWidgetType("Button")
 	}
 
-	// Register OnPressed callback if provided
-	if b.OnPressed != nil {
+	// A button without OnPressed still submits its form, so only Enabled disables it
+	enabled := isEnabled(b.Enabled, true) && !b.Disabled
+
+	// Register OnPressed callback only while enabled
+	if enabled && b.OnPressed != nil {
 		b.InteractiveWidget.RegisterCallback("OnPressed", b.OnPressed)
 	}
 
 	// Build base attributes
-	attrs := buildAttributes(b.ID, b.Style, b.Class+" godin-button")
+	attrs := buildAttributes(b.ID, b.Style, b.Class+" godin-button"+buttonSizeClass(b.FullWidth))
 
 	if b.Type != "" {
 		attrs["class"] += " godin-button-" + b.Type
 	}
 
-	if b.Disabled {
-		attrs["disabled"] = "disabled"
+	if !enabled {
+		applyDisabled(attrs, true)
 	}
 
 	// Merge with interactive widget attributes (HTMX, event handlers, etc.)
 	attrs = b.InteractiveWidget.MergeAttributes(attrs)
+	applyConfirm(attrs, b.Confirm, b.ConfirmStyle)
 
 	return htmlRenderer.RenderElement("button", attrs, b.Text, false)
 }
@@ -723,6 +842,7 @@ type Checkbox struct {
 	Value                 *bool                              // Checkbox value (null for indeterminate)
 	Tristate              bool                               // Allow three states
 	OnChanged             ValueChanged[bool]                 // On changed callback
+	Enabled               *bool                              // Enabled state; nil means enabled when OnChanged is set
 	ActiveColor           Color                              // Active color
 	FillColor             *MaterialStateProperty[Color]      // Fill color
 	CheckColor            Color                              // Check color
@@ -789,10 +909,9 @@ func (c Checkbox) Render(ctx *core.Context) string {
 	}
 
 	// Handle disabled state (checkbox is disabled if OnChanged is nil)
-	if c.OnChanged == nil {
-		attrs["disabled"] = "true"
-		styles = append(styles, "opacity: 0.6")
-		styles = append(styles, "cursor: not-allowed")
+	enabled := isEnabled(c.Enabled, c.OnChanged != nil)
+	if !enabled {
+		applyDisabled(attrs, true)
 	}
 
 	// Add visual density adjustments
@@ -822,10 +941,7 @@ func (c Checkbox) Render(ctx *core.Context) string {
 		styles = append(styles, "border-color: #f44336")
 	}
 
-	// Add autofocus
-	if c.AutoFocus {
-		attrs["autofocus"] = "true"
-	}
+	applyFocus(ctx, attrs, c.AutoFocus, c.FocusNode)
 
 	// Add semantic label
 	if c.SemanticLabel != "" {
@@ -833,7 +949,7 @@ func (c Checkbox) Render(ctx *core.Context) string {
 	}
 
 	// Add event handlers (simplified)
-	if c.OnChanged != nil {
+	if enabled {
 		attrs["onchange"] = "handleCheckboxChange(this)"
 	}
 
@@ -851,8 +967,9 @@ type Radio[T comparable] struct {
 	Style                      string
 	Class                      string
 	Value                      T                             // Radio value
-	GroupValue                 *T                            // Group value
-	OnChanged                  ValueChanged[T]               // On changed callback
+	GroupValue                 *T                            // Group value (defaults to the enclosing RadioGroup's)
+	OnChanged                  ValueChanged[T]               // On changed callback (defaults to the enclosing RadioGroup's)
+	Enabled                    *bool                         // Enabled state; nil means enabled when OnChanged is set
 	MouseCursor                MouseCursor                   // Mouse cursor
 	ToggleableActiveColor      Color                         // Toggleable active color
 	FillColor                  *MaterialStateProperty[Color] // Fill color
@@ -893,8 +1010,23 @@ func (r Radio[T]) Render(ctx *core.Context) string {
 	// Convert value to string for HTML
 	attrs["value"] = fmt.Sprintf("%v", r.Value)
 
+	// Take what the radio does not set itself from the enclosing RadioGroup
+	groupValue, onChanged, enabledOverride := r.GroupValue, r.OnChanged, r.Enabled
+	if group := enclosingRadioGroup[T](ctx); group != nil {
+		attrs["name"] = group.name
+		if groupValue == nil {
+			groupValue = &group.value
+		}
+		if onChanged == nil {
+			onChanged = group.onChanged
+		}
+		if enabledOverride == nil {
+			enabledOverride = group.enabled
+		}
+	}
+
 	// Check if this radio is selected
-	if r.GroupValue != nil && *r.GroupValue == r.Value {
+	if groupValue != nil && *groupValue == r.Value {
 		attrs["checked"] = "checked"
 	}
 
@@ -914,10 +1046,9 @@ func (r Radio[T]) Render(ctx *core.Context) string {
 	}
 
 	// Handle disabled state (radio is disabled if OnChanged is nil)
-	if r.OnChanged == nil {
-		attrs["disabled"] = "true"
-		styles = append(styles, "opacity: 0.6")
-		styles = append(styles, "cursor: not-allowed")
+	enabled := isEnabled(enabledOverride, onChanged != nil)
+	if !enabled {
+		applyDisabled(attrs, true)
 	}
 
 	// Add visual density adjustments
@@ -937,14 +1068,17 @@ func (r Radio[T]) Render(ctx *core.Context) string {
 		styles = append(styles, "min-height: 48px")
 	}
 
-	// Add autofocus
-	if r.AutoFocus {
-		attrs["autofocus"] = "true"
-	}
+	applyFocus(ctx, attrs, r.AutoFocus, r.FocusNode)
 
-	// Add event handlers (simplified)
-	if r.OnChanged != nil {
-		attrs["onchange"] = "handleRadioChange(this)"
+	// Call OnChanged with this radio's value when it is picked
+	if enabled && onChanged != nil && ctx != nil && ctx.App != nil {
+		value := r.Value
+		callbackID := ctx.App.RegisterCallback(r.ID, "Radio", "OnChanged", func() {
+			onChanged(value)
+		}, ctx)
+		if callbackID != "" {
+			attrs["onchange"] = fmt.Sprintf("handleWidgetCallback('%s', event)", appPath(ctx, "/api/callbacks/"+callbackID))
+		}
 	}
 
 	// Combine all styles
@@ -967,7 +1101,8 @@ type Dropdown struct {
 	Value    string
 	Options  []DropdownOption
 	OnChange string
-	Disabled bool
+	Enabled  *bool // Enabled state; nil means enabled
+	Disabled bool  // Deprecated: set Enabled instead
 }
 
 // Render renders the dropdown as HTML
@@ -977,12 +1112,14 @@ func (d Dropdown) Render(ctx *core.Context) string {
 	attrs := d.buildHTMXAttributes()
 	attrs["class"] += " godin-dropdown"
 
-	if d.Disabled {
-		attrs["disabled"] = "disabled"
+	// A dropdown without OnChange still submits its value, so only Enabled disables it
+	enabled := isEnabled(d.Enabled, true) && !d.Disabled
+	if !enabled {
+		applyDisabled(attrs, true)
 	}
 
 	// Add HTMX for onChange
-	if d.OnChange != "" && d.HTMX.Post == "" {
+	if enabled && d.OnChange != "" && d.HTMX.Post == "" {
 		attrs["hx-post"] = d.OnChange
 		attrs["hx-trigger"] = "change"
 	}
@@ -1015,6 +1152,7 @@ type Slider struct {
 	OnChanged                 ValueChanged[float64]     // On changed callback
 	OnChangeStart             ValueChanged[float64]     // On change start callback
 	OnChangeEnd               ValueChanged[float64]     // On change end callback
+	Enabled                   *bool                     // Enabled state; nil means enabled when OnChanged is set
 	Min                       float64                   // Minimum value
 	Max                       float64                   // Maximum value
 	Divisions                 *int                      // Number of divisions
@@ -1079,11 +1217,14 @@ func (s Slider) Render(ctx *core.Context) string {
 		inputAttrs["step"] = "any"
 	}
 
-	// Add autofocus
-	if s.AutoFocus {
-		inputAttrs["autofocus"] = "true"
+	// Handle disabled state (the slider is disabled if OnChanged is nil)
+	enabled := isEnabled(s.Enabled, s.OnChanged != nil)
+	if !enabled {
+		applyDisabled(inputAttrs, true)
 	}
 
+	applyFocus(ctx, inputAttrs, s.AutoFocus, s.FocusNode)
+
 	// Build input styles
 	var inputStyles []string
 
@@ -1110,15 +1251,15 @@ func (s Slider) Render(ctx *core.Context) string {
 		inputAttrs["style"] = strings.Join(inputStyles, "; ")
 	}
 
-	// Add event handlers
-	if s.OnChanged != nil {
+	// Add event handlers only while enabled
+	if enabled {
 		inputAttrs["oninput"] = "handleSliderChange(this)"
 	}
-	if s.OnChangeStart != nil {
+	if enabled && s.OnChangeStart != nil {
 		inputAttrs["onmousedown"] = "handleSliderChangeStart(this)"
 		inputAttrs["ontouchstart"] = "handleSliderChangeStart(this)"
 	}
-	if s.OnChangeEnd != nil {
+	if enabled && s.OnChangeEnd != nil {
 		inputAttrs["onmouseup"] = "handleSliderChangeEnd(this)"
 		inputAttrs["ontouchend"] = "handleSliderChangeEnd(this)"
 	}
@@ -1141,6 +1282,157 @@ func (s Slider) Render(ctx *core.Context) string {
 	return htmlRenderer.RenderElement("div", containerAttrs, content, false)
 }
 
+// NumberField represents a numeric input with min/max/step and optional stepper buttons
+type NumberField struct {
+	ID          string
+	Style       string
+	Class       string
+	Name        string                // Form field name
+	Value       float64               // Current value
+	Min         float64               // Minimum value (bounds apply only when Max > Min)
+	Max         float64               // Maximum value
+	Step        float64               // Step size (0 means any)
+	Decimals    int                   // Decimal places shown in the input
+	Label       string                // Label text
+	ShowButtons bool                  // Show decrement/increment buttons
+	Enabled     *bool                 // Enabled
+	OnChanged   ValueChanged[float64] // Receives the parsed and clamped value
+}
+
+// Clamp snaps a value to Step and keeps it within Min/Max
+func (nf NumberField) Clamp(value float64) float64 {
+	if nf.Step > 0 {
+		value = nf.Min + math.Round((value-nf.Min)/nf.Step)*nf.Step
+	}
+	if nf.Max > nf.Min {
+		value = math.Max(nf.Min, math.Min(nf.Max, value))
+	}
+	return value
+}
+
+// formatValue formats a value using the configured number of decimals
+func (nf NumberField) formatValue(value float64) string {
+	return strconv.FormatFloat(value, 'f', nf.Decimals, 64)
+}
+
+// Render renders the number field as HTML
+func (nf NumberField) Render(ctx *core.Context) string {
+	htmlRenderer := renderer.NewHTMLRenderer()
+
+	containerAttrs := buildAttributes(nf.ID+"_container", nf.Style, nf.Class+" godin-number-field")
+
+	var containerStyles []string
+	if nf.Style != "" {
+		containerStyles = append(containerStyles, nf.Style)
+	}
+	containerStyles = append(containerStyles, "display: inline-flex")
+	containerStyles = append(containerStyles, "align-items: center")
+	containerStyles = append(containerStyles, "gap: 4px")
+	containerAttrs["style"] = strings.Join(containerStyles, "; ")
+
+	enabled := isEnabled(nf.Enabled, true)
+
+	// Prefill from the enclosing Form's model when no value is set
+	value := nf.Value
+	if value == 0 {
+		if modelValue, exists := formModelValue(ctx, nf.Name); exists {
+			if parsed, err := strconv.ParseFloat(modelValue, 64); err == nil {
+				value = parsed
+			}
+		}
+	}
+
+	inputAttrs := map[string]string{
+		"type":      "number",
+		"class":     "godin-number-field-input",
+		"value":     nf.formatValue(nf.Clamp(value)),
+		"inputmode": "decimal",
+		"style":     "width: 6em; padding: 6px 8px; box-sizing: border-box; font-family: inherit; text-align: right",
+	}
+	if nf.ID != "" {
+		inputAttrs["id"] = nf.ID
+	}
+	if nf.Name != "" {
+		inputAttrs["name"] = nf.Name
+	}
+	if nf.Max > nf.Min {
+		inputAttrs["min"] = nf.formatValue(nf.Min)
+		inputAttrs["max"] = nf.formatValue(nf.Max)
+	}
+	if nf.Step > 0 {
+		inputAttrs["step"] = strconv.FormatFloat(nf.Step, 'f', -1, 64)
+	} else {
+		inputAttrs["step"] = "any"
+	}
+	if nf.Label != "" {
+		inputAttrs["aria-label"] = nf.Label
+	}
+	if !enabled {
+		applyDisabled(inputAttrs, true)
+	}
+
+	// Parse and clamp on the server before handing the value to OnChanged
+	if nf.OnChanged != nil && enabled && ctx != nil && ctx.App != nil {
+		onChanged := nf.OnChanged
+		callbackID := ctx.App.RegisterCallback(nf.ID, "NumberField", "OnChanged", func(raw string) {
+			value, err := strconv.ParseFloat(strings.TrimSpace(raw), 64)
+			if err != nil {
+				return
+			}
+			onChanged(nf.Clamp(value))
+		}, ctx)
+		if callbackID != "" {
+			inputAttrs["onchange"] = fmt.Sprintf("handleWidgetCallback('%s', event, this.value)", appPath(ctx, "/api/callbacks/"+callbackID))
+		}
+	}
+
+	input := htmlRenderer.RenderElement("input", inputAttrs, "", true)
+
+	var content string
+	if nf.Label != "" {
+		labelAttrs := map[string]string{
+			"class": "godin-number-field-label",
+			"style": "margin-right: 8px",
+		}
+		if nf.ID != "" {
+			labelAttrs["for"] = nf.ID
+		}
+		content += htmlRenderer.RenderElement("label", labelAttrs, htmlRenderer.RenderText(nf.Label), false)
+	}
+
+	if nf.ShowButtons {
+		buttonStyle := "width: 32px; height: 32px; border: 1px solid #ccc; border-radius: 4px; background: #f5f5f5; cursor: pointer"
+		stepScript := "var i=this.parentNode.querySelector('input');i.%s();i.dispatchEvent(new Event('change'))"
+
+		decrementAttrs := map[string]string{
+			"type":       "button",
+			"class":      "godin-number-field-decrement",
+			"aria-label": "Decrease",
+			"style":      buttonStyle,
+			"onclick":    fmt.Sprintf(stepScript, "stepDown"),
+		}
+		incrementAttrs := map[string]string{
+			"type":       "button",
+			"class":      "godin-number-field-increment",
+			"aria-label": "Increase",
+			"style":      buttonStyle,
+			"onclick":    fmt.Sprintf(stepScript, "stepUp"),
+		}
+		if !enabled {
+			applyDisabled(decrementAttrs, true)
+			applyDisabled(incrementAttrs, true)
+		}
+
+		content += htmlRenderer.RenderElement("button", decrementAttrs, "−", false)
+		content += input
+		content += htmlRenderer.RenderElement("button", incrementAttrs, "+", false)
+	} else {
+		content += input
+	}
+
+	return htmlRenderer.RenderElement("div", containerAttrs, content, false)
+}
+
 // ElevatedButton represents an elevated button widget with full Flutter properties
 type ElevatedButton struct {
 	InteractiveWidget // Embed InteractiveWidget for callback support
@@ -1148,6 +1440,7 @@ type ElevatedButton struct {
 	Style             string
 	Class             string
 	OnPressed         VoidCallback              // Callback when pressed
+	Enabled           *bool                     // Enabled state; nil means enabled when OnPressed is set
 	OnLongPress       VoidCallback              // Callback when long pressed
 	OnHover           ValueChanged[bool]        // Callback when hovered
 	OnFocusChange     ValueChanged[bool]        // Callback when focus changes
@@ -1157,11 +1450,9 @@ type ElevatedButton struct {
 	ClipBehavior      Clip                      // Clip behavior
 	StatesController  *MaterialStatesController // States controller
 	Child             Widget                    // Child widget
-}
-
-// FocusNode represents a focus node (simplified)
-type FocusNode struct {
-	HasFocus bool
+	FullWidth         bool                      // Span the container's width instead of hugging the content
+	Confirm           string                    // Confirmation message shown before OnPressed runs
+	ConfirmStyle      ConfirmStyle              // How the confirmation is shown (defaults to DefaultConfirmStyle)
 }
 
 // MaterialStatesController represents material states controller (simplified)
@@ -1187,7 +1478,7 @@ const (
 func (eb ElevatedButton) Render(ctx *core.Context) string {
 	htmlRenderer := renderer.NewHTMLRenderer()
 
-	attrs := buildAttributes(eb.ID, eb.Style, eb.Class+" godin-elevated-button")
+	attrs := buildAttributes(eb.ID, eb.Style, eb.Class+" godin-elevated-button"+buttonSizeClass(eb.FullWidth))
 
 	// Build inline styles
 	var styles []string
@@ -1224,7 +1515,7 @@ func (eb ElevatedButton) Render(ctx *core.Context) string {
 			styles = append(styles, fmt.Sprintf("color: %s", eb.ButtonStyle.ForegroundColor.Default))
 		}
 		if eb.ButtonStyle.Padding != nil {
-			styles = append(styles, fmt.Sprintf("padding: %s", eb.ButtonStyle.Padding.Default.ToCSSString()))
+			styles = append(styles, eb.ButtonStyle.Padding.Default.ToCSSDeclaration("padding"))
 		}
 		if eb.ButtonStyle.Shape != nil && eb.ButtonStyle.Shape.Default != nil {
 			styles = append(styles, eb.ButtonStyle.Shape.Default.ToCSSString())
@@ -1237,10 +1528,9 @@ func (eb ElevatedButton) Render(ctx *core.Context) string {
 	}
 
 	// Handle disabled state
-	if eb.OnPressed == nil {
-		styles = append(styles, "opacity: 0.6")
-		styles = append(styles, "cursor: not-allowed")
-		attrs["disabled"] = "true"
+	enabled := isEnabled(eb.Enabled, eb.OnPressed != nil)
+	if !enabled {
+		applyDisabled(attrs, true)
 	}
 
 	// Add hover and focus styles via CSS
@@ -1257,27 +1547,26 @@ func (eb ElevatedButton) Render(ctx *core.Context) string {
 		eb.InteractiveWidget.SetWidgetType("ElevatedButton")
 	}
 
-	// Register callbacks if provided
-	if eb.OnPressed != nil {
+	// Register callbacks only while enabled, so disabled buttons leave no handlers behind
+	if enabled && eb.OnPressed != nil {
 		eb.InteractiveWidget.RegisterCallback("OnPressed", eb.OnPressed)
 	}
-	if eb.OnLongPress != nil {
+	if enabled && eb.OnLongPress != nil {
 		eb.InteractiveWidget.RegisterCallback("OnLongPress", eb.OnLongPress)
 	}
-	if eb.OnHover != nil {
+	if enabled && eb.OnHover != nil {
 		eb.InteractiveWidget.RegisterCallback("OnHover", eb.OnHover)
 	}
 
 	// Merge with interactive widget attributes (HTMX, event handlers, etc.)
 	attrs = eb.InteractiveWidget.MergeAttributes(attrs)
+	applyConfirm(attrs, eb.Confirm, eb.ConfirmStyle)
 
 	// Add accessibility attributes
 	attrs["role"] = "button"
 	attrs["tabindex"] = "0"
 
-	if eb.AutoFocus {
-		attrs["autofocus"] = "true"
-	}
+	applyFocus(ctx, attrs, eb.AutoFocus, eb.FocusNode)
 
 	// Render child content
 	content := ""
@@ -1295,6 +1584,7 @@ type TextButton struct {
 	Style             string
 	Class             string
 	OnPressed         VoidCallback              // Callback when pressed
+	Enabled           *bool                     // Enabled state; nil means enabled when OnPressed is set
 	OnLongPress       VoidCallback              // Callback when long pressed
 	OnHover           ValueChanged[bool]        // Callback when hovered
 	OnFocusChange     ValueChanged[bool]        // Callback when focus changes
@@ -1304,13 +1594,14 @@ type TextButton struct {
 	ClipBehavior      Clip                      // Clip behavior
 	StatesController  *MaterialStatesController // States controller
 	Child             Widget                    // Child widget
+	FullWidth         bool                      // Span the container's width instead of hugging the content
 }
 
 // Render renders the text button as HTML
 func (tb TextButton) Render(ctx *core.Context) string {
 	htmlRenderer := renderer.NewHTMLRenderer()
 
-	attrs := buildAttributes(tb.ID, tb.Style, tb.Class+" godin-text-button")
+	attrs := buildAttributes(tb.ID, tb.Style, tb.Class+" godin-text-button"+buttonSizeClass(tb.FullWidth))
 
 	// Build inline styles
 	var styles []string
@@ -1346,7 +1637,7 @@ func (tb TextButton) Render(ctx *core.Context) string {
 			styles = append(styles, fmt.Sprintf("color: %s", tb.ButtonStyle.ForegroundColor.Default))
 		}
 		if tb.ButtonStyle.Padding != nil {
-			styles = append(styles, fmt.Sprintf("padding: %s", tb.ButtonStyle.Padding.Default.ToCSSString()))
+			styles = append(styles, tb.ButtonStyle.Padding.Default.ToCSSDeclaration("padding"))
 		}
 		if tb.ButtonStyle.Shape != nil && tb.ButtonStyle.Shape.Default != nil {
 			styles = append(styles, tb.ButtonStyle.Shape.Default.ToCSSString())
@@ -1359,10 +1650,9 @@ func (tb TextButton) Render(ctx *core.Context) string {
 	}
 
 	// Handle disabled state
-	if tb.OnPressed == nil {
-		styles = append(styles, "opacity: 0.6")
-		styles = append(styles, "cursor: not-allowed")
-		attrs["disabled"] = "true"
+	enabled := isEnabled(tb.Enabled, tb.OnPressed != nil)
+	if !enabled {
+		applyDisabled(attrs, true)
 	}
 
 	// Add hover and focus styles via CSS
@@ -1379,17 +1669,17 @@ func (tb TextButton) Render(ctx *core.Context) string {
 		tb.InteractiveWidget.SetWidgetType("TextButton")
 	}
 
-	// Register callbacks if provided
-	if tb.OnPressed != nil {
+	// Register callbacks only while enabled, so disabled buttons leave no handlers behind
+	if enabled && tb.OnPressed != nil {
 		tb.InteractiveWidget.RegisterCallback("OnPressed", tb.OnPressed)
 	}
-	if tb.OnLongPress != nil {
+	if enabled && tb.OnLongPress != nil {
 		tb.InteractiveWidget.RegisterCallback("OnLongPress", tb.OnLongPress)
 	}
-	if tb.OnHover != nil {
+	if enabled && tb.OnHover != nil {
 		tb.InteractiveWidget.RegisterCallback("OnHover", tb.OnHover)
 	}
-	if tb.OnFocusChange != nil {
+	if enabled && tb.OnFocusChange != nil {
 		tb.InteractiveWidget.RegisterCallback("OnFocusChange", tb.OnFocusChange)
 	}
 
@@ -1400,9 +1690,7 @@ func (tb TextButton) Render(ctx *core.Context) string {
 	attrs["role"] = "button"
 	attrs["tabindex"] = "0"
 
-	if tb.AutoFocus {
-		attrs["autofocus"] = "true"
-	}
+	applyFocus(ctx, attrs, tb.AutoFocus, tb.FocusNode)
 
 	// Render child content
 	content := ""
@@ -1420,6 +1708,7 @@ type OutlinedButton struct {
 	Style             string
 	Class             string
 	OnPressed         VoidCallback              // Callback when pressed
+	Enabled           *bool                     // Enabled state; nil means enabled when OnPressed is set
 	OnLongPress       VoidCallback              // Callback when long pressed
 	OnHover           ValueChanged[bool]        // Callback when hovered
 	OnFocusChange     ValueChanged[bool]        // Callback when focus changes
@@ -1429,13 +1718,14 @@ type OutlinedButton struct {
 	ClipBehavior      Clip                      // Clip behavior
 	StatesController  *MaterialStatesController // States controller
 	Child             Widget                    // Child widget
+	FullWidth         bool                      // Span the container's width instead of hugging the content
 }
 
 // Render renders the outlined button as HTML
 func (ob OutlinedButton) Render(ctx *core.Context) string {
 	htmlRenderer := renderer.NewHTMLRenderer()
 
-	attrs := buildAttributes(ob.ID, ob.Style, ob.Class+" godin-outlined-button")
+	attrs := buildAttributes(ob.ID, ob.Style, ob.Class+" godin-outlined-button"+buttonSizeClass(ob.FullWidth))
 
 	// Build inline styles
 	var styles []string
@@ -1475,7 +1765,7 @@ func (ob OutlinedButton) Render(ctx *core.Context) string {
 			styles = append(styles, fmt.Sprintf("border: %.1fpx %s %s", side.Width, side.Style, side.Color))
 		}
 		if ob.ButtonStyle.Padding != nil {
-			styles = append(styles, fmt.Sprintf("padding: %s", ob.ButtonStyle.Padding.Default.ToCSSString()))
+			styles = append(styles, ob.ButtonStyle.Padding.Default.ToCSSDeclaration("padding"))
 		}
 		if ob.ButtonStyle.Shape != nil && ob.ButtonStyle.Shape.Default != nil {
 			styles = append(styles, ob.ButtonStyle.Shape.Default.ToCSSString())
@@ -1488,10 +1778,9 @@ func (ob OutlinedButton) Render(ctx *core.Context) string {
 	}
 
 	// Handle disabled state
-	if ob.OnPressed == nil {
-		styles = append(styles, "opacity: 0.6")
-		styles = append(styles, "cursor: not-allowed")
-		attrs["disabled"] = "true"
+	enabled := isEnabled(ob.Enabled, ob.OnPressed != nil)
+	if !enabled {
+		applyDisabled(attrs, true)
 	}
 
 	// Add hover and focus styles via CSS
@@ -1508,17 +1797,17 @@ func (ob OutlinedButton) Render(ctx *core.Context) string {
 		ob.InteractiveWidget.SetWidgetType("OutlinedButton")
 	}
 
-	// Register callbacks if provided
-	if ob.OnPressed != nil {
+	// Register callbacks only while enabled, so disabled buttons leave no handlers behind
+	if enabled && ob.OnPressed != nil {
 		ob.InteractiveWidget.RegisterCallback("OnPressed", ob.OnPressed)
 	}
-	if ob.OnLongPress != nil {
+	if enabled && ob.OnLongPress != nil {
 		ob.InteractiveWidget.RegisterCallback("OnLongPress", ob.OnLongPress)
 	}
-	if ob.OnHover != nil {
+	if enabled && ob.OnHover != nil {
 		ob.InteractiveWidget.RegisterCallback("OnHover", ob.OnHover)
 	}
-	if ob.OnFocusChange != nil {
+	if enabled && ob.OnFocusChange != nil {
 		ob.InteractiveWidget.RegisterCallback("OnFocusChange", ob.OnFocusChange)
 	}
 
@@ -1529,9 +1818,7 @@ func (ob OutlinedButton) Render(ctx *core.Context) string {
 	attrs["role"] = "button"
 	attrs["tabindex"] = "0"
 
-	if ob.AutoFocus {
-		attrs["autofocus"] = "true"
-	}
+	applyFocus(ctx, attrs, ob.AutoFocus, ob.FocusNode)
 
 	// Render child content
 	content := ""
@@ -1549,6 +1836,7 @@ type FilledButton struct {
 	Style             string
 	Class             string
 	OnPressed         VoidCallback              // Callback when pressed
+	Enabled           *bool                     // Enabled state; nil means enabled when OnPressed is set
 	OnLongPress       VoidCallback              // Callback when long pressed
 	OnHover           ValueChanged[bool]        // Callback when hovered
 	OnFocusChange     ValueChanged[bool]        // Callback when focus changes
@@ -1558,13 +1846,14 @@ type FilledButton struct {
 	ClipBehavior      Clip                      // Clip behavior
 	StatesController  *MaterialStatesController // States controller
 	Child             Widget                    // Child widget
+	FullWidth         bool                      // Span the container's width instead of hugging the content
 }
 
 // Render renders the filled button as HTML
 func (fb FilledButton) Render(ctx *core.Context) string {
 	htmlRenderer := renderer.NewHTMLRenderer()
 
-	attrs := buildAttributes(fb.ID, fb.Style, fb.Class+" godin-filled-button")
+	attrs := buildAttributes(fb.ID, fb.Style, fb.Class+" godin-filled-button"+buttonSizeClass(fb.FullWidth))
 
 	// Build inline styles
 	var styles []string
@@ -1602,7 +1891,7 @@ func (fb FilledButton) Render(ctx *core.Context) string {
 			styles = append(styles, fmt.Sprintf("color: %s", fb.ButtonStyle.ForegroundColor.Default))
 		}
 		if fb.ButtonStyle.Padding != nil {
-			styles = append(styles, fmt.Sprintf("padding: %s", fb.ButtonStyle.Padding.Default.ToCSSString()))
+			styles = append(styles, fb.ButtonStyle.Padding.Default.ToCSSDeclaration("padding"))
 		}
 		if fb.ButtonStyle.Shape != nil {
 			if fb.ButtonStyle.Shape.Default != nil {
@@ -1617,10 +1906,9 @@ func (fb FilledButton) Render(ctx *core.Context) string {
 	}
 
 	// Handle disabled state
-	if fb.OnPressed == nil {
-		styles = append(styles, "opacity: 0.6")
-		styles = append(styles, "cursor: not-allowed")
-		attrs["disabled"] = "true"
+	enabled := isEnabled(fb.Enabled, fb.OnPressed != nil)
+	if !enabled {
+		applyDisabled(attrs, true)
 	}
 
 	// Add hover and focus styles via CSS
@@ -1632,13 +1920,13 @@ func (fb FilledButton) Render(ctx *core.Context) string {
 	}
 
 	// Add HTMX event handlers for OnPressed callback
-	if fb.OnPressed != nil {
+	if enabled {
 		handlerID := ctx.RegisterHandler(func(ctx *core.Context) Widget {
 			fb.OnPressed()
 			return nil // Return nil for callbacks that don't return widgets
 		})
 
-		attrs["hx-post"] = "/handlers/" + handlerID
+		attrs["hx-post"] = appPath(ctx, "/handlers/"+handlerID)
 		attrs["hx-trigger"] = "click"
 	}
 
@@ -1646,9 +1934,7 @@ func (fb FilledButton) Render(ctx *core.Context) string {
 	attrs["role"] = "button"
 	attrs["tabindex"] = "0"
 
-	if fb.AutoFocus {
-		attrs["autofocus"] = "true"
-	}
+	applyFocus(ctx, attrs, fb.AutoFocus, fb.FocusNode)
 
 	// Render child content
 	content := ""
@@ -1666,6 +1952,7 @@ type IconButton struct {
 	Style             string
 	Class             string
 	OnPressed         VoidCallback        // Callback when pressed
+	Enabled           *bool               // Enabled state; nil means enabled when OnPressed is set
 	Icon              Widget              // Icon widget
 	IconSize          *float64            // Icon size
 	VisualDensity     *VisualDensity      // Visual density
@@ -1687,6 +1974,8 @@ type IconButton struct {
 	ButtonStyle       *ButtonStyle        // Button style
 	IsSelected        *bool               // Is selected
 	SelectedIcon      Widget              // Selected icon
+	Confirm           string              // Confirmation message shown before OnPressed runs
+	ConfirmStyle      ConfirmStyle        // How the confirmation is shown (defaults to DefaultConfirmStyle)
 }
 
 // Render renders the icon button as HTML
@@ -1721,7 +2010,7 @@ func (ib IconButton) Render(ctx *core.Context) string {
 
 	// Add padding
 	if ib.Padding != nil {
-		styles = append(styles, fmt.Sprintf("padding: %s", ib.Padding.ToCSSString()))
+		styles = append(styles, ib.Padding.ToCSSDeclaration("padding"))
 	} else {
 		styles = append(styles, "padding: 8px")
 	}
@@ -1737,14 +2026,14 @@ func (ib IconButton) Render(ctx *core.Context) string {
 	}
 
 	// Handle disabled state
-	if ib.OnPressed == nil {
+	enabled := isEnabled(ib.Enabled, ib.OnPressed != nil)
+	if !enabled {
+		applyDisabled(attrs, true)
 		if ib.DisabledColor != "" {
+			// The disabled color replaces the dimming
 			styles = append(styles, fmt.Sprintf("color: %s", ib.DisabledColor))
-		} else {
-			styles = append(styles, "opacity: 0.6")
+			styles = append(styles, "opacity: 1 !important")
 		}
-		styles = append(styles, "cursor: not-allowed")
-		attrs["disabled"] = "true"
 	}
 
 	// Add hover and focus styles via CSS
@@ -1756,23 +2045,22 @@ func (ib IconButton) Render(ctx *core.Context) string {
 	}
 
 	// Add HTMX event handlers for OnPressed callback
-	if ib.OnPressed != nil {
+	if enabled {
 		handlerID := ctx.RegisterHandler(func(ctx *core.Context) Widget {
 			ib.OnPressed()
 			return nil // Return nil for callbacks that don't return widgets
 		})
 
-		attrs["hx-post"] = "/handlers/" + handlerID
+		attrs["hx-post"] = appPath(ctx, "/handlers/"+handlerID)
 		attrs["hx-trigger"] = "click"
+		applyConfirm(attrs, ib.Confirm, ib.ConfirmStyle)
 	}
 
 	// Add accessibility attributes
 	attrs["role"] = "button"
 	attrs["tabindex"] = "0"
 
-	if ib.AutoFocus {
-		attrs["autofocus"] = "true"
-	}
+	applyFocus(ctx, attrs, ib.AutoFocus, ib.FocusNode)
 
 	if ib.Tooltip != "" {
 		attrs["title"] = ib.Tooltip
@@ -1789,6 +2077,61 @@ func (ib IconButton) Render(ctx *core.Context) string {
 	return htmlRenderer.RenderElement("button", attrs, content, false)
 }
 
+// CopyButton copies Text to the clipboard when clicked, e.g. for "copy code",
+// "copy link" or API key displays. The copy happens in the browser; OnCopied
+// runs on the server afterwards, and CopiedMessage is shown as a snackbar.
+type CopyButton struct {
+	ID            string
+	Style         string
+	Class         string
+	Text          string       // Text copied to the clipboard
+	Child         Widget       // Button content, "Copy" when nil
+	OnCopied      VoidCallback // Called after the text was copied
+	Enabled       *bool        // Enabled state; nil means enabled
+	CopiedMessage string       // Snackbar shown after copying, e.g. "Link copied"
+	Tooltip       string       // Tooltip text
+}
+
+// Render renders the copy button as HTML
+func (cb CopyButton) Render(ctx *core.Context) string {
+	htmlRenderer := renderer.NewHTMLRenderer()
+
+	id := cb.ID
+	if id == "" {
+		id = fmt.Sprintf("copy_button_%p", &cb)
+	}
+
+	attrs := buildAttributes(cb.ID, cb.Style, cb.Class+" godin-copy-button")
+	attrs["type"] = "button"
+	attrs["data-copy-text"] = cb.Text
+	attrs["data-copied-message"] = cb.CopiedMessage
+
+	if cb.Tooltip != "" {
+		attrs["title"] = cb.Tooltip
+		attrs["aria-label"] = cb.Tooltip
+	}
+
+	// Copying needs no callback, so only Enabled disables the button
+	enabled := isEnabled(cb.Enabled, true)
+	if !enabled {
+		applyDisabled(attrs, true)
+	}
+
+	// godin.js posts to the callback once the clipboard write succeeded
+	if enabled && cb.OnCopied != nil && ctx != nil && ctx.App != nil {
+		if callbackID := ctx.App.RegisterCallback(id, "CopyButton", "OnCopied", cb.OnCopied, ctx); callbackID != "" {
+			attrs["data-on-copied"] = appPath(ctx, "/api/callbacks/"+callbackID)
+		}
+	}
+
+	content := "Copy"
+	if cb.Child != nil {
+		content = cb.Child.Render(ctx)
+	}
+
+	return htmlRenderer.RenderElement("button", attrs, content, false)
+}
+
 // FloatingActionButton represents a floating action button widget with full Flutter properties
 type FloatingActionButton struct {
 	InteractiveWidget     // Embed InteractiveWidget for callback support
@@ -1809,6 +2152,7 @@ type FloatingActionButton struct {
 	HighlightElevation    *float64              // Highlight elevation
 	DisabledElevation     *float64              // Disabled elevation
 	OnPressed             VoidCallback          // Callback when pressed
+	Enabled               *bool                 // Enabled state; nil means enabled when OnPressed is set
 	MouseCursor           MouseCursor           // Mouse cursor
 	Mini                  bool                  // Is mini FAB
 	Shape                 OutlinedBorder        // Shape
@@ -1884,10 +2228,9 @@ func (fab FloatingActionButton) Render(ctx *core.Context) string {
 	}
 
 	// Handle disabled state
-	if fab.OnPressed == nil {
-		styles = append(styles, "opacity: 0.6")
-		styles = append(styles, "cursor: not-allowed")
-		attrs["disabled"] = "true"
+	enabled := isEnabled(fab.Enabled, fab.OnPressed != nil)
+	if !enabled {
+		applyDisabled(attrs, true)
 	}
 
 	// Add hover and focus styles via CSS
@@ -1899,13 +2242,13 @@ func (fab FloatingActionButton) Render(ctx *core.Context) string {
 	}
 
 	// Add HTMX event handlers for OnPressed callback
-	if fab.OnPressed != nil {
+	if enabled {
 		handlerID := ctx.RegisterHandler(func(ctx *core.Context) Widget {
 			fab.OnPressed()
 			return nil // Return nil for callbacks that don't return widgets
 		})
 
-		attrs["hx-post"] = "/handlers/" + handlerID
+		attrs["hx-post"] = appPath(ctx, "/handlers/"+handlerID)
 		attrs["hx-trigger"] = "click"
 	}
 
@@ -1913,9 +2256,7 @@ func (fab FloatingActionButton) Render(ctx *core.Context) string {
 	attrs["role"] = "button"
 	attrs["tabindex"] = "0"
 
-	if fab.AutoFocus {
-		attrs["autofocus"] = "true"
-	}
+	applyFocus(ctx, attrs, fab.AutoFocus, fab.FocusNode)
 
 	if fab.Tooltip != "" {
 		attrs["title"] = fab.Tooltip
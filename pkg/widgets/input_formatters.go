@@ -0,0 +1,171 @@
+package widgets
+
+import (
+	"encoding/json"
+	"strings"
+	"unicode"
+)
+
+// ClientTextInputFormatter is a TextInputFormatter that godin.js also applies in the
+// browser as the user types. ClientSpec describes the formatter for the client; the
+// "type" entry selects the client implementation.
+type ClientTextInputFormatter interface {
+	TextInputFormatter
+	ClientSpec() map[string]interface{}
+}
+
+// DigitsOnlyFormatter removes every character that is not a digit
+type DigitsOnlyFormatter struct{}
+
+// FormatEditUpdate keeps only the digits of the new value
+func (f DigitsOnlyFormatter) FormatEditUpdate(oldValue, newValue TextEditingValue) TextEditingValue {
+	return textEditingValueAtEnd(digitsOf(newValue.Text))
+}
+
+// ClientSpec describes the formatter for godin.js
+func (f DigitsOnlyFormatter) ClientSpec() map[string]interface{} {
+	return map[string]interface{}{"type": "digits"}
+}
+
+// LengthLimitingFormatter truncates input to at most MaxLength characters
+type LengthLimitingFormatter struct {
+	MaxLength int
+}
+
+// FormatEditUpdate truncates the new value to MaxLength characters
+func (f LengthLimitingFormatter) FormatEditUpdate(oldValue, newValue TextEditingValue) TextEditingValue {
+	runes := []rune(newValue.Text)
+	if f.MaxLength <= 0 || len(runes) <= f.MaxLength {
+		return newValue
+	}
+	return textEditingValueAtEnd(string(runes[:f.MaxLength]))
+}
+
+// ClientSpec describes the formatter for godin.js
+func (f LengthLimitingFormatter) ClientSpec() map[string]interface{} {
+	return map[string]interface{}{"type": "length", "maxLength": f.MaxLength}
+}
+
+// MaskFormatter fits the digits of the input into a mask, where each '#' is a digit
+// slot and every other character is inserted literally, e.g. "#### #### #### ####"
+// for credit card numbers
+type MaskFormatter struct {
+	Mask string
+}
+
+// FormatEditUpdate applies the mask to the digits of the new value
+func (f MaskFormatter) FormatEditUpdate(oldValue, newValue TextEditingValue) TextEditingValue {
+	return textEditingValueAtEnd(applyDigitMask(f.Mask, digitsOf(newValue.Text)))
+}
+
+// ClientSpec describes the formatter for godin.js
+func (f MaskFormatter) ClientSpec() map[string]interface{} {
+	return map[string]interface{}{"type": "mask", "mask": f.Mask}
+}
+
+// PhoneNumberFormatter formats digits as a phone number using Pattern
+// ('#' is a digit slot), defaulting to "(###) ###-####"
+type PhoneNumberFormatter struct {
+	Pattern string
+}
+
+// pattern returns the phone pattern, falling back to the default
+func (f PhoneNumberFormatter) pattern() string {
+	if f.Pattern == "" {
+		return "(###) ###-####"
+	}
+	return f.Pattern
+}
+
+// FormatEditUpdate applies the phone pattern to the digits of the new value
+func (f PhoneNumberFormatter) FormatEditUpdate(oldValue, newValue TextEditingValue) TextEditingValue {
+	return MaskFormatter{Mask: f.pattern()}.FormatEditUpdate(oldValue, newValue)
+}
+
+// ClientSpec describes the formatter for godin.js
+func (f PhoneNumberFormatter) ClientSpec() map[string]interface{} {
+	return map[string]interface{}{"type": "mask", "mask": f.pattern()}
+}
+
+// ApplyInputFormatters runs text through each formatter in order, as Flutter does for an edit
+func ApplyInputFormatters(formatters []TextInputFormatter, oldText, text string) string {
+	oldValue := textEditingValueAtEnd(oldText)
+	value := textEditingValueAtEnd(text)
+	for _, formatter := range formatters {
+		if formatter != nil {
+			value = formatter.FormatEditUpdate(oldValue, value)
+		}
+	}
+	return value.Text
+}
+
+// inputFormattersAttribute encodes the client-side formatters as the data-input-formatters attribute value
+func inputFormattersAttribute(formatters []TextInputFormatter) string {
+	var specs []map[string]interface{}
+	for _, formatter := range formatters {
+		if clientFormatter, ok := formatter.(ClientTextInputFormatter); ok {
+			specs = append(specs, clientFormatter.ClientSpec())
+		}
+	}
+	if len(specs) == 0 {
+		return ""
+	}
+
+	data, err := json.Marshal(specs)
+	if err != nil {
+		return ""
+	}
+	return string(data)
+}
+
+// formattedValueChanged wraps a text callback so the value is re-validated by the formatters on the server
+func formattedValueChanged(formatters []TextInputFormatter, callback ValueChanged[string]) ValueChanged[string] {
+	if callback == nil || len(formatters) == 0 {
+		return callback
+	}
+	return func(value string) {
+		callback(ApplyInputFormatters(formatters, "", value))
+	}
+}
+
+// textEditingValueAtEnd returns a value with the cursor collapsed after the last character
+func textEditingValueAtEnd(text string) TextEditingValue {
+	length := len([]rune(text))
+	return TextEditingValue{
+		Text:      text,
+		Selection: TextSelection{Start: length, End: length},
+		Composing: TextRange{Start: -1, End: -1},
+	}
+}
+
+// digitsOf returns only the digits of text
+func digitsOf(text string) string {
+	return strings.Map(func(r rune) rune {
+		if unicode.IsDigit(r) {
+			return r
+		}
+		return -1
+	}, text)
+}
+
+// applyDigitMask fills mask's '#' slots with digits, stopping when the digits run out
+func applyDigitMask(mask, digits string) string {
+	if mask == "" {
+		return digits
+	}
+
+	var result strings.Builder
+	remaining := []rune(digits)
+	for _, slot := range mask {
+		if len(remaining) == 0 {
+			break
+		}
+		if slot == '#' {
+			result.WriteRune(remaining[0])
+			remaining = remaining[1:]
+		} else {
+			result.WriteRune(slot)
+		}
+	}
+	return result.String()
+}
@@ -137,7 +137,7 @@ func (iw *InteractiveWidget) GenerateHTMXAttributes() map[string]string {
 // generateHTMXForCallback generates HTMX attributes for a specific callback
 func (iw *InteractiveWidget) generateHTMXForCallback(callbackType, callbackID string) map[string]string {
 	attrs := make(map[string]string)
-	endpointPath := fmt.Sprintf("/api/callbacks/%s", callbackID)
+	endpointPath := appPath(iw.context, "/api/callbacks/"+callbackID)
 
 	switch callbackType {
 	case "OnPressed", "OnTap":
@@ -220,7 +220,7 @@ func (iw *InteractiveWidget) BuildEventHandlers() map[string]string {
 
 // generateEventHandler generates a JavaScript event handler for a callback
 func (iw *InteractiveWidget) generateEventHandler(callbackType, callbackID string) string {
-	endpointPath := fmt.Sprintf("/api/callbacks/%s", callbackID)
+	endpointPath := appPath(iw.context, "/api/callbacks/"+callbackID)
 
 	switch callbackType {
 	case "OnPressed", "OnTap":
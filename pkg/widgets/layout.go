@@ -17,24 +17,79 @@ type BoxConstraints struct {
 	MaxHeight *float64
 }
 
+// BoxConstraintsTight creates constraints that require exactly the given size
+func BoxConstraintsTight(width, height float64) *BoxConstraints {
+	return &BoxConstraints{MinWidth: &width, MaxWidth: &width, MinHeight: &height, MaxHeight: &height}
+}
+
+// BoxConstraintsTightFor creates constraints that fix only the dimensions that are given
+func BoxConstraintsTightFor(width, height *float64) *BoxConstraints {
+	return &BoxConstraints{MinWidth: width, MaxWidth: width, MinHeight: height, MaxHeight: height}
+}
+
+// BoxConstraintsLoose creates constraints that allow any size up to the given size
+func BoxConstraintsLoose(width, height float64) *BoxConstraints {
+	return &BoxConstraints{MaxWidth: &width, MaxHeight: &height}
+}
+
+// BoxConstraintsExpand creates constraints that fill the available space
+func BoxConstraintsExpand() *BoxConstraints {
+	infinity := math.Inf(1)
+	return &BoxConstraints{MinWidth: &infinity, MaxWidth: &infinity, MinHeight: &infinity, MaxHeight: &infinity}
+}
+
+// ToCSSStrings converts the constraints to CSS declarations. An infinite minimum
+// fills the parent (100%) and an infinite maximum is left unbounded.
+func (bc *BoxConstraints) ToCSSStrings() []string {
+	if bc == nil {
+		return nil
+	}
+
+	var styles []string
+	styles = append(styles, constraintCSS("width", bc.MinWidth, bc.MaxWidth)...)
+	styles = append(styles, constraintCSS("height", bc.MinHeight, bc.MaxHeight)...)
+	return styles
+}
+
+// constraintCSS converts one axis of a BoxConstraints to CSS declarations
+func constraintCSS(dimension string, min, max *float64) []string {
+	var styles []string
+	if min != nil {
+		if math.IsInf(*min, 1) {
+			styles = append(styles, fmt.Sprintf("%s: 100%%", dimension))
+		} else if *min > 0 {
+			styles = append(styles, fmt.Sprintf("min-%s: %.1fpx", dimension, *min))
+		}
+	}
+	if max != nil && !math.IsInf(*max, 1) {
+		styles = append(styles, fmt.Sprintf("max-%s: %.1fpx", dimension, *max))
+	}
+	return styles
+}
+
 // Container represents a container widget with full Flutter properties
 type Container struct {
 	ID                   string
 	Style                string
 	Class                string
-	Child                Widget              // Child widget
-	Padding              *EdgeInsetsGeometry // Padding around child
-	Margin               *EdgeInsetsGeometry // Margin around container
-	Width                *float64            // Container width
-	Height               *float64            // Container height
-	Constraints          *BoxConstraints     // Layout constraints
-	Decoration           *BoxDecoration      // Background decoration
-	ForegroundDecoration *BoxDecoration      // Foreground decoration
-	Transform            *Matrix4            // Transform matrix
-	TransformAlignment   AlignmentGeometry   // Transform alignment
-	Alignment            AlignmentGeometry   // Child alignment
-	Color                Color               // Background color
-	ClipBehavior         Clip                // Clip behavior
+	Child                Widget                         // Child widget
+	Padding              *EdgeInsetsGeometry            // Padding around child
+	Margin               *EdgeInsetsGeometry            // Margin around container
+	Width                *float64                       // Container width
+	Height               *float64                       // Container height
+	Constraints          *BoxConstraints                // Layout constraints
+	Decoration           *BoxDecoration                 // Background decoration
+	ForegroundDecoration *BoxDecoration                 // Foreground decoration
+	Transform            *Matrix4                       // Transform matrix
+	TransformAlignment   AlignmentGeometry              // Transform alignment
+	Alignment            AlignmentGeometry              // Child alignment
+	Color                Color                          // Background color
+	ClipBehavior         Clip                           // Clip behavior
+	OnTap                VoidCallback                   // Makes the container clickable and reachable from the keyboard
+	OnTapBuilder         func(ctx *core.Context) Widget // Runs on tap and returns a widget that replaces OnTapHxTarget
+	OnTapHxTarget        string                         // CSS selector replaced by OnTapBuilder's widget (defaults to the container)
+	TapLabel             string                         // Accessible name of a tappable container
+	SwapStrategy         SwapStrategy                   // How responses replace the container or its descendants (defaults to render.swap)
 }
 
 // Render renders the container as HTML
@@ -53,12 +108,12 @@ func (c Container) Render(ctx *core.Context) string {
 
 	// Add padding
 	if c.Padding != nil {
-		styles = append(styles, fmt.Sprintf("padding: %s", c.Padding.ToCSSString()))
+		styles = append(styles, c.Padding.ToCSSDeclaration("padding"))
 	}
 
 	// Add margin
 	if c.Margin != nil {
-		styles = append(styles, fmt.Sprintf("margin: %s", c.Margin.ToCSSString()))
+		styles = append(styles, c.Margin.ToCSSDeclaration("margin"))
 	}
 
 	// Add dimensions
@@ -91,27 +146,25 @@ func (c Container) Render(ctx *core.Context) string {
 		}
 	}
 
-	// Add constraints (simplified)
-	if c.Constraints != nil {
-		if c.Constraints.MinWidth != nil {
-			styles = append(styles, fmt.Sprintf("min-width: %.1fpx", *c.Constraints.MinWidth))
-		}
-		if c.Constraints.MaxWidth != nil {
-			styles = append(styles, fmt.Sprintf("max-width: %.1fpx", *c.Constraints.MaxWidth))
-		}
-		if c.Constraints.MinHeight != nil {
-			styles = append(styles, fmt.Sprintf("min-height: %.1fpx", *c.Constraints.MinHeight))
-		}
-		if c.Constraints.MaxHeight != nil {
-			styles = append(styles, fmt.Sprintf("max-height: %.1fpx", *c.Constraints.MaxHeight))
-		}
-	}
+	// Add constraints
+	styles = append(styles, c.Constraints.ToCSSStrings()...)
 
 	// Add clip behavior
 	if c.ClipBehavior != "" && c.ClipBehavior != ClipNone {
 		styles = append(styles, "overflow: hidden")
 	}
 
+	// Descendants without their own hx-swap inherit the strategy
+	if c.SwapStrategy != "" {
+		attrs["hx-swap"] = string(c.SwapStrategy)
+	}
+
+	// Add tap handler
+	if (c.OnTap != nil || c.OnTapBuilder != nil) && ctx != nil && ctx.App != nil {
+		c.applyTapHandler(ctx, attrs)
+		styles = append(styles, "cursor: pointer")
+	}
+
 	// Combine all styles
 	if len(styles) > 0 {
 		attrs["style"] = strings.Join(styles, "; ")
@@ -126,6 +179,56 @@ func (c Container) Render(ctx *core.Context) string {
 	return htmlRenderer.RenderElement("div", attrs, content, false)
 }
 
+// applyTapHandler posts taps, and Enter or Space while the container has
+// focus, to a handler running OnTap and OnTapBuilder
+func (c Container) applyTapHandler(ctx *core.Context, attrs map[string]string) {
+	onTap, builder := c.OnTap, c.OnTapBuilder
+	handlerID := ctx.RegisterHandler(func(ctx *core.Context) Widget {
+		if onTap != nil {
+			onTap()
+		}
+
+		var widget Widget
+		if builder != nil {
+			widget = builder(ctx)
+		}
+		if widget == nil {
+			ctx.SetHeader(renderer.HXReswap, "none")
+		}
+		return widget
+	})
+
+	attrs["hx-post"] = appPath(ctx, "/handlers/"+handlerID)
+	// Keys only count while the container itself has focus, not a field inside it
+	attrs["hx-trigger"] = "click, keydown[key=='Enter'&&target===this], keyup[key==' '&&target===this]"
+	attrs["hx-swap"] = string(c.tapSwap(ctx))
+	if c.OnTapHxTarget != "" {
+		attrs["hx-target"] = c.OnTapHxTarget
+	} else {
+		attrs["hx-target"] = "this"
+	}
+
+	// Announce the container as something that can be activated, like a link or button
+	attrs["role"] = "button"
+	attrs["tabindex"] = "0"
+	attrs["data-tap-container"] = "true"
+	if c.TapLabel != "" {
+		attrs["aria-label"] = c.TapLabel
+	}
+}
+
+// tapSwap returns how a tap's response replaces its target: SwapStrategy when
+// set, otherwise morphing when the app morphs swaps
+func (c Container) tapSwap(ctx *core.Context) SwapStrategy {
+	if c.SwapStrategy != "" {
+		return c.SwapStrategy
+	}
+	if ctx.App.MorphSwaps() {
+		return SwapMorphOuter
+	}
+	return SwapOuterHTML
+}
+
 // Row represents a row layout widget with full Flutter properties
 type Row struct {
 	ID                 string
@@ -206,6 +309,7 @@ func (r Row) Render(ctx *core.Context) string {
 	var children []string
 	for _, child := range r.Children {
 		if child != nil {
+			ctx.TrackRender(1)
 			children = append(children, child.Render(ctx))
 		}
 	}
@@ -285,6 +389,7 @@ func (c Column) Render(ctx *core.Context) string {
 	var children []string
 	for _, child := range c.Children {
 		if child != nil {
+			ctx.TrackRender(1)
 			children = append(children, child.Render(ctx))
 		}
 	}
@@ -366,6 +471,7 @@ func (s Stack) Render(ctx *core.Context) string {
 	var children []string
 	for _, child := range s.Children {
 		if child != nil {
+			ctx.TrackRender(1)
 			children = append(children, child.Render(ctx))
 		}
 	}
@@ -546,11 +652,23 @@ type SizedBox struct {
 	ID     string
 	Style  string
 	Class  string
-	Width  *float64 // Box width
-	Height *float64 // Box height
+	Width  *float64 // Box width (math.Inf(1) fills the parent)
+	Height *float64 // Box height (math.Inf(1) fills the parent)
 	Child  Widget   // Child widget
 }
 
+// SizedBoxExpand creates a SizedBox that fills its parent, like Flutter's SizedBox.expand
+func SizedBoxExpand(child Widget) SizedBox {
+	infinity := math.Inf(1)
+	return SizedBox{Width: &infinity, Height: &infinity, Child: child}
+}
+
+// SizedBoxShrink creates a SizedBox that is as small as possible, like Flutter's SizedBox.shrink
+func SizedBoxShrink(child Widget) SizedBox {
+	zero := 0.0
+	return SizedBox{Width: &zero, Height: &zero, Child: child}
+}
+
 // Render renders the sized box as HTML
 func (sb SizedBox) Render(ctx *core.Context) string {
 	htmlRenderer := renderer.NewHTMLRenderer()
@@ -567,10 +685,10 @@ func (sb SizedBox) Render(ctx *core.Context) string {
 
 	// Add dimensions
 	if sb.Width != nil {
-		styles = append(styles, fmt.Sprintf("width: %.1fpx", *sb.Width))
+		styles = append(styles, sizeCSS("width", *sb.Width))
 	}
 	if sb.Height != nil {
-		styles = append(styles, fmt.Sprintf("height: %.1fpx", *sb.Height))
+		styles = append(styles, sizeCSS("height", *sb.Height))
 	}
 
 	// Combine all styles
@@ -587,6 +705,147 @@ func (sb SizedBox) Render(ctx *core.Context) string {
 	return htmlRenderer.RenderElement("div", attrs, content, false)
 }
 
+// sizeCSS formats a fixed dimension, treating infinity as filling the parent
+func sizeCSS(dimension string, size float64) string {
+	if math.IsInf(size, 1) {
+		return fmt.Sprintf("%s: 100%%", dimension)
+	}
+	return fmt.Sprintf("%s: %.1fpx", dimension, size)
+}
+
+// ConstrainedBox represents a widget that imposes min/max size constraints on its child
+type ConstrainedBox struct {
+	ID          string
+	Style       string
+	Class       string
+	Constraints BoxConstraints // Size constraints
+	Child       Widget         // Child widget
+}
+
+// Render renders the constrained box as HTML
+func (cb ConstrainedBox) Render(ctx *core.Context) string {
+	htmlRenderer := renderer.NewHTMLRenderer()
+
+	attrs := buildAttributes(cb.ID, cb.Style, cb.Class+" godin-constrainedbox")
+
+	// Build inline styles
+	var styles []string
+
+	// Add custom style if provided
+	if cb.Style != "" {
+		styles = append(styles, cb.Style)
+	}
+
+	// Let the box grow within its bounds rather than sizing to a fixed value
+	styles = append(styles, "box-sizing: border-box")
+	styles = append(styles, cb.Constraints.ToCSSStrings()...)
+
+	// Combine all styles
+	if len(styles) > 0 {
+		attrs["style"] = strings.Join(styles, "; ")
+	}
+
+	// Render child content
+	content := ""
+	if cb.Child != nil {
+		content = cb.Child.Render(ctx)
+	}
+
+	return htmlRenderer.RenderElement("div", attrs, content, false)
+}
+
+// KeyedSubtree gives its child a stable identity across re-renders. The key is
+// emitted as data-key, and godin.js matches keyed elements when it morphs updated
+// HTML into the page, so reordered list items keep their DOM nodes, input focus
+// and typed text instead of being recreated.
+type KeyedSubtree struct {
+	Key   string // Identifier unique among siblings, e.g. a record ID
+	Child Widget // Child widget
+}
+
+// Render renders the keyed subtree as HTML
+func (ks KeyedSubtree) Render(ctx *core.Context) string {
+	htmlRenderer := renderer.NewHTMLRenderer()
+
+	attrs := map[string]string{
+		"class":    "godin-keyed-subtree",
+		"data-key": ks.Key,
+		"style":    "display: contents",
+	}
+
+	// Render child content
+	content := ""
+	if ks.Child != nil {
+		content = ks.Child.Render(ctx)
+	}
+
+	return htmlRenderer.RenderElement("div", attrs, content, false)
+}
+
+// MouseRegion reports the pointer entering and leaving its child to Go handlers
+// and sets the cursor shown over it. godin.js coalesces rapid enter/exit pairs,
+// so quick passes over the region do not flood the server with requests.
+type MouseRegion struct {
+	ID      string
+	Style   string
+	Class   string
+	OnEnter VoidCallback // Called when the pointer enters the region
+	OnExit  VoidCallback // Called when the pointer leaves the region
+	Cursor  MouseCursor  // Cursor shown over the region
+	Child   Widget       // Child widget
+}
+
+// Render renders the mouse region as HTML
+func (mr MouseRegion) Render(ctx *core.Context) string {
+	htmlRenderer := renderer.NewHTMLRenderer()
+
+	id := mr.ID
+	if id == "" {
+		id = fmt.Sprintf("mouse_region_%p", &mr)
+	}
+
+	attrs := buildAttributes(mr.ID, mr.Style, mr.Class+" godin-mouse-region")
+
+	// Build inline styles
+	var styles []string
+
+	// Add custom style if provided
+	if mr.Style != "" {
+		styles = append(styles, mr.Style)
+	}
+
+	if mr.Cursor != "" {
+		styles = append(styles, fmt.Sprintf("cursor: %s", mr.Cursor))
+	}
+
+	// Combine all styles
+	if len(styles) > 0 {
+		attrs["style"] = strings.Join(styles, "; ")
+	}
+
+	// Register the hover handlers as plain callbacks; godin.js posts to them on enter/exit
+	if ctx != nil && ctx.App != nil {
+		if mr.OnEnter != nil {
+			if callbackID := ctx.App.RegisterCallback(id, "MouseRegion", "OnEnter", mr.OnEnter, ctx); callbackID != "" {
+				attrs["data-on-enter"] = appPath(ctx, "/api/callbacks/"+callbackID)
+			}
+		}
+		if mr.OnExit != nil {
+			if callbackID := ctx.App.RegisterCallback(id, "MouseRegion", "OnExit", mr.OnExit, ctx); callbackID != "" {
+				attrs["data-on-exit"] = appPath(ctx, "/api/callbacks/"+callbackID)
+			}
+		}
+	}
+
+	// Render child content
+	content := ""
+	if mr.Child != nil {
+		content = mr.Child.Render(ctx)
+	}
+
+	return htmlRenderer.RenderElement("div", attrs, content, false)
+}
+
 // Padding represents a padding widget with full Flutter properties
 type Padding struct {
 	ID      string
@@ -611,7 +870,7 @@ func (p Padding) Render(ctx *core.Context) string {
 	}
 
 	// Add padding
-	styles = append(styles, fmt.Sprintf("padding: %s", p.Padding.ToCSSString()))
+	styles = append(styles, p.Padding.ToCSSDeclaration("padding"))
 
 	// Combine all styles
 	if len(styles) > 0 {
@@ -922,12 +1181,12 @@ func (ac AnimatedContainer) Render(ctx *core.Context) string {
 
 	// Add padding
 	if ac.Padding != nil {
-		styles = append(styles, fmt.Sprintf("padding: %s", ac.Padding.ToCSSString()))
+		styles = append(styles, ac.Padding.ToCSSDeclaration("padding"))
 	}
 
 	// Add margin
 	if ac.Margin != nil {
-		styles = append(styles, fmt.Sprintf("margin: %s", ac.Margin.ToCSSString()))
+		styles = append(styles, ac.Margin.ToCSSDeclaration("margin"))
 	}
 
 	// Add decoration
@@ -958,21 +1217,8 @@ func (ac AnimatedContainer) Render(ctx *core.Context) string {
 		styles = append(styles, "overflow: hidden")
 	}
 
-	// Add constraints (simplified as min/max width/height)
-	if ac.Constraints != nil {
-		if ac.Constraints.MinWidth != nil && *ac.Constraints.MinWidth > 0 {
-			styles = append(styles, fmt.Sprintf("min-width: %.1fpx", *ac.Constraints.MinWidth))
-		}
-		if ac.Constraints.MaxWidth != nil && *ac.Constraints.MaxWidth < math.Inf(1) {
-			styles = append(styles, fmt.Sprintf("max-width: %.1fpx", *ac.Constraints.MaxWidth))
-		}
-		if ac.Constraints.MinHeight != nil && *ac.Constraints.MinHeight > 0 {
-			styles = append(styles, fmt.Sprintf("min-height: %.1fpx", *ac.Constraints.MinHeight))
-		}
-		if ac.Constraints.MaxHeight != nil && *ac.Constraints.MaxHeight < math.Inf(1) {
-			styles = append(styles, fmt.Sprintf("max-height: %.1fpx", *ac.Constraints.MaxHeight))
-		}
-	}
+	// Add constraints as min/max width/height
+	styles = append(styles, ac.Constraints.ToCSSStrings()...)
 
 	// Combine all styles
 	if len(styles) > 0 {
@@ -0,0 +1,69 @@
+package widgets
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/gideonsigilai/godin/pkg/renderer"
+)
+
+// linkPattern matches the URLs and email addresses AutoLink turns into links
+var linkPattern = regexp.MustCompile(`(?i)\b(?:https?://|www\.)[^\s<>"']+|\b[a-z0-9._%+-]+@[a-z0-9.-]+\.[a-z]{2,}\b`)
+
+// AutoLinkText returns a RichText showing text with its URLs and email
+// addresses as clickable links, e.g. for user-written chat messages. The text
+// is HTML-escaped, so it is safe to use with untrusted input.
+func AutoLinkText(text string) RichText {
+	return RichText{HTML: linkify(text)}
+}
+
+// linkify HTML-escapes text, wrapping the URLs and email addresses in it in links
+func linkify(text string) string {
+	htmlRenderer := renderer.NewHTMLRenderer()
+
+	var result strings.Builder
+	last := 0
+	for _, match := range linkPattern.FindAllStringIndex(text, -1) {
+		start, end := match[0], match[1]
+		link := trimLinkPunctuation(text[start:end])
+		end = start + len(link)
+
+		var attrs map[string]string
+		switch {
+		case strings.Contains(link, "@") && !strings.Contains(link, "/"):
+			attrs = map[string]string{"href": "mailto:" + link}
+		case strings.HasPrefix(strings.ToLower(link), "www."):
+			attrs = map[string]string{"href": "https://" + link}
+		default:
+			attrs = map[string]string{"href": link}
+		}
+		if !strings.HasPrefix(attrs["href"], "mailto:") {
+			attrs["target"] = "_blank"
+			attrs["rel"] = "noopener noreferrer nofollow"
+		}
+		attrs["class"] = "godin-link"
+
+		result.WriteString(htmlRenderer.RenderText(text[last:start]))
+		result.WriteString(htmlRenderer.RenderElement("a", attrs, htmlRenderer.RenderText(link), false))
+		last = end
+	}
+	result.WriteString(htmlRenderer.RenderText(text[last:]))
+	return result.String()
+}
+
+// trimLinkPunctuation drops punctuation ending a sentence after a link, and a
+// closing parenthesis unless the link opened one, e.g. "(see example.com/a)."
+func trimLinkPunctuation(link string) string {
+	for len(link) > 0 {
+		last := link[len(link)-1]
+		switch {
+		case strings.IndexByte(".,;:!?", last) >= 0:
+			link = link[:len(link)-1]
+		case last == ')' && strings.Count(link, "(") < strings.Count(link, ")"):
+			link = link[:len(link)-1]
+		default:
+			return link
+		}
+	}
+	return link
+}
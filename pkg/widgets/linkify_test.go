@@ -0,0 +1,65 @@
+package widgets
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestLinkify(t *testing.T) {
+	tests := []struct {
+		text     string
+		expected []string // Substrings the HTML must contain
+	}{
+		{"no links here", []string{"no links here"}},
+		{"see https://example.com/docs", []string{`href="https://example.com/docs"`, ">https://example.com/docs</a>"}},
+		{"visit www.example.com", []string{`href="https://www.example.com"`, ">www.example.com</a>"}},
+		{"mail ada@example.com", []string{`href="mailto:ada@example.com"`}},
+		// Punctuation ending the sentence stays out of the link
+		{"Go to https://example.com/a.", []string{`href="https://example.com/a"`, "</a>."}},
+		{"(see https://example.com/a)", []string{`href="https://example.com/a"`, "</a>)"}},
+		// Parentheses that belong to the URL are kept
+		{"https://en.wikipedia.org/wiki/Go_(language)", []string{`href="https://en.wikipedia.org/wiki/Go_(language)"`}},
+	}
+
+	for _, test := range tests {
+		html := linkify(test.text)
+		for _, expected := range test.expected {
+			if !strings.Contains(html, expected) {
+				t.Errorf("linkify(%q) = %q, expected it to contain %q", test.text, html, expected)
+			}
+		}
+	}
+}
+
+func TestLinkifyOpensWebLinksSafely(t *testing.T) {
+	html := linkify("https://example.com")
+	if !strings.Contains(html, `target="_blank"`) || !strings.Contains(html, "noopener") {
+		t.Errorf("Expected web links to open in a new tab with noopener, got %q", html)
+	}
+
+	html = linkify("ada@example.com")
+	if strings.Contains(html, "_blank") {
+		t.Errorf("Expected mail links to open in place, got %q", html)
+	}
+}
+
+func TestLinkifyEscapesText(t *testing.T) {
+	html := linkify(`<script>alert(1)</script> https://example.com/?q="x"`)
+	if strings.Contains(html, "<script>") {
+		t.Errorf("Expected the text to be escaped, got %q", html)
+	}
+	if strings.Contains(html, `q="x"`) {
+		t.Errorf("Expected quotes not to end up unescaped in the link, got %q", html)
+	}
+
+	if html := linkify("javascript:alert(1)"); strings.Contains(html, "<a") {
+		t.Errorf("Expected javascript: URLs not to become links, got %q", html)
+	}
+}
+
+func TestAutoLinkText(t *testing.T) {
+	text := AutoLinkText("Docs at https://example.com")
+	if !strings.Contains(text.HTML, `href="https://example.com"`) {
+		t.Errorf("Expected the RichText to link the URL, got %q", text.HTML)
+	}
+}
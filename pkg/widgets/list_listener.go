@@ -0,0 +1,163 @@
+package widgets
+
+import (
+	"fmt"
+	"html"
+	"sync"
+
+	"github.com/gideonsigilai/godin/pkg/core"
+	"github.com/gideonsigilai/godin/pkg/renderer"
+	"github.com/gideonsigilai/godin/pkg/state"
+)
+
+// listPatchChannelPrefix prefixes the WebSocket channel a ListListener's item
+// patches are broadcast on
+const listPatchChannelPrefix = "list:"
+
+// listListenerBinding keeps a rendered ListListener subscribed to its list,
+// turning each change into a patch of the item it touched
+type listListenerBinding[T any] struct {
+	mutex   sync.Mutex
+	ctx     *core.Context // Shared context the items are rendered with, see core.Context.DetachShared
+	builder func(item T) Widget
+	stop    func()
+}
+
+// listListenerBindings holds one binding per ListListener element ID, so a list
+// rendered on many pages broadcasts each patch once
+var listListenerBindings = struct {
+	entries map[string]interface{}
+	mutex   sync.Mutex
+}{entries: make(map[string]interface{})}
+
+// ListListener renders a state.ListNotifier and keeps it live over WebSocket.
+// Unlike ValueListener, which rebuilds everything it shows on every change,
+// adding, removing or updating one item with list.Add, list.RemoveAt or
+// list.Update only sends and patches that item's DOM node, which keeps large
+// live lists cheap. ItemBuilder should depend only on the item, since the
+// positions of the other items shift without them being re-rendered, and not on
+// the user: patches go to every page and are rendered without a session.
+type ListListener[T any] struct {
+	ID          string
+	Style       string
+	Class       string
+	List        *state.ListNotifier[T]
+	ItemBuilder func(item T) Widget // Builds the widget for one item
+}
+
+// Render renders the list and subscribes it to item patches
+func (ll ListListener[T]) Render(ctx *core.Context) string {
+	htmlRenderer := renderer.NewHTMLRenderer()
+
+	if ll.List == nil || ll.ItemBuilder == nil {
+		return fmt.Sprintf(`<div class="value-listener-error">%s</div>`, html.EscapeString("ListListener needs a List and an ItemBuilder"))
+	}
+
+	id := ll.ID
+	if id == "" {
+		id = "ll_" + ll.List.ID()
+	}
+
+	if ctx != nil && ctx.App != nil {
+		ll.bind(ctx, id)
+	}
+
+	attrs := buildAttributes(id, ll.Style, ll.Class+" godin-list-listener")
+	attrs["data-list-listener"] = id
+	attrs["data-listener-id"] = id
+
+	content := ""
+	for _, item := range ll.List.Items() {
+		content += renderListListenerItem(ctx, ll.ItemBuilder, item)
+	}
+
+	return htmlRenderer.RenderElement("div", attrs, content, false)
+}
+
+// bind subscribes the element to the list, or refreshes the builder of an
+// existing subscription
+func (ll ListListener[T]) bind(ctx *core.Context, id string) {
+	listListenerBindings.mutex.Lock()
+	defer listListenerBindings.mutex.Unlock()
+
+	if binding, ok := listListenerBindings.entries[id].(*listListenerBinding[T]); ok {
+		binding.mutex.Lock()
+		binding.builder = ll.ItemBuilder
+		binding.mutex.Unlock()
+		return
+	}
+	if previous, ok := listListenerBindings.entries[id].(interface{ release() }); ok {
+		previous.release()
+	}
+
+	// Patches go to every page showing the list, so they are rendered without the
+	// session of whichever request rendered it first or last
+	binding := &listListenerBinding[T]{ctx: ctx.DetachShared(), builder: ll.ItemBuilder}
+	binding.stop = ll.List.Listen(func(change state.ListChange[T]) {
+		binding.patch(id, change)
+	})
+	listListenerBindings.entries[id] = binding
+
+	// Stop patching once the element has left every page
+	ctx.App.Listeners().Register(id, ctx.Session().ID(), func() {
+		listListenerBindings.mutex.Lock()
+		defer listListenerBindings.mutex.Unlock()
+		if listListenerBindings.entries[id] == binding {
+			delete(listListenerBindings.entries, id)
+			binding.release()
+		}
+	})
+}
+
+// release unsubscribes the binding from its list
+func (b *listListenerBinding[T]) release() {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	if b.stop != nil {
+		b.stop()
+		b.stop = nil
+	}
+}
+
+// patch broadcasts the DOM change for a list change
+func (b *listListenerBinding[T]) patch(id string, change state.ListChange[T]) {
+	b.mutex.Lock()
+	base, builder := b.ctx, b.builder
+	b.mutex.Unlock()
+
+	ws := base.App.WebSocket()
+	if !ws.IsEnabled() {
+		return
+	}
+
+	patch := map[string]interface{}{
+		"id":     id,
+		"action": string(change.Kind),
+		"index":  change.Index,
+	}
+	switch change.Kind {
+	case state.ListInsert, state.ListUpdate:
+		patch["html"] = renderListListenerItem(base.DetachShared(), builder, change.Value)
+	case state.ListReset:
+		ctx := base.DetachShared()
+		content := ""
+		for _, item := range change.Items {
+			content += renderListListenerItem(ctx, builder, item)
+		}
+		patch["html"] = content
+	}
+
+	ws.Broadcast(listPatchChannelPrefix+id, patch)
+}
+
+// renderListListenerItem renders one item in the wrapper godin.js patches
+func renderListListenerItem[T any](ctx *core.Context, builder func(item T) Widget, item T) string {
+	htmlRenderer := renderer.NewHTMLRenderer()
+
+	ctx.TrackRender(1)
+	content := ""
+	if widget := builder(item); widget != nil {
+		content = widget.Render(ctx)
+	}
+	return htmlRenderer.RenderElement("div", map[string]string{"class": "godin-list-listener-item"}, content, false)
+}
@@ -0,0 +1,105 @@
+package widgets
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/gideonsigilai/godin/pkg/core"
+	"github.com/gideonsigilai/godin/pkg/renderer"
+)
+
+// defaultMasonryCrossAxisCount is the number of columns when CrossAxisCount is unset
+const defaultMasonryCrossAxisCount = 2
+
+// MasonryGridView arranges children of varying heights into balanced columns,
+// Pinterest style, for image galleries and card feeds. Unlike GridView, items
+// keep their own height and pack under each other instead of lining up in rows.
+//
+// Children flow down the first column before the next, and the column count
+// reflows with the screen width: one column below SM, up to two from SM and
+// CrossAxisCount from MD. XS to XL override the count at a breakpoint.
+//
+//	MasonryGridView{CrossAxisCount: 4, Spacing: 12, Children: cards}
+type MasonryGridView struct {
+	ID             string
+	Style          string
+	Class          string
+	Children       []Widget
+	CrossAxisCount int     // Columns from MD up (default 2)
+	Spacing        float64 // Gap in pixels between columns and between items
+	XS             int     // Columns below SM (default 1)
+	SM             int     // Columns from SM (default up to 2)
+	MD             int     // Columns from MD (default CrossAxisCount)
+	LG             int     // Columns from LG (default CrossAxisCount)
+	XL             int     // Columns from XL (default CrossAxisCount)
+}
+
+// Render renders the masonry grid as HTML
+func (mg MasonryGridView) Render(ctx *core.Context) string {
+	htmlRenderer := renderer.NewHTMLRenderer()
+
+	// Each breakpoint's count is passed as a custom property the shared CSS reads
+	var styles []string
+	for i, count := range mg.columnCounts() {
+		styles = append(styles, fmt.Sprintf("--godin-masonry-%s: %d", responsiveBreakpoints[i], count))
+	}
+	if mg.Spacing > 0 {
+		styles = append(styles, fmt.Sprintf("--godin-masonry-spacing: %.1fpx", mg.Spacing))
+	}
+	if mg.Style != "" {
+		styles = append(styles, mg.Style)
+	}
+
+	attrs := buildAttributes(mg.ID, strings.Join(styles, "; "), mg.Class+" godin-masonry")
+
+	content := ""
+	for _, child := range mg.Children {
+		if child == nil {
+			continue
+		}
+		ctx.TrackRender(1)
+		content += htmlRenderer.RenderElement("div", map[string]string{"class": "godin-masonry-item"}, child.Render(ctx), false)
+	}
+
+	return injectStylesOnce(ctx, "masonry_grid_view", masonryGridCSS(ctx)) +
+		htmlRenderer.RenderElement("div", attrs, content, false)
+}
+
+// columnCounts returns the number of columns at each breakpoint, smallest first
+func (mg MasonryGridView) columnCounts() []int {
+	count := mg.CrossAxisCount
+	if count < 1 {
+		count = defaultMasonryCrossAxisCount
+	}
+	small := count
+	if small > 2 {
+		small = 2
+	}
+
+	counts := []int{1, small, count, count, count}
+	for i, override := range []int{mg.XS, mg.SM, mg.MD, mg.LG, mg.XL} {
+		if override > 0 {
+			counts[i] = override
+		}
+	}
+	return counts
+}
+
+// masonryGridCSS builds the column rules, with a media query per breakpoint at
+// the app's breakpoint widths so custom breakpoints are honored
+func masonryGridCSS(ctx *core.Context) string {
+	breakpoints := ctx.Breakpoints()
+
+	var b strings.Builder
+	b.WriteString(".godin-masonry { column-gap: var(--godin-masonry-spacing, 0px); }\n")
+	b.WriteString(".godin-masonry-item { break-inside: avoid; margin-bottom: var(--godin-masonry-spacing, 0px); }\n")
+	for _, breakpoint := range responsiveBreakpoints {
+		rule := fmt.Sprintf(".godin-masonry { column-count: var(--godin-masonry-%s); }\n", breakpoint)
+		if minWidth := breakpoints.MinWidth(breakpoint); minWidth > 0 {
+			fmt.Fprintf(&b, "@media (min-width: %.0fpx) {\n%s}\n", minWidth, rule)
+		} else {
+			b.WriteString(rule)
+		}
+	}
+	return b.String()
+}
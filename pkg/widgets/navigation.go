@@ -63,57 +63,45 @@ const (
 	SystemUiOverlayStyleDark  SystemUiOverlayStyle = "dark"
 )
 
-// Render renders the app bar as HTML
+// Render renders the app bar as HTML. Layout, height, elevation and colors
+// come from the theme through the godin-appbar classes: the primary color as
+// background and on-primary for text and icons. Fields set on the app bar
+// override them, and Style overrides both.
 func (ab AppBar) Render(ctx *core.Context) string {
 	htmlRenderer := renderer.NewHTMLRenderer()
 
-	attrs := buildAttributes(ab.ID, ab.Style, ab.Class+" godin-appbar")
-
-	// Build inline styles
-	var styles []string
-
-	// Add custom style if provided
-	if ab.Style != "" {
-		styles = append(styles, ab.Style)
+	class := ab.Class + " godin-appbar"
+	if ab.CenterTitle != nil && *ab.CenterTitle {
+		class += " godin-appbar-centered"
 	}
+	attrs := buildAttributes(ab.ID, ab.Style, class)
 
-	// Base app bar styles
-	styles = append(styles, "display: flex")
-	styles = append(styles, "align-items: center")
-	styles = append(styles, "padding: 0 16px")
+	// Build inline styles for the fields that override the theme
+	var styles []string
 
-	// Set default height
-	toolbarHeight := 56.0
 	if ab.ToolbarHeight != nil {
-		toolbarHeight = *ab.ToolbarHeight
+		styles = append(styles, fmt.Sprintf("height: %.1fpx", *ab.ToolbarHeight))
 	}
-	styles = append(styles, fmt.Sprintf("height: %.1fpx", toolbarHeight))
 
-	// Add background color
 	if ab.BackgroundColor != "" {
 		styles = append(styles, fmt.Sprintf("background-color: %s", ab.BackgroundColor))
-	} else {
-		styles = append(styles, "background-color: #2196F3") // Default primary color
 	}
-
-	// Add foreground color
 	if ab.ForegroundColor != "" {
 		styles = append(styles, fmt.Sprintf("color: %s", ab.ForegroundColor))
-	} else {
-		styles = append(styles, "color: white") // Default white text
 	}
 
-	// Add elevation (box shadow)
+	// Add elevation (box shadow); 0 makes the bar flat
 	if ab.Elevation != nil {
-		shadowBlur := *ab.Elevation * 2
-		shadowColor := "rgba(0, 0, 0, 0.2)"
-		if ab.ShadowColor != "" {
-			shadowColor = string(ab.ShadowColor)
+		if *ab.Elevation <= 0 {
+			styles = append(styles, "box-shadow: none")
+		} else {
+			shadowBlur := *ab.Elevation * 2
+			shadowColor := "rgba(0, 0, 0, 0.2)"
+			if ab.ShadowColor != "" {
+				shadowColor = string(ab.ShadowColor)
+			}
+			styles = append(styles, fmt.Sprintf("box-shadow: 0 %.1fpx %.1fpx %s", *ab.Elevation, shadowBlur, shadowColor))
 		}
-		styles = append(styles, fmt.Sprintf("box-shadow: 0 %.1fpx %.1fpx %s", *ab.Elevation, shadowBlur, shadowColor))
-	} else {
-		// Default elevation
-		styles = append(styles, "box-shadow: 0 2px 4px rgba(0, 0, 0, 0.2)")
 	}
 
 	// Add surface tint color (simplified as overlay)
@@ -139,6 +127,11 @@ func (ab AppBar) Render(ctx *core.Context) string {
 		styles = append(styles, "overflow: hidden")
 	}
 
+	// Custom style comes last so it wins over the theme and the fields
+	if ab.Style != "" {
+		styles = append(styles, ab.Style)
+	}
+
 	// Combine all styles
 	if len(styles) > 0 {
 		attrs["style"] = strings.Join(styles, "; ")
@@ -160,18 +153,12 @@ func (ab AppBar) Render(ctx *core.Context) string {
 		titleAttrs := map[string]string{"class": "godin-appbar-title"}
 
 		var titleStyles []string
-		titleStyles = append(titleStyles, "flex: 1")
 
 		// Add title spacing
 		if ab.TitleSpacing != nil {
 			titleStyles = append(titleStyles, fmt.Sprintf("margin-left: %.1fpx", *ab.TitleSpacing))
 		}
 
-		// Center title if specified
-		if ab.CenterTitle != nil && *ab.CenterTitle {
-			titleStyles = append(titleStyles, "text-align: center")
-		}
-
 		// Add title text style
 		if ab.TitleTextStyle != nil {
 			if ab.TitleTextStyle.Color != "" {
@@ -201,7 +188,6 @@ func (ab AppBar) Render(ctx *core.Context) string {
 			}
 		}
 		actionsAttrs := map[string]string{"class": "godin-appbar-actions"}
-		actionsAttrs["style"] = "display: flex; align-items: center; gap: 8px"
 		content += htmlRenderer.RenderContainer("div", actionsAttrs, actionElements)
 	}
 
@@ -482,7 +468,7 @@ func (bnb BottomNavigationBar) Render(ctx *core.Context) string {
 				bnb.OnTap(i)
 				return nil
 			})
-			itemAttrs["hx-post"] = "/handlers/" + handlerID
+			itemAttrs["hx-post"] = appPath(ctx, "/handlers/"+handlerID)
 			itemAttrs["hx-trigger"] = "click"
 		}
 
@@ -580,6 +566,68 @@ type TabController struct {
 	VSync        TickerProvider
 }
 
+// defaultTabControllerKey is the context key under which DefaultTabController shares its controller
+const defaultTabControllerKey = "godin.defaultTabController"
+
+// DefaultTabController shares a TabController with the TabBar and TabBarView widgets below it
+// and keeps the selected tab in the URL hash (e.g. #tab=1), so tabs are deep-linkable and
+// survive refresh and the back button
+type DefaultTabController struct {
+	ID           string
+	Style        string
+	Class        string
+	Length       int    // Number of tabs
+	InitialIndex int    // Tab selected when the URL does not name one
+	HashKey      string // URL hash parameter holding the selected tab (default "tab")
+	Child        Widget // Subtree containing the TabBar and TabBarView
+}
+
+// Render renders the tab controller scope as HTML
+func (dtc DefaultTabController) Render(ctx *core.Context) string {
+	htmlRenderer := renderer.NewHTMLRenderer()
+
+	hashKey := dtc.HashKey
+	if hashKey == "" {
+		hashKey = "tab"
+	}
+
+	initialIndex := dtc.InitialIndex
+	if initialIndex < 0 || (dtc.Length > 0 && initialIndex >= dtc.Length) {
+		initialIndex = 0
+	}
+
+	attrs := buildAttributes(dtc.ID, dtc.Style, dtc.Class+" godin-default-tab-controller")
+	attrs["data-tab-controller"] = "true"
+	attrs["data-tab-hash-key"] = hashKey
+	attrs["data-tab-length"] = fmt.Sprintf("%d", dtc.Length)
+	attrs["data-initial-index"] = fmt.Sprintf("%d", initialIndex)
+	if dtc.Style == "" {
+		attrs["style"] = "display: contents"
+	}
+
+	// Share the controller with descendant TabBar and TabBarView widgets
+	content := ""
+	if dtc.Child != nil {
+		previous := ctx.Get(defaultTabControllerKey)
+		ctx.Set(defaultTabControllerKey, &TabController{Length: dtc.Length, InitialIndex: initialIndex})
+		content = dtc.Child.Render(ctx)
+		ctx.Set(defaultTabControllerKey, previous)
+	}
+
+	return htmlRenderer.RenderElement("div", attrs, content, false)
+}
+
+// selectedTabIndex returns the initially selected tab from an explicit controller or the enclosing DefaultTabController
+func selectedTabIndex(ctx *core.Context, controller *TabController, length int) int {
+	if controller == nil && ctx != nil {
+		controller, _ = ctx.Get(defaultTabControllerKey).(*TabController)
+	}
+	if controller == nil || controller.InitialIndex < 0 || controller.InitialIndex >= length {
+		return 0
+	}
+	return controller.InitialIndex
+}
+
 // TickerProvider interface for animation
 type TickerProvider interface {
 	CreateTicker() Ticker
@@ -663,7 +711,7 @@ func (tb TabBar) Render(ctx *core.Context) string {
 
 	// Add padding
 	if tb.Padding != nil {
-		styles = append(styles, fmt.Sprintf("padding: %s", tb.Padding.ToCSSString()))
+		styles = append(styles, tb.Padding.ToCSSDeclaration("padding"))
 	}
 
 	// Combine all styles
@@ -671,11 +719,19 @@ func (tb TabBar) Render(ctx *core.Context) string {
 		attrs["style"] = strings.Join(styles, "; ")
 	}
 
+	selected := selectedTabIndex(ctx, tb.Controller, len(tb.Tabs))
+
 	// Render tabs
 	var children []string
 	for i, tab := range tb.Tabs {
 		tabAttrs := map[string]string{
-			"class": "godin-tab-item",
+			"class":          "godin-tab-item",
+			"role":           "tab",
+			"data-tab-index": fmt.Sprintf("%d", i),
+			"aria-selected":  fmt.Sprintf("%t", i == selected),
+		}
+		if i == selected {
+			tabAttrs["class"] += " active"
 		}
 
 		// Build tab styles
@@ -696,7 +752,7 @@ func (tb TabBar) Render(ctx *core.Context) string {
 
 		// Add label padding
 		if tb.LabelPadding != nil {
-			tabStyles = append(tabStyles, fmt.Sprintf("padding: %s", tb.LabelPadding.ToCSSString()))
+			tabStyles = append(tabStyles, tb.LabelPadding.ToCSSDeclaration("padding"))
 		}
 
 		if len(tabStyles) > 0 {
@@ -709,7 +765,7 @@ func (tb TabBar) Render(ctx *core.Context) string {
 				tb.OnTap(i)
 				return nil
 			})
-			tabAttrs["hx-post"] = "/handlers/" + handlerID
+			tabAttrs["hx-post"] = appPath(ctx, "/handlers/"+handlerID)
 			tabAttrs["hx-trigger"] = "click"
 		}
 
@@ -727,7 +783,11 @@ func (tb TabBar) Render(ctx *core.Context) string {
 	var indicatorStyles []string
 	indicatorStyles = append(indicatorStyles, "position: absolute")
 	indicatorStyles = append(indicatorStyles, "bottom: 0")
-	indicatorStyles = append(indicatorStyles, "left: 0")
+	if len(tb.Tabs) > 0 {
+		indicatorStyles = append(indicatorStyles, fmt.Sprintf("left: %.1f%%", 100.0*float64(selected)/float64(len(tb.Tabs))))
+	} else {
+		indicatorStyles = append(indicatorStyles, "left: 0")
+	}
 	indicatorStyles = append(indicatorStyles, fmt.Sprintf("height: %.1fpx", tb.IndicatorWeight))
 	indicatorStyles = append(indicatorStyles, "transition: all 0.3s ease")
 
@@ -791,11 +851,16 @@ func (tbv TabBarView) Render(ctx *core.Context) string {
 		attrs["style"] = strings.Join(styles, "; ")
 	}
 
+	selected := selectedTabIndex(ctx, tbv.Controller, len(tbv.Children))
+
 	// Render children as tab panels
 	var children []string
 	for i, child := range tbv.Children {
 		panelAttrs := map[string]string{
-			"class": "godin-tab-panel",
+			"class":          "godin-tab-panel",
+			"role":           "tabpanel",
+			"data-tab-index": fmt.Sprintf("%d", i),
+			"aria-hidden":    fmt.Sprintf("%t", i != selected),
 		}
 
 		// Build panel styles
@@ -807,10 +872,13 @@ func (tbv TabBarView) Render(ctx *core.Context) string {
 		panelStyles = append(panelStyles, "height: 100%")
 		panelStyles = append(panelStyles, "transition: transform 0.3s ease")
 
-		// Only show the first panel by default (in a real implementation, this would be controlled by the TabController)
-		if i == 0 {
+		// Show the selected panel; panels before it sit to the left, panels after it to the right
+		if i == selected {
 			panelStyles = append(panelStyles, "transform: translateX(0)")
 			panelStyles = append(panelStyles, "opacity: 1")
+		} else if i < selected {
+			panelStyles = append(panelStyles, "transform: translateX(-100%)")
+			panelStyles = append(panelStyles, "opacity: 0")
 		} else {
 			panelStyles = append(panelStyles, "transform: translateX(100%)")
 			panelStyles = append(panelStyles, "opacity: 0")
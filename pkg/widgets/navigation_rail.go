@@ -0,0 +1,199 @@
+package widgets
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/gideonsigilai/godin/pkg/core"
+	"github.com/gideonsigilai/godin/pkg/renderer"
+)
+
+// Default NavigationRail widths, as in Material
+const (
+	defaultNavRailMinWidth         = 72.0
+	defaultNavRailMinExtendedWidth = 256.0
+)
+
+// NavRailDestination is one destination of a NavigationRail
+type NavRailDestination struct {
+	Icon         Widget // Icon shown for the destination
+	SelectedIcon Widget // Icon shown while selected (defaults to Icon)
+	Label        string // Label beside the icon when extended, and its tooltip when collapsed
+	Tooltip      string // Tooltip text (defaults to Label)
+}
+
+// NavigationRail is a vertical strip of destinations for the side of wide
+// layouts, the desktop counterpart of BottomNavigationBar. Extended rails show
+// each destination's label beside its icon; collapsed rails show icons only.
+// With ShowExtendToggle, a button at the top lets the user expand and collapse
+// the rail without a round trip. When the rail has an ID, the selected
+// destination is kept in the session, so it survives page loads.
+type NavigationRail struct {
+	ID                    string
+	Style                 string
+	Class                 string
+	Destinations          []NavRailDestination
+	SelectedIndex         int               // Destination selected until the user picks one
+	OnDestinationSelected ValueChanged[int] // Called with the index of the destination picked
+	Extended              bool              // Show labels beside the icons
+	ShowExtendToggle      bool              // Add a button that expands and collapses the rail
+	Leading               Widget            // Shown above the destinations, e.g. a FloatingActionButton
+	Trailing              Widget            // Shown below the destinations
+	BackgroundColor       Color             // Rail background
+	IndicatorColor        Color             // Background of the selected destination
+	SelectedColor         Color             // Icon and label color of the selected destination
+	UnselectedColor       Color             // Icon and label color of the other destinations
+	MinWidth              float64           // Width when collapsed (default 72)
+	MinExtendedWidth      float64           // Width when extended (default 256)
+}
+
+// navRailSelectionKey returns the session key holding a rail's selected index
+func navRailSelectionKey(railID string) string {
+	return "godin.navrail.selection." + railID
+}
+
+// NavigationRailSelection returns the destination last selected in the
+// NavigationRail with the given ID, and whether one has been selected
+func NavigationRailSelection(ctx *core.Context, railID string) (int, bool) {
+	index, ok := ctx.Session().Get(navRailSelectionKey(railID)).(int)
+	return index, ok
+}
+
+// Render renders the navigation rail as HTML
+func (nr NavigationRail) Render(ctx *core.Context) string {
+	htmlRenderer := renderer.NewHTMLRenderer()
+
+	// The selection is stored under the rail's ID; without a stable one it lasts a single render
+	railID := nr.ID
+	selected := nr.SelectedIndex
+	if railID != "" && ctx != nil && ctx.App != nil {
+		if index, ok := NavigationRailSelection(ctx, railID); ok && index < len(nr.Destinations) {
+			selected = index
+		}
+	} else if railID == "" {
+		railID = generateWidgetID()
+	}
+
+	minWidth := nr.MinWidth
+	if minWidth <= 0 {
+		minWidth = defaultNavRailMinWidth
+	}
+	extendedWidth := nr.MinExtendedWidth
+	if extendedWidth <= 0 {
+		extendedWidth = defaultNavRailMinExtendedWidth
+	}
+
+	var styles []string
+	styles = append(styles, fmt.Sprintf("--godin-nav-rail-width: %.1fpx", minWidth))
+	styles = append(styles, fmt.Sprintf("--godin-nav-rail-extended-width: %.1fpx", extendedWidth))
+	if nr.BackgroundColor != "" {
+		styles = append(styles, fmt.Sprintf("background-color: %s", nr.BackgroundColor))
+	}
+	if nr.IndicatorColor != "" {
+		styles = append(styles, fmt.Sprintf("--godin-nav-rail-indicator: %s", nr.IndicatorColor))
+	}
+	if nr.SelectedColor != "" {
+		styles = append(styles, fmt.Sprintf("--godin-nav-rail-selected: %s", nr.SelectedColor))
+	}
+	if nr.UnselectedColor != "" {
+		styles = append(styles, fmt.Sprintf("--godin-nav-rail-unselected: %s", nr.UnselectedColor))
+	}
+	if nr.Style != "" {
+		styles = append(styles, nr.Style)
+	}
+
+	class := nr.Class + " godin-nav-rail"
+	if nr.Extended {
+		class += " godin-nav-rail-extended"
+	}
+	attrs := buildAttributes(railID, strings.Join(styles, "; "), class)
+	attrs["data-nav-rail"] = "true"
+
+	content := ""
+	if nr.ShowExtendToggle {
+		label := "Expand navigation"
+		if nr.Extended {
+			label = "Collapse navigation"
+		}
+		content += htmlRenderer.RenderElement("button", map[string]string{
+			"type":                 "button",
+			"class":                "godin-nav-rail-toggle",
+			"data-nav-rail-toggle": "true",
+			"aria-label":           label,
+			"aria-controls":        railID,
+			"aria-expanded":        strconv.FormatBool(nr.Extended),
+		}, Icon{Icon: IconMenu}.Render(ctx), false)
+	}
+	if nr.Leading != nil {
+		content += htmlRenderer.RenderElement("div", map[string]string{"class": "godin-nav-rail-leading"}, nr.Leading.Render(ctx), false)
+	}
+
+	destinations := ""
+	for i, destination := range nr.Destinations {
+		ctx.TrackRender(1)
+		destinations += nr.renderDestination(ctx, railID, i, destination, i == selected)
+	}
+	content += htmlRenderer.RenderElement("div", map[string]string{"class": "godin-nav-rail-destinations"}, destinations, false)
+
+	if nr.Trailing != nil {
+		content += htmlRenderer.RenderElement("div", map[string]string{"class": "godin-nav-rail-trailing"}, nr.Trailing.Render(ctx), false)
+	}
+
+	return htmlRenderer.RenderElement("nav", attrs, content, false)
+}
+
+// renderDestination renders one destination as a button that selects it
+func (nr NavigationRail) renderDestination(ctx *core.Context, railID string, index int, destination NavRailDestination, selected bool) string {
+	htmlRenderer := renderer.NewHTMLRenderer()
+
+	attrs := map[string]string{
+		"type":                "button",
+		"class":               "godin-nav-rail-destination",
+		"data-nav-rail-index": strconv.Itoa(index),
+	}
+	if selected {
+		attrs["class"] += " godin-nav-rail-selected"
+		attrs["aria-current"] = "page"
+	}
+	tooltip := destination.Tooltip
+	if tooltip == "" {
+		tooltip = destination.Label
+	}
+	if tooltip != "" {
+		attrs["title"] = tooltip
+	}
+
+	// godin.js moves the selection straight away; the handler records it and notifies the app
+	if ctx != nil && ctx.App != nil {
+		onSelected := nr.OnDestinationSelected
+		persist := nr.ID != ""
+		handlerID := ctx.RegisterHandler(func(ctx *core.Context) Widget {
+			if persist {
+				ctx.Session().Set(navRailSelectionKey(railID), index)
+			}
+			if onSelected != nil {
+				onSelected(index)
+			}
+			return nil
+		})
+		attrs["hx-post"] = appPath(ctx, "/handlers/"+handlerID)
+		attrs["hx-trigger"] = "click"
+		attrs["hx-swap"] = "none"
+	}
+
+	// Both icons are rendered so the selected one can show as soon as the selection moves
+	content := ""
+	if destination.Icon != nil {
+		content += htmlRenderer.RenderElement("span", map[string]string{"class": "godin-nav-rail-icon"}, destination.Icon.Render(ctx), false)
+	}
+	if destination.SelectedIcon != nil {
+		attrs["class"] += " godin-nav-rail-has-selected-icon"
+		content += htmlRenderer.RenderElement("span", map[string]string{"class": "godin-nav-rail-icon godin-nav-rail-selected-icon"}, destination.SelectedIcon.Render(ctx), false)
+	}
+	if destination.Label != "" {
+		content += htmlRenderer.RenderElement("span", map[string]string{"class": "godin-nav-rail-label"}, htmlRenderer.RenderText(destination.Label), false)
+	}
+
+	return htmlRenderer.RenderElement("button", attrs, content, false)
+}
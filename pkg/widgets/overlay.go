@@ -0,0 +1,94 @@
+package widgets
+
+import (
+	"fmt"
+	"html"
+	"time"
+
+	"github.com/gideonsigilai/godin/pkg/core"
+	"github.com/gideonsigilai/godin/pkg/renderer"
+)
+
+// OverlayHostID is the DOM id of the framework-managed overlay layer in the base template
+const OverlayHostID = "godin-overlay"
+
+// overlayChannel is the WebSocket channel used to push and remove overlay entries
+const overlayChannel = "overlay"
+
+// overlayEntry describes one dialog, sheet or menu pushed into the overlay layer
+type overlayEntry struct {
+	ID                string
+	Kind              string // "dialog", "bottom-sheet", ...
+	ZIndex            int
+	Modal             bool // Shows the shared scrim
+	Dismissible       bool // Scrim click and Escape dismiss the entry
+	DismissCallbackID string
+}
+
+// dialogManagerSessionKey is the session value holding the session's DialogManager
+const dialogManagerSessionKey = "godin.dialogs"
+
+// dialogManagerFor returns the DialogManager of the browser's session, creating one
+// on first use, bound to the context of the current request
+func dialogManagerFor(ctx *core.Context) *DialogManager {
+	if ctx == nil || ctx.App == nil {
+		return nil
+	}
+
+	dm := ctx.Session().GetOrSet(dialogManagerSessionKey, func() interface{} {
+		return NewDialogManager(ctx)
+	}).(*DialogManager)
+
+	dm.bindContext(ctx)
+	return dm
+}
+
+// renderOverlayEntry wraps rendered content in an overlay entry element
+func renderOverlayEntry(ctx *core.Context, entry overlayEntry, content string) string {
+	htmlRenderer := renderer.NewHTMLRenderer()
+
+	attrs := map[string]string{
+		"id":                  entry.ID,
+		"class":               "godin-overlay-entry godin-overlay-" + entry.Kind,
+		"style":               fmt.Sprintf("z-index: %d", entry.ZIndex),
+		"data-overlay-kind":   entry.Kind,
+		"data-overlay-modal":  fmt.Sprintf("%t", entry.Modal),
+		"data-overlay-zindex": fmt.Sprintf("%d", entry.ZIndex),
+	}
+	if entry.Dismissible && entry.DismissCallbackID != "" {
+		attrs["data-overlay-dismiss"] = appPath(ctx, "/api/callbacks/"+entry.DismissCallbackID)
+	}
+
+	return htmlRenderer.RenderElement("div", attrs, content, false)
+}
+
+// pushOverlayEntry sends an entry to the session's connections as an out-of-band swap into the overlay host
+func pushOverlayEntry(ctx *core.Context, entry overlayEntry, content string) {
+	if ctx == nil || ctx.App == nil || !ctx.App.WebSocket().IsEnabled() {
+		return
+	}
+
+	entryHTML := renderOverlayEntry(ctx, entry, content)
+	oob := fmt.Sprintf(`<div hx-swap-oob="beforeend:#%s">%s</div>`, OverlayHostID, entryHTML)
+
+	ctx.App.WebSocket().SendToClient(ctx.Session().ID(), overlayChannel, map[string]interface{}{
+		"action":    "push",
+		"id":        entry.ID,
+		"html":      entryHTML,
+		"oob":       oob,
+		"timestamp": time.Now().Unix(),
+	})
+}
+
+// removeOverlayEntry removes an entry from the overlay host on the session's connections
+func removeOverlayEntry(ctx *core.Context, id string) {
+	if ctx == nil || ctx.App == nil || !ctx.App.WebSocket().IsEnabled() {
+		return
+	}
+
+	ctx.App.WebSocket().SendToClient(ctx.Session().ID(), overlayChannel, map[string]interface{}{
+		"action":    "remove",
+		"id":        html.EscapeString(id),
+		"timestamp": time.Now().Unix(),
+	})
+}
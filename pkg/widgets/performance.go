@@ -2,6 +2,8 @@ package widgets
 
 import (
 	"fmt"
+	"html"
+	"net/url"
 	"runtime"
 	"sync"
 	"time"
@@ -590,3 +592,84 @@ func CleanupPerformanceOptimizations() {
 		GlobalRenderBatcher.Flush()
 	}
 }
+
+// Cached renders its built child once and reuses the HTML until the TTL expires.
+// Renders are kept per theme brightness and locale; core.InvalidateCache(Key)
+// drops all of them. The HTML is shared by every user, so Cached must only wrap
+// content that is the same for everyone: nothing per user or per session, such
+// as names, CSRF tokens or session state. Renders with callbacks or handlers,
+// whose IDs belong to one render, are never cached.
+type Cached struct {
+	Key   string        // Cache key shared across requests
+	TTL   time.Duration // How long the render stays valid (zero means until invalidated)
+	Build func() Widget // Builds the widget to cache
+}
+
+// Render renders the cached widget, building it only on a cache miss
+func (c Cached) Render(ctx *core.Context) string {
+	if c.Build == nil {
+		return ""
+	}
+
+	// Without a key there is nothing to cache against
+	if c.Key == "" {
+		widget := c.Build()
+		if widget == nil {
+			return ""
+		}
+		return widget.Render(ctx)
+	}
+
+	// Each theme and locale gets its own render of the key
+	cache := core.GetRenderCache()
+	variant := ctx.RenderVariant()
+	if html, ok := cache.GetVariant(c.Key, variant); ok {
+		return html
+	}
+
+	widget := c.Build()
+	if widget == nil {
+		return ""
+	}
+
+	html := widget.Render(ctx)
+	if ids := core.HandlerIDs(html); len(ids) > 0 {
+		if _, warned := uncachedKeys.LoadOrStore(c.Key, true); !warned {
+			fmt.Printf("Not caching %s: it renders callbacks, which cannot be shared\n", c.Key)
+		}
+		return html
+	}
+	cache.SetVariant(c.Key, variant, html, c.TTL)
+	return html
+}
+
+// uncachedKeys holds the Cached keys already reported as rendering callbacks
+var uncachedKeys sync.Map
+
+// RepaintBoundary marks a subtree as independently updatable. Handlers can call
+// ctx.Rebuild(ID) to re-render and swap just this subtree, and HTMX elements can
+// target it via GET /api/boundary/{ID}.
+type RepaintBoundary struct {
+	ID    string // Unique boundary ID, also used as the DOM id
+	Child Widget // Subtree to render inside the boundary
+}
+
+// Render renders the boundary wrapper and registers the subtree for rebuilds
+func (rb RepaintBoundary) Render(ctx *core.Context) string {
+	content := ""
+	if rb.Child != nil {
+		content = rb.Child.Render(ctx)
+	}
+
+	if rb.ID == "" {
+		return content
+	}
+
+	if ctx != nil && ctx.App != nil {
+		ctx.Boundaries().Register(rb.ID, rb.Child)
+	}
+
+	id := html.EscapeString(rb.ID)
+	return fmt.Sprintf(`<div id="%s" class="godin-repaint-boundary" data-repaint-boundary="%s" hx-get="%s" hx-trigger="godin:rebuild" hx-swap="innerHTML">%s</div>`,
+		id, id, html.EscapeString(appPath(ctx, "/api/boundary/"+url.PathEscape(rb.ID))), content)
+}
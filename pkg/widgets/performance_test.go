@@ -0,0 +1,42 @@
+package widgets
+
+import (
+	"testing"
+
+	"github.com/gideonsigilai/godin/pkg/core"
+)
+
+func TestCachedReusesRender(t *testing.T) {
+	builds := 0
+	cached := Cached{Key: "test:static", Build: func() Widget {
+		builds++
+		return MockWidget{Content: "<p>Static</p>"}
+	}}
+	defer core.InvalidateCache(cached.Key)
+
+	ctx := core.NewTestContext()
+	for i := 0; i < 3; i++ {
+		if html := ctx.Render(cached); html != "<p>Static</p>" {
+			t.Errorf("Expected the cached HTML, got %q", html)
+		}
+	}
+	if builds != 1 {
+		t.Errorf("Expected one build, got %d", builds)
+	}
+}
+
+func TestCachedSkipsRendersWithCallbacks(t *testing.T) {
+	builds := 0
+	cached := Cached{Key: "test:interactive", Build: func() Widget {
+		builds++
+		return MockWidget{Content: `<button hx-post="/api/callbacks/0123456789abcdef">Save</button>`}
+	}}
+	defer core.InvalidateCache(cached.Key)
+
+	ctx := core.NewTestContext()
+	ctx.Render(cached)
+	ctx.Render(cached)
+	if builds != 2 {
+		t.Errorf("Expected a render with callbacks to be built every time, got %d builds", builds)
+	}
+}
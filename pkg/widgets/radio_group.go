@@ -0,0 +1,106 @@
+package widgets
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/gideonsigilai/godin/pkg/core"
+	"github.com/gideonsigilai/godin/pkg/renderer"
+)
+
+// radioGroupKey is the context key under which RadioGroup shares its value with descendant radios
+const radioGroupKey = "godin.radioGroup"
+
+// radioGroupScope is what a RadioGroup shares with the Radio[T]s under it
+type radioGroupScope[T comparable] struct {
+	name      string          // Input name shared by the group's radios
+	value     T               // Selected value
+	onChanged ValueChanged[T] // Called with the value of the radio picked
+	enabled   *bool           // Enabled state for radios without their own
+}
+
+// RadioGroup coordinates the Radio[T]s among its descendants: each radio is
+// checked when its Value equals the group's Value and calls the group's
+// OnChanged when picked, so radios need no GroupValue pointer or callback of
+// their own. Radios that set GroupValue or OnChanged keep them.
+//
+//	widgets.RadioGroup[string]{
+//		Value:     plan,
+//		OnChanged: func(value string) { plan = value },
+//		Children: []widgets.Widget{
+//			widgets.Row{Children: []widgets.Widget{widgets.Radio[string]{Value: "free"}, widgets.Text{Data: "Free"}}},
+//			widgets.Row{Children: []widgets.Widget{widgets.Radio[string]{Value: "pro"}, widgets.Text{Data: "Pro"}}},
+//		},
+//	}
+type RadioGroup[T comparable] struct {
+	ID        string
+	Style     string
+	Class     string
+	Value     T               // Selected value
+	OnChanged ValueChanged[T] // Called with the value of the radio picked
+	Enabled   *bool           // Enabled state; nil means enabled when OnChanged is set
+	Children  []Widget        // Radios, and the rows or tiles that hold them
+	Direction Axis            // Layout of the children (default vertical)
+	Spacing   float64         // Gap between children in pixels
+	Semantics string          // Accessible name of the group
+}
+
+// Render renders the radio group as HTML
+func (rg RadioGroup[T]) Render(ctx *core.Context) string {
+	htmlRenderer := renderer.NewHTMLRenderer()
+
+	// Radios share the group's input name so the browser checks only one
+	id := rg.ID
+	if id == "" {
+		id = generateWidgetID()
+	}
+
+	var styles []string
+	styles = append(styles, "display: flex")
+	if rg.Direction == AxisHorizontal {
+		styles = append(styles, "flex-direction: row", "flex-wrap: wrap", "align-items: center")
+	} else {
+		styles = append(styles, "flex-direction: column")
+	}
+	if rg.Spacing > 0 {
+		styles = append(styles, fmt.Sprintf("gap: %.1fpx", rg.Spacing))
+	}
+	if rg.Style != "" {
+		styles = append(styles, rg.Style)
+	}
+
+	attrs := buildAttributes(id, strings.Join(styles, "; "), rg.Class+" godin-radio-group")
+	attrs["role"] = "radiogroup"
+	if rg.Semantics != "" {
+		attrs["aria-label"] = rg.Semantics
+	}
+
+	content := ""
+	if ctx != nil {
+		previous := ctx.Get(radioGroupKey)
+		ctx.Set(radioGroupKey, &radioGroupScope[T]{
+			name:      id,
+			value:     rg.Value,
+			onChanged: rg.OnChanged,
+			enabled:   rg.Enabled,
+		})
+		for _, child := range rg.Children {
+			if child != nil {
+				ctx.TrackRender(1)
+				content += child.Render(ctx)
+			}
+		}
+		ctx.Set(radioGroupKey, previous)
+	}
+
+	return htmlRenderer.RenderElement("div", attrs, content, false)
+}
+
+// enclosingRadioGroup returns the scope of the RadioGroup[T] a radio is rendered in, if any
+func enclosingRadioGroup[T comparable](ctx *core.Context) *radioGroupScope[T] {
+	if ctx == nil {
+		return nil
+	}
+	group, _ := ctx.Get(radioGroupKey).(*radioGroupScope[T])
+	return group
+}
@@ -0,0 +1,130 @@
+package widgets
+
+import (
+	"strconv"
+	"sync"
+
+	"github.com/gideonsigilai/godin/pkg/core"
+	"github.com/gideonsigilai/godin/pkg/renderer"
+)
+
+// refreshIndicatorEvent is the client event godin.js handles by refreshing a RefreshIndicator
+const refreshIndicatorEvent = "godin:refresh"
+
+// defaultRefreshDisplacement is how far in pixels the spinner settles below the top while refreshing
+const defaultRefreshDisplacement = 40.0
+
+// RefreshIndicatorController starts a RefreshIndicator's refresh from handlers,
+// as if the user had pulled it down. Pass the same controller to the
+// RefreshIndicator on every render.
+type RefreshIndicatorController struct {
+	elementID string
+	mutex     sync.Mutex
+}
+
+// NewRefreshIndicatorController creates a refresh indicator controller
+func NewRefreshIndicatorController() *RefreshIndicatorController {
+	return &RefreshIndicatorController{}
+}
+
+// Show shows the spinner and runs OnRefresh once the current response is swapped in
+func (c *RefreshIndicatorController) Show(ctx *core.Context) {
+	c.mutex.Lock()
+	id := c.elementID
+	c.mutex.Unlock()
+
+	if ctx != nil && id != "" {
+		ctx.TriggerAfterSettle(refreshIndicatorEvent, map[string]string{"id": id})
+	}
+}
+
+// attach ties the controller to a RefreshIndicator element, giving it a stable ID
+func (c *RefreshIndicatorController) attach(id string) string {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if id == "" {
+		if c.elementID == "" {
+			c.elementID = generateWidgetID()
+		}
+		id = c.elementID
+	}
+	c.elementID = id
+	return id
+}
+
+// RefreshIndicator adds pull-to-refresh to its Child: on touch devices, pulling
+// the content down while it is scrolled to the top and releasing past the
+// threshold shows a spinner and calls OnRefresh, whose widget replaces Child.
+// Refreshes can also be started from handlers with a Controller, or from
+// scripts with Godin.refresh(id).
+type RefreshIndicator struct {
+	ID              string
+	Style           string
+	Class           string
+	Child           Widget                      // Scrollable content, e.g. a ListView
+	OnRefresh       func() Widget               // Loads fresh content; a nil widget keeps Child
+	Color           Color                       // Spinner color (defaults to the primary color)
+	BackgroundColor Color                       // Background of the spinner's circle
+	Displacement    float64                     // Distance in pixels the spinner settles below the top (default 40)
+	Controller      *RefreshIndicatorController // Starts refreshes from handlers
+}
+
+// Render renders the refresh indicator as HTML
+func (ri RefreshIndicator) Render(ctx *core.Context) string {
+	htmlRenderer := renderer.NewHTMLRenderer()
+
+	id := ri.ID
+	if ri.Controller != nil {
+		id = ri.Controller.attach(id)
+	} else if id == "" {
+		id = generateWidgetID()
+	}
+
+	displacement := ri.Displacement
+	if displacement <= 0 {
+		displacement = defaultRefreshDisplacement
+	}
+
+	attrs := buildAttributes(id, ri.Style, ri.Class+" godin-refresh-indicator")
+	attrs["data-refresh-indicator"] = "true"
+	attrs["data-refresh-displacement"] = strconv.FormatFloat(displacement, 'f', -1, 64)
+
+	if ri.OnRefresh != nil && ctx != nil && ctx.App != nil {
+		onRefresh := ri.OnRefresh
+		handlerID := ctx.RegisterHandler(func(ctx *core.Context) Widget {
+			widget := onRefresh()
+			if widget == nil {
+				ctx.SetHeader(renderer.HXReswap, "none")
+			}
+			return widget
+		})
+		attrs["data-refresh-url"] = appPath(ctx, "/handlers/"+handlerID)
+	}
+
+	// Spinner, hidden above the content until pulled down
+	spinnerStyle := ""
+	if ri.Color != "" {
+		spinnerStyle += "color: " + string(ri.Color) + "; "
+	}
+	if ri.BackgroundColor != "" {
+		spinnerStyle += "background-color: " + string(ri.BackgroundColor) + "; "
+	}
+	spinnerAttrs := map[string]string{
+		"class":       "godin-refresh-indicator-spinner",
+		"aria-hidden": "true",
+	}
+	if spinnerStyle != "" {
+		spinnerAttrs["style"] = spinnerStyle
+	}
+	spinner := htmlRenderer.RenderElement("div", spinnerAttrs,
+		htmlRenderer.RenderElement("span", map[string]string{"class": "godin-refresh-indicator-arc"}, "", false), false)
+
+	child := ""
+	if ri.Child != nil {
+		child = ri.Child.Render(ctx)
+	}
+	content := htmlRenderer.RenderElement("div", map[string]string{"class": "godin-refresh-indicator-content"}, child, false)
+
+	return htmlRenderer.RenderElement("div", attrs, spinner+content, false)
+}
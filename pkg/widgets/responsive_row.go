@@ -0,0 +1,146 @@
+package widgets
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/gideonsigilai/godin/pkg/core"
+	"github.com/gideonsigilai/godin/pkg/renderer"
+)
+
+// responsiveGridColumns is the number of columns a ResponsiveRow is divided into
+const responsiveGridColumns = 12
+
+// responsiveBreakpoints lists the breakpoints smallest first, the order their spans cascade in
+var responsiveBreakpoints = []core.Breakpoint{
+	core.BreakpointXS,
+	core.BreakpointSM,
+	core.BreakpointMD,
+	core.BreakpointLG,
+	core.BreakpointXL,
+}
+
+// ResponsiveRow lays its columns out on a 12-column grid that reflows with the
+// screen width. Each column spans a number of the 12 columns at each breakpoint;
+// columns that no longer fit wrap onto the next line.
+//
+//	ResponsiveRow{Children: []ResponsiveColumn{
+//		{XS: 12, MD: 6, LG: 4, Child: card1},
+//		{XS: 12, MD: 6, LG: 4, Child: card2},
+//		{XS: 12, MD: 12, LG: 4, Child: card3},
+//	}}
+type ResponsiveRow struct {
+	ID         string
+	Style      string
+	Class      string
+	Children   []ResponsiveColumn
+	Spacing    float64 // Gap in pixels between columns
+	RunSpacing float64 // Gap in pixels between lines of columns
+}
+
+// ResponsiveColumn is one column of a ResponsiveRow. A span of 0 keeps the span
+// of the next smaller breakpoint, so XS: 12, MD: 6 spans the full row below MD
+// and half of it from MD up. XS defaults to the full row.
+type ResponsiveColumn struct {
+	ID     string
+	Style  string
+	Class  string
+	Child  Widget
+	XS     int // Columns spanned below SM
+	SM     int // Columns spanned from SM
+	MD     int // Columns spanned from MD
+	LG     int // Columns spanned from LG
+	XL     int // Columns spanned from XL
+	Offset int // Columns left empty before this one on its line
+}
+
+// Render renders the responsive row as HTML
+func (rr ResponsiveRow) Render(ctx *core.Context) string {
+	htmlRenderer := renderer.NewHTMLRenderer()
+
+	styles := []string{}
+	if rr.Spacing > 0 {
+		styles = append(styles, fmt.Sprintf("column-gap: %.1fpx", rr.Spacing))
+	}
+	if rr.RunSpacing > 0 {
+		styles = append(styles, fmt.Sprintf("row-gap: %.1fpx", rr.RunSpacing))
+	}
+	if rr.Style != "" {
+		styles = append(styles, rr.Style)
+	}
+
+	attrs := buildAttributes(rr.ID, strings.Join(styles, "; "), rr.Class+" godin-responsive-row")
+
+	content := ""
+	for _, column := range rr.Children {
+		ctx.TrackRender(1)
+		content += column.Render(ctx)
+	}
+
+	return injectStylesOnce(ctx, "responsive_row", responsiveGridCSS(ctx)) +
+		htmlRenderer.RenderElement("div", attrs, content, false)
+}
+
+// Render renders the responsive column as HTML
+func (rc ResponsiveColumn) Render(ctx *core.Context) string {
+	htmlRenderer := renderer.NewHTMLRenderer()
+
+	classes := []string{rc.Class, "godin-responsive-col"}
+	for i, span := range []int{rc.XS, rc.SM, rc.MD, rc.LG, rc.XL} {
+		if span > 0 {
+			classes = append(classes, fmt.Sprintf("godin-col-%s-%d", responsiveBreakpoints[i], clampSpan(span)))
+		}
+	}
+
+	style := rc.Style
+	if rc.Offset > 0 {
+		// The offset pushes the column along, so it starts a fixed number of columns in
+		offset := fmt.Sprintf("grid-column-start: %d", clampSpan(rc.Offset)+1)
+		if style != "" {
+			offset += "; " + style
+		}
+		style = offset
+	}
+
+	attrs := buildAttributes(rc.ID, style, strings.Join(classes, " "))
+
+	content := ""
+	if rc.Child != nil {
+		content = rc.Child.Render(ctx)
+	}
+
+	return htmlRenderer.RenderElement("div", attrs, content, false)
+}
+
+// clampSpan keeps a span within the grid
+func clampSpan(span int) int {
+	if span < 1 {
+		return 1
+	}
+	if span > responsiveGridColumns {
+		return responsiveGridColumns
+	}
+	return span
+}
+
+// responsiveGridCSS builds the grid classes, with a media query per breakpoint
+// at the app's breakpoint widths so custom breakpoints are honored
+func responsiveGridCSS(ctx *core.Context) string {
+	breakpoints := ctx.Breakpoints()
+
+	var b strings.Builder
+	fmt.Fprintf(&b, ".godin-responsive-row { display: grid; grid-template-columns: repeat(%d, minmax(0, 1fr)); }\n", responsiveGridColumns)
+	fmt.Fprintf(&b, ".godin-responsive-col { grid-column-end: span %d; min-width: 0; }\n", responsiveGridColumns)
+	for _, breakpoint := range responsiveBreakpoints {
+		rules := ""
+		for span := 1; span <= responsiveGridColumns; span++ {
+			rules += fmt.Sprintf(".godin-col-%s-%d { grid-column-end: span %d; }\n", breakpoint, span, span)
+		}
+		if minWidth := breakpoints.MinWidth(breakpoint); minWidth > 0 {
+			fmt.Fprintf(&b, "@media (min-width: %.0fpx) {\n%s}\n", minWidth, rules)
+		} else {
+			b.WriteString(rules)
+		}
+	}
+	return b.String()
+}
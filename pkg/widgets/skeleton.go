@@ -0,0 +1,172 @@
+package widgets
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/gideonsigilai/godin/pkg/core"
+	"github.com/gideonsigilai/godin/pkg/renderer"
+)
+
+// shimmerCSS holds the keyframes and base classes shared by all skeleton widgets
+const shimmerCSS = `@keyframes godin-shimmer {
+	0% { background-position: -468px 0; }
+	100% { background-position: 468px 0; }
+}
+.godin-skeleton {
+	background-color: var(--godin-skeleton-color, #e0e0e0);
+	background-image: linear-gradient(90deg, transparent 0px, rgba(255, 255, 255, 0.6) 40px, transparent 80px);
+	background-size: 936px 100%;
+	background-repeat: no-repeat;
+	animation: godin-shimmer 1.2s linear infinite;
+}
+.godin-shimmer-paused .godin-skeleton {
+	animation: none;
+}
+.godin-shimmer > *:not(.godin-skeleton) {
+	color: transparent !important;
+	pointer-events: none;
+}
+@media (prefers-reduced-motion: reduce) {
+	.godin-skeleton { animation: none; }
+}`
+
+// Shimmer represents a loading placeholder that animates its skeleton children
+type Shimmer struct {
+	ID        string
+	Style     string
+	Class     string
+	Child     Widget // Skeleton layout shown while loading
+	BaseColor Color  // Base placeholder color
+	Enabled   *bool  // Whether the shimmer animation runs (defaults to true)
+}
+
+// Render renders the shimmer as HTML
+func (s Shimmer) Render(ctx *core.Context) string {
+	htmlRenderer := renderer.NewHTMLRenderer()
+
+	class := s.Class + " godin-shimmer"
+	if s.Enabled != nil && !*s.Enabled {
+		class += " godin-shimmer-paused"
+	}
+
+	attrs := buildAttributes(s.ID, s.Style, class)
+	attrs["aria-busy"] = "true"
+	attrs["aria-live"] = "polite"
+
+	var styles []string
+	if s.Style != "" {
+		styles = append(styles, s.Style)
+	}
+	if s.BaseColor != "" {
+		styles = append(styles, fmt.Sprintf("--godin-skeleton-color: %s", s.BaseColor))
+	}
+	if len(styles) > 0 {
+		attrs["style"] = strings.Join(styles, "; ")
+	}
+
+	content := ""
+	if s.Child != nil {
+		content = s.Child.Render(ctx)
+	}
+
+	return injectStylesOnce(ctx, "shimmer", shimmerCSS) + htmlRenderer.RenderElement("div", attrs, content, false)
+}
+
+// SkeletonBox represents a rectangular loading placeholder
+type SkeletonBox struct {
+	ID           string
+	Style        string
+	Class        string
+	Width        *float64 // Box width in pixels (defaults to full width)
+	Height       *float64 // Box height in pixels
+	BorderRadius *float64 // Corner radius in pixels
+	Circle       bool     // Render as a circle (for avatars)
+}
+
+// Render renders the skeleton box as HTML
+func (sb SkeletonBox) Render(ctx *core.Context) string {
+	htmlRenderer := renderer.NewHTMLRenderer()
+
+	attrs := buildAttributes(sb.ID, sb.Style, sb.Class+" godin-skeleton godin-skeleton-box")
+	attrs["aria-hidden"] = "true"
+
+	var styles []string
+	if sb.Style != "" {
+		styles = append(styles, sb.Style)
+	}
+
+	if sb.Width != nil {
+		styles = append(styles, fmt.Sprintf("width: %.1fpx", *sb.Width))
+	} else {
+		styles = append(styles, "width: 100%")
+	}
+
+	if sb.Height != nil {
+		styles = append(styles, fmt.Sprintf("height: %.1fpx", *sb.Height))
+	} else {
+		styles = append(styles, "height: 48px")
+	}
+
+	if sb.Circle {
+		styles = append(styles, "border-radius: 50%")
+	} else if sb.BorderRadius != nil {
+		styles = append(styles, fmt.Sprintf("border-radius: %.1fpx", *sb.BorderRadius))
+	} else {
+		styles = append(styles, "border-radius: 4px")
+	}
+
+	attrs["style"] = strings.Join(styles, "; ")
+
+	return injectStylesOnce(ctx, "shimmer", shimmerCSS) + htmlRenderer.RenderElement("div", attrs, "", false)
+}
+
+// SkeletonLine represents one or more text-line loading placeholders
+type SkeletonLine struct {
+	ID     string
+	Style  string
+	Class  string
+	Lines  int      // Number of lines (defaults to 1)
+	Height *float64 // Line height in pixels (defaults to 12)
+	Width  string   // CSS width of each line (defaults to 100%)
+}
+
+// Render renders the skeleton lines as HTML
+func (sl SkeletonLine) Render(ctx *core.Context) string {
+	htmlRenderer := renderer.NewHTMLRenderer()
+
+	attrs := buildAttributes(sl.ID, sl.Style, sl.Class+" godin-skeleton-lines")
+	attrs["aria-hidden"] = "true"
+
+	lines := sl.Lines
+	if lines <= 0 {
+		lines = 1
+	}
+
+	height := 12.0
+	if sl.Height != nil {
+		height = *sl.Height
+	}
+
+	width := sl.Width
+	if width == "" {
+		width = "100%"
+	}
+
+	var children []string
+	for i := 0; i < lines; i++ {
+		lineWidth := width
+		// Shorten the last line of a paragraph so it reads like text
+		if lines > 1 && i == lines-1 {
+			lineWidth = "60%"
+		}
+
+		lineAttrs := map[string]string{
+			"class": "godin-skeleton godin-skeleton-line",
+			"style": fmt.Sprintf("width: %s; height: %.1fpx; border-radius: 4px; margin-bottom: 8px", lineWidth, height),
+		}
+		children = append(children, htmlRenderer.RenderElement("div", lineAttrs, "", false))
+	}
+
+	return injectStylesOnce(ctx, "shimmer", shimmerCSS) + htmlRenderer.RenderElement("div", attrs, strings.Join(children, ""), false)
+}
@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"html"
+	"log"
 	"net/http"
 	"sync"
 	"time"
@@ -27,6 +28,7 @@ func (vlb *ValueListenableBuilder) Render(ctx *core.Context) string {
 
 	// Get current value and build widget
 	value := vlb.ValueListenable.GetValue()
+	ctx.TrackRender(1)
 	widget := vlb.Builder(value)
 
 	if widget == nil {
@@ -54,6 +56,7 @@ func (sb *StreamBuilder) Render(ctx *core.Context) string {
 	// register the stream with the WebSocket manager
 
 	// For now, just render with nil data
+	ctx.TrackRender(1)
 	widget := sb.Builder(nil)
 	if widget == nil {
 		return ""
@@ -83,6 +86,7 @@ func (fb *FutureBuilder) Render(ctx *core.Context) string {
 	// Execute future
 	data = fb.Future()
 
+	ctx.TrackRender(1)
 	widget := fb.Builder(data, false, err)
 	if widget == nil {
 		return ""
@@ -104,6 +108,7 @@ func (sb *StateBuilder) Render(ctx *core.Context) string {
 		return ""
 	}
 
+	ctx.TrackRender(1)
 	widget := sb.Builder(sb.State)
 	if widget == nil {
 		return ""
@@ -115,8 +120,12 @@ func (sb *StateBuilder) Render(ctx *core.Context) string {
 // Consumer represents a widget that consumes state changes
 type Consumer struct {
 	HTMXWidget
-	StateKey string
-	Builder  func(value interface{}) Widget
+	StateKey    string
+	Builder     func(value interface{}) Widget
+	Placeholder Widget // Shown instead of Builder while the state value is nil (e.g. a Shimmer)
+	// ErrorBuilder is shown instead of Builder when it panics, e.g. on a value
+	// of an unexpected type; it receives the recovered panic value
+	ErrorBuilder func(err interface{}) Widget
 }
 
 // Render renders the consumer as HTML
@@ -125,41 +134,177 @@ func (c *Consumer) Render(ctx *core.Context) string {
 		return ""
 	}
 
-	// Get state from context (assuming it's available)
-	stateManager := ctx.App.State()
-	value := stateManager.Get(c.StateKey)
-
-	widget := c.Builder(value)
+	widget := c.build(ctx)
 	if widget == nil {
 		return ""
 	}
 
-	// Register a custom endpoint for this specific Consumer widget
-	// This ensures the state updates use the same Builder function
+	// Register this Consumer's Builder so state updates re-render with the same function
 	consumerID := fmt.Sprintf("consumer_%s_%p", c.StateKey, c.Builder)
-	endpointPath := fmt.Sprintf("/api/consumer/%s", consumerID)
-
-	// Register the endpoint that uses this Consumer's Builder function
-	ctx.App.Router().HandleFunc(endpointPath, func(w http.ResponseWriter, r *http.Request) {
-		consumerCtx := core.NewContext(w, r, ctx.App)
-		currentValue := ctx.App.State().Get(c.StateKey)
-
-		// Use the same Builder function to render the updated content
-		updatedWidget := c.Builder(currentValue)
-		if updatedWidget != nil {
-			html := updatedWidget.Render(consumerCtx)
-			consumerCtx.WriteHTML(html)
-		}
-	}).Methods("GET")
+	endpointPath := appPath(ctx, "/api/consumer/"+consumerID)
+	registerConsumer(ctx, consumerID, c)
+	// Clients showing this Consumer may resync its key after reconnecting
+	ctx.App.AllowStateSync(c.StateKey)
 
 	// Wrap the widget in a container with state tracking attributes
 	// Use the custom endpoint instead of the generic state endpoint
-	containerHTML := fmt.Sprintf(`<div data-state-key="%s" data-state-endpoint="%s">%s</div>`,
-		c.StateKey, endpointPath, widget.Render(ctx))
+	containerHTML := fmt.Sprintf(`<div data-state-key="%s" data-state-endpoint="%s" data-listener-id="%s">%s</div>`,
+		c.StateKey, endpointPath, consumerID, widget.Render(ctx))
 
 	return containerHTML
 }
 
+// build returns the widget showing the current state value
+func (c *Consumer) build(ctx *core.Context) Widget {
+	// Get state from context; in dev mode this also catches undeclared keys
+	value := ctx.GetState(c.StateKey)
+
+	if value == nil && c.Placeholder != nil {
+		return c.Placeholder
+	}
+	ctx.TrackRender(1)
+	if c.ErrorBuilder == nil {
+		return c.Builder(value)
+	}
+	return c.buildRecovering(value)
+}
+
+// buildRecovering runs Builder, replacing a panic with ErrorBuilder's widget
+// and logging the value it failed on
+func (c *Consumer) buildRecovering(value interface{}) (widget Widget) {
+	defer func() {
+		if r := recover(); r != nil {
+			// Render limits abort the whole render, not just this Consumer
+			if _, ok := r.(*core.RenderLimitError); ok {
+				panic(r)
+			}
+			log.Printf("Consumer %q: Builder panicked on %T value %#v: %v", c.StateKey, value, value, r)
+			widget = c.ErrorBuilder(r)
+		}
+	}()
+	return c.Builder(value)
+}
+
+// consumerSessionKey is the session value holding the Consumers the session's pages show
+const consumerSessionKey = "godin.consumers"
+
+// maxConsumersPerSession bounds the Consumers remembered per session; the oldest
+// go first, as they belong to pages left long ago that never reported leaving
+const maxConsumersPerSession = 512
+
+// sessionConsumers are the Consumers rendered for one session, by consumer ID,
+// in the order first rendered
+type sessionConsumers struct {
+	consumers map[string]*Consumer
+	order     []string
+	mutex     sync.Mutex
+}
+
+// add stores a Consumer, dropping the oldest beyond maxConsumersPerSession
+func (sc *sessionConsumers) add(consumerID string, consumer *Consumer) {
+	sc.mutex.Lock()
+	defer sc.mutex.Unlock()
+	if _, exists := sc.consumers[consumerID]; !exists {
+		sc.order = append(sc.order, consumerID)
+	}
+	sc.consumers[consumerID] = consumer
+	for len(sc.order) > maxConsumersPerSession {
+		delete(sc.consumers, sc.order[0])
+		sc.order = sc.order[1:]
+	}
+}
+
+// get returns a Consumer by ID
+func (sc *sessionConsumers) get(consumerID string) (*Consumer, bool) {
+	sc.mutex.Lock()
+	defer sc.mutex.Unlock()
+	consumer, exists := sc.consumers[consumerID]
+	return consumer, exists
+}
+
+// remove forgets a Consumer whose element left the page
+func (sc *sessionConsumers) remove(consumerID string) {
+	sc.mutex.Lock()
+	defer sc.mutex.Unlock()
+	if _, exists := sc.consumers[consumerID]; !exists {
+		return
+	}
+	delete(sc.consumers, consumerID)
+	for i, id := range sc.order {
+		if id == consumerID {
+			sc.order = append(sc.order[:i], sc.order[i+1:]...)
+			break
+		}
+	}
+}
+
+// consumersFor returns the Consumers of a context's session
+func consumersFor(ctx *core.Context) *sessionConsumers {
+	return ctx.Session().GetOrSet(consumerSessionKey, func() interface{} {
+		return &sessionConsumers{consumers: make(map[string]*Consumer)}
+	}).(*sessionConsumers)
+}
+
+// consumerApps are the apps serving the consumer endpoint
+var consumerApps = struct {
+	apps  map[*core.App]bool
+	mutex sync.Mutex
+}{apps: make(map[*core.App]bool)}
+
+// registerConsumer stores a Consumer in the session rendering it, serves the
+// consumer endpoint once per app, and releases the Consumer once its element
+// was removed from the page or the session ends
+func registerConsumer(ctx *core.Context, consumerID string, consumer *Consumer) {
+	app, sessionID := ctx.App, ctx.Session().ID()
+	consumers := consumersFor(ctx)
+	consumers.add(consumerID, consumer)
+	app.Listeners().Register(consumerID, sessionID, func() {
+		consumers.remove(consumerID)
+	})
+
+	consumerApps.mutex.Lock()
+	defer consumerApps.mutex.Unlock()
+	if consumerApps.apps[app] {
+		return
+	}
+	consumerApps.apps[app] = true
+
+	// Without WebSocket, HTMX handlers that set state refresh the Consumers of it
+	// on the requesting page out-of-band
+	app.AddStateRefresher(func(ctx *core.Context, key string) string {
+		consumers := consumersFor(ctx)
+		swaps := ""
+		for _, id := range ctx.PageListeners() {
+			consumer, exists := consumers.get(id)
+			if !exists || consumer.StateKey != key {
+				continue
+			}
+			content := ""
+			if widget := consumer.build(ctx); widget != nil {
+				content = widget.Render(ctx)
+			}
+			swaps += fmt.Sprintf(`<div hx-swap-oob="innerHTML:[data-listener-id='%s']">%s</div>`, id, content)
+		}
+		return swaps
+	})
+
+	app.Router().HandleFunc("/api/consumer/{id}", func(w http.ResponseWriter, r *http.Request) {
+		consumerCtx := core.NewContext(w, r, app)
+
+		consumer, exists := consumersFor(consumerCtx).get(consumerCtx.Param("id"))
+
+		if !exists {
+			http.Error(w, "Consumer not found", http.StatusNotFound)
+			return
+		}
+
+		// Use the same Builder function to render the updated content
+		if updatedWidget := consumer.build(consumerCtx); updatedWidget != nil {
+			consumerCtx.WriteHTML(updatedWidget.Render(consumerCtx))
+		}
+	}).Methods("GET")
+}
+
 // Provider represents a widget that provides state to its children
 type Provider struct {
 	HTMXWidget
@@ -202,6 +347,7 @@ func (s *Selector) Render(ctx *core.Context) string {
 	// Select specific part of state
 	selected := s.Selector(state)
 
+	ctx.TrackRender(1)
 	widget := s.Builder(selected)
 	if widget == nil {
 		return ""
@@ -255,6 +401,7 @@ func (ab *AnimatedBuilder) Render(ctx *core.Context) string {
 		return ""
 	}
 
+	ctx.TrackRender(1)
 	widget := ab.Builder(ab.Animation)
 	if widget == nil {
 		return ""
@@ -265,10 +412,11 @@ func (ab *AnimatedBuilder) Render(ctx *core.Context) string {
 
 // LayoutBuilderConstraints represents layout constraints for LayoutBuilder
 type LayoutBuilderConstraints struct {
-	MinWidth  float64
-	MaxWidth  float64
-	MinHeight float64
-	MaxHeight float64
+	MinWidth   float64
+	MaxWidth   float64
+	MinHeight  float64
+	MaxHeight  float64
+	Breakpoint core.Breakpoint // Breakpoint for MaxWidth using the app's configured breakpoints
 }
 
 // LayoutBuilder represents a widget that builds based on layout constraints
@@ -290,7 +438,9 @@ func (lb *LayoutBuilder) Render(ctx *core.Context) string {
 		MinHeight: 0,
 		MaxHeight: 800, // Default max height
 	}
+	constraints.Breakpoint = ctx.Breakpoints().Resolve(constraints.MaxWidth)
 
+	ctx.TrackRender(1)
 	widget := lb.Builder(constraints)
 	if widget == nil {
 		return ""
@@ -405,7 +555,7 @@ func (vlb ValueListenableBuilderGeneric[T]) Render(ctx *core.Context) string {
 					window.godin.subscribe('state:' + notifierId, function(data) {
 						// Update the element with new content
 						if (data && data.html) {
-							element.innerHTML = data.html;
+							window.Godin ? window.Godin.morph(element, data.html) : (element.innerHTML = data.html);
 						}
 
 						// Update data attribute
@@ -423,11 +573,11 @@ func (vlb ValueListenableBuilderGeneric[T]) Render(ctx *core.Context) string {
 				// Fallback polling if WebSocket not available
 				if (!window.godin || !window.godin.subscribe) {
 					setInterval(function() {
-						fetch('/api/state/' + notifierId)
+						fetch((window.godinBasePath || '') + '/api/state/' + notifierId)
 							.then(response => response.json())
 							.then(data => {
 								if (data.html && data.html !== element.innerHTML) {
-									element.innerHTML = data.html;
+									window.Godin ? window.Godin.morph(element, data.html) : (element.innerHTML = data.html);
 									element.setAttribute('data-current-value', JSON.stringify(data.value));
 									element.dispatchEvent(new CustomEvent('valueChanged', {
 										detail: { value: data.value, notifierId: notifierId }
@@ -603,7 +753,7 @@ func (vlb *ValueListenableBuilderInt) Render(ctx *core.Context) string {
 								this.subscriptions.forEach((callbacks, channel) => {
 									if (channel.startsWith('state:')) {
 										const notifierId = channel.replace('state:', '');
-										fetch('/api/state/' + notifierId)
+										fetch((window.godinBasePath || '') + '/api/state/' + notifierId)
 											.then(response => response.json())
 											.then(data => {
 												callbacks.forEach(callback => callback(data));
@@ -620,7 +770,7 @@ func (vlb *ValueListenableBuilderInt) Render(ctx *core.Context) string {
 				window.godin.subscribe('state:' + notifierId, function(data) {
 					// Update the element with new content if provided
 					if (data && data.html) {
-						element.innerHTML = data.html;
+						window.Godin ? window.Godin.morph(element, data.html) : (element.innerHTML = data.html);
 					}
 
 					// Update data attributes
@@ -862,7 +1012,7 @@ func (vlb *ValueListenableBuilderFloat64) Render(ctx *core.Context) string {
 								this.subscriptions.forEach((callbacks, channel) => {
 									if (channel.startsWith('state:')) {
 										const notifierId = channel.replace('state:', '');
-										fetch('/api/state/' + notifierId)
+										fetch((window.godinBasePath || '') + '/api/state/' + notifierId)
 											.then(response => response.json())
 											.then(data => {
 												callbacks.forEach(callback => callback(data));
@@ -879,7 +1029,7 @@ func (vlb *ValueListenableBuilderFloat64) Render(ctx *core.Context) string {
 				window.godin.subscribe('state:' + notifierId, function(data) {
 					// Update the element with new content if provided
 					if (data && data.html) {
-						element.innerHTML = data.html;
+						window.Godin ? window.Godin.morph(element, data.html) : (element.innerHTML = data.html);
 					}
 
 					// Update data attributes
@@ -1121,7 +1271,7 @@ func (vlb *ValueListenableBuilderString) Render(ctx *core.Context) string {
 								this.subscriptions.forEach((callbacks, channel) => {
 									if (channel.startsWith('state:')) {
 										const notifierId = channel.replace('state:', '');
-										fetch('/api/state/' + notifierId)
+										fetch((window.godinBasePath || '') + '/api/state/' + notifierId)
 											.then(response => response.json())
 											.then(data => {
 												callbacks.forEach(callback => callback(data));
@@ -1138,7 +1288,7 @@ func (vlb *ValueListenableBuilderString) Render(ctx *core.Context) string {
 				window.godin.subscribe('state:' + notifierId, function(data) {
 					// Update the element with new content if provided
 					if (data && data.html) {
-						element.innerHTML = data.html;
+						window.Godin ? window.Godin.morph(element, data.html) : (element.innerHTML = data.html);
 					}
 
 					// Update data attributes
@@ -1336,7 +1486,7 @@ func (vlb ValueListenableBuilderBool) Render(ctx *core.Context) string {
 					window.godin.subscribe('state:' + notifierId, function(data) {
 						// Update the element with new content
 						if (data && data.html) {
-							element.innerHTML = data.html;
+							window.Godin ? window.Godin.morph(element, data.html) : (element.innerHTML = data.html);
 						}
 
 						// Update data attribute
@@ -1354,11 +1504,11 @@ func (vlb ValueListenableBuilderBool) Render(ctx *core.Context) string {
 				// Fallback polling if WebSocket not available
 				if (!window.godin || !window.godin.subscribe) {
 					setInterval(function() {
-						fetch('/api/state/' + notifierId)
+						fetch((window.godinBasePath || '') + '/api/state/' + notifierId)
 							.then(response => response.json())
 							.then(data => {
 								if (data.html && data.html !== element.innerHTML) {
-									element.innerHTML = data.html;
+									window.Godin ? window.Godin.morph(element, data.html) : (element.innerHTML = data.html);
 									element.setAttribute('data-current-value', JSON.stringify(data.value));
 									element.dispatchEvent(new CustomEvent('valueChanged', {
 										detail: { value: data.value, notifierId: notifierId }
@@ -0,0 +1,176 @@
+package widgets
+
+import (
+	"fmt"
+	"html"
+	"net/url"
+	"sync"
+
+	"github.com/gideonsigilai/godin/pkg/core"
+)
+
+// statefulSessionKey is the session value holding the session's StatefulWidget states
+const statefulSessionKey = "godin.stateful"
+
+// statefulStates holds the state of every StatefulWidget instance of one session, keyed by widget ID
+type statefulStates struct {
+	states map[string]*WidgetState
+	mutex  sync.Mutex
+}
+
+// WidgetState is the isolated state bucket of one StatefulWidget instance
+type WidgetState struct {
+	id     string
+	values map[string]interface{}
+	ctx    *core.Context // Detached copy of the latest render's context, for SetState rebuilds
+	mutex  sync.RWMutex
+}
+
+// ID returns the ID of the widget that owns the state
+func (s *WidgetState) ID() string {
+	return s.id
+}
+
+// Get returns a state value
+func (s *WidgetState) Get(key string) interface{} {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	return s.values[key]
+}
+
+// GetInt returns a state value as an int
+func (s *WidgetState) GetInt(key string) int {
+	value, _ := s.Get(key).(int)
+	return value
+}
+
+// GetString returns a state value as a string
+func (s *WidgetState) GetString(key string) string {
+	value, _ := s.Get(key).(string)
+	return value
+}
+
+// GetBool returns a state value as a bool
+func (s *WidgetState) GetBool(key string) bool {
+	value, _ := s.Get(key).(bool)
+	return value
+}
+
+// Set stores a state value without rebuilding the widget
+func (s *WidgetState) Set(key string, value interface{}) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.values[key] = value
+}
+
+// SetState runs fn, which typically calls Set, and then rebuilds only this widget
+// with a detached copy of the context of its latest render. With WebSocket enabled
+// the new HTML is pushed to the connections of the session that owns the state;
+// otherwise godin.js refetches the widget after the callback that called SetState.
+// The state of a widget without an ID is not rebuilt.
+func (s *WidgetState) SetState(fn func()) {
+	if fn != nil {
+		fn()
+	}
+
+	s.mutex.RLock()
+	ctx := s.ctx
+	s.mutex.RUnlock()
+
+	if ctx != nil && ctx.App != nil && ctx.App.WebSocket().IsEnabled() {
+		ctx.Rebuild(s.id)
+	}
+}
+
+// StatefulWidget is a reusable component with its own state, so several
+// instances on one page (e.g. two counters) update independently. The state
+// is kept per browser session and keyed by ID, which must be stable across
+// renders and unique per instance. Without an ID the state lasts one render
+// and the widget is not a repaint boundary, so SetState cannot rebuild it.
+type StatefulWidget struct {
+	ID        string                                             // Unique, stable instance ID
+	InitState func(state *WidgetState)                           // Called once when the instance's state is created
+	Builder   func(ctx *core.Context, state *WidgetState) Widget // Builds the widget from its state
+}
+
+// Render renders the stateful widget inside a repaint boundary that SetState rebuilds
+func (sw StatefulWidget) Render(ctx *core.Context) string {
+	if sw.Builder == nil {
+		return ""
+	}
+
+	if sw.ID == "" {
+		// Without an ID the state cannot be found again, so it lives for this render only
+		state := &WidgetState{values: make(map[string]interface{})}
+		if sw.InitState != nil {
+			sw.InitState(state)
+		}
+		content := statefulBuild{builder: sw.Builder, state: state}.Render(ctx)
+		return `<div class="godin-stateful-widget">` + content + `</div>`
+	}
+
+	state := statefulState(ctx, sw.ID, sw.InitState)
+	child := statefulBuild{builder: sw.Builder, state: state}
+
+	content := child.Render(ctx)
+	if ctx != nil && ctx.App != nil {
+		ctx.Boundaries().Register(sw.ID, child)
+	}
+
+	escapedID := html.EscapeString(sw.ID)
+	return fmt.Sprintf(`<div id="%s" class="godin-stateful-widget godin-repaint-boundary" data-stateful-widget="true" data-repaint-boundary="%s" hx-get="%s" hx-trigger="godin:rebuild" hx-swap="innerHTML">%s</div>`,
+		escapedID, escapedID, html.EscapeString(appPath(ctx, "/api/boundary/"+url.PathEscape(sw.ID))), content)
+}
+
+// statefulBuild renders a StatefulWidget's builder with its state, for the page and for rebuilds
+type statefulBuild struct {
+	builder func(ctx *core.Context, state *WidgetState) Widget
+	state   *WidgetState
+}
+
+// Render builds the widget from the current state
+func (sb statefulBuild) Render(ctx *core.Context) string {
+	widget := sb.builder(ctx, sb.state)
+	if widget == nil {
+		return ""
+	}
+	return widget.Render(ctx)
+}
+
+// statefulState returns the session's state for a widget ID, creating and initializing it on first use
+func statefulState(ctx *core.Context, id string, initState func(state *WidgetState)) *WidgetState {
+	if ctx == nil {
+		state := &WidgetState{id: id, values: make(map[string]interface{})}
+		if initState != nil {
+			initState(state)
+		}
+		return state
+	}
+
+	registry := ctx.Session().GetOrSet(statefulSessionKey, func() interface{} {
+		return &statefulStates{states: make(map[string]*WidgetState)}
+	}).(*statefulStates)
+
+	registry.mutex.Lock()
+	state, exists := registry.states[id]
+	if !exists {
+		state = &WidgetState{id: id, values: make(map[string]interface{})}
+		// Concurrent first renders of the session must not see the state before it is initialized
+		if initState != nil {
+			initState(state)
+		}
+		registry.states[id] = state
+	}
+	registry.mutex.Unlock()
+
+	// Remember the session's latest render, detached from its request, so SetState
+	// can rebuild from callbacks
+	if ctx.App != nil && ctx.App.WebSocket().IsEnabled() {
+		detached := ctx.Detach()
+		state.mutex.Lock()
+		state.ctx = detached
+		state.mutex.Unlock()
+	}
+
+	return state
+}
@@ -0,0 +1,64 @@
+package widgets
+
+import (
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/gideonsigilai/godin/pkg/core"
+)
+
+// counterText shows a state's count
+func counterText(ctx *core.Context, state *WidgetState) Widget {
+	return Text{Data: "count"}
+}
+
+func TestStatefulWidgetWithoutIDIsNoBoundary(t *testing.T) {
+	ctx := core.NewTestContext()
+	html := ctx.Render(StatefulWidget{Builder: counterText})
+
+	if !strings.Contains(html, "count") {
+		t.Errorf("Expected the builder's output, got %q", html)
+	}
+	if strings.Contains(html, "hx-get") || strings.Contains(html, "data-repaint-boundary") {
+		t.Errorf("Expected no repaint boundary without an ID, got %q", html)
+	}
+}
+
+func TestStatefulWidgetIsBoundary(t *testing.T) {
+	ctx := core.NewTestContext()
+	html := ctx.Render(StatefulWidget{ID: "counter", Builder: counterText})
+
+	if !strings.Contains(html, `hx-get="/api/boundary/counter"`) {
+		t.Errorf("Expected the widget to refetch its boundary, got %q", html)
+	}
+	if _, ok := ctx.Boundaries().Get("counter"); !ok {
+		t.Error("Expected the boundary to be registered")
+	}
+}
+
+func TestStatefulStateInitializesOnce(t *testing.T) {
+	ctx := core.NewTestContext()
+	ctx.Session()
+
+	var inits sync.WaitGroup
+	calls := make(chan struct{}, 8)
+	for i := 0; i < 8; i++ {
+		inits.Add(1)
+		go func() {
+			defer inits.Done()
+			state := statefulState(ctx.Context, "counter", func(state *WidgetState) {
+				calls <- struct{}{}
+				state.Set("count", 1)
+			})
+			if state.GetInt("count") != 1 {
+				t.Error("Expected every render to see the initialized state")
+			}
+		}()
+	}
+	inits.Wait()
+
+	if len(calls) != 1 {
+		t.Errorf("Expected InitState to run once, ran %d times", len(calls))
+	}
+}
@@ -0,0 +1,68 @@
+package widgets
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/gideonsigilai/godin/pkg/core"
+	"github.com/gideonsigilai/godin/pkg/renderer"
+)
+
+// StickyHeader keeps its Header pinned to the top of the scrolling page or
+// container while the Content below it scrolls, like a pinned SliverAppBar.
+// With ShrinkOnScroll the header collapses once the content has scrolled past
+// ShrinkOffset, showing CollapsedHeader in its place when one is given.
+type StickyHeader struct {
+	ID              string
+	Style           string
+	Class           string
+	Header          Widget  // Pinned header, e.g. an AppBar
+	Content         Widget  // Content scrolling under the header
+	CollapsedHeader Widget  // Replaces Header once shrunk (Header is compacted when nil)
+	ShrinkOnScroll  bool    // Collapse the header while the content is scrolled
+	ShrinkOffset    float64 // Distance in pixels the content scrolls before the header shrinks
+	Top             float64 // Distance in pixels from the top of the viewport the header pins at
+	BackgroundColor Color   // Header background, so content does not show through it
+	ZIndex          *int    // Stacking order of the header (default 10)
+}
+
+// Render renders the sticky header as HTML
+func (sh StickyHeader) Render(ctx *core.Context) string {
+	htmlRenderer := renderer.NewHTMLRenderer()
+
+	attrs := buildAttributes(sh.ID, sh.Style, sh.Class+" godin-sticky-header")
+	attrs["data-sticky-header"] = "true"
+	if sh.ShrinkOnScroll {
+		attrs["data-shrink-on-scroll"] = "true"
+		attrs["data-shrink-offset"] = strconv.FormatFloat(sh.ShrinkOffset, 'f', -1, 64)
+	}
+
+	// Header bar
+	barStyle := fmt.Sprintf("top: %.1fpx", sh.Top)
+	if sh.BackgroundColor != "" {
+		barStyle += fmt.Sprintf("; background-color: %s", sh.BackgroundColor)
+	}
+	if sh.ZIndex != nil {
+		barStyle += fmt.Sprintf("; z-index: %d", *sh.ZIndex)
+	}
+
+	header := ""
+	if sh.Header != nil {
+		header = htmlRenderer.RenderElement("div", map[string]string{"class": "godin-sticky-header-expanded"}, sh.Header.Render(ctx), false)
+	}
+	if sh.ShrinkOnScroll && sh.CollapsedHeader != nil {
+		attrs["data-collapsed-header"] = "true"
+		header += htmlRenderer.RenderElement("div", map[string]string{"class": "godin-sticky-header-collapsed"}, sh.CollapsedHeader.Render(ctx), false)
+	}
+
+	content := htmlRenderer.RenderElement("div", map[string]string{
+		"class": "godin-sticky-header-bar",
+		"style": barStyle,
+	}, header, false)
+
+	if sh.Content != nil {
+		content += htmlRenderer.RenderElement("div", map[string]string{"class": "godin-sticky-header-content"}, sh.Content.Render(ctx), false)
+	}
+
+	return htmlRenderer.RenderElement("div", attrs, content, false)
+}
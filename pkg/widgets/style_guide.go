@@ -0,0 +1,252 @@
+package widgets
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/gideonsigilai/godin/pkg/core"
+	"github.com/gideonsigilai/godin/pkg/renderer"
+)
+
+// The style guide is served at core.StyleGuidePath in dev mode
+func init() {
+	core.RegisterStyleGuide(func(ctx *core.Context) core.Widget {
+		return StyleGuide{}
+	})
+}
+
+// styleGuideSample is the text each typography style is shown with
+const styleGuideSample = "The quick brown fox jumps over the lazy dog"
+
+// StyleGuide is a living style guide of the app's theme: its color palettes,
+// light and dark, the typography scale, and buttons, fields and cards rendered
+// with the theme. Everything is generated from the theme, so custom themes set
+// with WithLightTheme and WithDarkTheme show up as they are.
+//
+// In dev mode it is served at /__godin/styleguide. Apps can serve their own
+// page there to add their widgets after the built-in sections:
+//
+//	core.RegisterStyleGuide(func(ctx *core.Context) core.Widget {
+//		return widgets.StyleGuide{Children: []widgets.Widget{ProductCard{Product: sample}}}
+//	})
+type StyleGuide struct {
+	ID       string
+	Style    string
+	Class    string
+	Children []Widget // App widgets shown in a section after the built-in ones
+}
+
+// Render renders the style guide as HTML
+func (sg StyleGuide) Render(ctx *core.Context) string {
+	htmlRenderer := renderer.NewHTMLRenderer()
+	theme := ctx.Theme()
+
+	header := htmlRenderer.RenderElement("h1", map[string]string{"class": "godin-style-guide-title"}, htmlRenderer.RenderText("Style guide"), false)
+	header += htmlRenderer.RenderElement("p", map[string]string{"class": "godin-style-guide-subtitle"},
+		htmlRenderer.RenderText(fmt.Sprintf("Rendered with the %s theme", theme.Brightness)), false)
+
+	content := htmlRenderer.RenderElement("header", nil, header, false)
+	content += styleGuideSection("Colors", styleGuideColors(ctx))
+	content += styleGuideSection("Typography", styleGuideTypography(ctx, theme))
+	content += styleGuideSection("Buttons", styleGuideButtons(ctx))
+	content += styleGuideSection("Fields", styleGuideFields(ctx))
+	content += styleGuideSection("Cards", styleGuideCards(ctx))
+	if len(sg.Children) > 0 {
+		children := ""
+		for _, child := range sg.Children {
+			if child == nil {
+				continue
+			}
+			ctx.TrackRender(1)
+			children += child.Render(ctx)
+		}
+		content += styleGuideSection("App widgets", styleGuideRow(children))
+	}
+
+	attrs := buildAttributes(sg.ID, sg.Style, sg.Class+" godin-style-guide")
+	return injectStylesOnce(ctx, "style_guide", styleGuideCSS) +
+		htmlRenderer.RenderElement("main", attrs, content, false)
+}
+
+// styleGuideColors renders a swatch for every color of the light and dark
+// palettes, or of the current theme when the app has no theme provider
+func styleGuideColors(ctx *core.Context) string {
+	htmlRenderer := renderer.NewHTMLRenderer()
+
+	type palette struct {
+		label string
+		theme *core.ThemeData
+	}
+	palettes := []palette{{"Current", ctx.Theme()}}
+	if ctx.App != nil {
+		if provider := ctx.App.ThemeProvider(); provider != nil {
+			palettes = []palette{{"Light", provider.GetLightTheme()}, {"Dark", provider.GetDarkTheme()}}
+		}
+	}
+
+	content := ""
+	for _, p := range palettes {
+		if p.theme == nil {
+			continue
+		}
+		swatches := ""
+		for _, role := range p.theme.ColorScheme.Roles() {
+			swatches += styleGuideSwatch(role)
+		}
+		content += htmlRenderer.RenderElement("h3", map[string]string{"class": "godin-style-guide-subheading"}, htmlRenderer.RenderText(p.label), false)
+		content += htmlRenderer.RenderElement("div", map[string]string{"class": "godin-style-guide-swatches"}, swatches, false)
+	}
+	return content
+}
+
+// styleGuideSwatch renders one color with its name and hex value, in black or
+// white text depending on which reads better on it
+func styleGuideSwatch(role core.ColorRole) string {
+	htmlRenderer := renderer.NewHTMLRenderer()
+
+	text := "#ffffff"
+	if role.Color.Luminance() > 0.5 {
+		text = "#000000"
+	}
+	content := htmlRenderer.RenderElement("span", map[string]string{"class": "godin-style-guide-swatch-name"}, htmlRenderer.RenderText(role.Name), false)
+	content += htmlRenderer.RenderElement("code", nil, htmlRenderer.RenderText(role.Color.ToHex()), false)
+	return htmlRenderer.RenderElement("div", map[string]string{
+		"class": "godin-style-guide-swatch",
+		"style": fmt.Sprintf("background-color: %s; color: %s", role.Color.ToCSS(), text),
+		"title": "--godin-color-" + role.Name,
+	}, content, false)
+}
+
+// styleGuideTypography renders a sample line in every style of the typography scale
+func styleGuideTypography(ctx *core.Context, theme *core.ThemeData) string {
+	htmlRenderer := renderer.NewHTMLRenderer()
+	typography := theme.TextTheme()
+
+	content := ""
+	for _, variant := range core.TextThemeVariants {
+		style := typography.Style(variant)
+		if style == nil {
+			continue
+		}
+		ctx.TrackRender(1)
+
+		details := []string{styleGuideLabel(string(variant))}
+		if style.FontSize != nil {
+			details = append(details, fmt.Sprintf("%gpx", *style.FontSize))
+		}
+		if style.FontWeight != nil {
+			details = append(details, fmt.Sprintf("%d", *style.FontWeight))
+		}
+
+		row := htmlRenderer.RenderElement("span", map[string]string{"class": "godin-style-guide-caption"}, htmlRenderer.RenderText(strings.Join(details, " · ")), false)
+		row += Text{Data: styleGuideSample, Variant: variant}.Render(ctx)
+		content += htmlRenderer.RenderElement("div", map[string]string{"class": "godin-style-guide-type"}, row, false)
+	}
+	return content
+}
+
+// styleGuideButtons renders each kind of button, enabled and disabled
+func styleGuideButtons(ctx *core.Context) string {
+	pressed := func() {}
+	disabled := false
+
+	enabledRow := ElevatedButton{Child: Text{Data: "Elevated"}, OnPressed: pressed}.Render(ctx) +
+		FilledButton{Child: Text{Data: "Filled"}, OnPressed: pressed}.Render(ctx) +
+		OutlinedButton{Child: Text{Data: "Outlined"}, OnPressed: pressed}.Render(ctx) +
+		TextButton{Child: Text{Data: "Text"}, OnPressed: pressed}.Render(ctx) +
+		IconButton{Icon: Icon{Icon: IconEdit}, OnPressed: pressed}.Render(ctx) +
+		FloatingActionButton{Child: Icon{Icon: IconAdd}, Tooltip: "Add", OnPressed: pressed}.Render(ctx)
+
+	disabledRow := ElevatedButton{Child: Text{Data: "Elevated"}, Enabled: &disabled}.Render(ctx) +
+		FilledButton{Child: Text{Data: "Filled"}, Enabled: &disabled}.Render(ctx) +
+		OutlinedButton{Child: Text{Data: "Outlined"}, Enabled: &disabled}.Render(ctx) +
+		TextButton{Child: Text{Data: "Text"}, Enabled: &disabled}.Render(ctx) +
+		IconButton{Icon: Icon{Icon: IconEdit}, Enabled: &disabled}.Render(ctx)
+
+	return styleGuideRow(enabledRow) + styleGuideRow(disabledRow)
+}
+
+// styleGuideFields renders text fields, enabled, read only and disabled, and the other form controls
+func styleGuideFields(ctx *core.Context) string {
+	disabled := false
+	checked, unchecked := true, false
+
+	fields := TextField{Decoration: &InputDecoration{HintText: "Hint text"}}.Render(ctx) +
+		TextField{Decoration: &InputDecoration{HintText: "Read only"}, ReadOnly: true}.Render(ctx) +
+		TextField{Decoration: &InputDecoration{HintText: "Disabled"}, Enabled: &disabled}.Render(ctx)
+
+	controls := Checkbox{Value: &checked, OnChanged: func(bool) {}}.Render(ctx) +
+		Checkbox{Value: &unchecked, OnChanged: func(bool) {}}.Render(ctx) +
+		Switch{Value: true, OnChanged: func(bool) {}}.Render(ctx) +
+		Switch{Value: false, OnChanged: func(bool) {}}.Render(ctx) +
+		Slider{Value: 40, Min: 0, Max: 100, OnChanged: func(float64) {}}.Render(ctx) +
+		DropdownButton[string]{
+			Value: "light",
+			Items: []DropdownMenuItem[string]{
+				{Value: "light", Child: Text{Data: "Light"}},
+				{Value: "dark", Child: Text{Data: "Dark"}},
+			},
+			OnChanged: func(string) {},
+		}.Render(ctx)
+
+	return styleGuideRow(fields) + styleGuideRow(controls)
+}
+
+// styleGuideCards renders a card with text content and one holding a list tile
+func styleGuideCards(ctx *core.Context) string {
+	padding := EdgeInsets(16)
+
+	textCard := Card{
+		Padding: &padding,
+		Child: Column{Children: []Widget{
+			Text{Data: "Card title", Variant: TextThemeTitleMedium},
+			Text{Data: "Cards group related content on the surface color.", Variant: TextThemeBodyMedium},
+		}},
+	}.Render(ctx)
+
+	tileCard := Card{
+		Child: ListTile{
+			Leading:  Icon{Icon: IconCheckCircle},
+			Title:    Text{Data: "List tile"},
+			Subtitle: Text{Data: "Supporting text"},
+		},
+	}.Render(ctx)
+
+	return styleGuideRow(textCard + tileCard)
+}
+
+// styleGuideSection renders a titled section of the style guide
+func styleGuideSection(title, content string) string {
+	htmlRenderer := renderer.NewHTMLRenderer()
+	heading := htmlRenderer.RenderElement("h2", map[string]string{"class": "godin-style-guide-heading"}, htmlRenderer.RenderText(title), false)
+	return htmlRenderer.RenderElement("section", map[string]string{"class": "godin-style-guide-section"}, heading+content, false)
+}
+
+// styleGuideRow lays out samples side by side, wrapping on narrow screens
+func styleGuideRow(content string) string {
+	return renderer.NewHTMLRenderer().RenderElement("div", map[string]string{"class": "godin-style-guide-row"}, content, false)
+}
+
+// styleGuideLabel turns a CSS name such as "display-large" into "Display large"
+func styleGuideLabel(name string) string {
+	label := strings.ReplaceAll(name, "-", " ")
+	if label == "" {
+		return label
+	}
+	return strings.ToUpper(label[:1]) + label[1:]
+}
+
+// styleGuideCSS lays out the style guide; the samples use only the theme's styles
+const styleGuideCSS = `.godin-style-guide { max-width: 1100px; margin: 0 auto; padding: 24px; }
+.godin-style-guide-subtitle { color: var(--godin-color-on-surface-variant); margin-top: 0; }
+.godin-style-guide-section { margin: 32px 0; }
+.godin-style-guide-heading { border-bottom: 1px solid var(--godin-color-outline-variant); padding-bottom: 8px; }
+.godin-style-guide-subheading { margin: 16px 0 8px; }
+.godin-style-guide-swatches { display: grid; grid-template-columns: repeat(auto-fill, minmax(150px, 1fr)); gap: 8px; }
+.godin-style-guide-swatch { display: flex; flex-direction: column; justify-content: flex-end; min-height: 72px; padding: 8px; border-radius: 8px; border: 1px solid rgba(0,0,0,0.08); font-size: 12px; }
+.godin-style-guide-swatch-name { font-weight: 500; }
+.godin-style-guide-type { display: grid; grid-template-columns: 200px 1fr; gap: 16px; align-items: baseline; margin-bottom: 12px; }
+.godin-style-guide-caption { font-size: 12px; color: var(--godin-color-on-surface-variant); }
+.godin-style-guide-row { display: flex; flex-wrap: wrap; align-items: center; gap: 16px; margin-bottom: 16px; }
+@media (max-width: 600px) { .godin-style-guide-type { grid-template-columns: 1fr; gap: 4px; } }
+`
@@ -1325,6 +1325,17 @@ const (
 	AxisVertical   Axis = "vertical"
 )
 
+// SwapStrategy enum for how HTMX applies a response to its target
+type SwapStrategy string
+
+const (
+	SwapMorph      SwapStrategy = "morph:innerHTML" // Morph the content in place, keeping focus and transitions
+	SwapMorphOuter SwapStrategy = "morph:outerHTML" // Morph the element itself in place
+	SwapInnerHTML  SwapStrategy = "innerHTML"       // Replace the content
+	SwapOuterHTML  SwapStrategy = "outerHTML"       // Replace the element
+	SwapNone       SwapStrategy = "none"            // Ignore the response
+)
+
 // ScrollViewKeyboardDismissBehavior enum
 type ScrollViewKeyboardDismissBehavior string
 
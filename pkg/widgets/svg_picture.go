@@ -0,0 +1,369 @@
+package widgets
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+	"sync"
+
+	"github.com/gideonsigilai/godin/pkg/core"
+	"github.com/gideonsigilai/godin/pkg/renderer"
+)
+
+// maxSVGCacheEntries bounds the parsed SVG cache, which is cleared when full so
+// inline strings built per request cannot grow it without limit
+const maxSVGCacheEntries = 512
+
+var (
+	svgRootPattern       = regexp.MustCompile(`(?is)<svg\b([^>]*)>(.*)</svg\s*>`)
+	svgSizePattern       = regexp.MustCompile(`(?i)\s+(width|height)\s*=\s*("[^"]*"|'[^']*')`)
+	svgPaintPattern      = regexp.MustCompile(`(?i)\b(fill|stroke)(\s*=\s*)("[^"]*"|'[^']*')`)
+	svgPaintStylePattern = regexp.MustCompile(`(?i)\b(fill|stroke)(\s*:\s*)([^;"']+)`)
+)
+
+// parsedSVG is an SVG document split into its root attributes and content, ready
+// to be rendered inline at any size
+type parsedSVG struct {
+	attrs     string // Root <svg> attributes without width and height
+	width     string // Intrinsic width attribute
+	height    string // Intrinsic height attribute
+	content   string // Markup inside the root element
+	recolored string // Content with its paint replaced by currentColor, built on first use
+}
+
+// svgCache holds parsed SVGs by source, so each asset is parsed once
+var svgCache = struct {
+	entries map[string]*parsedSVG
+	mutex   sync.RWMutex
+}{entries: make(map[string]*parsedSVG)}
+
+// SvgPicture renders an SVG inline, so icons and illustrations stay crisp at any
+// size and can be recolored with CSS. The SVG is read from the static directory
+// by AssetPath, e.g. "icons/logo.svg", or given as markup in String. Only
+// drawing elements and attributes are kept: scripts, styles, foreign objects,
+// images, links, animations and event handlers are removed, so SVGs from users
+// can be shown, though they must be well-formed XML.
+type SvgPicture struct {
+	ID             string
+	Style          string
+	Class          string
+	AssetPath      string  // SVG file in the static directory
+	String         string  // SVG markup, used when AssetPath is empty
+	Width          float64 // Rendered width in pixels (the SVG's own when 0)
+	Height         float64 // Rendered height in pixels (the SVG's own when 0)
+	Color          Color   // Recolors the SVG's fills and strokes
+	SemanticsLabel string  // Accessible label; the SVG is hidden from screen readers without one
+}
+
+// Render renders the SVG picture as HTML
+func (sp SvgPicture) Render(ctx *core.Context) string {
+	htmlRenderer := renderer.NewHTMLRenderer()
+
+	attrs := buildAttributes(sp.ID, sp.Style, sp.Class+" godin-svg-picture")
+
+	var styles []string
+	if sp.Style != "" {
+		styles = append(styles, sp.Style)
+	}
+	if sp.Width > 0 {
+		styles = append(styles, fmt.Sprintf("width: %.1fpx", sp.Width))
+	}
+	if sp.Height > 0 {
+		styles = append(styles, fmt.Sprintf("height: %.1fpx", sp.Height))
+	}
+	if sp.Color != "" {
+		styles = append(styles, fmt.Sprintf("color: %s", sp.Color))
+	}
+	if len(styles) > 0 {
+		attrs["style"] = strings.Join(styles, "; ")
+	}
+
+	if sp.SemanticsLabel != "" {
+		attrs["role"] = "img"
+		attrs["aria-label"] = sp.SemanticsLabel
+	} else {
+		attrs["aria-hidden"] = "true"
+	}
+
+	svg, err := sp.load(ctx)
+	if err != nil {
+		if ctx != nil {
+			ctx.Logf("SvgPicture: %v", err)
+		}
+		return htmlRenderer.RenderElement("span", attrs, "", false)
+	}
+
+	return htmlRenderer.RenderElement("span", attrs, sp.renderSVG(svg), false)
+}
+
+// load returns the parsed SVG from the asset or string
+func (sp SvgPicture) load(ctx *core.Context) (*parsedSVG, error) {
+	source := sp.String
+	if sp.AssetPath != "" {
+		if ctx == nil || ctx.App == nil {
+			return nil, fmt.Errorf("cannot read %q without an app", sp.AssetPath)
+		}
+		data, err := ctx.App.ReadAsset(sp.AssetPath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %q: %v", sp.AssetPath, err)
+		}
+		source = string(data)
+	}
+	return parseSVG(source)
+}
+
+// renderSVG writes the root element back, sized to the picture and recolored
+func (sp SvgPicture) renderSVG(svg *parsedSVG) string {
+	var b strings.Builder
+	b.WriteString("<svg")
+	b.WriteString(svg.attrs)
+
+	// Fill the picture's box in the sized dimensions; when only one is set, the
+	// other follows the viewBox's aspect ratio
+	switch {
+	case sp.Width > 0 && sp.Height > 0:
+		b.WriteString(` width="100%" height="100%"`)
+	case sp.Width > 0:
+		b.WriteString(` width="100%"`)
+	case sp.Height > 0:
+		b.WriteString(` height="100%"`)
+	default:
+		if svg.width != "" {
+			b.WriteString(" width=" + svg.width)
+		}
+		if svg.height != "" {
+			b.WriteString(" height=" + svg.height)
+		}
+	}
+
+	content := svg.content
+	if sp.Color != "" {
+		if !svgPaintPattern.MatchString(svg.attrs) {
+			b.WriteString(` fill="currentColor"`)
+		}
+		content = svg.recolor()
+	}
+
+	b.WriteString(">")
+	b.WriteString(content)
+	b.WriteString("</svg>")
+	return b.String()
+}
+
+// recolor returns the content with every fill and stroke painted currentColor,
+// leaving "none" and gradient references alone
+func (svg *parsedSVG) recolor() string {
+	svgCache.mutex.RLock()
+	recolored := svg.recolored
+	svgCache.mutex.RUnlock()
+	if recolored != "" {
+		return recolored
+	}
+
+	recolored = svgPaintPattern.ReplaceAllStringFunc(svg.content, func(match string) string {
+		parts := svgPaintPattern.FindStringSubmatch(match)
+		if keepSVGPaint(strings.Trim(parts[3], `"'`)) {
+			return match
+		}
+		return parts[1] + parts[2] + `"currentColor"`
+	})
+	recolored = svgPaintStylePattern.ReplaceAllStringFunc(recolored, func(match string) string {
+		parts := svgPaintStylePattern.FindStringSubmatch(match)
+		if keepSVGPaint(parts[3]) {
+			return match
+		}
+		return parts[1] + parts[2] + "currentColor"
+	})
+
+	svgCache.mutex.Lock()
+	svg.recolored = recolored
+	svgCache.mutex.Unlock()
+	return recolored
+}
+
+// keepSVGPaint reports whether a paint value survives recoloring
+func keepSVGPaint(value string) bool {
+	value = strings.ToLower(strings.TrimSpace(value))
+	return value == "" || value == "none" || value == "transparent" || value == "currentcolor" || strings.HasPrefix(value, "url(")
+}
+
+// parseSVG splits an SVG document into its root attributes and content, keeping
+// only what sanitizeSVG allows
+func parseSVG(source string) (*parsedSVG, error) {
+	svgCache.mutex.RLock()
+	svg, exists := svgCache.entries[source]
+	svgCache.mutex.RUnlock()
+	if exists {
+		return svg, nil
+	}
+
+	cleaned, err := sanitizeSVG(source)
+	if err != nil {
+		return nil, err
+	}
+
+	match := svgRootPattern.FindStringSubmatch(cleaned)
+	if match == nil {
+		return nil, fmt.Errorf("no <svg> element found")
+	}
+
+	svg = &parsedSVG{content: strings.TrimSpace(match[2])}
+	svg.attrs = svgSizePattern.ReplaceAllStringFunc(strings.TrimRight(match[1], "/ \t\r\n"), func(attr string) string {
+		parts := svgSizePattern.FindStringSubmatch(attr)
+		if strings.EqualFold(parts[1], "width") {
+			svg.width = parts[2]
+		} else {
+			svg.height = parts[2]
+		}
+		return ""
+	})
+
+	svgCache.mutex.Lock()
+	if len(svgCache.entries) >= maxSVGCacheEntries {
+		svgCache.entries = make(map[string]*parsedSVG)
+	}
+	svgCache.entries[source] = svg
+	svgCache.mutex.Unlock()
+
+	return svg, nil
+}
+
+// svgElements are the elements an SVG keeps: shapes, text, paint servers and
+// filters. Scripts, styles (which would apply to the whole page), foreignObject,
+// images, links and animations are dropped with their content.
+var svgElements = map[string]bool{
+	"svg": true, "g": true, "defs": true, "symbol": true, "use": true, "title": true, "desc": true,
+	"path": true, "rect": true, "circle": true, "ellipse": true, "line": true, "polyline": true, "polygon": true,
+	"text": true, "tspan": true, "textPath": true,
+	"linearGradient": true, "radialGradient": true, "stop": true, "pattern": true,
+	"clipPath": true, "mask": true, "marker": true,
+	"filter": true, "feBlend": true, "feColorMatrix": true, "feComponentTransfer": true, "feComposite": true,
+	"feDisplacementMap": true, "feDropShadow": true, "feFlood": true, "feFuncA": true, "feFuncB": true,
+	"feFuncG": true, "feFuncR": true, "feGaussianBlur": true, "feMerge": true, "feMergeNode": true,
+	"feMorphology": true, "feOffset": true, "feTurbulence": true,
+}
+
+// svgAttributes are the attributes an SVG keeps, besides data-* and aria-* ones;
+// event handlers and anything else are dropped
+var svgAttributes = map[string]bool{
+	"id": true, "class": true, "style": true, "role": true, "xmlns": true, "version": true, "focusable": true,
+	"viewBox": true, "preserveAspectRatio": true, "transform": true, "width": true, "height": true,
+	"x": true, "y": true, "x1": true, "y1": true, "x2": true, "y2": true, "cx": true, "cy": true,
+	"r": true, "rx": true, "ry": true, "d": true, "points": true, "pathLength": true, "href": true,
+	"fill": true, "fill-opacity": true, "fill-rule": true, "stroke": true, "stroke-width": true,
+	"stroke-linecap": true, "stroke-linejoin": true, "stroke-miterlimit": true, "stroke-dasharray": true,
+	"stroke-dashoffset": true, "stroke-opacity": true, "opacity": true, "color": true, "visibility": true,
+	"display": true, "overflow": true, "vector-effect": true, "shape-rendering": true, "paint-order": true,
+	"clip-path": true, "clip-rule": true, "clipPathUnits": true, "mask": true, "maskUnits": true,
+	"maskContentUnits": true, "gradientUnits": true, "gradientTransform": true, "spreadMethod": true,
+	"offset": true, "stop-color": true, "stop-opacity": true, "fx": true, "fy": true, "fr": true,
+	"patternUnits": true, "patternContentUnits": true, "patternTransform": true,
+	"marker-start": true, "marker-mid": true, "marker-end": true, "markerWidth": true, "markerHeight": true,
+	"markerUnits": true, "refX": true, "refY": true, "orient": true,
+	"font-family": true, "font-size": true, "font-weight": true, "font-style": true, "text-anchor": true,
+	"dominant-baseline": true, "alignment-baseline": true, "letter-spacing": true, "word-spacing": true,
+	"text-decoration": true, "dx": true, "dy": true, "rotate": true, "textLength": true, "lengthAdjust": true,
+	"filter": true, "filterUnits": true, "primitiveUnits": true, "in": true, "in2": true, "result": true,
+	"mode": true, "operator": true, "k1": true, "k2": true, "k3": true, "k4": true, "stdDeviation": true,
+	"flood-color": true, "flood-opacity": true, "values": true, "type": true, "tableValues": true,
+	"slope": true, "intercept": true, "amplitude": true, "exponent": true, "scale": true,
+	"xChannelSelector": true, "yChannelSelector": true, "baseFrequency": true, "numOctaves": true,
+	"seed": true, "stitchTiles": true, "radius": true, "mix-blend-mode": true, "isolation": true,
+}
+
+// sanitizeSVG rewrites an SVG document keeping only the elements in svgElements
+// and the attributes in svgAttributes. References, in href or url(), must point
+// inside the document. The prolog, comments and processing instructions go too.
+func sanitizeSVG(source string) (string, error) {
+	decoder := xml.NewDecoder(strings.NewReader(source))
+	decoder.Strict = false
+	decoder.Entity = xml.HTMLEntity
+
+	var b strings.Builder
+	depth, skip := 0, 0 // Open kept elements, and open elements inside a dropped one
+	for {
+		token, err := decoder.RawToken()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return "", fmt.Errorf("invalid SVG: %v", err)
+		}
+
+		switch t := token.(type) {
+		case xml.StartElement:
+			if skip > 0 || t.Name.Space != "" || !svgElements[t.Name.Local] || (depth == 0 && t.Name.Local != "svg") {
+				skip++
+				continue
+			}
+			depth++
+			b.WriteString("<" + t.Name.Local)
+			for _, attr := range t.Attr {
+				if name, ok := svgAttributeName(attr); ok {
+					b.WriteString(" " + name + `="`)
+					xml.EscapeText(&b, []byte(attr.Value))
+					b.WriteString(`"`)
+				}
+			}
+			b.WriteString(">")
+		case xml.EndElement:
+			if skip > 0 {
+				skip--
+				continue
+			}
+			if depth > 0 {
+				depth--
+				b.WriteString("</" + t.Name.Local + ">")
+			}
+		case xml.CharData:
+			if skip == 0 && depth > 0 {
+				xml.EscapeText(&b, t)
+			}
+		}
+	}
+	return b.String(), nil
+}
+
+// svgAttributeName returns the name an allowed attribute is written back under
+func svgAttributeName(attr xml.Attr) (string, bool) {
+	name := attr.Name.Local
+	switch attr.Name.Space {
+	case "":
+		if !svgAttributes[name] && !strings.HasPrefix(name, "data-") && !strings.HasPrefix(name, "aria-") {
+			return "", false
+		}
+	case "xlink":
+		if name != "href" {
+			return "", false
+		}
+		name = "xlink:href"
+	case "xmlns":
+		if name != "xlink" {
+			return "", false
+		}
+		return "xmlns:xlink", true
+	default:
+		return "", false
+	}
+
+	value := strings.ToLower(attr.Value)
+	if (name == "href" || name == "xlink:href") && !strings.HasPrefix(strings.TrimSpace(value), "#") {
+		return "", false
+	}
+	if strings.Contains(value, "javascript:") || strings.Contains(value, "expression(") || strings.Contains(value, "@import") {
+		return "", false
+	}
+	for rest := value; ; {
+		i := strings.Index(rest, "url(")
+		if i < 0 {
+			break
+		}
+		rest = strings.TrimLeft(rest[i+len("url("):], " \t'\"")
+		if !strings.HasPrefix(rest, "#") {
+			return "", false
+		}
+	}
+	return name, true
+}
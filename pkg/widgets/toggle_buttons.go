@@ -0,0 +1,321 @@
+package widgets
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/gideonsigilai/godin/pkg/core"
+	"github.com/gideonsigilai/godin/pkg/renderer"
+)
+
+// ToggleButtons represents a connected row of buttons that can each be toggled on or off
+type ToggleButtons struct {
+	ID            string
+	Style         string
+	Class         string
+	Children      []Widget        // Button contents
+	IsSelected    []bool          // Selection state for each child
+	OnPressed     func(index int) // Called with the index of the pressed button
+	Color         Color           // Text color of unselected buttons
+	SelectedColor Color           // Text and border color of selected buttons
+	FillColor     Color           // Background color of selected buttons
+	BorderColor   Color           // Border color
+	BorderRadius  *float64        // Corner radius of the group
+	Vertical      bool            // Stack buttons vertically
+	Enabled       *bool           // Enabled
+}
+
+// Render renders the toggle buttons as HTML
+func (tb ToggleButtons) Render(ctx *core.Context) string {
+	enabled := isEnabled(tb.Enabled, tb.OnPressed != nil)
+
+	segments := make([]toggleSegment, len(tb.Children))
+	for i, child := range tb.Children {
+		segments[i] = toggleSegment{
+			content:  renderChild(ctx, child),
+			selected: i < len(tb.IsSelected) && tb.IsSelected[i],
+			enabled:  enabled,
+		}
+
+		if enabled && ctx != nil && ctx.App != nil {
+			index := i
+			onPressed := tb.OnPressed
+			callbackID := ctx.App.RegisterCallback(tb.ID, "ToggleButtons", "OnPressed", func() {
+				onPressed(index)
+			}, ctx)
+			if callbackID != "" {
+				segments[i].callbackPath = appPath(ctx, "/api/callbacks/"+callbackID)
+			}
+		}
+	}
+
+	return renderToggleGroup(toggleGroup{
+		id:            tb.ID,
+		style:         tb.Style,
+		class:         tb.Class + " godin-toggle-buttons",
+		segments:      segments,
+		color:         tb.Color,
+		selectedColor: tb.SelectedColor,
+		fillColor:     tb.FillColor,
+		borderColor:   tb.BorderColor,
+		borderRadius:  tb.BorderRadius,
+		vertical:      tb.Vertical,
+	})
+}
+
+// ButtonSegment describes one segment of a SegmentedButton
+type ButtonSegment[T comparable] struct {
+	Value   T      // Value represented by this segment
+	Label   Widget // Segment label
+	Icon    Widget // Optional leading icon
+	Tooltip string // Tooltip text
+	Enabled *bool  // Enabled (defaults to true)
+}
+
+// SegmentedButton represents a Material 3 segmented button for single or multi-select choices
+type SegmentedButton[T comparable] struct {
+	ID                    string
+	Style                 string
+	Class                 string
+	Segments              []ButtonSegment[T] // Available segments
+	Selected              []T                // Currently selected values
+	MultiSelectionEnabled bool               // Allow more than one selected segment
+	EmptySelectionAllowed bool               // Allow deselecting the last selected segment
+	ShowSelectedIcon      *bool              // Show a check mark on selected segments (defaults to true)
+	OnSelectionChanged    func(selected []T) // Receives the new selection
+	SelectedColor         Color              // Text color of selected segments
+	FillColor             Color              // Background color of selected segments
+	Enabled               *bool              // Enabled
+}
+
+// isSelected reports whether a value is in the current selection
+func (sb SegmentedButton[T]) isSelected(value T) bool {
+	for _, selected := range sb.Selected {
+		if selected == value {
+			return true
+		}
+	}
+	return false
+}
+
+// toggle returns the selection that results from pressing the segment with the given value
+func (sb SegmentedButton[T]) toggle(value T) []T {
+	if !sb.MultiSelectionEnabled {
+		if sb.isSelected(value) {
+			if sb.EmptySelectionAllowed {
+				return []T{}
+			}
+			return []T{value}
+		}
+		return []T{value}
+	}
+
+	if !sb.isSelected(value) {
+		return append(append([]T{}, sb.Selected...), value)
+	}
+
+	if len(sb.Selected) == 1 && !sb.EmptySelectionAllowed {
+		return append([]T{}, sb.Selected...)
+	}
+
+	next := make([]T, 0, len(sb.Selected))
+	for _, selected := range sb.Selected {
+		if selected != value {
+			next = append(next, selected)
+		}
+	}
+	return next
+}
+
+// Render renders the segmented button as HTML
+func (sb SegmentedButton[T]) Render(ctx *core.Context) string {
+	enabled := isEnabled(sb.Enabled, sb.OnSelectionChanged != nil)
+
+	showSelectedIcon := true
+	if sb.ShowSelectedIcon != nil {
+		showSelectedIcon = *sb.ShowSelectedIcon
+	}
+
+	segments := make([]toggleSegment, len(sb.Segments))
+	for i, segment := range sb.Segments {
+		selected := sb.isSelected(segment.Value)
+		segmentEnabled := enabled
+		if segment.Enabled != nil {
+			segmentEnabled = segmentEnabled && *segment.Enabled
+		}
+
+		var content string
+		if selected && showSelectedIcon {
+			content += `<span class="godin-segmented-button-check" aria-hidden="true">✓</span>`
+		} else if segment.Icon != nil {
+			content += segment.Icon.Render(ctx)
+		}
+		content += renderChild(ctx, segment.Label)
+
+		segments[i] = toggleSegment{
+			content:  content,
+			selected: selected,
+			enabled:  segmentEnabled,
+			tooltip:  segment.Tooltip,
+		}
+
+		if segmentEnabled && ctx != nil && ctx.App != nil {
+			next := sb.toggle(segment.Value)
+			onSelectionChanged := sb.OnSelectionChanged
+			callbackID := ctx.App.RegisterCallback(sb.ID, "SegmentedButton", "OnSelectionChanged", func() {
+				onSelectionChanged(next)
+			}, ctx)
+			if callbackID != "" {
+				segments[i].callbackPath = appPath(ctx, "/api/callbacks/"+callbackID)
+			}
+		}
+	}
+
+	radius := 20.0
+	return renderToggleGroup(toggleGroup{
+		id:            sb.ID,
+		style:         sb.Style,
+		class:         sb.Class + " godin-segmented-button",
+		segments:      segments,
+		selectedColor: sb.SelectedColor,
+		fillColor:     sb.FillColor,
+		borderRadius:  &radius,
+		minHeight:     40,
+	})
+}
+
+// toggleSegment is one rendered button in a toggle group
+type toggleSegment struct {
+	content      string
+	selected     bool
+	enabled      bool
+	tooltip      string
+	callbackPath string // Endpoint of the segment's callback
+}
+
+// toggleGroup holds the shared rendering options for ToggleButtons and SegmentedButton
+type toggleGroup struct {
+	id            string
+	style         string
+	class         string
+	segments      []toggleSegment
+	color         Color
+	selectedColor Color
+	fillColor     Color
+	borderColor   Color
+	borderRadius  *float64
+	vertical      bool
+	minHeight     float64
+}
+
+// renderChild renders a child widget, returning an empty string for nil
+func renderChild(ctx *core.Context, child Widget) string {
+	if child == nil {
+		return ""
+	}
+	return child.Render(ctx)
+}
+
+// renderToggleGroup renders a connected group of toggle segments
+func renderToggleGroup(group toggleGroup) string {
+	htmlRenderer := renderer.NewHTMLRenderer()
+
+	color := string(group.color)
+	if color == "" {
+		color = "rgba(0, 0, 0, 0.87)"
+	}
+	selectedColor := string(group.selectedColor)
+	if selectedColor == "" {
+		selectedColor = "#1976d2"
+	}
+	fillColor := string(group.fillColor)
+	if fillColor == "" {
+		fillColor = "rgba(25, 118, 210, 0.12)"
+	}
+	borderColor := string(group.borderColor)
+	if borderColor == "" {
+		borderColor = "rgba(0, 0, 0, 0.23)"
+	}
+	radius := 4.0
+	if group.borderRadius != nil {
+		radius = *group.borderRadius
+	}
+	minHeight := group.minHeight
+	if minHeight == 0 {
+		minHeight = 36
+	}
+
+	attrs := buildAttributes(group.id, group.style, group.class)
+	attrs["role"] = "group"
+
+	var styles []string
+	if group.style != "" {
+		styles = append(styles, group.style)
+	}
+	styles = append(styles, "display: inline-flex")
+	if group.vertical {
+		styles = append(styles, "flex-direction: column")
+	}
+	styles = append(styles, fmt.Sprintf("border: 1px solid %s", borderColor))
+	styles = append(styles, fmt.Sprintf("border-radius: %.1fpx", radius))
+	styles = append(styles, "overflow: hidden")
+	attrs["style"] = strings.Join(styles, "; ")
+
+	divider := "border-left"
+	if group.vertical {
+		divider = "border-top"
+	}
+
+	var content strings.Builder
+	for i, segment := range group.segments {
+		buttonAttrs := map[string]string{
+			"type":         "button",
+			"class":        "godin-toggle-segment",
+			"aria-pressed": fmt.Sprintf("%t", segment.selected),
+		}
+		if group.id != "" {
+			buttonAttrs["id"] = fmt.Sprintf("%s_%d", group.id, i)
+		}
+		if segment.tooltip != "" {
+			buttonAttrs["title"] = segment.tooltip
+		}
+
+		buttonStyles := []string{
+			"display: inline-flex",
+			"align-items: center",
+			"justify-content: center",
+			"gap: 8px",
+			"padding: 8px 16px",
+			fmt.Sprintf("min-height: %.0fpx", minHeight),
+			"border: none",
+			"font-family: inherit",
+			"font-weight: 500",
+			"transition: background-color 0.2s ease",
+		}
+		if i > 0 {
+			buttonStyles = append(buttonStyles, fmt.Sprintf("%s: 1px solid %s", divider, borderColor))
+		}
+		if segment.selected {
+			buttonAttrs["class"] += " selected"
+			buttonStyles = append(buttonStyles, fmt.Sprintf("background-color: %s", fillColor))
+			buttonStyles = append(buttonStyles, fmt.Sprintf("color: %s", selectedColor))
+		} else {
+			buttonStyles = append(buttonStyles, "background-color: transparent")
+			buttonStyles = append(buttonStyles, fmt.Sprintf("color: %s", color))
+		}
+
+		if segment.enabled {
+			buttonStyles = append(buttonStyles, "cursor: pointer")
+			if segment.callbackPath != "" {
+				buttonAttrs["onclick"] = fmt.Sprintf("handleWidgetCallback('%s', event)", segment.callbackPath)
+			}
+		} else {
+			applyDisabled(buttonAttrs, true)
+		}
+		buttonAttrs["style"] = strings.Join(buttonStyles, "; ")
+
+		content.WriteString(htmlRenderer.RenderElement("button", buttonAttrs, segment.content, false))
+	}
+
+	return htmlRenderer.RenderElement("div", attrs, content.String(), false)
+}
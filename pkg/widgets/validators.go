@@ -0,0 +1,161 @@
+package widgets
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/gideonsigilai/godin/pkg/core"
+)
+
+// emailPattern is the shape of address the Email validator accepts
+var emailPattern = regexp.MustCompile(`^[^\s@]+@[^\s@]+\.[^\s@]+$`)
+
+// ValidatorSet builds FormFieldValidators with consistent error messages. Use
+// Validators for English messages, or ValidatorsFor(ctx) to translate them
+// with ctx.T under the "validation.*" keys:
+//
+//	Validator: widgets.Validators.Compose(
+//		widgets.Validators.Required,
+//		widgets.Validators.MinLength(8),
+//	)
+//
+// Apart from Required, validators accept an empty value, so optional fields
+// are only checked once something has been entered.
+type ValidatorSet struct {
+	ctx *core.Context // Translates messages when set
+}
+
+// Validators builds validators with English error messages
+var Validators = ValidatorSet{}
+
+// ValidatorsFor returns a ValidatorSet whose messages are translated for the
+// request's locale; keys missing from the catalog keep the English message
+func ValidatorsFor(ctx *core.Context) ValidatorSet {
+	return ValidatorSet{ctx: ctx}
+}
+
+// Required rejects empty and whitespace-only values (validation.required)
+func (v ValidatorSet) Required(value string) *string {
+	if strings.TrimSpace(value) == "" {
+		return v.message("validation.required", "This field is required")
+	}
+	return nil
+}
+
+// Email rejects values that are not an email address (validation.email)
+func (v ValidatorSet) Email(value string) *string {
+	if value != "" && !emailPattern.MatchString(value) {
+		return v.message("validation.email", "Enter a valid email address")
+	}
+	return nil
+}
+
+// MinLength rejects values shorter than n characters (validation.min_length, with {min})
+func (v ValidatorSet) MinLength(n int) FormFieldValidator[string] {
+	return func(value string) *string {
+		if value != "" && utf8.RuneCountInString(value) < n {
+			return v.message("validation.min_length", fmt.Sprintf("Must be at least %d characters", n), "min", n)
+		}
+		return nil
+	}
+}
+
+// MaxLength rejects values longer than n characters (validation.max_length, with {max})
+func (v ValidatorSet) MaxLength(n int) FormFieldValidator[string] {
+	return func(value string) *string {
+		if utf8.RuneCountInString(value) > n {
+			return v.message("validation.max_length", fmt.Sprintf("Must be at most %d characters", n), "max", n)
+		}
+		return nil
+	}
+}
+
+// Min rejects numbers below n (validation.min, with {min}), and values that are
+// not numbers (validation.number)
+func (v ValidatorSet) Min(n float64) FormFieldValidator[string] {
+	return func(value string) *string {
+		number, invalid := v.number(value)
+		if invalid != nil {
+			return invalid
+		}
+		if value != "" && number < n {
+			return v.message("validation.min", "Must be at least "+formatValidationNumber(n), "min", formatValidationNumber(n))
+		}
+		return nil
+	}
+}
+
+// Max rejects numbers above n (validation.max, with {max}), and values that are
+// not numbers (validation.number)
+func (v ValidatorSet) Max(n float64) FormFieldValidator[string] {
+	return func(value string) *string {
+		number, invalid := v.number(value)
+		if invalid != nil {
+			return invalid
+		}
+		if value != "" && number > n {
+			return v.message("validation.max", "Must be at most "+formatValidationNumber(n), "max", formatValidationNumber(n))
+		}
+		return nil
+	}
+}
+
+// Pattern rejects values that do not match re (validation.pattern); anchor re
+// with ^ and $ to match the whole value
+func (v ValidatorSet) Pattern(re *regexp.Regexp) FormFieldValidator[string] {
+	return func(value string) *string {
+		if value != "" && !re.MatchString(value) {
+			return v.message("validation.pattern", "Enter a valid value")
+		}
+		return nil
+	}
+}
+
+// Compose combines validators into one that returns the first error, in order
+func (v ValidatorSet) Compose(validators ...FormFieldValidator[string]) FormFieldValidator[string] {
+	return func(value string) *string {
+		for _, validator := range validators {
+			if validator == nil {
+				continue
+			}
+			if err := validator(value); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+}
+
+// number parses a numeric value, returning the validation.number error for
+// anything else; empty values parse as zero without an error
+func (v ValidatorSet) number(value string) (float64, *string) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0, nil
+	}
+	number, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return 0, v.message("validation.number", "Enter a number")
+	}
+	return number, nil
+}
+
+// message returns the translated message for key, or fallback when the set has
+// no context or the catalog has no such key
+func (v ValidatorSet) message(key, fallback string, args ...interface{}) *string {
+	message := fallback
+	if v.ctx != nil {
+		if translated := v.ctx.T(key, args...); translated != key {
+			message = translated
+		}
+	}
+	return &message
+}
+
+// formatValidationNumber formats a limit without trailing zeros, e.g. 5 or 2.5
+func formatValidationNumber(n float64) string {
+	return strconv.FormatFloat(n, 'f', -1, 64)
+}
@@ -24,6 +24,7 @@ type ValueListener[T any] struct {
 	// Internal state for lifecycle management
 	listenerID     string
 	isRegistered   bool
+	removeListener func()
 	mutex          sync.RWMutex
 	lastValue      T
 	lastRenderTime time.Time
@@ -88,6 +89,7 @@ type ValueListenerInt struct {
 	// Internal state
 	listenerID     string
 	isRegistered   bool
+	removeListener func()
 	mutex          sync.RWMutex
 	lastValue      int
 	lastRenderTime time.Time
@@ -125,6 +127,7 @@ type ValueListenerString struct {
 	// Internal state
 	listenerID     string
 	isRegistered   bool
+	removeListener func()
 	mutex          sync.RWMutex
 	lastValue      string
 	lastRenderTime time.Time
@@ -162,6 +165,7 @@ type ValueListenerBool struct {
 	// Internal state
 	listenerID     string
 	isRegistered   bool
+	removeListener func()
 	mutex          sync.RWMutex
 	lastValue      bool
 	lastRenderTime time.Time
@@ -199,6 +203,7 @@ type ValueListenerFloat64 struct {
 	// Internal state
 	listenerID     string
 	isRegistered   bool
+	removeListener func()
 	mutex          sync.RWMutex
 	lastValue      float64
 	lastRenderTime time.Time
@@ -301,7 +306,7 @@ func (vb EnhancedValueListenableBuilder) Render(ctx *core.Context) string {
 		if vb.ValueListenable != nil {
 			if idGetter, ok := vb.ValueListenable.(interface{ GetID() string }); ok {
 				notifierID := idGetter.GetID()
-				pollingEndpoint := "/api/value_notifier/" + notifierID + "/poll"
+				pollingEndpoint := appPath(ctx, "/api/value_notifier/"+notifierID+"/poll")
 				attrs["data-polling-endpoint"] = pollingEndpoint
 				attrs["data-polling-interval"] = "1000" // Default 1 second
 			}
@@ -311,7 +316,7 @@ func (vb EnhancedValueListenableBuilder) Render(ctx *core.Context) string {
 		if vb.ValueListenable != nil {
 			if idGetter, ok := vb.ValueListenable.(interface{ GetID() string }); ok {
 				notifierID := idGetter.GetID()
-				attrs["hx-get"] = "/api/value_notifier/" + notifierID + "/value"
+				attrs["hx-get"] = appPath(ctx, "/api/value_notifier/"+notifierID+"/value")
 				attrs["hx-trigger"] = "valueChanged from:body"
 				attrs["hx-swap"] = "innerHTML"
 			}
@@ -464,7 +469,7 @@ func (vl *ValueListener[T]) Render(ctx *core.Context) string {
 								this.subscriptions.forEach((callbacks, channel) => {
 									if (channel.startsWith('state:')) {
 										const notifierId = channel.replace('state:', '');
-										fetch('/api/state/' + notifierId)
+										fetch((window.godinBasePath || '') + '/api/state/' + notifierId)
 											.then(response => response.json())
 											.then(data => {
 												callbacks.forEach(callback => callback(data));
@@ -481,7 +486,7 @@ func (vl *ValueListener[T]) Render(ctx *core.Context) string {
 				window.godin.subscribe('state:' + notifierId, function(data) {
 					// Update the element with new content if provided
 					if (data && data.html) {
-						element.innerHTML = data.html;
+						window.Godin ? window.Godin.morph(element, data.html) : (element.innerHTML = data.html);
 					}
 
 					// Update data attributes
@@ -533,7 +538,7 @@ func (vl *ValueListener[T]) registerWithStateManager(ctx *core.Context) {
 	vl.ValueNotifier.SetManager(stateManager)
 
 	// Add listener to the ValueNotifier for change notifications
-	vl.ValueNotifier.AddListener(func(newValue T) {
+	vl.removeListener = vl.ValueNotifier.Listen(func(newValue T) {
 		// Call OnValueChanged callback if provided
 		if vl.OnValueChanged != nil {
 			vl.OnValueChanged(vl.lastValue, newValue)
@@ -542,6 +547,11 @@ func (vl *ValueListener[T]) registerWithStateManager(ctx *core.Context) {
 	})
 
 	vl.isRegistered = true
+
+	// Release the listener when the client reports the element was removed
+	if vl.listenerID != "" {
+		ctx.App.Listeners().Register(vl.listenerID, ctx.Session().ID(), vl.Cleanup)
+	}
 }
 
 // renderError renders an error state for the ValueListener
@@ -565,16 +575,17 @@ func (vl *ValueListener[T]) renderError(err error) string {
 	)
 }
 
-// Cleanup removes the listener from the ValueNotifier (should be called when widget is disposed)
+// Cleanup removes this widget's listener from the ValueNotifier (called when the widget is disposed
+// or its element is removed from the page)
 func (vl *ValueListener[T]) Cleanup() {
 	vl.mutex.Lock()
 	defer vl.mutex.Unlock()
 
 	if vl.ValueNotifier != nil && vl.isRegistered {
-		// Note: ValueNotifier doesn't have a RemoveListener method that takes a specific listener
-		// In a production implementation, you'd need to enhance ValueNotifier to support this
-		// For now, we'll clear all listeners (not ideal but functional)
-		vl.ValueNotifier.ClearListeners()
+		if vl.removeListener != nil {
+			vl.removeListener()
+			vl.removeListener = nil
+		}
 		vl.isRegistered = false
 	}
 }
@@ -663,7 +674,7 @@ func (vl *ValueListenerInt) registerWithStateManagerInt(ctx *core.Context) {
 	stateManager.RegisterValueNotifier(vl.ValueNotifier.ID(), vl.ValueNotifier)
 	vl.ValueNotifier.SetManager(stateManager)
 
-	vl.ValueNotifier.AddListener(func(newValue int) {
+	vl.removeListener = vl.ValueNotifier.Listen(func(newValue int) {
 		if vl.OnValueChanged != nil {
 			vl.OnValueChanged(vl.lastValue, newValue)
 		}
@@ -671,6 +682,11 @@ func (vl *ValueListenerInt) registerWithStateManagerInt(ctx *core.Context) {
 	})
 
 	vl.isRegistered = true
+
+	// Release the listener when the client reports the element was removed
+	if vl.listenerID != "" {
+		ctx.App.Listeners().Register(vl.listenerID, ctx.Session().ID(), vl.Cleanup)
+	}
 }
 
 func (vl *ValueListenerInt) renderErrorInt(err error) string {
@@ -763,7 +779,7 @@ func (vl *ValueListenerString) registerWithStateManagerString(ctx *core.Context)
 	stateManager.RegisterValueNotifier(vl.ValueNotifier.ID(), vl.ValueNotifier)
 	vl.ValueNotifier.SetManager(stateManager)
 
-	vl.ValueNotifier.AddListener(func(newValue string) {
+	vl.removeListener = vl.ValueNotifier.Listen(func(newValue string) {
 		if vl.OnValueChanged != nil {
 			vl.OnValueChanged(vl.lastValue, newValue)
 		}
@@ -771,6 +787,11 @@ func (vl *ValueListenerString) registerWithStateManagerString(ctx *core.Context)
 	})
 
 	vl.isRegistered = true
+
+	// Release the listener when the client reports the element was removed
+	if vl.listenerID != "" {
+		ctx.App.Listeners().Register(vl.listenerID, ctx.Session().ID(), vl.Cleanup)
+	}
 }
 
 func (vl *ValueListenerString) renderErrorString(err error) string {
@@ -863,7 +884,7 @@ func (vl *ValueListenerBool) registerWithStateManagerBool(ctx *core.Context) {
 	stateManager.RegisterValueNotifier(vl.ValueNotifier.ID(), vl.ValueNotifier)
 	vl.ValueNotifier.SetManager(stateManager)
 
-	vl.ValueNotifier.AddListener(func(newValue bool) {
+	vl.removeListener = vl.ValueNotifier.Listen(func(newValue bool) {
 		if vl.OnValueChanged != nil {
 			vl.OnValueChanged(vl.lastValue, newValue)
 		}
@@ -871,6 +892,11 @@ func (vl *ValueListenerBool) registerWithStateManagerBool(ctx *core.Context) {
 	})
 
 	vl.isRegistered = true
+
+	// Release the listener when the client reports the element was removed
+	if vl.listenerID != "" {
+		ctx.App.Listeners().Register(vl.listenerID, ctx.Session().ID(), vl.Cleanup)
+	}
 }
 
 func (vl *ValueListenerBool) renderErrorBool(err error) string {
@@ -963,7 +989,7 @@ func (vl *ValueListenerFloat64) registerWithStateManagerFloat64(ctx *core.Contex
 	stateManager.RegisterValueNotifier(vl.ValueNotifier.ID(), vl.ValueNotifier)
 	vl.ValueNotifier.SetManager(stateManager)
 
-	vl.ValueNotifier.AddListener(func(newValue float64) {
+	vl.removeListener = vl.ValueNotifier.Listen(func(newValue float64) {
 		if vl.OnValueChanged != nil {
 			vl.OnValueChanged(vl.lastValue, newValue)
 		}
@@ -971,6 +997,11 @@ func (vl *ValueListenerFloat64) registerWithStateManagerFloat64(ctx *core.Contex
 	})
 
 	vl.isRegistered = true
+
+	// Release the listener when the client reports the element was removed
+	if vl.listenerID != "" {
+		ctx.App.Listeners().Register(vl.listenerID, ctx.Session().ID(), vl.Cleanup)
+	}
 }
 
 func (vl *ValueListenerFloat64) renderErrorFloat64(err error) string {
@@ -1366,7 +1397,7 @@ func (vl *ValueListener[T]) RenderWithFallback(ctx *core.Context) string {
 					if (pollingInterval) return; // Already polling
 					
 					pollingInterval = setInterval(function() {
-						fetch('/api/state/' + notifierId)
+						fetch((window.godinBasePath || '') + '/api/state/' + notifierId)
 							.then(response => {
 								if (!response.ok) throw new Error('Network response was not ok');
 								return response.json();
@@ -1392,7 +1423,7 @@ func (vl *ValueListener[T]) RenderWithFallback(ctx *core.Context) string {
 									
 									// If we have HTML content, update it
 									if (data.html) {
-										element.innerHTML = data.html;
+										window.Godin ? window.Godin.morph(element, data.html) : (element.innerHTML = data.html);
 									}
 								}
 							})
@@ -1452,7 +1483,10 @@ func (vl *ValueListenerInt) Cleanup() {
 	defer vl.mutex.Unlock()
 
 	if vl.ValueNotifier != nil && vl.isRegistered {
-		vl.ValueNotifier.ClearListeners()
+		if vl.removeListener != nil {
+			vl.removeListener()
+			vl.removeListener = nil
+		}
 		vl.isRegistered = false
 	}
 }
@@ -1463,7 +1497,10 @@ func (vl *ValueListenerString) Cleanup() {
 	defer vl.mutex.Unlock()
 
 	if vl.ValueNotifier != nil && vl.isRegistered {
-		vl.ValueNotifier.ClearListeners()
+		if vl.removeListener != nil {
+			vl.removeListener()
+			vl.removeListener = nil
+		}
 		vl.isRegistered = false
 	}
 }
@@ -1474,7 +1511,10 @@ func (vl *ValueListenerBool) Cleanup() {
 	defer vl.mutex.Unlock()
 
 	if vl.ValueNotifier != nil && vl.isRegistered {
-		vl.ValueNotifier.ClearListeners()
+		if vl.removeListener != nil {
+			vl.removeListener()
+			vl.removeListener = nil
+		}
 		vl.isRegistered = false
 	}
 }
@@ -1485,7 +1525,10 @@ func (vl *ValueListenerFloat64) Cleanup() {
 	defer vl.mutex.Unlock()
 
 	if vl.ValueNotifier != nil && vl.isRegistered {
-		vl.ValueNotifier.ClearListeners()
+		if vl.removeListener != nil {
+			vl.removeListener()
+			vl.removeListener = nil
+		}
 		vl.isRegistered = false
 	}
 }
@@ -1718,7 +1761,7 @@ func (vl *ValueListenerInt) RenderWithFallback(ctx *core.Context) string {
 					if (pollingInterval) return;
 					
 					pollingInterval = setInterval(function() {
-						fetch('/api/state/' + notifierId)
+						fetch((window.godinBasePath || '') + '/api/state/' + notifierId)
 							.then(response => response.json())
 							.then(data => {
 								const currentValue = parseInt(element.getAttribute('data-current-value'));
@@ -1776,7 +1819,7 @@ func (vl *ValueListenerString) RenderWithFallback(ctx *core.Context) string {
 					if (pollingInterval) return;
 					
 					pollingInterval = setInterval(function() {
-						fetch('/api/state/' + notifierId)
+						fetch((window.godinBasePath || '') + '/api/state/' + notifierId)
 							.then(response => response.json())
 							.then(data => {
 								const currentValue = element.getAttribute('data-current-value');
@@ -1833,7 +1876,7 @@ func (vl *ValueListenerBool) RenderWithFallback(ctx *core.Context) string {
 					if (pollingInterval) return;
 					
 					pollingInterval = setInterval(function() {
-						fetch('/api/state/' + notifierId)
+						fetch((window.godinBasePath || '') + '/api/state/' + notifierId)
 							.then(response => response.json())
 							.then(data => {
 								const currentValue = element.getAttribute('data-current-value') === 'true';
@@ -1890,7 +1933,7 @@ func (vl *ValueListenerFloat64) RenderWithFallback(ctx *core.Context) string {
 					if (pollingInterval) return;
 					
 					pollingInterval = setInterval(function() {
-						fetch('/api/state/' + notifierId)
+						fetch((window.godinBasePath || '') + '/api/state/' + notifierId)
 							.then(response => response.json())
 							.then(data => {
 								const currentValue = parseFloat(element.getAttribute('data-current-value'));
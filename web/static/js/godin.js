@@ -11,6 +11,18 @@ class GodinFramework {
         this.maxReconnectAttempts = 5;
         this.reconnectDelay = 1000;
         this.subscriptions = new Map();
+        this.hasConnected = false;
+        this.removedListeners = new Set();
+        this.unsubscribeTimer = null;
+        this.snackbarQueue = [];
+        this.visibleSnackbars = [];
+        this.lastSnackbar = null;
+        this.presence = new Map();
+        this.presenceMembers = new Map();
+        this.connectionState = 'connecting';
+        this.heartbeatTimer = null;
+        this.heartbeatTimeout = null;
+        this.restorationTimers = new Map();
         
         this.init();
     }
@@ -45,6 +57,24 @@ class GodinFramework {
 
         // Setup native button click handling
         this.setupNativeButtonHandling();
+
+        // Release server-side listeners when their elements leave the page
+        this.setupListenerLifecycle();
+
+        // Show flash messages left by the previous request
+        this.showFlashes();
+
+        // Queue snackbars shown with ctx.ShowSnackBar while rendering the page
+        this.showPageSnackbars();
+
+        // Focus the element requested with ctx.RequestFocus or FocusNode.RequestFocus
+        this.applyFocusRequests(document);
+
+        // Fill in the dev mode banner, present only with GODIN_DEV_MODE
+        this.initDebugBanner();
+
+        // Follow the OS color scheme when the app uses ThemeModeSystem
+        this.initColorScheme();
     }
     
     // WebSocket Management
@@ -60,6 +90,7 @@ class GodinFramework {
         }
 
         console.log('Attempting to connect to WebSocket:', this.wsUrl);
+        this.setConnectionState(this.hasConnected ? 'reconnecting' : 'connecting');
 
         try {
             this.websocket = new WebSocket(this.wsUrl);
@@ -67,6 +98,8 @@ class GodinFramework {
             this.websocket.onopen = (event) => {
                 console.log('WebSocket connected successfully');
                 this.reconnectAttempts = 0;
+                this.setConnectionState('connected');
+                this.startHeartbeat();
                 this.onWebSocketOpen(event);
             };
             
@@ -93,6 +126,43 @@ class GodinFramework {
         this.subscriptions.forEach((callback, channel) => {
             this.subscribe(channel, callback);
         });
+
+        // Rejoin presence topics, which the server forgot when the old connection closed
+        this.presence.forEach((data, topic) => {
+            this.sendPresence(topic, data);
+        });
+
+        // After a reconnect, Consumers may have missed broadcasts while offline
+        if (this.hasConnected) {
+            this.requestStateSync();
+        }
+        this.hasConnected = true;
+    }
+
+    requestStateSync() {
+        const keys = new Set();
+        document.querySelectorAll('[data-state-key]').forEach(element => {
+            element.getAttribute('data-state-key').split(/\s+/).filter(Boolean).forEach(key => keys.add(key));
+        });
+
+        // Sent even without keys, since the server's OnResync hook may supply state of its own
+        console.log('Requesting state snapshot for', keys.size, 'keys');
+        this.websocket.send(JSON.stringify({
+            type: 'sync',
+            data: { keys: Array.from(keys) }
+        }));
+    }
+
+    handleSnapshot(message) {
+        const values = (message.data && message.data.values) || {};
+        const refreshed = new Set();
+        Object.keys(values).forEach(key => {
+            this.handleStateChange('state:' + key, { key: key, value: values[key] }, refreshed);
+        });
+
+        document.dispatchEvent(new CustomEvent('godin:stateSync', {
+            detail: { values: values }
+        }));
     }
     
     onWebSocketMessage(event) {
@@ -105,14 +175,77 @@ class GodinFramework {
     }
     
     onWebSocketClose(event) {
-        if (this.reconnectAttempts < this.maxReconnectAttempts) {
+        this.stopHeartbeat();
+        if (this.reconnectAttempts < this.maxReconnectAttempts && navigator.onLine !== false) {
+            this.setConnectionState('reconnecting');
             setTimeout(() => {
                 this.reconnectAttempts++;
                 console.log(`Attempting to reconnect (${this.reconnectAttempts}/${this.maxReconnectAttempts})`);
                 this.connectWebSocket();
             }, this.reconnectDelay * this.reconnectAttempts);
+        } else {
+            this.setConnectionState('offline');
+        }
+    }
+
+    // Reconnect from scratch, e.g. when the browser comes back online or the user asks to retry
+    retryWebSocket() {
+        this.reconnectAttempts = 0;
+        this.connectWebSocket();
+    }
+
+    // The server pings at the protocol level, which the browser answers without telling the page.
+    // The client pings too, so a connection that silently died is noticed and reconnected.
+    startHeartbeat() {
+        this.stopHeartbeat();
+        this.heartbeatTimer = setInterval(() => {
+            if (!this.websocket || this.websocket.readyState !== WebSocket.OPEN) {
+                return;
+            }
+            this.websocket.send(JSON.stringify({ type: 'ping' }));
+            clearTimeout(this.heartbeatTimeout);
+            this.heartbeatTimeout = setTimeout(() => {
+                console.log('WebSocket heartbeat timed out');
+                this.websocket.close();
+            }, 10000);
+        }, 25000);
+    }
+
+    stopHeartbeat() {
+        clearInterval(this.heartbeatTimer);
+        clearTimeout(this.heartbeatTimeout);
+        this.heartbeatTimer = null;
+        this.heartbeatTimeout = null;
+    }
+
+    // Connection state: connecting, connected, reconnecting or offline
+    setConnectionState(state) {
+        const changed = state !== this.connectionState;
+        this.connectionState = state;
+        document.documentElement.setAttribute('data-godin-connection', state);
+        this.renderConnectionStatus();
+
+        if (changed) {
+            document.dispatchEvent(new CustomEvent('godin:connection', {
+                detail: { state: state, attempt: this.reconnectAttempts }
+            }));
         }
     }
+
+    renderConnectionStatus(root = document) {
+        root.querySelectorAll('[data-connection-status]').forEach(status => {
+            const state = this.connectionState;
+            status.setAttribute('data-state', state);
+            const label = status.querySelector('.godin-connection-status-label');
+            if (label) {
+                label.textContent = status.getAttribute('data-label-' + state) || '';
+            }
+            const retry = status.querySelector('[data-connection-retry]');
+            if (retry) {
+                retry.hidden = state !== 'offline';
+            }
+        });
+    }
     
     onWebSocketError(event) {
         // Handle WebSocket errors
@@ -124,22 +257,58 @@ class GodinFramework {
                 this.handleBroadcast(message);
                 break;
             case 'pong':
-                // Handle ping/pong
+                // The connection answered the heartbeat
+                clearTimeout(this.heartbeatTimeout);
+                break;
+            case 'snapshot':
+                this.handleSnapshot(message);
+                break;
+            case 'presence':
+                this.handlePresence(message);
                 break;
             default:
                 console.log('Unknown WebSocket message type:', message.type);
         }
     }
     
-    handleBroadcast(message) {
+    handleBroadcast(message, refreshed) {
         const callback = this.subscriptions.get(message.channel);
         if (callback) {
             callback(message.data);
         }
 
+        // A batch carries several state changes; replay each as its own state broadcast,
+        // refreshing an element depending on several of the keys only once
+        if (message.channel === 'state_batch') {
+            const batchRefreshed = new Set();
+            (message.data.changes || []).forEach(change => {
+                this.handleBroadcast({
+                    type: 'broadcast',
+                    channel: 'state:' + change.key,
+                    data: change
+                }, batchRefreshed);
+            });
+            return;
+        }
+
         // Handle state changes for automatic UI updates
         if (message.channel.startsWith('state:')) {
-            this.handleStateChange(message.channel, message.data);
+            this.handleStateChange(message.channel, message.data, refreshed);
+        }
+
+        // Push or remove dialogs and sheets in the overlay layer
+        if (message.channel === 'overlay') {
+            this.handleOverlay(message.data);
+        }
+
+        // Swap rebuilt repaint boundaries in place
+        if (message.channel.startsWith('boundary:')) {
+            this.handleBoundaryRebuild(message.data);
+        }
+
+        // Patch single items of live lists
+        if (message.channel.startsWith('list:')) {
+            this.handleListPatch(message.data);
         }
 
         // Trigger custom event
@@ -152,13 +321,21 @@ class GodinFramework {
         document.dispatchEvent(event);
     }
 
-    handleStateChange(channel, data) {
+    // refreshed, when given, collects the elements already refreshed for other keys of the same update
+    handleStateChange(channel, data, refreshed) {
         const stateKey = channel.replace('state:', '');
 
-        // Find all elements that depend on this state key
-        const stateElements = document.querySelectorAll(`[data-state-key="${stateKey}"]`);
+        // Find all elements that depend on this state key; data-state-key may list several keys
+        const stateElements = document.querySelectorAll(`[data-state-key~="${stateKey}"]`);
 
         stateElements.forEach(element => {
+            if (refreshed) {
+                if (refreshed.has(element)) {
+                    return;
+                }
+                refreshed.add(element);
+            }
+
             // Trigger HTMX refresh for state-dependent elements
             if (element.hasAttribute('hx-get')) {
                 htmx.trigger(element, 'refresh');
@@ -168,8 +345,7 @@ class GodinFramework {
                 fetch(endpoint)
                     .then(response => response.text())
                     .then(html => {
-                        element.innerHTML = html;
-                        this.initializeComponents(element);
+                        this.morph(element, html);
                     })
                     .catch(error => console.error('Error updating state element:', error));
             }
@@ -185,140 +361,2152 @@ class GodinFramework {
         document.dispatchEvent(stateEvent);
     }
     
-    subscribe(channel, callback) {
-        this.subscriptions.set(channel, callback);
-        
-        if (this.websocket && this.websocket.readyState === WebSocket.OPEN) {
-            this.websocket.send(JSON.stringify({
-                type: 'subscribe',
-                channel: channel
-            }));
+    // Overlay Management
+    handleOverlay(data) {
+        const host = document.getElementById('godin-overlay');
+        if (!host || !data || !data.id) {
+            return;
         }
-    }
-    
-    unsubscribe(channel) {
-        this.subscriptions.delete(channel);
-        
-        if (this.websocket && this.websocket.readyState === WebSocket.OPEN) {
-            this.websocket.send(JSON.stringify({
-                type: 'unsubscribe',
-                channel: channel
-            }));
+
+        const existing = document.getElementById(data.id);
+        if (existing) {
+            existing.remove();
         }
+
+        if (data.action === 'push') {
+            if (typeof htmx !== 'undefined' && typeof htmx.swap === 'function') {
+                htmx.swap(host, data.oob, { swapStyle: 'none' });
+            } else {
+                host.insertAdjacentHTML('beforeend', data.html);
+            }
+            const entry = document.getElementById(data.id);
+            if (entry) {
+                this.initializeComponents(entry);
+            }
+        }
+
+        this.updateOverlayScrim();
     }
-    
-    // HTMX Integration
-    setupHTMXListeners() {
-        // Listen for HTMX events
-        document.addEventListener('htmx:beforeRequest', (event) => {
-            this.onHTMXBeforeRequest(event);
-        });
-        
-        document.addEventListener('htmx:afterRequest', (event) => {
-            this.onHTMXAfterRequest(event);
-        });
-        
-        document.addEventListener('htmx:afterSwap', (event) => {
-            this.onHTMXAfterSwap(event);
-        });
-    }
-    
-    onHTMXBeforeRequest(event) {
-        // Add loading indicators
-        const target = event.target;
-        target.classList.add('godin-loading');
+
+    topOverlayEntry(modalOnly = false) {
+        const entries = Array.from(document.querySelectorAll('#godin-overlay .godin-overlay-entry'))
+            .filter(entry => !modalOnly || entry.getAttribute('data-overlay-modal') === 'true');
+        entries.sort((a, b) =>
+            parseInt(a.getAttribute('data-overlay-zindex') || '0', 10) -
+            parseInt(b.getAttribute('data-overlay-zindex') || '0', 10));
+        return entries.length > 0 ? entries[entries.length - 1] : null;
     }
-    
-    onHTMXAfterRequest(event) {
-        // Remove loading indicators
-        const target = event.target;
-        target.classList.remove('godin-loading');
+
+    updateOverlayScrim() {
+        const scrim = document.querySelector('#godin-overlay .godin-overlay-scrim');
+        if (!scrim) {
+            return;
+        }
+
+        // The scrim sits just below the top-most modal entry
+        const top = this.topOverlayEntry(true);
+        if (top) {
+            scrim.style.zIndex = parseInt(top.getAttribute('data-overlay-zindex') || '1000', 10) - 1;
+            scrim.hidden = false;
+        } else {
+            scrim.hidden = true;
+        }
     }
-    
-    onHTMXAfterSwap(event) {
-        // Re-initialize any new components
-        this.initializeComponents(event.target);
+
+    dismissTopOverlay() {
+        const top = this.topOverlayEntry(true);
+        if (!top) {
+            return false;
+        }
+
+        const endpoint = top.getAttribute('data-overlay-dismiss');
+        if (!endpoint) {
+            return false;
+        }
+
+        top.remove();
+        this.updateOverlayScrim();
+        window.handleWidgetCallback(endpoint);
+        return true;
     }
-    
-    // UI Event Listeners
-    setupUIListeners() {
-        // Handle drawer toggles
-        document.addEventListener('click', (event) => {
-            if (event.target.matches('[data-godin-drawer-toggle]')) {
-                const drawerId = event.target.getAttribute('data-godin-drawer-toggle');
-                this.toggleDrawer(drawerId);
+
+    // Autocomplete
+    setAutocompleteOpen(listbox, open) {
+        listbox.style.display = open ? 'block' : 'none';
+        const container = listbox.closest('.godin-autocomplete');
+        const input = container && container.querySelector('.godin-autocomplete-input');
+        if (input) {
+            input.setAttribute('aria-expanded', open ? 'true' : 'false');
+            if (!open) {
+                input.removeAttribute('aria-activedescendant');
             }
+        }
+    }
+
+    handleAutocompleteKey(event) {
+        const input = event.target;
+        const listbox = document.getElementById(input.getAttribute('aria-controls'));
+        if (!listbox || listbox.style.display === 'none') {
+            return;
+        }
+
+        const options = Array.from(listbox.querySelectorAll('.godin-autocomplete-option'));
+        if (options.length === 0) {
+            return;
+        }
+
+        let index = options.findIndex(option => option.getAttribute('aria-selected') === 'true');
+        switch (event.key) {
+            case 'ArrowDown':
+                index = (index + 1) % options.length;
+                break;
+            case 'ArrowUp':
+                index = index <= 0 ? options.length - 1 : index - 1;
+                break;
+            case 'Enter':
+                if (index >= 0) {
+                    event.preventDefault();
+                    this.selectAutocompleteOption(options[index]);
+                }
+                return;
+            case 'Escape':
+                this.setAutocompleteOpen(listbox, false);
+                return;
+            default:
+                return;
+        }
+
+        event.preventDefault();
+        options.forEach((option, i) => {
+            const active = i === index;
+            option.setAttribute('aria-selected', active ? 'true' : 'false');
+            option.style.backgroundColor = active ? 'rgba(25, 118, 210, 0.12)' : '';
         });
-        
-        // Handle dialog close
-        document.addEventListener('click', (event) => {
-            if (event.target.matches('.godin-dialog-overlay')) {
-                this.closeDialog(event.target.nextElementSibling);
+        input.setAttribute('aria-activedescendant', options[index].id);
+        options[index].scrollIntoView({ block: 'nearest' });
+    }
+
+    selectAutocompleteOption(option) {
+        const container = option.closest('.godin-autocomplete');
+        if (!container) {
+            return;
+        }
+
+        const value = option.getAttribute('data-value');
+        const input = container.querySelector('.godin-autocomplete-input');
+        if (input) {
+            input.value = option.getAttribute('data-display');
+        }
+        const hidden = container.querySelector('.godin-autocomplete-value');
+        if (hidden) {
+            hidden.value = value;
+        }
+        this.setAutocompleteOpen(option.parentElement, false);
+
+        const endpoint = container.getAttribute('data-on-selected');
+        if (endpoint) {
+            window.handleWidgetCallback(endpoint, null, value);
+        }
+
+        container.dispatchEvent(new CustomEvent('godin:autocompleteSelected', {
+            bubbles: true,
+            detail: { value: value }
+        }));
+    }
+
+    // DropdownButton
+    setDropdownButtonOpen(dropdown, open) {
+        const trigger = dropdown.querySelector('.godin-dropdown-button-trigger');
+        const menu = dropdown.querySelector('.godin-dropdown-button-menu');
+        if (!trigger || !menu) {
+            return;
+        }
+
+        menu.hidden = !open;
+        trigger.setAttribute('aria-expanded', open ? 'true' : 'false');
+        if (open) {
+            const items = Array.from(menu.querySelectorAll('.godin-dropdown-button-item'));
+            const selected = items.find(item => item.getAttribute('aria-selected') === 'true') || items[0];
+            if (selected) {
+                selected.focus();
             }
-        });
-        
-        // Handle tab switching
-        document.addEventListener('click', (event) => {
-            if (event.target.matches('.godin-tab')) {
-                this.switchTab(event.target);
+        }
+    }
+
+    handleDropdownButtonKey(event) {
+        const dropdown = event.target.closest('[data-dropdown-button]');
+        if (!dropdown) {
+            return;
+        }
+
+        if (event.target.matches('.godin-dropdown-button-trigger')) {
+            if (['ArrowDown', 'ArrowUp', 'Enter', ' '].includes(event.key)) {
+                event.preventDefault();
+                this.setDropdownButtonOpen(dropdown, true);
             }
-        });
+            return;
+        }
+
+        const item = event.target.closest('.godin-dropdown-button-item');
+        if (!item) {
+            return;
+        }
+        const items = Array.from(dropdown.querySelectorAll('.godin-dropdown-button-item'));
+        const index = items.indexOf(item);
+        switch (event.key) {
+            case 'ArrowDown':
+                items[(index + 1) % items.length].focus();
+                break;
+            case 'ArrowUp':
+                items[index <= 0 ? items.length - 1 : index - 1].focus();
+                break;
+            case 'Enter':
+            case ' ':
+                this.selectDropdownButtonItem(item);
+                break;
+            case 'Escape':
+                this.setDropdownButtonOpen(dropdown, false);
+                dropdown.querySelector('.godin-dropdown-button-trigger').focus();
+                break;
+            case 'Tab':
+                this.setDropdownButtonOpen(dropdown, false);
+                return;
+            default:
+                return;
+        }
+        event.preventDefault();
     }
-    
-    // UI Component Methods
-    toggleDrawer(drawerId) {
-        const drawer = document.getElementById(drawerId);
-        if (drawer) {
-            drawer.classList.toggle('open');
+
+    selectDropdownButtonItem(item) {
+        const dropdown = item.closest('[data-dropdown-button]');
+        if (!dropdown || item.getAttribute('aria-disabled') === 'true') {
+            return;
+        }
+
+        // Show the choice straight away; the item's handler passes its typed value to OnChanged
+        dropdown.querySelectorAll('.godin-dropdown-button-item').forEach(other => {
+            other.setAttribute('aria-selected', other === item ? 'true' : 'false');
+        });
+        const label = dropdown.querySelector('.godin-dropdown-button-label');
+        if (label) {
+            label.innerHTML = item.innerHTML;
+        }
+        const hidden = dropdown.querySelector('.godin-dropdown-button-value');
+        if (hidden) {
+            hidden.value = item.getAttribute('data-value') || '';
+            hidden.dispatchEvent(new Event('change', { bubbles: true }));
         }
+
+        this.setDropdownButtonOpen(dropdown, false);
+        dropdown.querySelector('.godin-dropdown-button-trigger').focus();
+        item.dispatchEvent(new CustomEvent('godin:select', { bubbles: true }));
     }
-    
-    openDialog(dialogId) {
-        const dialog = document.getElementById(dialogId);
-        if (dialog) {
-            dialog.style.display = 'block';
+
+    handleBoundaryRebuild(data) {
+        if (!data || !data.id) {
+            return;
         }
+
+        const boundary = document.querySelector(`[data-repaint-boundary="${data.id}"]`);
+        if (!boundary) {
+            return;
+        }
+
+        this.morph(boundary, data.html);
+
+        document.dispatchEvent(new CustomEvent('godin:boundaryRebuild', {
+            detail: { id: data.id }
+        }));
     }
     
-    closeDialog(dialog) {
-        if (dialog) {
-            dialog.style.display = 'none';
+    showPanicOverlay(html) {
+        const existing = document.getElementById('godin-panic-overlay');
+        if (existing) {
+            existing.remove();
         }
+
+        const overlay = document.createElement('div');
+        overlay.id = 'godin-panic-overlay';
+        overlay.className = 'godin-panic-overlay';
+        overlay.setAttribute('role', 'alertdialog');
+
+        const close = document.createElement('button');
+        close.type = 'button';
+        close.className = 'godin-panic-overlay-close';
+        close.setAttribute('aria-label', 'Close');
+        close.textContent = '\u00d7';
+        close.addEventListener('click', () => overlay.remove());
+
+        const frame = document.createElement('iframe');
+        frame.title = 'Panic';
+        frame.srcdoc = html;
+
+        overlay.append(close, frame);
+        document.body.appendChild(overlay);
+        close.focus();
     }
-    
-    switchTab(tabElement) {
-        const tabBar = tabElement.closest('.godin-tab-bar');
-        const tabs = tabBar.querySelectorAll('.godin-tab');
-        
-        // Remove active class from all tabs
-        tabs.forEach(tab => tab.classList.remove('active'));
-        
-        // Add active class to clicked tab
-        tabElement.classList.add('active');
-        
-        // Trigger custom event
-        const event = new CustomEvent('godin:tabSwitch', {
-            detail: {
-                tab: tabElement,
-                index: Array.from(tabs).indexOf(tabElement)
+
+    handleListPatch(data) {
+        const list = data && data.id ? document.getElementById(data.id) : null;
+        if (!list || !list.hasAttribute('data-list-listener')) {
+            return;
+        }
+
+        const item = list.children[data.index];
+        switch (data.action) {
+            case 'insert': {
+                const template = document.createElement('template');
+                template.innerHTML = data.html;
+                const node = template.content.firstElementChild;
+                if (node) {
+                    list.insertBefore(node, item || null);
+                    this.initializeComponents(node);
+                }
+                break;
             }
-        });
-        document.dispatchEvent(event);
+            case 'remove':
+                if (item) {
+                    item.remove();
+                }
+                break;
+            case 'update':
+                if (item) {
+                    this.morph(item, data.html, true);
+                }
+                break;
+            case 'reset':
+                this.morph(list, data.html);
+                break;
+        }
+
+        list.dispatchEvent(new CustomEvent('godin:listPatch', {
+            bubbles: true,
+            detail: { id: data.id, action: data.action, index: data.index }
+        }));
     }
-    
-    showSnackbar(message, type = 'info', duration = 3000) {
-        const snackbar = document.createElement('div');
-        snackbar.className = `godin-snackbar godin-snackbar-${type}`;
-        snackbar.textContent = message;
-        
-        document.body.appendChild(snackbar);
-        
-        // Auto-remove after duration
-        setTimeout(() => {
-            if (snackbar.parentNode) {
-                snackbar.parentNode.removeChild(snackbar);
-            }
-        }, duration);
+
+    // DOM Morphing
+    morph(element, html, outer = false) {
+        const template = document.createElement('template');
+        template.innerHTML = html;
+
+        // Moving a node blurs it, so remember the focused field and its caret
+        const active = document.activeElement;
+        const selection = active && typeof active.selectionStart === 'number'
+            ? [active.selectionStart, active.selectionEnd]
+            : null;
+
+        // An outer morph updates the element itself from the root of the new HTML
+        if (outer) {
+            this.morphNode(element, template.content.firstElementChild);
+        } else {
+            this.morphChildren(element, template.content);
+        }
+
+        if (active && active !== document.activeElement && element.contains(active)) {
+            active.focus();
+            if (selection) {
+                active.setSelectionRange(selection[0], selection[1]);
+            }
+        }
+
+        if (typeof htmx !== 'undefined') {
+            htmx.process(element);
+        }
+        this.initializeComponents(element);
+    }
+
+    morphKey(node) {
+        if (node.nodeType !== Node.ELEMENT_NODE) {
+            return null;
+        }
+        return node.getAttribute('data-key') || node.id || null;
+    }
+
+    morphChildren(parent, source) {
+        const animated = parent.nodeType === Node.ELEMENT_NODE && parent.hasAttribute('data-animated-list');
+
+        // Index keyed children so reordered items reuse their existing nodes
+        const keyed = new Map();
+        parent.childNodes.forEach(node => {
+            const key = this.morphKey(node);
+            if (key && !node.godinLeaving) {
+                keyed.set(key, node);
+            }
+        });
+
+        // Animated list items missing from the new HTML animate out where they are
+        if (animated) {
+            const kept = new Set(Array.from(source.childNodes).map(node => this.morphKey(node)));
+            keyed.forEach((node, key) => {
+                if (!kept.has(key)) {
+                    keyed.delete(key);
+                    this.leaveListItem(parent, node);
+                }
+            });
+        }
+
+        let current = parent.firstChild;
+        Array.from(source.childNodes).forEach(next => {
+            while (current && current.godinLeaving) {
+                current = current.nextSibling;
+            }
+
+            const key = this.morphKey(next);
+            let match = null;
+            if (key) {
+                match = keyed.get(key) || null;
+                keyed.delete(key);
+            } else if (current && !this.morphKey(current) &&
+                current.nodeType === next.nodeType && current.nodeName === next.nodeName) {
+                match = current;
+            }
+
+            if (!match || match.nodeName !== next.nodeName) {
+                parent.insertBefore(next, current);
+                if (animated) {
+                    this.enterListItem(next);
+                }
+                return;
+            }
+
+            if (match === current) {
+                current = current.nextSibling;
+            } else {
+                parent.insertBefore(match, current);
+            }
+            this.morphNode(match, next);
+        });
+
+        // Anything left after the cursor has no counterpart in the new HTML
+        while (current) {
+            const stale = current;
+            current = current.nextSibling;
+            if (!stale.godinLeaving) {
+                parent.removeChild(stale);
+            }
+        }
+    }
+
+    // Animated Lists
+    enterListItem(item) {
+        if (item.nodeType !== Node.ELEMENT_NODE) {
+            return;
+        }
+        item.classList.add('godin-animated-list-entering');
+        item.addEventListener('animationend', () => {
+            item.classList.remove('godin-animated-list-entering');
+        }, { once: true });
+    }
+
+    leaveListItem(list, item) {
+        if (item.nodeType !== Node.ELEMENT_NODE) {
+            item.remove();
+            return;
+        }
+        item.godinLeaving = true;
+
+        // Collapse from the item's current height so the items below slide up
+        item.style.height = item.offsetHeight + 'px';
+        void item.offsetHeight;
+        item.classList.add('godin-animated-list-leaving');
+
+        const duration = parseInt(list.getAttribute('data-animation-duration'), 10) || 300;
+        setTimeout(() => item.remove(), duration);
+    }
+
+    animatedListItems(list) {
+        return Array.from(list.children).filter(item => !item.godinLeaving);
+    }
+
+    applyAnimatedListChanges(detail) {
+        if (!detail || !Array.isArray(detail.changes)) {
+            return;
+        }
+
+        detail.changes.forEach(change => {
+            const list = document.getElementById(change.id);
+            if (!list) {
+                return;
+            }
+            const items = this.animatedListItems(list);
+
+            if (change.action === 'remove') {
+                if (items[change.index]) {
+                    this.leaveListItem(list, items[change.index]);
+                }
+                return;
+            }
+
+            const template = document.createElement('template');
+            template.innerHTML = change.html;
+            const item = template.content.firstElementChild;
+            if (!item) {
+                return;
+            }
+            list.insertBefore(item, items[change.index] || null);
+            this.enterListItem(item);
+            if (typeof htmx !== 'undefined') {
+                htmx.process(item);
+            }
+            this.initializeComponents(item);
+        });
+    }
+
+    // Morph HTMX responses into an animated list instead of replacing it, so only
+    // the items that were added or removed animate
+    swapAnimatedList(event) {
+        const target = event.detail.target;
+        if (!event.detail.shouldSwap || !target || !target.matches('[data-animated-list]')) {
+            return;
+        }
+
+        const element = event.detail.requestConfig && event.detail.requestConfig.elt;
+        const swapSpec = event.detail.xhr.getResponseHeader('HX-Reswap') ||
+            (element && element.closest('[hx-swap]') ? element.closest('[hx-swap]').getAttribute('hx-swap') : '') ||
+            'innerHTML';
+        const swapStyle = swapSpec.split(' ')[0];
+
+        const html = event.detail.serverResponse;
+        if (swapStyle === 'outerHTML') {
+            const template = document.createElement('template');
+            template.innerHTML = html;
+            const root = template.content.firstElementChild;
+            if (!root || !root.hasAttribute('data-animated-list')) {
+                return;
+            }
+        } else if (swapStyle !== 'innerHTML') {
+            return;
+        }
+
+        // Swapping nothing still runs out-of-band swaps and triggers from the response
+        event.detail.swapOverride = 'none';
+        this.morph(target, html, swapStyle === 'outerHTML');
+    }
+
+    morphNode(node, next) {
+        if (node.nodeType !== Node.ELEMENT_NODE) {
+            if (node.nodeValue !== next.nodeValue) {
+                node.nodeValue = next.nodeValue;
+            }
+            return;
+        }
+
+        // Leave the value of the field being typed in alone
+        const editing = node === document.activeElement &&
+            (node.tagName === 'INPUT' || node.tagName === 'TEXTAREA' || node.tagName === 'SELECT');
+
+        Array.from(node.attributes).forEach(attr => {
+            if (!next.hasAttribute(attr.name)) {
+                node.removeAttribute(attr.name);
+            }
+        });
+        Array.from(next.attributes).forEach(attr => {
+            if (node.getAttribute(attr.name) !== attr.value) {
+                node.setAttribute(attr.name, attr.value);
+            }
+        });
+
+        if (node.tagName === 'INPUT' && !editing) {
+            node.value = next.getAttribute('value') || '';
+            node.checked = next.hasAttribute('checked');
+        }
+        if (node.tagName === 'TEXTAREA') {
+            if (!editing) {
+                node.value = next.textContent;
+            }
+            return;
+        }
+
+        this.morphChildren(node, next);
+    }
+    
+    subscribe(channel, callback) {
+        this.subscriptions.set(channel, callback);
+        
+        if (this.websocket && this.websocket.readyState === WebSocket.OPEN) {
+            this.websocket.send(JSON.stringify({
+                type: 'subscribe',
+                channel: channel
+            }));
+        }
+    }
+    
+    unsubscribe(channel) {
+        this.subscriptions.delete(channel);
+        
+        if (this.websocket && this.websocket.readyState === WebSocket.OPEN) {
+            this.websocket.send(JSON.stringify({
+                type: 'unsubscribe',
+                channel: channel
+            }));
+        }
+    }
+    
+    // Presence
+    setPresence(topic, data) {
+        this.presence.set(topic, data);
+        this.sendPresence(topic, data);
+    }
+
+    clearPresence(topic) {
+        this.presence.delete(topic);
+        this.presenceMembers.delete(topic);
+        this.sendPresence(topic, null);
+    }
+
+    sendPresence(topic, data) {
+        if (this.websocket && this.websocket.readyState === WebSocket.OPEN) {
+            this.websocket.send(JSON.stringify({
+                type: 'presence',
+                channel: topic,
+                data: data
+            }));
+        }
+    }
+
+    handlePresence(message) {
+        const topic = message.channel;
+        const data = message.data || {};
+
+        // Joining sends the topic's members; after that only the member who changed,
+        // with null data when it left
+        let topicMembers = this.presenceMembers.get(topic);
+        if (data.members) {
+            topicMembers = { self: data.self, members: new Map(Object.entries(data.members)) };
+            this.presenceMembers.set(topic, topicMembers);
+        } else if (topicMembers && data.id) {
+            if (data.data === null || data.data === undefined) {
+                topicMembers.members.delete(data.id);
+            } else {
+                topicMembers.members.set(data.id, data.data);
+            }
+        } else {
+            return;
+        }
+
+        // Other clients in the topic, leaving this one out
+        const others = Array.from(topicMembers.members.entries())
+            .filter(([id]) => id !== topicMembers.self)
+            .map(([id, memberData]) => ({ id: id, data: memberData }));
+
+        document.dispatchEvent(new CustomEvent('godin:presence', {
+            detail: { topic: topic, self: topicMembers.self, others: others }
+        }));
+    }
+
+    // Listener Lifecycle
+    setupListenerLifecycle() {
+        if (typeof MutationObserver === 'undefined') {
+            return;
+        }
+
+        const observer = new MutationObserver((mutations) => {
+            mutations.forEach(mutation => {
+                mutation.removedNodes.forEach(node => {
+                    if (node.nodeType !== Node.ELEMENT_NODE) {
+                        return;
+                    }
+                    if (node.hasAttribute('data-listener-id')) {
+                        this.removedListeners.add(node.getAttribute('data-listener-id'));
+                    }
+                    node.querySelectorAll('[data-listener-id]').forEach(element => {
+                        this.removedListeners.add(element.getAttribute('data-listener-id'));
+                    });
+                });
+            });
+
+            if (this.removedListeners.size > 0) {
+                clearTimeout(this.unsubscribeTimer);
+                this.unsubscribeTimer = setTimeout(() => this.flushRemovedListeners(), 500);
+            }
+        });
+
+        observer.observe(document.body, { childList: true, subtree: true });
+        window.addEventListener('pagehide', () => this.flushRemovedListeners());
+    }
+
+    flushRemovedListeners() {
+        // Swaps often re-render the same listener, so only release IDs that are really gone
+        const ids = Array.from(this.removedListeners).filter(id =>
+            !document.querySelector(`[data-listener-id="${CSS.escape(id)}"]`)
+        );
+        this.removedListeners.clear();
+
+        if (ids.length === 0) {
+            return;
+        }
+
+        fetch(this.basePath() + '/api/listeners/unsubscribe', {
+            method: 'POST',
+            headers: {
+                'Content-Type': 'application/json',
+                'X-CSRF-Token': this.getCSRFToken()
+            },
+            body: JSON.stringify({ ids: ids }),
+            keepalive: true
+        }).catch(error => console.error('Error releasing listeners:', error));
+    }
+
+    basePath() {
+        return window.godinBasePath || '';
+    }
+
+    getCSRFToken() {
+        const meta = document.querySelector('meta[name="csrf-token"]');
+        return meta ? meta.getAttribute('content') : '';
+    }
+
+    // Headers asking the server for out-of-band state refreshes: without a WebSocket,
+    // state changes come back in the response; name the listeners this page shows
+    // so it refreshes only those
+    stateRefreshHeaders() {
+        if (this.websocket && this.websocket.readyState === WebSocket.OPEN) {
+            return {};
+        }
+        const headers = { 'HX-Request': 'true' };
+        const ids = new Set();
+        document.querySelectorAll('[data-listener-id]').forEach(element => {
+            ids.add(element.getAttribute('data-listener-id'));
+        });
+        if (ids.size > 0) {
+            headers['X-Godin-Listeners'] = Array.from(ids).join(' ');
+        }
+        return headers;
+    }
+
+    // Applies the out-of-band swaps of a fetch response, as HTMX does for its own
+    // requests; resolves to whether the response was such HTML
+    applyStateFragments(response) {
+        const type = response.headers.get('Content-Type') || '';
+        if (!response.ok || !type.startsWith('text/html') || typeof htmx === 'undefined') {
+            return Promise.resolve(false);
+        }
+        return response.text().then(html => {
+            htmx.swap(document.body, html, { swapStyle: 'none' });
+            return true;
+        });
+    }
+
+    // HTMX Integration
+    setupHTMXListeners() {
+        // Attach the CSRF token to HTMX requests when the server provides one
+        document.addEventListener('htmx:configRequest', (event) => {
+            const token = this.getCSRFToken();
+            if (token) {
+                event.detail.headers['X-CSRF-Token'] = token;
+            }
+        });
+
+        // Without a WebSocket, state changes come back as out-of-band swaps; tell the
+        // server which listeners this page shows so it refreshes only those
+        document.addEventListener('htmx:configRequest', (event) => {
+            Object.assign(event.detail.headers, this.stateRefreshHeaders());
+        });
+
+        // Tell a Form's OnFormChanged handler which field changed
+        document.addEventListener('htmx:configRequest', (event) => {
+            const trigger = event.detail.triggeringEvent;
+            if (event.detail.elt.hasAttribute('data-form-changes') && trigger && trigger.target) {
+                event.detail.parameters['godin_changed_name'] = trigger.target.name || '';
+                event.detail.parameters['godin_changed_id'] = trigger.target.id || '';
+            }
+        });
+
+        // Listen for HTMX events
+        document.addEventListener('htmx:beforeRequest', (event) => {
+            this.onHTMXBeforeRequest(event);
+        });
+        
+        document.addEventListener('htmx:afterRequest', (event) => {
+            this.onHTMXAfterRequest(event);
+        });
+        
+        document.addEventListener('htmx:afterSwap', (event) => {
+            this.onHTMXAfterSwap(event);
+        });
+
+        // HTMX does not swap error responses, so show the debug panic page over the page
+        document.addEventListener('htmx:responseError', (event) => {
+            const xhr = event.detail.xhr;
+            if (xhr && xhr.getResponseHeader('X-Godin-Panic') && /^<!DOCTYPE/i.test(xhr.responseText)) {
+                this.showPanicOverlay(xhr.responseText);
+            }
+        });
+
+        // Replace the browser confirm() with a styled dialog for data-confirm-style="dialog"
+        document.addEventListener('htmx:confirm', (event) => {
+            const element = event.detail.elt;
+            if (!event.detail.question || !element || element.getAttribute('data-confirm-style') !== 'dialog') {
+                return;
+            }
+
+            event.preventDefault();
+            this.showConfirmDialog(event.detail.question, () => event.detail.issueRequest(true));
+        });
+
+        // Move focus where the server asked once swapped content has settled
+        document.addEventListener('godin:focus', (event) => {
+            if (event.detail && event.detail.id) {
+                this.focusElement(event.detail.id);
+            }
+        });
+
+        document.addEventListener('htmx:afterSettle', (event) => {
+            this.applyFocusRequests(event.target);
+        });
+
+        // Add the styles and scripts partial responses contributed with ctx.AddCSS and friends
+        document.addEventListener('htmx:afterSettle', () => {
+            this.applyPageAssets();
+        });
+
+        // Morph updates into animated lists so their items animate in and out
+        document.addEventListener('htmx:beforeSwap', (event) => {
+            this.swapAnimatedList(event);
+        });
+
+        // Insert or remove items with AnimatedListController
+        document.addEventListener('godin:animated-list', (event) => {
+            this.applyAnimatedListChanges(event.detail);
+        });
+
+        // Queue snackbars shown with ctx.ShowSnackBar or SnackBarController.Show
+        document.addEventListener('godin:snackbar', (event) => {
+            this.applySnackbarUpdate(event.detail);
+        });
+
+        // ConnectionStatus widgets swapped in show the current state
+        document.addEventListener('htmx:afterSwap', () => {
+            this.renderConnectionStatus();
+        });
+
+        // Show or hide autocomplete suggestions after they are fetched
+        document.addEventListener('htmx:afterSwap', (event) => {
+            if (event.target.matches('.godin-autocomplete-options')) {
+                this.setAutocompleteOpen(event.target, event.target.children.length > 0);
+            }
+        });
+    }
+    
+    applyPageAssets() {
+        const containers = document.querySelectorAll('.godin-page-assets');
+        if (containers.length === 0) {
+            return;
+        }
+
+        // Assets the full page render already included, and those added since
+        if (!this.pageAssets) {
+            const meta = document.querySelector('meta[name="godin-page-assets"]');
+            this.pageAssets = new Set(meta ? meta.content.split(/\s+/).filter(Boolean) : []);
+        }
+
+        containers.forEach(container => {
+            container.querySelectorAll('template[data-godin-asset]').forEach(template => {
+                const id = template.getAttribute('data-godin-asset');
+                if (this.pageAssets.has(id)) {
+                    return;
+                }
+                this.pageAssets.add(id);
+
+                Array.from(template.content.childNodes).forEach(node => {
+                    if (node.nodeName === 'SCRIPT') {
+                        // Scripts run only when created, not when moved out of a template
+                        const script = document.createElement('script');
+                        Array.from(node.attributes).forEach(attr => script.setAttribute(attr.name, attr.value));
+                        script.textContent = node.textContent;
+                        document.head.appendChild(script);
+                    } else {
+                        document.head.appendChild(node.cloneNode(true));
+                    }
+                });
+            });
+            container.remove();
+        });
+    }
+
+    onHTMXBeforeRequest(event) {
+        // Add loading indicators
+        const target = event.target;
+        target.classList.add('godin-loading');
+    }
+    
+    onHTMXAfterRequest(event) {
+        // Remove loading indicators
+        const target = event.target;
+        target.classList.remove('godin-loading');
+
+        // Without WebSocket pushes, refetch a stateful widget after its callbacks run SetState
+        const stateful = target.closest && target.closest('[data-stateful-widget]');
+        const pushed = this.websocket && this.websocket.readyState === WebSocket.OPEN;
+        if (stateful && stateful !== target && !pushed && event.detail.successful) {
+            htmx.trigger(stateful, 'godin:rebuild');
+        }
+    }
+    
+    onHTMXAfterSwap(event) {
+        // Re-initialize any new components
+        this.initializeComponents(event.target);
+
+        // Apply the URL's tab selection to swapped-in tab controllers
+        this.restoreTabsFromURL(event.target);
+
+        // Set up swapped-in page views
+        this.initPageViews();
+
+        // Start autosaving swapped-in forms
+        this.initAutoSaveForms();
+
+        // Set swapped-in sticky headers to the current scroll position
+        this.updateStickyHeaders();
+    }
+    
+    // UI Event Listeners
+    setupUIListeners() {
+        // Report pointer enter/exit for MouseRegion widgets (mouseenter only fires in the capture phase here)
+        document.addEventListener('mouseenter', (event) => {
+            if (event.target.matches && event.target.matches('.godin-mouse-region')) {
+                this.notifyHover(event.target, true);
+            }
+        }, true);
+        document.addEventListener('mouseleave', (event) => {
+            if (event.target.matches && event.target.matches('.godin-mouse-region')) {
+                this.notifyHover(event.target, false);
+            }
+        }, true);
+
+        // Copy text to the clipboard for CopyButton widgets
+        document.addEventListener('click', (event) => {
+            const button = event.target.closest && event.target.closest('.godin-copy-button');
+            if (button) {
+                this.copyToClipboard(button);
+            }
+        });
+
+        // Handle drawer toggles
+        document.addEventListener('click', (event) => {
+            if (event.target.matches('[data-godin-drawer-toggle]')) {
+                const drawerId = event.target.getAttribute('data-godin-drawer-toggle');
+                this.toggleDrawer(drawerId);
+            }
+        });
+        
+        // Tappable containers activate on Space like buttons, without scrolling the page
+        document.addEventListener('keydown', (event) => {
+            if (event.key === ' ' && event.target.matches && event.target.matches('[data-tap-container]')) {
+                event.preventDefault();
+            }
+        });
+
+        // NavigationRail: expand/collapse toggles, and moving the selection before its handler answers
+        document.addEventListener('click', (event) => {
+            const toggle = event.target.closest && event.target.closest('[data-nav-rail-toggle]');
+            if (toggle) {
+                const rail = toggle.closest('[data-nav-rail]');
+                const extended = rail.classList.toggle('godin-nav-rail-extended');
+                toggle.setAttribute('aria-expanded', String(extended));
+                toggle.setAttribute('aria-label', extended ? 'Collapse navigation' : 'Expand navigation');
+                return;
+            }
+            const destination = event.target.closest && event.target.closest('.godin-nav-rail-destination');
+            if (destination) {
+                const rail = destination.closest('[data-nav-rail]');
+                rail.querySelectorAll('.godin-nav-rail-destination').forEach(item => {
+                    const selected = item === destination;
+                    item.classList.toggle('godin-nav-rail-selected', selected);
+                    if (selected) {
+                        item.setAttribute('aria-current', 'page');
+                    } else {
+                        item.removeAttribute('aria-current');
+                    }
+                });
+            }
+        });
+
+        // Handle dialog close
+        document.addEventListener('click', (event) => {
+            if (event.target.matches('.godin-dialog-overlay')) {
+                this.closeDialog(event.target.nextElementSibling);
+            }
+        });
+
+        // Apply InputFormatters before other input handlers (HTMX, callbacks) see the value
+        document.addEventListener('input', (event) => {
+            if (event.target.matches && event.target.matches('[data-input-formatters]')) {
+                this.applyInputFormatters(event.target);
+            }
+        }, true);
+
+        // Save the values of fields with a RestorationID as the user types, and
+        // before navigating away, so they are restored when the user comes back
+        document.addEventListener('input', (event) => {
+            if (event.target.matches && event.target.matches('[data-restoration-url]')) {
+                this.scheduleRestorationSave(event.target);
+            }
+        });
+        document.addEventListener('change', (event) => {
+            if (event.target.matches && event.target.matches('[data-restoration-url]')) {
+                this.saveRestorationValue(event.target);
+            }
+        });
+        document.addEventListener('htmx:beforeRequest', () => this.flushRestorationValues());
+        window.addEventListener('pagehide', () => this.flushRestorationValues());
+
+        // Submit text fields with OnSubmitted on Enter (Ctrl/Cmd+Enter in multiline fields)
+        document.addEventListener('keydown', (event) => {
+            const field = event.target.closest && event.target.closest('[data-submit-url]');
+            if (!field || event.key !== 'Enter' || event.isComposing) {
+                return;
+            }
+            if (field.tagName === 'TEXTAREA' && !event.ctrlKey && !event.metaKey) {
+                return;
+            }
+            event.preventDefault();
+            this.submitField(field);
+        });
+
+        // Autocomplete keyboard navigation and selection
+        document.addEventListener('keydown', (event) => {
+            if (event.target.matches('.godin-autocomplete-input')) {
+                this.handleAutocompleteKey(event);
+            }
+        });
+        document.addEventListener('click', (event) => {
+            const option = event.target.closest('.godin-autocomplete-option');
+            if (option) {
+                this.selectAutocompleteOption(option);
+            }
+        });
+        document.addEventListener('focusout', (event) => {
+            if (event.target.matches('.godin-autocomplete-input')) {
+                const listbox = document.getElementById(event.target.getAttribute('aria-controls'));
+                if (listbox) {
+                    this.setAutocompleteOpen(listbox, false);
+                }
+            }
+        });
+
+        // ConnectionStatus: retry once reconnecting gave up, and follow the browser's network state
+        document.addEventListener('click', (event) => {
+            if (event.target.closest && event.target.closest('[data-connection-retry]')) {
+                this.retryWebSocket();
+            }
+        });
+        window.addEventListener('offline', () => {
+            this.setConnectionState('offline');
+        });
+        window.addEventListener('online', () => {
+            if (!this.websocket || this.websocket.readyState !== WebSocket.OPEN) {
+                this.retryWebSocket();
+            } else {
+                this.setConnectionState('connected');
+            }
+        });
+
+        // DropdownButton menus: open from the button, pick with a click or the keyboard, close when clicking elsewhere
+        document.addEventListener('click', (event) => {
+            const trigger = event.target.closest && event.target.closest('.godin-dropdown-button-trigger');
+            const item = event.target.closest && event.target.closest('.godin-dropdown-button-item');
+            if (item) {
+                this.selectDropdownButtonItem(item);
+            } else if (trigger) {
+                const dropdown = trigger.closest('[data-dropdown-button]');
+                this.setDropdownButtonOpen(dropdown, trigger.getAttribute('aria-expanded') !== 'true');
+            }
+            document.querySelectorAll('[data-dropdown-button]').forEach(dropdown => {
+                if (!dropdown.contains(event.target)) {
+                    this.setDropdownButtonOpen(dropdown, false);
+                }
+            });
+        });
+        document.addEventListener('keydown', (event) => {
+            if (event.target.closest && event.target.closest('[data-dropdown-button]')) {
+                this.handleDropdownButtonKey(event);
+            }
+        });
+
+        // Dismiss the top overlay entry via the shared scrim or Escape
+        document.addEventListener('click', (event) => {
+            if (event.target.matches('.godin-overlay-scrim')) {
+                this.dismissTopOverlay();
+            }
+        });
+        document.addEventListener('keydown', (event) => {
+            if (event.key === 'Escape') {
+                this.dismissTopOverlay();
+            }
+        });
+        
+        // Handle tab switching
+        document.addEventListener('click', (event) => {
+            if (event.target.matches('.godin-tab')) {
+                this.switchTab(event.target);
+            }
+        });
+
+        // DefaultTabController: select tabs on click and keep them in sync with the URL hash
+        document.addEventListener('click', (event) => {
+            const tab = event.target.closest('[data-tab-controller] .godin-tab-item');
+            if (tab) {
+                const controller = tab.closest('[data-tab-controller]');
+                this.selectTab(controller, parseInt(tab.getAttribute('data-tab-index'), 10), true);
+            }
+        });
+        window.addEventListener('popstate', () => this.restoreTabsFromURL());
+        window.addEventListener('hashchange', () => this.restoreTabsFromURL());
+        this.restoreTabsFromURL();
+
+        // PageView: dot indicators, next/previous buttons, arrow keys and PageController moves
+        document.addEventListener('click', (event) => {
+            const dot = event.target.closest('.godin-page-view-dot');
+            if (dot) {
+                this.showPage(dot.closest('[data-page-view]'), parseInt(dot.getAttribute('data-page-index'), 10), true);
+                return;
+            }
+
+            // Any element can drive a PageView, e.g. <button data-page-view-action="next" data-page-view-target="intro">
+            const action = event.target.closest('[data-page-view-action]');
+            if (action) {
+                const targetID = action.getAttribute('data-page-view-target');
+                const view = targetID ? document.getElementById(targetID) : action.closest('[data-page-view]');
+                this.movePage(view, action.getAttribute('data-page-view-action') === 'previous' ? -1 : 1);
+            }
+        });
+        document.addEventListener('keydown', (event) => {
+            if (!event.target.matches('.godin-page-view-track')) {
+                return;
+            }
+            const view = event.target.closest('[data-page-view]');
+            const horizontal = view.getAttribute('data-page-axis') !== 'vertical';
+            const keys = horizontal ? ['ArrowLeft', 'ArrowRight'] : ['ArrowUp', 'ArrowDown'];
+            const index = keys.indexOf(event.key);
+            if (index !== -1) {
+                event.preventDefault();
+                const delta = index === 0 ? -1 : 1;
+                this.movePage(view, view.getAttribute('data-page-reverse') === 'true' ? -delta : delta);
+            }
+        });
+        document.addEventListener('godin:page', (event) => {
+            const detail = event.detail || {};
+            const view = document.getElementById(detail.id);
+            if (typeof detail.delta === 'number') {
+                this.movePage(view, detail.delta);
+            } else if (typeof detail.page === 'number') {
+                this.showPage(view, detail.page, detail.animate !== false);
+            }
+        });
+        this.initPageViews();
+        this.initAutoSaveForms();
+
+        // Track sticky headers in any scrolling container (scroll events only reach
+        // the document in the capture phase)
+        let stickyFrame = null;
+        document.addEventListener('scroll', () => {
+            if (stickyFrame === null) {
+                stickyFrame = requestAnimationFrame(() => {
+                    stickyFrame = null;
+                    this.updateStickyHeaders();
+                });
+            }
+        }, { capture: true, passive: true });
+        window.addEventListener('resize', this.debounce(() => this.updateStickyHeaders(), 100));
+        this.updateStickyHeaders();
+
+        // Pull-to-refresh for RefreshIndicator widgets, and refreshes started from handlers
+        this.setupRefreshIndicators();
+        document.addEventListener('godin:refresh', (event) => {
+            const detail = event.detail || {};
+            this.refresh(detail.id);
+        });
+    }
+    
+    // UI Component Methods
+    notifyHover(region, entered) {
+        const state = region.godinHover || (region.godinHover = { inFlight: false, sent: false, wanted: false });
+        state.wanted = entered;
+        if (!state.inFlight) {
+            this.flushHover(region, state);
+        }
+    }
+
+    flushHover(region, state) {
+        // An enter and exit that happened while a request was in flight cancel out
+        if (state.wanted === state.sent) {
+            return;
+        }
+
+        const entered = state.wanted;
+        state.sent = entered;
+
+        const endpoint = region.getAttribute(entered ? 'data-on-enter' : 'data-on-exit');
+        if (!endpoint) {
+            return;
+        }
+
+        state.inFlight = true;
+        fetch(endpoint, {
+            method: 'POST',
+            headers: { 'X-CSRF-Token': this.getCSRFToken() },
+            keepalive: true
+        })
+            .catch(error => console.error('MouseRegion callback failed:', error))
+            .finally(() => {
+                state.inFlight = false;
+                this.flushHover(region, state);
+            });
+    }
+
+    copyToClipboard(button) {
+        const text = button.getAttribute('data-copy-text') || '';
+
+        // The async clipboard API needs a secure context and may be denied; fall back to a selection copy
+        const copy = navigator.clipboard && window.isSecureContext
+            ? navigator.clipboard.writeText(text).catch(() => this.copyWithSelection(text))
+            : this.copyWithSelection(text);
+
+        copy.then(() => this.onCopied(button))
+            .catch(error => {
+                console.error('Copy to clipboard failed:', error);
+                this.showSnackbar('Could not copy to the clipboard', 'error');
+            });
+    }
+
+    copyWithSelection(text) {
+        return new Promise((resolve, reject) => {
+            const textarea = document.createElement('textarea');
+            textarea.value = text;
+            textarea.setAttribute('readonly', '');
+            textarea.style.position = 'fixed';
+            textarea.style.opacity = '0';
+            document.body.appendChild(textarea);
+            textarea.select();
+
+            let copied = false;
+            try {
+                copied = document.execCommand('copy');
+            } catch (error) {
+                copied = false;
+            }
+            document.body.removeChild(textarea);
+
+            if (copied) {
+                resolve();
+            } else {
+                reject(new Error('copy command was rejected'));
+            }
+        });
+    }
+
+    onCopied(button) {
+        button.classList.add('godin-copied');
+        setTimeout(() => button.classList.remove('godin-copied'), 2000);
+
+        const message = button.getAttribute('data-copied-message');
+        if (message) {
+            this.showSnackbar(message, 'success');
+        }
+
+        const endpoint = button.getAttribute('data-on-copied');
+        if (endpoint) {
+            fetch(endpoint, {
+                method: 'POST',
+                headers: { 'X-CSRF-Token': this.getCSRFToken() }
+            }).catch(error => console.error('CopyButton callback failed:', error));
+        }
+    }
+
+    toggleDrawer(drawerId) {
+        const drawer = document.getElementById(drawerId);
+        if (drawer) {
+            drawer.classList.toggle('open');
+        }
+    }
+    
+    openDialog(dialogId) {
+        const dialog = document.getElementById(dialogId);
+        if (dialog) {
+            dialog.style.display = 'block';
+        }
+    }
+    
+    closeDialog(dialog) {
+        if (dialog) {
+            dialog.style.display = 'none';
+        }
+    }
+    
+    switchTab(tabElement) {
+        const tabBar = tabElement.closest('.godin-tab-bar');
+        const tabs = tabBar.querySelectorAll('.godin-tab');
+        
+        // Remove active class from all tabs
+        tabs.forEach(tab => tab.classList.remove('active'));
+        
+        // Add active class to clicked tab
+        tabElement.classList.add('active');
+        
+        // Trigger custom event
+        const event = new CustomEvent('godin:tabSwitch', {
+            detail: {
+                tab: tabElement,
+                index: Array.from(tabs).indexOf(tabElement)
+            }
+        });
+        document.dispatchEvent(event);
+    }
+    
+    // Text field submission
+    submitField(field) {
+        const selector = field.getAttribute('data-submit-target');
+        const target = (selector && document.querySelector(selector)) || field;
+
+        htmx.ajax('POST', field.getAttribute('data-submit-url'), {
+            source: field,
+            target: target,
+            swap: 'outerHTML',
+            values: { value: field.value }
+        }).then(() => {
+            if (field.getAttribute('data-clear-on-submit') === 'true' && field.isConnected) {
+                field.value = '';
+            }
+        });
+    }
+
+    // Input Formatters
+    applyInputFormatters(input) {
+        let formatters;
+        try {
+            formatters = JSON.parse(input.getAttribute('data-input-formatters'));
+        } catch (error) {
+            console.error('Invalid input formatters:', error);
+            return;
+        }
+
+        const original = input.value;
+        const formatted = formatters.reduce((text, formatter) => this.formatInput(formatter, text), original);
+        if (formatted === original) {
+            return;
+        }
+
+        // Keep the cursor after the same number of significant characters
+        const cursor = input.selectionStart !== null ? input.selectionStart : original.length;
+        const significant = (text) => text.replace(/[^0-9A-Za-z]/g, '').length;
+        const before = significant(original.slice(0, cursor));
+
+        input.value = formatted;
+
+        let position = formatted.length;
+        if (cursor < original.length) {
+            let count = 0;
+            for (position = 0; position < formatted.length && count < before; position++) {
+                if (/[0-9A-Za-z]/.test(formatted[position])) {
+                    count++;
+                }
+            }
+        }
+        try {
+            input.setSelectionRange(position, position);
+        } catch (error) {
+            // Some input types (e.g. number, email) do not support selection
+        }
+    }
+
+    formatInput(formatter, text) {
+        switch (formatter.type) {
+            case 'digits':
+                return text.replace(/\D/g, '');
+            case 'length':
+                return formatter.maxLength > 0 ? Array.from(text).slice(0, formatter.maxLength).join('') : text;
+            case 'mask': {
+                const digits = text.replace(/\D/g, '');
+                let result = '';
+                let index = 0;
+                for (const slot of formatter.mask || '') {
+                    if (index >= digits.length) break;
+                    if (slot === '#') {
+                        result += digits[index++];
+                    } else {
+                        result += slot;
+                    }
+                }
+                return formatter.mask ? result : digits;
+            }
+            default:
+                return text;
+        }
+    }
+
+    initAutoSaveForms(container = document) {
+        container.querySelectorAll('form[data-autosave-url]').forEach(form => {
+            if (form.godinAutoSave) {
+                return;
+            }
+            const state = form.godinAutoSave = { dirty: false };
+
+            // Restore the draft saved on an earlier visit
+            const draft = form.getAttribute('data-autosave-draft');
+            if (draft) {
+                try {
+                    this.restoreDraft(form, JSON.parse(draft));
+                } catch (error) {
+                    console.error('Invalid form draft:', error);
+                }
+            }
+
+            form.addEventListener('input', () => { state.dirty = true; });
+            form.addEventListener('change', () => { state.dirty = true; });
+            form.addEventListener('submit', () => { state.dirty = false; });
+
+            const interval = parseInt(form.getAttribute('data-autosave-interval'), 10) || 3000;
+            state.timer = setInterval(() => {
+                if (!form.isConnected) {
+                    clearInterval(state.timer);
+                    return;
+                }
+                this.saveDraft(form);
+            }, interval);
+
+            // Save the last changes when the user leaves the page
+            window.addEventListener('pagehide', () => {
+                if (form.isConnected) {
+                    this.saveDraft(form);
+                }
+            });
+        });
+    }
+
+    saveDraft(form) {
+        const state = form.godinAutoSave;
+        if (!state.dirty) {
+            return;
+        }
+        state.dirty = false;
+
+        // Passwords, payment details and hidden fields such as CSRF tokens are never saved
+        const skipped = new Set();
+        Array.from(form.elements).forEach(element => {
+            if (element.name && !this.isDraftable(element)) {
+                skipped.add(element.name);
+            }
+        });
+
+        const body = new URLSearchParams();
+        new FormData(form).forEach((value, name) => {
+            if (typeof value === 'string' && !skipped.has(name)) {
+                body.append(name, value);
+            }
+        });
+
+        fetch(form.getAttribute('data-autosave-url'), {
+            method: 'POST',
+            headers: { 'X-CSRF-Token': this.getCSRFToken() },
+            body: body,
+            keepalive: true
+        }).catch(error => {
+            state.dirty = true;
+            console.error('Error saving draft:', error);
+        });
+    }
+
+    isDraftable(element) {
+        if (['password', 'hidden', 'file'].includes(element.type)) {
+            return false;
+        }
+        const autocomplete = (element.getAttribute('autocomplete') || '').toLowerCase().split(/\s+/);
+        return !autocomplete.some(token =>
+            token === 'off' || token.startsWith('cc-') ||
+            token === 'new-password' || token === 'current-password' || token === 'one-time-code');
+    }
+
+    scheduleRestorationSave(field) {
+        clearTimeout(this.restorationTimers.get(field));
+        this.restorationTimers.set(field, setTimeout(() => this.saveRestorationValue(field), 500));
+    }
+
+    flushRestorationValues() {
+        Array.from(this.restorationTimers.keys()).forEach(field => this.saveRestorationValue(field));
+    }
+
+    saveRestorationValue(field) {
+        clearTimeout(this.restorationTimers.get(field));
+        this.restorationTimers.delete(field);
+
+        // Keep the value in the markup too, so HTMX history snapshots hold it
+        field.defaultValue = field.value;
+
+        fetch(field.getAttribute('data-restoration-url'), {
+            method: 'POST',
+            headers: { 'X-CSRF-Token': this.getCSRFToken() },
+            body: new URLSearchParams({ value: field.value }),
+            keepalive: true
+        }).catch(error => console.error('Error saving restoration value:', error));
+    }
+
+    restoreDraft(form, draft) {
+        // Fields sharing a name take the draft's values in order
+        const used = {};
+        Array.from(form.elements).forEach(element => {
+            if (!element.name || !this.isDraftable(element)) {
+                return;
+            }
+            const values = draft[element.name] || [];
+            if (element.type === 'checkbox' || element.type === 'radio') {
+                element.checked = values.includes(element.value);
+            } else if (element.multiple) {
+                Array.from(element.options).forEach(option => {
+                    option.selected = values.includes(option.value);
+                });
+            } else if (element.name in draft) {
+                const index = used[element.name] || 0;
+                if (index < values.length) {
+                    element.value = values[index];
+                }
+                used[element.name] = index + 1;
+            }
+        });
+    }
+
+    // updateStickyHeaders marks pinned headers as stuck once content scrolls under
+    // them, and shrinks those with data-shrink-on-scroll past their offset
+    updateStickyHeaders() {
+        document.querySelectorAll('[data-sticky-header]').forEach(header => {
+            const bar = header.querySelector(':scope > .godin-sticky-header-bar');
+            if (!bar) {
+                return;
+            }
+
+            // The bar stays put while its container scrolls up, so the gap between
+            // them is how far the content has scrolled under the header
+            const scrolled = bar.getBoundingClientRect().top - header.getBoundingClientRect().top;
+            header.classList.toggle('godin-sticky-header-stuck', scrolled > 0);
+
+            if (header.getAttribute('data-shrink-on-scroll') === 'true') {
+                const offset = parseFloat(header.getAttribute('data-shrink-offset')) || 0;
+                header.classList.toggle('godin-sticky-header-shrunk', scrolled > offset);
+            }
+        });
+    }
+
+    // setupRefreshIndicators follows pull-down gestures that start on
+    // RefreshIndicator content scrolled to the top, refreshing on release past
+    // the spinner's displacement
+    setupRefreshIndicators() {
+        let pull = null;
+        document.addEventListener('touchstart', (event) => {
+            const indicator = event.target.closest && event.target.closest('[data-refresh-indicator]');
+            if (!indicator || indicator.godinRefreshing || event.touches.length !== 1 || !this.atScrollTop(event.target)) {
+                return;
+            }
+            pull = { indicator: indicator, startY: event.touches[0].clientY, distance: 0 };
+        }, { passive: true });
+        document.addEventListener('touchmove', (event) => {
+            if (!pull) {
+                return;
+            }
+            pull.distance = Math.max(event.touches[0].clientY - pull.startY, 0);
+            if (pull.distance > 0 && event.cancelable) {
+                // Pulling moves the spinner instead of overscrolling the page
+                event.preventDefault();
+            }
+            this.setRefreshPull(pull.indicator, pull.distance);
+        }, { passive: false });
+        const release = () => {
+            if (!pull) {
+                return;
+            }
+            const indicator = pull.indicator;
+            const armed = indicator.classList.contains('godin-refresh-indicator-armed');
+            pull = null;
+            if (armed) {
+                this.refresh(indicator);
+            } else {
+                this.setRefreshPull(indicator, 0);
+            }
+        };
+        document.addEventListener('touchend', release);
+        document.addEventListener('touchcancel', release);
+    }
+
+    // atScrollTop reports whether nothing around element is scrolled down, so a
+    // downward pull would overscroll rather than scroll
+    atScrollTop(element) {
+        for (let node = element; node && node !== document; node = node.parentNode) {
+            if (node.scrollTop > 0) {
+                return false;
+            }
+        }
+        return window.scrollY <= 0;
+    }
+
+    // setRefreshPull moves a RefreshIndicator's spinner for a pull of distance
+    // pixels, with resistance, arming it once it reaches the displacement
+    setRefreshPull(indicator, distance) {
+        const displacement = parseFloat(indicator.getAttribute('data-refresh-displacement')) || 40;
+        const offset = Math.min(distance * 0.5, displacement * 2);
+        indicator.style.setProperty('--godin-refresh-offset', offset + 'px');
+        indicator.style.setProperty('--godin-refresh-progress', Math.min(offset / displacement, 1));
+        indicator.classList.toggle('godin-refresh-indicator-pulling', offset > 0);
+        indicator.classList.toggle('godin-refresh-indicator-armed', offset >= displacement);
+    }
+
+    // refresh shows a RefreshIndicator's spinner while its OnRefresh runs and
+    // swaps in the content it returns; it takes the element or its ID
+    refresh(indicator) {
+        if (typeof indicator === 'string') {
+            indicator = document.getElementById(indicator);
+        }
+        if (!indicator || indicator.godinRefreshing) {
+            return Promise.resolve();
+        }
+
+        const url = indicator.getAttribute('data-refresh-url');
+        const content = indicator.querySelector(':scope > .godin-refresh-indicator-content');
+        const displacement = parseFloat(indicator.getAttribute('data-refresh-displacement')) || 40;
+
+        indicator.godinRefreshing = true;
+        indicator.classList.remove('godin-refresh-indicator-pulling', 'godin-refresh-indicator-armed');
+        indicator.classList.add('godin-refresh-indicator-refreshing');
+        indicator.setAttribute('aria-busy', 'true');
+        indicator.style.setProperty('--godin-refresh-offset', displacement + 'px');
+        indicator.style.setProperty('--godin-refresh-progress', 1);
+
+        const done = () => {
+            indicator.godinRefreshing = false;
+            indicator.classList.remove('godin-refresh-indicator-refreshing');
+            indicator.removeAttribute('aria-busy');
+            this.setRefreshPull(indicator, 0);
+            indicator.dispatchEvent(new CustomEvent('godin:refreshed', { bubbles: true }));
+        };
+        if (!url || !content) {
+            done();
+            return Promise.resolve();
+        }
+        return htmx.ajax('POST', url, { source: indicator, target: content, swap: 'innerHTML' }).then(done, done);
+    }
+
+    initPageViews(container = document) {
+        container.querySelectorAll('[data-page-view]').forEach(view => {
+            const track = view.querySelector(':scope > .godin-page-view-track');
+            if (view.godinPageView || !track) {
+                return;
+            }
+
+            const initial = parseInt(view.getAttribute('data-initial-page'), 10) || 0;
+            view.godinPageView = { track: track, page: initial };
+            this.showPage(view, initial, false);
+
+            // Report the page once scrolling has settled on it
+            track.addEventListener('scroll', this.debounce(() => this.onPageScroll(view), 100));
+            this.setupPageDrag(view, track);
+        });
+    }
+
+    pageElements(view) {
+        return Array.from(view.godinPageView.track.children);
+    }
+
+    // currentPage finds the page aligned with the track, whatever the direction
+    currentPage(view) {
+        const state = view.godinPageView;
+        const horizontal = view.getAttribute('data-page-axis') !== 'vertical';
+        const trackRect = state.track.getBoundingClientRect();
+        let closest = state.page;
+        let distance = Infinity;
+        this.pageElements(view).forEach((page, i) => {
+            const offset = this.pageOffset(view, trackRect, page.getBoundingClientRect(), horizontal);
+            if (Math.abs(offset) < distance) {
+                distance = Math.abs(offset);
+                closest = i;
+            }
+        });
+        return closest;
+    }
+
+    pageOffset(view, trackRect, pageRect, horizontal) {
+        if (view.getAttribute('data-page-align') === 'center') {
+            return horizontal
+                ? (pageRect.left + pageRect.width / 2) - (trackRect.left + trackRect.width / 2)
+                : (pageRect.top + pageRect.height / 2) - (trackRect.top + trackRect.height / 2);
+        }
+        return horizontal ? pageRect.left - trackRect.left : pageRect.top - trackRect.top;
+    }
+
+    showPage(view, index, animate) {
+        if (!view || !view.godinPageView || isNaN(index)) {
+            return;
+        }
+        const pages = this.pageElements(view);
+        const page = pages[Math.max(0, Math.min(index, pages.length - 1))];
+        if (!page) {
+            return;
+        }
+
+        const track = view.godinPageView.track;
+        const horizontal = view.getAttribute('data-page-axis') !== 'vertical';
+        const offset = this.pageOffset(view, track.getBoundingClientRect(), page.getBoundingClientRect(), horizontal);
+        track.scrollBy({
+            left: horizontal ? offset : 0,
+            top: horizontal ? 0 : offset,
+            behavior: animate ? 'smooth' : 'instant'
+        });
+    }
+
+    movePage(view, delta) {
+        if (!view || !view.godinPageView) {
+            return;
+        }
+        const count = this.pageElements(view).length;
+        let index = view.godinPageView.page + delta;
+        if (view.getAttribute('data-page-loop') === 'true') {
+            index = ((index % count) + count) % count;
+        }
+        this.showPage(view, index, true);
+    }
+
+    onPageScroll(view) {
+        const state = view.godinPageView;
+        const page = this.currentPage(view);
+        if (page === state.page) {
+            return;
+        }
+        state.page = page;
+
+        view.querySelectorAll(':scope > .godin-page-view-dots > .godin-page-view-dot').forEach((dot, i) => {
+            dot.classList.toggle('active', i === page);
+            if (i === page) {
+                dot.setAttribute('aria-current', 'true');
+            } else {
+                dot.removeAttribute('aria-current');
+            }
+        });
+
+        const url = view.getAttribute('data-page-changed-url');
+        if (url) {
+            htmx.ajax('POST', url, { source: view, swap: 'none', values: { page: page } });
+        }
+    }
+
+    // setupPageDrag lets mice drag pages like a swipe; touch and pens scroll natively
+    setupPageDrag(view, track) {
+        if (view.getAttribute('data-page-draggable') === 'false') {
+            return;
+        }
+
+        const horizontal = view.getAttribute('data-page-axis') !== 'vertical';
+        let drag = null;
+        let suppressClick = false;
+
+        track.addEventListener('pointerdown', (event) => {
+            if (event.pointerType !== 'mouse' || event.button !== 0) {
+                return;
+            }
+            drag = { last: horizontal ? event.clientX : event.clientY, moved: 0, page: view.godinPageView.page };
+        });
+        track.addEventListener('pointermove', (event) => {
+            if (!drag) {
+                return;
+            }
+            const position = horizontal ? event.clientX : event.clientY;
+            const step = position - drag.last;
+            drag.last = position;
+            drag.moved += step;
+
+            // Free-scroll while dragging, snapping again on release
+            if (Math.abs(drag.moved) > 5 && !track.hasPointerCapture(event.pointerId)) {
+                track.setPointerCapture(event.pointerId);
+                track.style.scrollSnapType = 'none';
+            }
+            if (track.hasPointerCapture(event.pointerId)) {
+                track.scrollBy(horizontal ? -step : 0, horizontal ? 0 : -step);
+            }
+        });
+        const release = () => {
+            if (!drag) {
+                return;
+            }
+            const moved = drag.moved;
+            const page = drag.page;
+            drag = null;
+            if (Math.abs(moved) <= 5) {
+                return;
+            }
+
+            track.style.scrollSnapType = '';
+            suppressClick = true;
+            view.godinPageView.page = page;
+            if (Math.abs(moved) > 50) {
+                const delta = moved < 0 ? 1 : -1;
+                this.movePage(view, view.getAttribute('data-page-reverse') === 'true' ? -delta : delta);
+            } else {
+                this.showPage(view, page, true);
+            }
+        };
+        track.addEventListener('pointerup', release);
+        track.addEventListener('pointercancel', release);
+
+        // A drag should not also click the link or button it started on
+        track.addEventListener('click', (event) => {
+            if (suppressClick) {
+                suppressClick = false;
+                event.preventDefault();
+                event.stopPropagation();
+            }
+        }, true);
+    }
+
+    restoreTabsFromURL(container = document) {
+        container.querySelectorAll('[data-tab-controller]').forEach(controller => {
+            const index = this.tabIndexFromURL(controller);
+            const initial = parseInt(controller.getAttribute('data-initial-index'), 10) || 0;
+            this.selectTab(controller, index !== null ? index : initial, false);
+        });
+    }
+
+    tabIndexFromURL(controller) {
+        const params = new URLSearchParams(window.location.hash.slice(1));
+        const value = params.get(controller.getAttribute('data-tab-hash-key'));
+        if (value === null) {
+            return null;
+        }
+
+        const index = parseInt(value, 10);
+        const length = parseInt(controller.getAttribute('data-tab-length'), 10);
+        if (isNaN(index) || index < 0 || (length > 0 && index >= length)) {
+            return null;
+        }
+        return index;
+    }
+
+    selectTab(controller, index, updateURL) {
+        if (!controller || isNaN(index)) {
+            return;
+        }
+
+        // Only touch tab bars and views owned by this controller, not nested ones
+        const owned = (element) => element.closest('[data-tab-controller]') === controller;
+
+        controller.querySelectorAll('.godin-tab-bar').forEach(bar => {
+            if (!owned(bar)) return;
+            const items = bar.querySelectorAll(':scope > .godin-tab-item');
+            items.forEach((item, i) => {
+                item.classList.toggle('active', i === index);
+                item.setAttribute('aria-selected', String(i === index));
+            });
+            const indicator = bar.querySelector(':scope > .godin-tab-indicator');
+            if (indicator && items.length > 0) {
+                indicator.style.left = (100 * index / items.length) + '%';
+            }
+        });
+
+        controller.querySelectorAll('.godin-tab-bar-view').forEach(view => {
+            if (!owned(view)) return;
+            view.querySelectorAll(':scope > .godin-tab-panel').forEach((panel, i) => {
+                panel.style.transform = i === index ? 'translateX(0)' : (i < index ? 'translateX(-100%)' : 'translateX(100%)');
+                panel.style.opacity = i === index ? '1' : '0';
+                panel.setAttribute('aria-hidden', String(i !== index));
+            });
+        });
+
+        const changed = controller.getAttribute('data-selected-index') !== String(index);
+        controller.setAttribute('data-selected-index', String(index));
+
+        // Push a history entry so the back button returns to the previous tab
+        if (updateURL && this.tabIndexFromURL(controller) !== index) {
+            const params = new URLSearchParams(window.location.hash.slice(1));
+            params.set(controller.getAttribute('data-tab-hash-key'), String(index));
+            history.pushState(null, '', '#' + params.toString());
+        }
+
+        if (changed) {
+            controller.dispatchEvent(new CustomEvent('godin:tabSwitch', {
+                bubbles: true,
+                detail: { controller: controller, index: index }
+            }));
+        }
+    }
+
+    showConfirmDialog(message, onConfirm) {
+        const overlay = document.createElement('div');
+        overlay.className = 'godin-dialog-overlay godin-confirm-overlay';
+
+        const dialog = document.createElement('div');
+        dialog.className = 'godin-dialog godin-confirm-dialog';
+        dialog.setAttribute('role', 'alertdialog');
+        dialog.setAttribute('aria-modal', 'true');
+
+        const content = document.createElement('p');
+        content.className = 'godin-confirm-dialog-content';
+        content.textContent = message;
+
+        const actions = document.createElement('div');
+        actions.className = 'godin-confirm-dialog-actions';
+
+        const cancel = document.createElement('button');
+        cancel.type = 'button';
+        cancel.className = 'godin-confirm-dialog-cancel';
+        cancel.textContent = 'Cancel';
+
+        const confirm = document.createElement('button');
+        confirm.type = 'button';
+        confirm.className = 'godin-confirm-dialog-confirm';
+        confirm.textContent = 'OK';
+
+        const close = () => {
+            overlay.remove();
+            dialog.remove();
+            document.removeEventListener('keydown', onKey);
+        };
+        const onKey = (event) => {
+            if (event.key === 'Escape') {
+                close();
+            }
+        };
+
+        cancel.addEventListener('click', close);
+        overlay.addEventListener('click', close);
+        confirm.addEventListener('click', () => {
+            close();
+            onConfirm();
+        });
+        document.addEventListener('keydown', onKey);
+
+        actions.append(cancel, confirm);
+        dialog.append(content, actions);
+        document.body.append(overlay, dialog);
+        confirm.focus();
+    }
+
+    showFlashes() {
+        const data = document.getElementById('godin-flashes');
+        if (!data) {
+            return;
+        }
+
+        let messages = [];
+        try {
+            messages = JSON.parse(data.textContent) || [];
+        } catch (error) {
+            console.error('Invalid flash messages:', error);
+        }
+        data.remove();
+
+        // Prefer a banner in the page's flash container, falling back to snackbars
+        const container = document.querySelector('[data-flash-container]');
+        messages.forEach(flash => {
+            if (container) {
+                const banner = document.createElement('div');
+                banner.className = `godin-flash godin-flash-${flash.category}`;
+                banner.setAttribute('role', 'status');
+                banner.textContent = flash.message;
+                container.appendChild(banner);
+            } else {
+                this.showSnackbar(flash.message, flash.category, 5000);
+            }
+        });
+    }
+
+    applyFocusRequests(root) {
+        const data = document.getElementById('godin-focus');
+        if (data) {
+            try {
+                this.focusElement(JSON.parse(data.textContent));
+            } catch (error) {
+                console.error('Invalid focus request:', error);
+            }
+            data.remove();
+        }
+
+        const scope = root && root.querySelectorAll ? root : document;
+        const marked = [];
+        if (scope.matches && scope.matches('[data-request-focus]')) {
+            marked.push(scope);
+        }
+        marked.push(...scope.querySelectorAll('[data-request-focus]'));
+        marked.forEach(element => {
+            element.removeAttribute('data-request-focus');
+            element.focus();
+        });
+    }
+
+    focusElement(id) {
+        const element = document.getElementById(id);
+        if (!element) {
+            return;
+        }
+
+        // Focus the field inside wrappers such as TextField containers
+        const focusable = element.matches('input, textarea, select, button, a[href], [tabindex]')
+            ? element
+            : element.querySelector('input, textarea, select, button, a[href], [tabindex]') || element;
+        focusable.focus();
+    }
+
+    initDebugBanner() {
+        const banner = document.getElementById('godin-debug-banner');
+        if (!banner) {
+            return;
+        }
+
+        // The page reloads on every hot reload, so its load time is the last reload time
+        const reloaded = banner.querySelector('[data-debug-reloaded]');
+        reloaded.textContent = 'reloaded ' + new Date().toLocaleTimeString();
+
+        const navigation = performance.getEntriesByType ? performance.getEntriesByType('navigation')[0] : null;
+        const timing = navigation && navigation.serverTiming
+            ? navigation.serverTiming.find(entry => entry.name === 'total')
+            : null;
+        if (timing) {
+            this.showRenderTime(banner, timing.duration, 'page');
+        }
+
+        // HTMX responses carry their own Server-Timing header
+        document.addEventListener('htmx:afterRequest', (event) => {
+            const header = event.detail.xhr ? event.detail.xhr.getResponseHeader('Server-Timing') : null;
+            const match = header ? /total;[^,]*dur=([\d.]+)/.exec(header) : null;
+            if (match) {
+                this.showRenderTime(banner, parseFloat(match[1]), event.detail.pathInfo ? event.detail.pathInfo.requestPath : '');
+            }
+        });
+    }
+
+    initColorScheme() {
+        const root = document.documentElement;
+        if (root.dataset.themeMode !== 'system' || !window.matchMedia) {
+            return;
+        }
+
+        // The theme CSS switches on its own; keep the cookie current so the server
+        // renders the matching theme next time, and tell the page about changes
+        const query = window.matchMedia('(prefers-color-scheme: dark)');
+        const apply = () => {
+            const scheme = query.matches ? 'dark' : 'light';
+            document.cookie = `godin_color_scheme=${scheme}; path=/; max-age=31536000; samesite=lax`;
+            if (root.dataset.theme !== scheme) {
+                root.dataset.theme = scheme;
+                document.dispatchEvent(new CustomEvent('godin:color-scheme', { detail: { scheme: scheme } }));
+            }
+        };
+        apply();
+        query.addEventListener('change', apply);
+    }
+
+    showRenderTime(banner, duration, path) {
+        const render = banner.querySelector('[data-debug-render]');
+        render.textContent = `render ${duration.toFixed(1)}ms`;
+        render.title = path;
+
+        // Flag renders slow enough to notice
+        banner.classList.toggle('godin-debug-banner-slow', duration >= 100);
+    }
+
+    showSnackbar(message, type = 'info', duration = 3000, priority = null) {
+        this.queueSnackbar({
+            message: message,
+            category: type,
+            duration: duration,
+            priority: priority === null ? this.snackbarPriority(type) : priority
+        });
+    }
+
+    // Mirrors SnackBarPriority: errors preempt warnings, which preempt everything else
+    snackbarPriority(category) {
+        if (category === 'error') {
+            return 2;
+        }
+        return category === 'warning' ? 1 : 0;
+    }
+
+    queueSnackbar(snackbar) {
+        // Show a message identical to the one before it only once
+        const last = this.lastSnackbar;
+        const lastPending = last && (this.snackbarQueue.includes(last) ||
+            this.visibleSnackbars.some(visible => visible.snackbar === last));
+        if (lastPending && last.message === snackbar.message && last.category === snackbar.category) {
+            return;
+        }
+        this.lastSnackbar = snackbar;
+
+        // Order by priority, first in first out within the same priority
+        const index = this.snackbarQueue.findIndex(queued => queued.priority < snackbar.priority);
+        if (index === -1) {
+            this.snackbarQueue.push(snackbar);
+        } else {
+            this.snackbarQueue.splice(index, 0, snackbar);
+        }
+        this.showQueuedSnackbars();
+    }
+
+    showQueuedSnackbars() {
+        const maxVisible = parseInt(document.body.dataset.snackbarMaxVisible, 10) || 1;
+        while (this.snackbarQueue.length > 0) {
+            const next = this.snackbarQueue[0];
+            if (this.visibleSnackbars.length >= maxVisible) {
+                // A higher priority replaces the lowest visible snackbar instead of waiting
+                const lowest = this.visibleSnackbars.reduce((a, b) => b.snackbar.priority < a.snackbar.priority ? b : a);
+                if (lowest.snackbar.priority >= next.priority) {
+                    return;
+                }
+                this.hideSnackbar(lowest, false);
+            }
+            this.snackbarQueue.shift();
+            this.displaySnackbar(next);
+        }
+    }
+
+    displaySnackbar(snackbar) {
+        let stack = document.getElementById('godin-snackbar-stack');
+        if (!stack) {
+            stack = document.createElement('div');
+            stack.id = 'godin-snackbar-stack';
+            stack.className = 'godin-snackbar-stack';
+            document.body.appendChild(stack);
+        }
+
+        const element = document.createElement('div');
+        element.className = `godin-snackbar godin-snackbar-${snackbar.category}`;
+        element.setAttribute('role', snackbar.category === 'error' ? 'alert' : 'status');
+        element.textContent = snackbar.message;
+        stack.appendChild(element);
+
+        const entry = { snackbar: snackbar, element: element };
+        entry.timer = setTimeout(() => this.hideSnackbar(entry), snackbar.duration);
+        this.visibleSnackbars.push(entry);
+    }
+
+    hideSnackbar(entry, showNext = true) {
+        clearTimeout(entry.timer);
+        entry.element.remove();
+        this.visibleSnackbars = this.visibleSnackbars.filter(visible => visible !== entry);
+        if (showNext) {
+            this.showQueuedSnackbars();
+        }
+    }
+
+    clearSnackbarQueue() {
+        this.snackbarQueue = [];
+    }
+
+    applySnackbarUpdate(update) {
+        if (!update) {
+            return;
+        }
+        if (update.clear) {
+            this.clearSnackbarQueue();
+        }
+        (update.messages || []).forEach(snackbar => this.queueSnackbar(snackbar));
+    }
+
+    showPageSnackbars() {
+        const data = document.getElementById('godin-snackbars');
+        if (!data) {
+            return;
+        }
+        try {
+            this.applySnackbarUpdate(JSON.parse(data.textContent));
+        } catch (error) {
+            console.error('Invalid snackbars:', error);
+        }
+        data.remove();
     }
     
     initializeComponents(container = document) {
@@ -380,13 +2568,15 @@ class GodinFramework {
             console.log('Native button clicked (fallback):', buttonId);
 
             // Send button click to server via fetch
-            fetch(`/api/button-click/${buttonId}`, {
+            fetch(`${this.basePath()}/api/button-click/${buttonId}`, {
                 method: 'POST',
-                headers: {
+                headers: Object.assign({
                     'Content-Type': 'application/json',
-                },
+                    'X-CSRF-Token': this.getCSRFToken(),
+                }, this.stateRefreshHeaders()),
             })
             .then(response => {
+                this.applyStateFragments(response);
                 if (response.ok) {
                     console.log('Button click processed successfully');
                 } else {
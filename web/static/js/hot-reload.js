@@ -123,6 +123,11 @@ class GodinHotReload {
     }
 
     handleHotReloadMessage(message) {
+        // Server broadcasts arrive wrapped in a channel envelope
+        if (message.type === 'broadcast' && message.data) {
+            message = message.data;
+        }
+
         console.log('📨 Hot reload message:', message);
 
         switch (message.type) {
@@ -132,6 +137,9 @@ class GodinHotReload {
             case 'hot-refresh':
                 this.handleHotRefresh(message);
                 break;
+            case 'theme-update':
+                this.handleThemeUpdate(message);
+                break;
             default:
                 console.log('🤷 Unknown hot reload message type:', message.type);
         }
@@ -171,6 +179,25 @@ class GodinHotReload {
         }, 1000);
     }
 
+    handleThemeUpdate(message) {
+        console.log('🎨 Theme updated - applying new CSS variables...');
+
+        let style = document.getElementById('godin-theme');
+        if (!style) {
+            style = document.createElement('style');
+            style.id = 'godin-theme';
+            document.head.appendChild(style);
+        }
+        style.textContent = message.css || '';
+
+        this.showStatus('🎨 Theme updated', 'success');
+
+        // Widgets inline some theme colors, which only a new render updates
+        if (message.reload) {
+            this.handleHotReload(message);
+        }
+    }
+
     refreshCSS() {
         const links = document.querySelectorAll('link[rel="stylesheet"]');
         links.forEach(link => {
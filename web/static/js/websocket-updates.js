@@ -138,7 +138,12 @@ class GodinWebSocketManager {
         
         const element = document.getElementById(widgetId);
         if (element) {
-            element.innerHTML = html;
+            // Morph keyed children in place so focus and typed text survive
+            if (window.Godin) {
+                window.Godin.morph(element, html);
+            } else {
+                element.innerHTML = html;
+            }
             
             // Re-initialize any interactive elements
             this.reinitializeInteractiveElements(element);